@@ -0,0 +1,20 @@
+#!/bin/bash
+
+# Build the project bundle tool
+echo "🔨 Building bundle tool..."
+
+go build -o bundle ./cmd/bundle/main.go
+
+if [ $? -eq 0 ]; then
+    echo "✅ Built: bundle"
+    echo ""
+    echo "Usage examples:"
+    echo "  ./bundle export my-project            - Export a project to my-project-<date>.tar.gz"
+    echo "  ./bundle export my-project out.zip    - Export as a zip"
+    echo "  ./bundle inspect out.zip              - Show contents and verify checksums"
+    echo "  ./bundle import out.zip --dry-run     - Preview an import"
+    echo "  ./bundle import out.zip --strategy rename"
+else
+    echo "❌ Build failed"
+    exit 1
+fi
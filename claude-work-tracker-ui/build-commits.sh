@@ -0,0 +1,18 @@
+#!/bin/bash
+
+# Build the commit linker
+echo "🔨 Building commit linker..."
+
+go build -o commits ./cmd/commits/main.go
+
+if [ $? -eq 0 ]; then
+    echo "✅ Built: commits"
+    echo ""
+    echo "Usage examples:"
+    echo "  ./commits scan --dry-run                      - Preview which commits would be linked"
+    echo "  ./commits scan --days 30                      - Link the last 30 days of commits"
+    echo "  ./commits list <work-id>                      - Show a work item's commits"
+else
+    echo "❌ Build failed"
+    exit 1
+fi
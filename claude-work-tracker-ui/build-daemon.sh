@@ -0,0 +1,19 @@
+#!/bin/bash
+
+# Build the automation daemon
+echo "🔨 Building automation daemon..."
+
+go build -o daemon ./cmd/daemon/main.go
+
+if [ $? -eq 0 ]; then
+    echo "✅ Built: daemon"
+    echo ""
+    echo "Usage examples:"
+    echo "  nohup ./daemon run > ~/.claude/logs/daemon.log 2>&1 &   - Start in the background"
+    echo "  ./daemon status                               - Show what the daemon has been doing"
+    echo "  ./daemon status --prompt                      - One-line summary for your shell prompt"
+    echo "  ./daemon stop                                 - Stop it gracefully"
+else
+    echo "❌ Build failed"
+    exit 1
+fi
@@ -0,0 +1,18 @@
+#!/bin/bash
+
+# Build the work hierarchy tool
+echo "🔨 Building epics..."
+
+go build -o epics ./cmd/epics/main.go
+
+if [ $? -eq 0 ]; then
+    echo "✅ Built: epics"
+    echo ""
+    echo "Usage examples:"
+    echo "  ./epics tree                                  - This project's work as a tree"
+    echo "  ./epics link work-login work-auth-epic        - Put work-login under an epic"
+    echo "  ./epics unlink work-login                     - Make it top-level again"
+else
+    echo "❌ Build failed"
+    exit 1
+fi
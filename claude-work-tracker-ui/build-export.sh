@@ -0,0 +1,19 @@
+#!/bin/bash
+
+# Build the work export tool
+echo "🔨 Building export tool..."
+
+go build -o export ./cmd/export/main.go
+
+if [ $? -eq 0 ]; then
+    echo "✅ Built: export"
+    echo ""
+    echo "Usage examples:"
+    echo "  ./export markdown -o status.md                - Status report for the current project"
+    echo "  ./export csv --project all --schedule now     - Everything in NOW across projects"
+    echo "  ./export json --tag backend --since 2025-01-01"
+    echo "  ./export ics -o work.ics                      - Calendar feed of review dates"
+else
+    echo "❌ Build failed"
+    exit 1
+fi
@@ -0,0 +1,18 @@
+#!/bin/bash
+
+# Build the hook script tool
+echo "🔨 Building hook script tool..."
+
+go build -o hooks ./cmd/hooks/main.go
+
+if [ $? -eq 0 ]; then
+    echo "✅ Built: hooks"
+    echo ""
+    echo "Usage examples:"
+    echo "  ./hooks list                                  - Show configured hook scripts"
+    echo "  ./hooks events                                - Events scripts can subscribe to"
+    echo "  ./hooks run after_status_change <work-id>     - Try the scripts for an event"
+else
+    echo "❌ Build failed"
+    exit 1
+fi
@@ -0,0 +1,19 @@
+#!/bin/bash
+
+# Build the issue importer
+echo "🔨 Building issue importer..."
+
+go build -o importer ./cmd/importer/main.go
+
+if [ $? -eq 0 ]; then
+    echo "✅ Built: importer"
+    echo ""
+    echo "Usage examples:"
+    echo "  gh issue list --state all --json number,title,body,state,stateReason,url,labels,assignees,createdAt,updatedAt | ./importer github -"
+    echo "  ./importer jira export.csv"
+    echo "  ./importer mapped linear-mapping.yaml issues.json"
+    echo "  ./importer github issues.json --dry-run"
+else
+    echo "❌ Build failed"
+    exit 1
+fi
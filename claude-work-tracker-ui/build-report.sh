@@ -0,0 +1,18 @@
+#!/bin/bash
+
+# Build the standup and changelog report tool
+echo "🔨 Building report tool..."
+
+go build -o report ./cmd/report/main.go
+
+if [ $? -eq 0 ]; then
+    echo "✅ Built: report"
+    echo ""
+    echo "Usage examples:"
+    echo "  ./report standup                              - What happened since the last working day"
+    echo "  ./report weekly --project all --group tag     - The week across projects, by tag"
+    echo "  ./report range --since 2025-01-01 --until 2025-01-31 --format json"
+else
+    echo "❌ Build failed"
+    exit 1
+fi
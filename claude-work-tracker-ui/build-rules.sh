@@ -0,0 +1,18 @@
+#!/bin/bash
+
+# Build the transition rules tool
+echo "🔨 Building transition rules tool..."
+
+go build -o rules ./cmd/rules/main.go
+
+if [ $? -eq 0 ]; then
+    echo "✅ Built: rules"
+    echo ""
+    echo "Usage examples:"
+    echo "  ./rules init                                  - Start from the built-in rules"
+    echo "  ./rules validate                              - Check ~/.claude/config/rules.yaml"
+    echo "  ./rules test <work-id>                        - Show which rule would fire and why"
+else
+    echo "❌ Build failed"
+    exit 1
+fi
@@ -0,0 +1,20 @@
+#!/bin/bash
+
+# Build the git sync tool
+echo "🔨 Building git sync tool..."
+
+go build -o sync ./cmd/sync/main.go
+
+if [ $? -eq 0 ]; then
+    echo "✅ Built: sync"
+    echo ""
+    echo "Usage examples:"
+    echo "  ./sync init <remote> [branch] - Track work data in git"
+    echo "  ./sync status                 - Show sync status"
+    echo "  ./sync now                    - Commit, pull and push"
+    echo "  ./sync conflicts              - List unresolved conflicts"
+    echo "  ./sync resolve 1 theirs       - Take the remote value for a conflict"
+else
+    echo "❌ Build failed"
+    exit 1
+fi
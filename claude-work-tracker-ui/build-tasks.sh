@@ -0,0 +1,18 @@
+#!/bin/bash
+
+# Build the cross-project task list
+echo "🔨 Building task list..."
+
+go build -o tasks ./cmd/tasks/main.go
+
+if [ $? -eq 0 ]; then
+    echo "✅ Built: tasks"
+    echo ""
+    echo "Usage examples:"
+    echo "  ./tasks list --open --owner me                - My open tasks in every project"
+    echo "  ./tasks list --group due --due-in 7           - Tasks due this week, by due date"
+    echo "  ./tasks done \"Add login redirect\"             - Tick a task in its work file"
+else
+    echo "❌ Build failed"
+    exit 1
+fi
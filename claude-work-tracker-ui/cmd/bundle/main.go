@@ -0,0 +1,212 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/storage"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("Usage: bundle <command> [options]")
+		fmt.Println("Commands:")
+		fmt.Println("  export <project> [output]  - Export a project (ID or name) to a .tar.gz or .zip bundle")
+		fmt.Println("  import <bundle> [flags]    - Import a bundle into centralized storage")
+		fmt.Println("  inspect <bundle>           - Show bundle contents and verify checksums")
+		fmt.Println("  projects                   - List registered projects")
+		fmt.Println("")
+		fmt.Println("Import flags:")
+		fmt.Println("  --strategy merge|rename|skip  How to handle items that already exist")
+		fmt.Println("  --into <project>              Import into an existing project")
+		fmt.Println("  --path <dir>                  Register the project at a local path")
+		fmt.Println("  --dry-run                     Report what would happen without writing")
+		os.Exit(1)
+	}
+
+	externalStorage, err := storage.NewExternalStorage()
+	if err != nil {
+		log.Fatalf("Failed to open storage: %v", err)
+	}
+
+	registry, err := storage.LoadProjectRegistry(externalStorage)
+	if err != nil {
+		log.Fatalf("Failed to load project registry: %v", err)
+	}
+
+	manager := storage.NewBundleManager(externalStorage, registry)
+
+	switch os.Args[1] {
+	case "export":
+		runExport(manager, registry)
+	case "import":
+		runImport(manager, registry, externalStorage)
+	case "inspect":
+		runInspect(manager)
+	case "projects":
+		runProjects(registry)
+	default:
+		fmt.Printf("Unknown command: %s\n", os.Args[1])
+		os.Exit(1)
+	}
+}
+
+func runExport(manager *storage.BundleManager, registry *storage.ProjectRegistry) {
+	if len(os.Args) < 3 {
+		fmt.Println("Usage: bundle export <project> [output]")
+		os.Exit(1)
+	}
+
+	project := findProject(registry, os.Args[2])
+
+	output := fmt.Sprintf("%s-%s.tar.gz", project.Name, time.Now().Format("2006-01-02"))
+	if len(os.Args) > 3 {
+		output = os.Args[3]
+	}
+
+	fmt.Printf("📦 Exporting %s (%s)...\n", project.Name, project.ID)
+
+	manifest, err := manager.Export(project.ID, output)
+	if err != nil {
+		log.Fatalf("Export failed: %v", err)
+	}
+
+	fmt.Printf("✅ Wrote %s (%d files)\n", output, len(manifest.Files))
+}
+
+func runImport(manager *storage.BundleManager, registry *storage.ProjectRegistry, externalStorage *storage.ExternalStorage) {
+	if len(os.Args) < 3 {
+		fmt.Println("Usage: bundle import <bundle> [--strategy merge|rename|skip] [--into <project>] [--path <dir>] [--dry-run]")
+		os.Exit(1)
+	}
+
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	strategy := flags.String("strategy", "", "merge, rename or skip colliding items")
+	into := flags.String("into", "", "existing project to import into")
+	path := flags.String("path", "", "local project path to register the bundle under")
+	dryRun := flags.Bool("dry-run", false, "report without writing")
+	flags.Parse(os.Args[3:])
+
+	opts := storage.ImportOptions{
+		Strategy:    *strategy,
+		ProjectPath: *path,
+		DryRun:      *dryRun,
+	}
+	if *into != "" {
+		opts.TargetProjectID = findProject(registry, *into).ID
+	}
+
+	fmt.Printf("📥 Importing %s...\n", os.Args[2])
+
+	result, err := manager.Import(os.Args[2], opts)
+	if err != nil {
+		if result != nil && len(result.Collisions) > 0 {
+			printCollisions(result.Collisions)
+			fmt.Println("\nRe-run with --strategy merge (newest wins), rename (import under new IDs) or skip.")
+			os.Exit(1)
+		}
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	if *dryRun {
+		fmt.Println("\n🔍 Dry run, nothing was written")
+	}
+	fmt.Printf("\nProject: %s\n", result.ProjectID)
+	fmt.Printf("Imported: %d\n", len(result.Imported))
+	if len(result.Merged) > 0 {
+		fmt.Printf("Merged (incoming was newer): %d\n", len(result.Merged))
+	}
+	if len(result.Skipped) > 0 {
+		fmt.Printf("Kept existing: %d\n", len(result.Skipped))
+	}
+	for oldID, newID := range result.Renamed {
+		fmt.Printf("Renamed: %s → %s\n", oldID, newID)
+	}
+
+	if !*dryRun {
+		commitImport(externalStorage, os.Args[2])
+		fmt.Println("✅ Import complete")
+	}
+}
+
+func runInspect(manager *storage.BundleManager) {
+	if len(os.Args) < 3 {
+		fmt.Println("Usage: bundle inspect <bundle>")
+		os.Exit(1)
+	}
+
+	manifest, err := manager.Inspect(os.Args[2])
+	if err != nil {
+		log.Fatalf("Invalid bundle: %v", err)
+	}
+
+	fmt.Printf("\n📦 Bundle\n")
+	fmt.Printf("═══════════════════════════\n")
+	fmt.Printf("Project: %s (%s)\n", manifest.Project.Name, manifest.Project.ID)
+	fmt.Printf("Exported: %s from %s\n", manifest.ExportedAt.Format("2006-01-02 15:04"), manifest.Hostname)
+	fmt.Printf("Format version: %d\n", manifest.Version)
+
+	counts := make(map[string]int)
+	for _, file := range manifest.Files {
+		parts := strings.Split(file.Path, "/")
+		section := parts[0]
+		if len(parts) > 2 {
+			section = parts[0] + "/" + parts[1]
+		}
+		counts[section]++
+	}
+
+	fmt.Printf("\nFiles (%d, checksums verified)\n", len(manifest.Files))
+	fmt.Printf("────────────────────────────\n")
+	for section, count := range counts {
+		fmt.Printf("• %-24s %d\n", section, count)
+	}
+}
+
+func runProjects(registry *storage.ProjectRegistry) {
+	for _, project := range registry.ListProjects() {
+		fmt.Printf("%s  %-24s %s\n", project.ID, project.Name, project.Path)
+	}
+}
+
+func findProject(registry *storage.ProjectRegistry, ref string) *storage.Project {
+	if project, exists := registry.GetProject(ref); exists {
+		return project
+	}
+
+	for _, project := range registry.ListProjects() {
+		if project.Name == ref {
+			return project
+		}
+	}
+
+	log.Fatalf("Project not found: %s (run 'bundle projects' to list them)", ref)
+	return nil
+}
+
+func printCollisions(collisions []storage.BundleCollision) {
+	fmt.Printf("\n⚠️  %d items already exist in the target project\n", len(collisions))
+	fmt.Printf("────────────────────────────\n")
+	for _, c := range collisions {
+		newer := "existing is newer"
+		if c.IncomingUpdated.After(c.ExistingUpdated) {
+			newer = "incoming is newer"
+		}
+		fmt.Printf("• %s (%s)\n", c.ItemID, newer)
+	}
+}
+
+// commitImport records the import in git sync history when it's enabled
+func commitImport(externalStorage *storage.ExternalStorage, bundlePath string) {
+	gitSync, err := storage.NewGitSync(externalStorage)
+	if err != nil {
+		return
+	}
+	if err := gitSync.AutoCommit("Import bundle " + bundlePath); err != nil {
+		fmt.Printf("Warning: could not commit import: %v\n", err)
+	}
+}
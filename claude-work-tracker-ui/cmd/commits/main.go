@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"claude-work-tracker-ui/internal/git"
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("Usage: commits <command> [flags]")
+		fmt.Println("Commands:")
+		fmt.Println("  scan [--days N] [--dry-run] [--no-files]   - Link commits from git history to work items")
+		fmt.Println("  list <work-id>                             - Show the commits linked to a work item")
+		fmt.Println("")
+		fmt.Println("Commits are linked when the message mentions a work ID, when they are on the")
+		fmt.Println("work's branch (or a branch named after it), or when they touch files the work mentions.")
+		os.Exit(1)
+	}
+
+	command := os.Args[1]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	days := flags.Int("days", 90, "how many days of history to scan, 0 for all")
+	dryRun := flags.Bool("dry-run", false, "show matches without saving them")
+	noFiles := flags.Bool("no-files", false, "don't match commits by the files a work mentions")
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+
+	client, err := storage.NewCentralizedClient()
+	if err != nil {
+		log.Fatalf("Failed to open work storage: %v", err)
+	}
+	defer client.Close() // Let queued auto-commits finish
+
+	switch command {
+	case "scan":
+		config := git.DefaultScanConfig()
+		config.MatchFiles = !*noFiles
+		config.Since = time.Time{}
+		if *days > 0 {
+			config.Since = time.Now().AddDate(0, 0, -*days)
+		}
+		scanCommits(client, config, *dryRun)
+	case "list":
+		if len(args) < 1 {
+			fmt.Println("Usage: commits list <work-id>")
+			os.Exit(1)
+		}
+		listCommits(client, args[0])
+	default:
+		fmt.Printf("Unknown command: %s\n", command)
+		os.Exit(1)
+	}
+}
+
+func scanCommits(client *storage.CentralizedClient, config *git.ScanConfig, dryRun bool) {
+	works, err := client.GetAllWork()
+	if err != nil {
+		log.Fatalf("Failed to load work: %v", err)
+	}
+
+	project := client.GetCurrentProject()
+	fmt.Printf("🔍 Scanning %s for commits...\n\n", project.Path)
+
+	ctx := context.Background()
+	cm := git.NewContextManager()
+	links, err := git.NewCommitScanner(cm, project.Path, config).Scan(ctx, works)
+	if err != nil {
+		log.Fatalf("Failed to scan commits: %v", err)
+	}
+	if len(links) == 0 {
+		fmt.Println("No new commits to link")
+	}
+
+	titles := make(map[string]string)
+	for _, work := range works {
+		titles[work.ID] = work.Title
+	}
+	for _, link := range links {
+		fmt.Printf("🔗 %.8s %-50s → %s (%s)\n", link.Commit.SHA, truncate(link.Commit.Subject, 50), titles[link.WorkID], link.MatchedBy)
+		if trailers := git.ParseTrailers(link.Commit.Message()); trailers.HasUpdates() {
+			if trailers.Progress >= 0 {
+				fmt.Printf("   ↳ Progress: %d%%\n", trailers.Progress)
+			}
+			for _, task := range trailers.CompletesTasks {
+				fmt.Printf("   ↳ Completes-Task: %s\n", task)
+			}
+		}
+	}
+
+	// Merged branches let the complete_on_merge rule propose completion
+	merged := cm.DetectMerges(ctx, project.Path, works)
+	for _, work := range merged {
+		fmt.Printf("🔀 %s: branch %s merged, completion will be proposed in the transition inbox\n", work.Title, work.GitContext.Branch)
+	}
+
+	if dryRun {
+		fmt.Printf("\n%d commits would be linked, %d merged branches (dry run)\n", len(links), len(merged))
+		return
+	}
+	for _, work := range merged {
+		if err := client.UpdateWork(work); err != nil {
+			log.Fatalf("Failed to save %s: %v", work.ID, err)
+		}
+	}
+	if len(links) == 0 {
+		return
+	}
+
+	changed, updates := git.ApplyLinks(works, links)
+	for _, work := range changed {
+		if err := client.UpdateWork(work); err != nil {
+			log.Fatalf("Failed to save %s: %v", work.ID, err)
+		}
+	}
+	for _, update := range updates {
+		if err := client.CreateUpdate(update.WorkID, update); err != nil {
+			log.Fatalf("Failed to save update for %s: %v", update.WorkID, err)
+		}
+	}
+	fmt.Printf("\n✅ Linked %d commits to %d work items, %d trailer updates\n", len(links), len(changed), len(updates))
+}
+
+func listCommits(client *storage.CentralizedClient, workID string) {
+	work := findWork(client, workID)
+	if len(work.Commits) == 0 {
+		fmt.Printf("No commits linked to %s, run `commits scan` first\n", work.Title)
+		return
+	}
+
+	fmt.Printf("📝 %s (%d commits)\n\n", work.Title, len(work.Commits))
+	for _, commit := range work.Commits {
+		fmt.Printf("%s  %s  %-20s %s  (%s)\n",
+			commit.ShortSHA(), commit.Timestamp.Format("2006-01-02 15:04"), truncate(commit.Author, 20), commit.Subject, commit.MatchedBy)
+	}
+}
+
+func findWork(client *storage.CentralizedClient, workID string) *models.Work {
+	works, err := client.GetAllWork()
+	if err != nil {
+		log.Fatalf("Failed to load work: %v", err)
+	}
+	for _, work := range works {
+		if work.ID == workID {
+			return work
+		}
+	}
+	log.Fatalf("Work item not found: %s", workID)
+	return nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
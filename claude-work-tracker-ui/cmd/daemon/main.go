@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"claude-work-tracker-ui/internal/daemon"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("Usage: daemon <command> [flags]")
+		fmt.Println("Commands:")
+		fmt.Println("  run                 - Run the automation daemon in the foreground")
+		fmt.Println("  stop                - Stop the daemon for this project")
+		fmt.Println("  status [--prompt]   - Show daemon status, or a one-line summary for shell prompts")
+		fmt.Println("")
+		fmt.Println("Flags for run:")
+		fmt.Println("  --rules-interval <d>   How often to evaluate rules and inactivity (default 5m)")
+		fmt.Println("  --git-interval <d>     How often to look for new commits (default 1m)")
+		fmt.Println("  --score-interval <d>   How often to refresh activity scores (default 1h)")
+		os.Exit(1)
+	}
+
+	defaults := daemon.DefaultDaemonConfig()
+	command := os.Args[1]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	rulesInterval := flags.Duration("rules-interval", defaults.RulesInterval, "rules evaluation interval")
+	gitInterval := flags.Duration("git-interval", defaults.GitInterval, "git activity interval")
+	scoreInterval := flags.Duration("score-interval", defaults.ScoreInterval, "activity score interval")
+	prompt := flags.Bool("prompt", false, "print a one-line summary for shell prompts")
+	flags.Parse(os.Args[2:])
+
+	switch command {
+	case "run":
+		config := daemon.DefaultDaemonConfig()
+		config.RulesInterval = *rulesInterval
+		config.GitInterval = *gitInterval
+		config.ScoreInterval = *scoreInterval
+		runDaemon(config)
+	case "stop":
+		stopDaemon()
+	case "status":
+		showStatus(*prompt)
+	default:
+		fmt.Printf("Unknown command: %s\n", command)
+		os.Exit(1)
+	}
+}
+
+func runDaemon(config *daemon.DaemonConfig) {
+	client, err := storage.NewCentralizedClient()
+	if err != nil {
+		log.Fatalf("Failed to open work storage: %v", err)
+	}
+	defer client.Close() // Let queued auto-commits finish
+
+	// SIGINT and SIGTERM let the current job finish before shutting down
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	d := daemon.NewDaemon(client, config)
+	log.Printf("Status: %s", config.StatusPath)
+	if err := d.Run(ctx); err != nil {
+		log.Fatalf("Daemon failed: %v", err)
+	}
+}
+
+func stopDaemon() {
+	pidPath := daemon.PIDPath(storage.CurrentProjectID())
+	pid, err := daemon.ReadPID(pidPath)
+	if err != nil {
+		fmt.Println("Daemon is not running")
+		return
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		log.Fatalf("Failed to find daemon process %d: %v", pid, err)
+	}
+	if err := process.Signal(syscall.SIGTERM); err != nil {
+		// The process is gone, clean up after it
+		os.Remove(pidPath)
+		fmt.Println("Daemon is not running, removed stale PID file")
+		return
+	}
+
+	// Wait for the PID file to disappear so callers know shutdown finished
+	for i := 0; i < 50; i++ {
+		if _, err := os.Stat(pidPath); os.IsNotExist(err) {
+			fmt.Printf("✅ Stopped daemon (pid %d)\n", pid)
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	fmt.Printf("⏳ Sent stop signal to daemon (pid %d), still shutting down\n", pid)
+}
+
+func showStatus(prompt bool) {
+	status, err := daemon.ReadStatus(daemon.StatusPath(storage.CurrentProjectID()))
+	if prompt {
+		// Prompts print nothing rather than an error when the daemon isn't running
+		if err == nil {
+			if summary := status.PromptSummary(); summary != "" {
+				fmt.Println(summary)
+			}
+		}
+		return
+	}
+	if err != nil {
+		fmt.Println("Daemon has not run for this project")
+		return
+	}
+
+	if status.IsLive() {
+		fmt.Printf("🤖 Daemon running for %s (pid %d, up %s)\n", status.ProjectName, status.PID, time.Since(status.StartedAt).Round(time.Second))
+	} else {
+		fmt.Printf("⏹  Daemon not running for %s\n", status.ProjectName)
+	}
+	fmt.Printf("   updated %s\n\n", status.UpdatedAt.Format("2006-01-02 15:04:05"))
+
+	fmt.Printf("Work items:          %d\n", status.WorkItems)
+	fmt.Printf("Transitions applied: %d\n", status.TransitionsApplied)
+	fmt.Printf("Pending transitions: %d\n", status.PendingTransitions)
+	fmt.Printf("Commits recorded:    %d\n", status.CommitsRecorded)
+	fmt.Printf("Reminders sent:      %d\n", status.RemindersSent)
+	fmt.Printf("Health score:        %.0f%% (%d stale)\n", status.HealthScore*100, status.StaleWork)
+
+	if len(status.Inactive) > 0 {
+		fmt.Printf("\n💤 Inactive NOW items (%d)\n", len(status.Inactive))
+		for _, work := range status.Inactive {
+			fmt.Printf("   • %s (%.0fh idle)\n", work.Title, work.IdleHours)
+		}
+	}
+	if status.LastError != "" {
+		fmt.Printf("\n❌ Last error: %s\n", status.LastError)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("Usage: epics <command> [flags]")
+		fmt.Println("Commands:")
+		fmt.Println("  tree [--all]                   - Show the work of this project as a tree, with child work rolled up")
+		fmt.Println("  link <work-id> <parent-id>     - Put a work item under a parent")
+		fmt.Println("  unlink <work-id>               - Make a work item top-level again")
+		os.Exit(1)
+	}
+
+	command := os.Args[1]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	all := flags.Bool("all", false, "include closed top-level items")
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+
+	client, err := storage.NewCentralizedClient()
+	if err != nil {
+		log.Fatalf("Failed to open work storage: %v", err)
+	}
+	defer client.Close() // Let queued auto-commits finish
+
+	switch command {
+	case "tree":
+		showTree(client, *all)
+	case "link":
+		if len(args) < 2 {
+			fmt.Println("Usage: epics link <work-id> <parent-id>")
+			os.Exit(1)
+		}
+		setParent(client, args[0], args[1])
+	case "unlink":
+		if len(args) < 1 {
+			fmt.Println("Usage: epics unlink <work-id>")
+			os.Exit(1)
+		}
+		setParent(client, args[0], "")
+	default:
+		fmt.Printf("Unknown command: %s\n", command)
+		os.Exit(1)
+	}
+}
+
+func showTree(client *storage.CentralizedClient, all bool) {
+	works, err := client.GetAllWork()
+	if err != nil {
+		log.Fatalf("Failed to load work: %v", err)
+	}
+	tree := models.NewWorkTree(works)
+
+	fmt.Printf("🌳 %s\n", client.GetCurrentProject().Name)
+	shown := 0
+	for _, work := range works {
+		if tree.Parent(work.ID) != nil || (work.IsClosed() && !all) {
+			continue
+		}
+		printWork(tree, work, "", "")
+		shown++
+	}
+	if shown == 0 {
+		fmt.Println("No open work items")
+	}
+}
+
+// printWork prints a work item and, below it, the work it's broken down into
+func printWork(tree *models.WorkTree, work *models.Work, prefix, indent string) {
+	line := fmt.Sprintf("%s%s %s [%s, %s, %d%%]", prefix, statusIcon(work), work.Title, work.GetDisplaySchedule(), work.Metadata.Status, work.Metadata.ProgressPercent)
+	if rollup, ok := tree.Rollup(work.ID); ok {
+		line += fmt.Sprintf(" — %d/%d children done, effort %s", rollup.Completed, rollup.Children, rollup.Effort)
+	} else if work.Metadata.EstimatedEffort == models.WorkEffortEpic {
+		line += " — epic, needs breakdown"
+	}
+	fmt.Printf("%s  (%s)\n", line, work.ID)
+
+	children := tree.Children(work.ID)
+	for i, child := range children {
+		if i == len(children)-1 {
+			printWork(tree, child, indent+"└─ ", indent+"   ")
+		} else {
+			printWork(tree, child, indent+"├─ ", indent+"│  ")
+		}
+	}
+}
+
+func setParent(client *storage.CentralizedClient, workID, parentID string) {
+	work, err := client.SetWorkParent(workID, parentID)
+	if err != nil {
+		log.Fatalf("Failed to update %s: %v", workID, err)
+	}
+	if parentID == "" {
+		fmt.Printf("✅ %s is now a top-level item\n", work.Title)
+		return
+	}
+	fmt.Printf("✅ %s is now part of %s\n", work.Title, parentID)
+	fmt.Println("   ↳ its progress, effort and status roll up into the parent")
+}
+
+func statusIcon(work *models.Work) string {
+	switch work.Metadata.Status {
+	case models.WorkStatusCompleted:
+		return "✅"
+	case models.WorkStatusCanceled, models.WorkStatusArchived:
+		return "❌"
+	case models.WorkStatusBlocked:
+		return "🚫"
+	case models.WorkStatusInProgress:
+		return "🔄"
+	}
+	return "⬜"
+}
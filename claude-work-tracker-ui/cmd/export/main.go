@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"claude-work-tracker-ui/internal/report"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("Usage: export <markdown|csv|json|ics> [flags]")
+		fmt.Println("Flags:")
+		fmt.Println("  --schedule now,next   Only include these schedules")
+		fmt.Println("  --project <name|id>   Projects to include (comma separated, 'all' for every project)")
+		fmt.Println("  --tag <tag>           Only include items with one of these tags (comma separated)")
+		fmt.Println("  --since YYYY-MM-DD    Items created, updated or completed on or after this date")
+		fmt.Println("  --until YYYY-MM-DD    Items created, updated or completed on or before this date")
+		fmt.Println("  -o <file>             Write to a file instead of stdout")
+		os.Exit(1)
+	}
+
+	format := os.Args[1]
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	schedules := flags.String("schedule", "", "comma separated schedules")
+	projects := flags.String("project", "", "comma separated project names or IDs, or 'all'")
+	tags := flags.String("tag", "", "comma separated tags")
+	since := flags.String("since", "", "start date (YYYY-MM-DD)")
+	until := flags.String("until", "", "end date, inclusive (YYYY-MM-DD)")
+	output := flags.String("o", "", "output file")
+	flags.Parse(os.Args[2:])
+
+	filter := report.Filter{
+		Schedules: splitList(*schedules),
+		Projects:  splitList(*projects),
+		Tags:      splitList(*tags),
+	}
+
+	if *since != "" {
+		t, err := report.ParseDate(*since)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		filter.Since = t
+	}
+	if *until != "" {
+		t, err := report.ParseDate(*until)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		// Make the end date inclusive
+		end := t.AddDate(0, 0, 1)
+		filter.Until = &end
+	}
+
+	client, err := storage.NewCentralizedClient()
+	if err != nil {
+		log.Fatalf("Failed to open work storage: %v", err)
+	}
+
+	dataset, err := report.Collect(client, filter)
+	if err != nil {
+		log.Fatalf("Failed to collect work: %v", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			log.Fatalf("Failed to create %s: %v", *output, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if err := report.Render(dataset, format, w); err != nil {
+		log.Fatalf("Export failed: %v", err)
+	}
+
+	if *output != "" {
+		fmt.Printf("✅ Exported %d items to %s\n", len(dataset.Entries), *output)
+	}
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
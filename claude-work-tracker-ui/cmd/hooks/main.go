@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/hooks"
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("Usage: hooks <command> [flags]")
+		fmt.Println("Commands:")
+		fmt.Println("  list                         - Show configured hook scripts")
+		fmt.Println("  events                       - List hook events scripts can subscribe to")
+		fmt.Println("  run <event> <work-id>        - Run the scripts for an event against a work item")
+		fmt.Println("  log [--failed] [--limit N]   - Show recent hook runs from the audit log")
+		fmt.Println("")
+		fmt.Println("Flags:")
+		fmt.Println("  --config <path>   Hooks config file (default ~/.claude/config/hooks.json)")
+		os.Exit(1)
+	}
+
+	command := os.Args[1]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	configPath := flags.String("config", hooks.DefaultExternalHooksPath(), "hooks config file")
+	failedOnly := flags.Bool("failed", false, "only show failed runs")
+	limit := flags.Int("limit", 20, "number of runs to show")
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+
+	switch command {
+	case "list":
+		listHooks(*configPath)
+	case "events":
+		for _, event := range hooks.AllHookTypes() {
+			note := ""
+			if event.IsBefore() {
+				note = "  (non-zero exit vetoes the change)"
+			}
+			fmt.Printf("%s%s\n", event, note)
+		}
+	case "run":
+		if len(args) < 2 {
+			fmt.Println("Usage: hooks run <event> <work-id>")
+			os.Exit(1)
+		}
+		runHooks(*configPath, hooks.HookType(args[0]), args[1])
+	case "log":
+		showLog(*failedOnly, *limit)
+	default:
+		fmt.Printf("Unknown command: %s\n", command)
+		os.Exit(1)
+	}
+}
+
+func listHooks(configPath string) {
+	configured, err := hooks.LoadExternalHooks(configPath)
+	if err != nil {
+		log.Fatalf("Invalid hooks config: %v", err)
+	}
+
+	if len(configured) == 0 {
+		fmt.Printf("No hook scripts configured in %s\n", configPath)
+		return
+	}
+
+	fmt.Printf("🪝 Hook scripts (%s)\n\n", configPath)
+	for _, hook := range configured {
+		status := "✅"
+		if hook.Disabled {
+			status = "⏸ "
+		}
+		command := hook.Command
+		if len(hook.Args) > 0 {
+			command += " " + strings.Join(hook.Args, " ")
+		}
+		fmt.Printf("%s %-20s %-24s %s\n", status, hook.Name, hook.Event, command)
+	}
+}
+
+func runHooks(configPath string, event hooks.HookType, workID string) {
+	hookSystem := hooks.NewHookSystem(hooks.DefaultHookConfig())
+	hookSystem.SetAuditLog(hooks.NewAuditLog(hooks.DefaultAuditLogPath(), hooks.DefaultAuditConfig()))
+	count, err := hookSystem.LoadExternalHooks(configPath)
+	if err != nil {
+		log.Fatalf("Invalid hooks config: %v", err)
+	}
+	if hookSystem.GetHandlerCount(event) == 0 {
+		fmt.Printf("No enabled hook scripts for %s (%d loaded)\n", event, count)
+		return
+	}
+
+	client, err := storage.NewCentralizedClient()
+	if err != nil {
+		log.Fatalf("Failed to open work storage: %v", err)
+	}
+	defer client.Close() // Let queued auto-commits finish
+	work := findWork(client, workID)
+
+	oldWork := *work
+	results, err := hookSystem.ExecuteSync(context.Background(), &hooks.HookContext{
+		WorkItem:    work,
+		OldWorkItem: &oldWork,
+		EventType:   event,
+		Timestamp:   time.Now(),
+	})
+
+	for _, result := range results {
+		icon := "✅"
+		if !result.Success {
+			icon = "❌"
+		}
+		fmt.Printf("%s %s (%s)\n", icon, result.HookName, result.Duration.Round(time.Millisecond))
+		if result.Decision != nil {
+			fmt.Printf("   decision: %s", result.Decision.Action)
+			if result.Decision.Reason != "" {
+				fmt.Printf(" (%s)", result.Decision.Reason)
+			}
+			for field, value := range result.Decision.Patch {
+				fmt.Printf(" %s=%v", field, value)
+			}
+			fmt.Println()
+		}
+		if stdout, _ := result.Metadata["stdout"].(string); stdout != "" {
+			fmt.Printf("   stdout: %s\n", strings.TrimSpace(stdout))
+		}
+		if stderr, _ := result.Metadata["stderr"].(string); stderr != "" {
+			fmt.Printf("   stderr: %s\n", strings.TrimSpace(stderr))
+		}
+		if result.Error != nil {
+			fmt.Printf("   error: %v\n", result.Error)
+		}
+	}
+
+	if veto, ok := err.(*hooks.VetoError); ok {
+		fmt.Printf("\n🚫 Change would be vetoed: %s\n", veto.Reason)
+		os.Exit(1)
+	}
+}
+
+func showLog(failedOnly bool, limit int) {
+	auditLog := hooks.NewAuditLog(hooks.DefaultAuditLogPath(), hooks.DefaultAuditConfig())
+	entries, err := auditLog.Recent(0)
+	if err != nil {
+		log.Fatalf("Failed to read audit log: %v", err)
+	}
+
+	shown := 0
+	for _, entry := range entries {
+		if shown >= limit {
+			break
+		}
+		if failedOnly && entry.Success {
+			continue
+		}
+		shown++
+
+		icon := "✅"
+		switch {
+		case entry.Decision == string(hooks.ActionDeny):
+			icon = "🚫"
+		case !entry.Success:
+			icon = "❌"
+		}
+		fmt.Printf("%s %s  %-22s %-24s %-12s %s\n", icon, entry.Time.Format("2006-01-02 15:04:05"),
+			entry.Hook, entry.Event, entry.WorkID, entry.Duration.Round(time.Millisecond))
+		if entry.Rerun {
+			fmt.Println("   re-run")
+		}
+		if entry.Error != "" {
+			fmt.Printf("   error: %s\n", entry.Error)
+		}
+	}
+
+	if shown == 0 {
+		fmt.Printf("No hook runs recorded in %s\n", auditLog.Path())
+	}
+}
+
+func findWork(client *storage.CentralizedClient, workID string) *models.Work {
+	works, err := client.GetAllWork()
+	if err != nil {
+		log.Fatalf("Failed to load work: %v", err)
+	}
+	for _, work := range works {
+		if work.ID == workID {
+			return work
+		}
+	}
+	log.Fatalf("Work item not found: %s", workID)
+	return nil
+}
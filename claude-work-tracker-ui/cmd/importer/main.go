@@ -0,0 +1,119 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"claude-work-tracker-ui/internal/importer"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+func main() {
+	if len(os.Args) < 3 {
+		fmt.Println("Usage: importer <source> [flags] <file|->")
+		fmt.Println("Sources:")
+		fmt.Println("  github <issues.json>            - Output of gh issue list --json number,title,body,state,url,labels,assignees,createdAt,updatedAt")
+		fmt.Println("  jira <export.csv>               - Jira CSV export")
+		fmt.Println("  mapped <mapping.yaml> <file>    - Generic CSV/JSON using a field mapping file")
+		fmt.Println("")
+		fmt.Println("Flags:")
+		fmt.Println("  --dry-run           Show what would change without writing")
+		fmt.Println("  --schedule <name>   Schedule for new open items (now|next|later, default next)")
+		fmt.Println("")
+		fmt.Println("Re-importing the same export updates existing items instead of duplicating them.")
+		os.Exit(1)
+	}
+
+	source := os.Args[1]
+	flags := flag.NewFlagSet(source, flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "show changes without writing")
+	schedule := flags.String("schedule", "", "schedule for new open items")
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+
+	config := importer.DefaultImportConfig()
+	config.DryRun = *dryRun
+
+	var records []importer.IssueRecord
+	var err error
+
+	switch source {
+	case "github":
+		requireArgs(args, 1)
+		records, err = importer.ParseGitHubIssues(readInput(args[0]))
+	case "jira":
+		requireArgs(args, 1)
+		records, err = importer.ParseJiraCSV(readInput(args[0]))
+	case "mapped":
+		requireArgs(args, 2)
+		mapping, mapErr := importer.LoadFieldMapping(args[0])
+		if mapErr != nil {
+			log.Fatalf("Invalid mapping: %v", mapErr)
+		}
+		mapping.ApplyTo(config)
+		records, err = importer.ParseMapped(readInput(args[1]), mapping)
+	default:
+		fmt.Printf("Unknown source: %s\n", source)
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatalf("Failed to parse input: %v", err)
+	}
+
+	if *schedule != "" {
+		config.DefaultSchedule = *schedule
+	}
+
+	client, err := storage.NewCentralizedClient()
+	if err != nil {
+		log.Fatalf("Failed to open work storage: %v", err)
+	}
+	defer client.Close() // Let queued auto-commits finish
+
+	project := client.GetCurrentProject()
+	fmt.Printf("📥 Importing %d %s issues into %s...\n", len(records), source, project.Name)
+
+	result, err := importer.NewImporter(client, config).Import(records)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	if *dryRun {
+		fmt.Println("\n🔍 Dry run, nothing was written")
+	}
+	fmt.Printf("\n✨ Created:   %d\n", len(result.Created))
+	fmt.Printf("🔄 Updated:   %d\n", len(result.Updated))
+	fmt.Printf("✓  Unchanged: %d\n", len(result.Unchanged))
+
+	if len(result.Errors) > 0 {
+		fmt.Printf("\n⚠️  Errors (%d)\n", len(result.Errors))
+		for _, e := range result.Errors {
+			fmt.Printf("• %s\n", e)
+		}
+		os.Exit(1)
+	}
+}
+
+func requireArgs(args []string, n int) {
+	if len(args) < n {
+		fmt.Println("Missing input file (use - for stdin)")
+		os.Exit(1)
+	}
+}
+
+func readInput(path string) []byte {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return data
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/report"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	period := os.Args[1]
+	switch period {
+	case report.PeriodStandup, report.PeriodWeekly, report.PeriodRange:
+	default:
+		printUsage()
+		os.Exit(1)
+	}
+
+	flags := flag.NewFlagSet("report", flag.ExitOnError)
+	projects := flags.String("project", "", "comma separated project names or IDs, or 'all'")
+	tags := flags.String("tag", "", "comma separated tags")
+	group := flags.String("group", report.GroupByProject, "group by project, work or tag")
+	format := flags.String("format", report.FormatMarkdown, "markdown or json")
+	since := flags.String("since", "", "start date (YYYY-MM-DD), for range")
+	until := flags.String("until", "", "end date, inclusive (YYYY-MM-DD), for range")
+	output := flags.String("o", "", "output file")
+	flags.Parse(os.Args[2:])
+
+	switch *group {
+	case report.GroupByProject, report.GroupByWork, report.GroupByTag:
+	default:
+		log.Fatalf("Unknown grouping %q, expected project, work or tag", *group)
+	}
+
+	options := report.SummaryOptions{
+		Period:   period,
+		Projects: splitList(*projects),
+		Tags:     splitList(*tags),
+		GroupBy:  *group,
+	}
+
+	now := time.Now()
+	switch period {
+	case report.PeriodStandup:
+		options.Since, options.Until = report.StandupRange(now)
+	case report.PeriodWeekly:
+		options.Since, options.Until = report.WeeklyRange(now)
+	case report.PeriodRange:
+		if *since == "" {
+			log.Fatalf("range needs --since YYYY-MM-DD")
+		}
+		start, err := report.ParseDate(*since)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		options.Since, options.Until = *start, now
+		if *until != "" {
+			end, err := report.ParseDate(*until)
+			if err != nil {
+				log.Fatalf("%v", err)
+			}
+			// Make the end date inclusive
+			options.Until = end.AddDate(0, 0, 1)
+		}
+	}
+
+	client, err := storage.NewCentralizedClient()
+	if err != nil {
+		log.Fatalf("Failed to open work storage: %v", err)
+	}
+
+	summary, err := report.Summarize(client, options)
+	if err != nil {
+		log.Fatalf("Failed to summarize work: %v", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			log.Fatalf("Failed to create %s: %v", *output, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if err := report.RenderSummary(summary, *format, w); err != nil {
+		log.Fatalf("Report failed: %v", err)
+	}
+
+	if *output != "" {
+		fmt.Printf("✅ Wrote %s report to %s\n", period, *output)
+	}
+}
+
+func printUsage() {
+	fmt.Println("Usage: report <standup|weekly|range> [flags]")
+	fmt.Println("  standup               Since the start of the previous working day")
+	fmt.Println("  weekly                The last seven days")
+	fmt.Println("  range                 Between --since and --until")
+	fmt.Println("Flags:")
+	fmt.Println("  --project <name|id>   Projects to include (comma separated, 'all' for every project)")
+	fmt.Println("  --tag <tag>           Only include items with one of these tags (comma separated)")
+	fmt.Println("  --group <by>          Group by project (default), work or tag")
+	fmt.Println("  --format <format>     markdown (default) or json")
+	fmt.Println("  --since YYYY-MM-DD    Start of a range")
+	fmt.Println("  --until YYYY-MM-DD    Last day of a range (default today)")
+	fmt.Println("  -o <file>             Write to a file instead of stdout")
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
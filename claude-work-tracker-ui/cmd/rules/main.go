@@ -0,0 +1,189 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/automation"
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("Usage: rules <command> [flags]")
+		fmt.Println("Commands:")
+		fmt.Println("  list                   - Show the active transition rules")
+		fmt.Println("  validate               - Check the rules file for errors")
+		fmt.Println("  test <work-id>         - Dry-run the rules against a work item")
+		fmt.Println("  fields                 - List the fields conditions can test")
+		fmt.Println("  init                   - Write the built-in rules to the rules file")
+		fmt.Println("")
+		fmt.Println("Flags:")
+		fmt.Println("  --file <path>   Rules file (default ~/.claude/config/rules.yaml)")
+		os.Exit(1)
+	}
+
+	command := os.Args[1]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	rulesPath := flags.String("file", automation.DefaultRulesPath(), "rules file")
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+
+	switch command {
+	case "list":
+		listRules(*rulesPath)
+	case "validate":
+		validateRules(*rulesPath)
+	case "test":
+		if len(args) < 1 {
+			fmt.Println("Usage: rules test <work-id>")
+			os.Exit(1)
+		}
+		testRules(*rulesPath, args[0])
+	case "fields":
+		for _, field := range automation.RuleFieldNames() {
+			fmt.Println(field)
+		}
+		fmt.Println("\nVariables: $stale_threshold_days, $inactivity_threshold_hours, $auto_archive_threshold_days")
+	case "init":
+		initRules(*rulesPath)
+	default:
+		fmt.Printf("Unknown command: %s\n", command)
+		os.Exit(1)
+	}
+}
+
+// newEngine builds a transition engine reading the given rules file
+func newEngine(rulesPath string) *automation.TransitionEngine {
+	config := automation.DefaultTransitionConfig()
+	config.RulesPath = rulesPath
+	engine := automation.NewTransitionEngine(nil, config)
+	if err := engine.RulesError(); err != nil {
+		log.Fatalf("Invalid rules file %s:\n%v", rulesPath, err)
+	}
+	return engine
+}
+
+// rulesSource describes where the active rules come from
+func rulesSource(rulesPath string) string {
+	if _, err := os.Stat(rulesPath); err != nil {
+		return "built-in rules"
+	}
+	return rulesPath
+}
+
+func listRules(rulesPath string) {
+	engine := newEngine(rulesPath)
+
+	fmt.Printf("📋 Transition rules (%s)\n\n", rulesSource(rulesPath))
+	for _, evaluation := range engine.Explain(&models.Work{}, time.Now()) {
+		fmt.Printf("%4d  %-28s %s\n", evaluation.Priority, evaluation.Rule, evaluation.Description)
+	}
+}
+
+func validateRules(rulesPath string) {
+	if _, err := os.Stat(rulesPath); err != nil {
+		fmt.Printf("No rules file at %s, the built-in rules are used\n", rulesPath)
+		return
+	}
+
+	specs, err := automation.LoadRulesFile(rulesPath)
+	if err != nil {
+		fmt.Printf("❌ %s\n", rulesPath)
+		for _, line := range strings.Split(err.Error(), "\n") {
+			fmt.Printf("   %s\n", line)
+		}
+		os.Exit(1)
+	}
+	fmt.Printf("✅ %s: %d rules\n", rulesPath, len(specs))
+}
+
+func testRules(rulesPath, workID string) {
+	engine := newEngine(rulesPath)
+
+	client, err := storage.NewCentralizedClient()
+	if err != nil {
+		log.Fatalf("Failed to open work storage: %v", err)
+	}
+	defer client.Close() // Let queued auto-commits finish
+	work := findWork(client, workID)
+	now := time.Now()
+
+	fmt.Printf("🧪 %s: %s (%s, %s, %d%%)\n", work.ID, work.Title, work.Metadata.Status, work.Schedule, work.Metadata.ProgressPercent)
+	fmt.Printf("   rules: %s\n\n", rulesSource(rulesPath))
+
+	fired := ""
+	for _, evaluation := range engine.Explain(work, now) {
+		icon := "⚪"
+		if evaluation.Matched {
+			icon = "🟢"
+			if fired == "" {
+				fired = evaluation.Rule
+				icon = "🔥"
+			}
+		}
+		fmt.Printf("%s %s (priority %d)\n", icon, evaluation.Rule, evaluation.Priority)
+		for _, cond := range evaluation.Conditions {
+			mark := "✗"
+			if cond.Passed {
+				mark = "✓"
+			}
+			fmt.Printf("   %s %s  (is %s)\n", mark, cond.Expr, cond.Actual)
+		}
+		if evaluation.Matched {
+			for _, action := range evaluation.Actions {
+				fmt.Printf("   → %s\n", action)
+			}
+		}
+	}
+
+	fmt.Println()
+	if fired == "" {
+		fmt.Println("No rule would fire")
+		return
+	}
+
+	result, _ := engine.DryRun(work, now)
+	fmt.Printf("Would fire %s: status %s → %s, schedule %s → %s\n",
+		fired, work.Metadata.Status, result.Metadata.Status, work.Schedule, result.Schedule)
+	if pending := engine.PendingTransitions([]*models.Work{work}, now); len(pending) > 0 && pending[0].Rule == fired && pending[0].NeedsConfirmation {
+		fmt.Println("Needs approval in the transition inbox (ctrl+t)")
+	}
+	if work.IsTransitionSnoozed(fired, now) {
+		fmt.Println("Snoozed: the suggestion was rejected and won't be applied until the snooze ends")
+	}
+}
+
+func initRules(rulesPath string) {
+	if _, err := os.Stat(rulesPath); err == nil {
+		log.Fatalf("Rules file already exists: %s", rulesPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(rulesPath), 0755); err != nil {
+		log.Fatalf("Failed to create config directory: %v", err)
+	}
+	if err := ioutil.WriteFile(rulesPath, []byte(automation.DefaultRulesYAML), 0644); err != nil {
+		log.Fatalf("Failed to write rules file: %v", err)
+	}
+	fmt.Printf("✅ Wrote built-in rules to %s\n", rulesPath)
+}
+
+func findWork(client *storage.CentralizedClient, workID string) *models.Work {
+	works, err := client.GetAllWork()
+	if err != nil {
+		log.Fatalf("Failed to load work: %v", err)
+	}
+	for _, work := range works {
+		if work.ID == workID {
+			return work
+		}
+	}
+	log.Fatalf("Work item not found: %s", workID)
+	return nil
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"claude-work-tracker-ui/internal/storage"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("Usage: sync <command> [options]")
+		fmt.Println("Commands:")
+		fmt.Println("  init <remote> [branch]   - Turn ~/.claude/work-data into a git repo tracking <remote>")
+		fmt.Println("  status                   - Show sync status")
+		fmt.Println("  now                      - Commit, pull and push")
+		fmt.Println("  pull                     - Pull and merge remote changes")
+		fmt.Println("  push                     - Push local commits")
+		fmt.Println("  conflicts                - List unresolved conflicts")
+		fmt.Println("  resolve <n> ours|theirs  - Resolve a conflict")
+		os.Exit(1)
+	}
+
+	externalStorage, err := storage.NewExternalStorage()
+	if err != nil {
+		log.Fatalf("Failed to open storage: %v", err)
+	}
+
+	gitSync, err := storage.NewGitSync(externalStorage)
+	if err != nil {
+		log.Fatalf("Failed to load sync config: %v", err)
+	}
+
+	switch os.Args[1] {
+	case "init":
+		runInit(gitSync)
+	case "status":
+		runStatus(gitSync)
+	case "now":
+		runSync(gitSync)
+	case "pull":
+		runPull(gitSync)
+	case "push":
+		runPush(gitSync)
+	case "conflicts":
+		runConflicts(gitSync)
+	case "resolve":
+		runResolve(gitSync)
+	default:
+		fmt.Printf("Unknown command: %s\n", os.Args[1])
+		os.Exit(1)
+	}
+}
+
+func runInit(gs *storage.GitSync) {
+	remote := ""
+	if len(os.Args) > 2 {
+		remote = os.Args[2]
+	}
+	branch := ""
+	if len(os.Args) > 3 {
+		branch = os.Args[3]
+	}
+
+	if err := gs.Init(remote, branch); err != nil {
+		log.Fatalf("Failed to initialize sync: %v", err)
+	}
+
+	config := gs.GetConfig()
+	fmt.Println("✅ Git sync initialized")
+	fmt.Printf("Remote: %s\n", valueOr(config.Remote, "(none)"))
+	fmt.Printf("Branch: %s\n", config.Branch)
+	fmt.Printf("Auto-commit: %v • Auto-push: %v\n", config.AutoCommit, config.AutoPush)
+}
+
+func runStatus(gs *storage.GitSync) {
+	status, err := gs.Status()
+	if err != nil {
+		log.Fatalf("Failed to read status: %v", err)
+	}
+
+	fmt.Printf("\n🔄 Sync Status\n")
+	fmt.Printf("═══════════════════════════\n")
+	fmt.Printf("Enabled: %v\n", status.Enabled)
+	fmt.Printf("Initialized: %v\n", status.Initialized)
+	fmt.Printf("Remote: %s\n", valueOr(status.Remote, "(none)"))
+	fmt.Printf("Branch: %s\n", status.Branch)
+	fmt.Printf("Uncommitted files: %d\n", status.UncommittedFiles)
+	fmt.Printf("Open conflicts: %d\n", status.OpenConflicts)
+	if status.LastSync != nil {
+		fmt.Printf("Last sync: %s\n", status.LastSync.Format("2006-01-02 15:04"))
+	} else {
+		fmt.Printf("Last sync: never\n")
+	}
+}
+
+func runSync(gs *storage.GitSync) {
+	fmt.Println("🔄 Syncing work data...")
+
+	result, err := gs.Sync()
+	if err != nil {
+		log.Fatalf("Sync failed: %v", err)
+	}
+
+	printResult(result)
+	fmt.Println("✅ Sync complete")
+}
+
+func runPull(gs *storage.GitSync) {
+	fmt.Println("⬇️  Pulling remote changes...")
+
+	result, err := gs.Pull()
+	if err != nil {
+		log.Fatalf("Pull failed: %v", err)
+	}
+
+	printResult(result)
+}
+
+func runPush(gs *storage.GitSync) {
+	fmt.Println("⬆️  Pushing local changes...")
+
+	if _, err := gs.CommitAll("Local changes before push"); err != nil {
+		log.Fatalf("Commit failed: %v", err)
+	}
+	if err := gs.Push(); err != nil {
+		log.Fatalf("Push failed: %v", err)
+	}
+
+	fmt.Println("✅ Pushed")
+}
+
+func runConflicts(gs *storage.GitSync) {
+	conflicts, err := gs.LoadConflicts()
+	if err != nil {
+		log.Fatalf("Failed to load conflicts: %v", err)
+	}
+
+	if len(conflicts) == 0 {
+		fmt.Println("✅ No open conflicts")
+		return
+	}
+
+	fmt.Printf("\n⚠️  Open Conflicts (%d)\n", len(conflicts))
+	fmt.Printf("────────────────────────────\n")
+	for i, conflict := range conflicts {
+		fmt.Printf("%d. %s • %s\n", i+1, conflict.Path, conflict.Field)
+		fmt.Printf("   local:  %v\n", conflict.Ours)
+		fmt.Printf("   remote: %v\n", conflict.Theirs)
+	}
+}
+
+func runResolve(gs *storage.GitSync) {
+	if len(os.Args) < 4 {
+		fmt.Println("Usage: sync resolve <n> ours|theirs")
+		os.Exit(1)
+	}
+
+	n, err := strconv.Atoi(os.Args[2])
+	if err != nil {
+		log.Fatalf("Invalid conflict number: %s", os.Args[2])
+	}
+
+	var takeTheirs bool
+	switch os.Args[3] {
+	case "ours":
+		takeTheirs = false
+	case "theirs":
+		takeTheirs = true
+	default:
+		log.Fatalf("Unknown side: %s (expected ours or theirs)", os.Args[3])
+	}
+
+	if err := gs.ResolveConflict(n-1, takeTheirs); err != nil {
+		log.Fatalf("Failed to resolve conflict: %v", err)
+	}
+
+	fmt.Println("✅ Conflict resolved")
+}
+
+func printResult(result *storage.SyncResult) {
+	if result.Committed {
+		fmt.Println("• Committed local changes")
+	}
+	if result.Pulled {
+		fmt.Println("• Merged remote changes")
+	}
+	for _, file := range result.MergedFiles {
+		fmt.Printf("  ↳ merged %s\n", file)
+	}
+	if len(result.Conflicts) > 0 {
+		fmt.Printf("⚠️  %d conflicts kept the local value, run 'sync conflicts' to review\n", len(result.Conflicts))
+	}
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
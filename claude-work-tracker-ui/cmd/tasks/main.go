@@ -0,0 +1,252 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/data"
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("Usage: tasks <command> [flags]")
+		fmt.Println("Commands:")
+		fmt.Println("  list [--group G] [--open] [--status S] [--owner O] [--tag T] [--project P] [--due-in N] [--search Q] [--all]")
+		fmt.Println("                                    - List the tasks of all work items across all projects")
+		fmt.Println("  set <task> <status> [--work ID]   - Change a task's status (todo, in_progress, completed, blocked, cancelled)")
+		fmt.Println("  done <task> [--work ID]           - Mark a task completed")
+		fmt.Println("  toggle <task> [--work ID]         - Tick an open task, or reopen a finished one")
+		fmt.Println("  promote <source-id> <task>        - Spin a task of a work item or plan artifact out into its own work item")
+		fmt.Println("  promote-phase <source-id> <phase> - Spin a whole phase out into its own work item")
+		fmt.Println("")
+		fmt.Println("Tasks are given by ID (task-1a2b3c4d) or by title. Groupings: status, phase, assignee, due.")
+		os.Exit(1)
+	}
+
+	command := os.Args[1]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	group := flags.String("group", data.TaskGroupStatus, "group by status, phase, assignee or due")
+	open := flags.Bool("open", false, "only show tasks that aren't completed or cancelled")
+	status := flags.String("status", "", "comma-separated statuses to show")
+	owner := flags.String("owner", "", "only show tasks assigned to this owner, \"me\" for yourself")
+	tag := flags.String("tag", "", "only show tasks with this tag")
+	project := flags.String("project", "", "only show tasks of this project (name or ID)")
+	dueIn := flags.Int("due-in", -1, "only show tasks due within N days, 0 for today")
+	search := flags.String("search", "", "only show tasks whose task, phase or work title contains this")
+	all := flags.Bool("all", false, "include tasks of closed work items")
+	workID := flags.String("work", "", "work item the task belongs to, when its title is ambiguous")
+
+	// Flags may follow the positional arguments
+	var args []string
+	rest := os.Args[2:]
+	for {
+		flags.Parse(rest)
+		rest = flags.Args()
+		if len(rest) == 0 {
+			break
+		}
+		args = append(args, rest[0])
+		rest = rest[1:]
+	}
+
+	client, err := storage.NewCentralizedClient()
+	if err != nil {
+		log.Fatalf("Failed to open work storage: %v", err)
+	}
+	defer client.Close() // Let queued auto-commits finish
+
+	switch command {
+	case "list":
+		filter := data.TaskFilter{
+			OpenOnly:      *open,
+			Assignee:      *owner,
+			Tag:           *tag,
+			Project:       *project,
+			Query:         *search,
+			IncludeClosed: *all,
+		}
+		if *status != "" {
+			for _, name := range strings.Split(*status, ",") {
+				s, err := models.ParseTaskStatus(name)
+				if err != nil {
+					log.Fatalf("%v", err)
+				}
+				filter.Statuses = append(filter.Statuses, s)
+			}
+		}
+		if *dueIn >= 0 {
+			now := time.Now()
+			before := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, *dueIn+1)
+			filter.DueBefore = &before
+		}
+		listTasks(client, filter, *group)
+	case "set":
+		if len(args) < 2 {
+			fmt.Println("Usage: tasks set <task> <status> [--work ID]")
+			os.Exit(1)
+		}
+		s, err := models.ParseTaskStatus(args[1])
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		setTask(client, args[0], *workID, func(models.TaskStatus) models.TaskStatus { return s })
+	case "done":
+		if len(args) < 1 {
+			fmt.Println("Usage: tasks done <task> [--work ID]")
+			os.Exit(1)
+		}
+		setTask(client, args[0], *workID, func(models.TaskStatus) models.TaskStatus { return models.TaskStatusCompleted })
+	case "toggle":
+		if len(args) < 1 {
+			fmt.Println("Usage: tasks toggle <task> [--work ID]")
+			os.Exit(1)
+		}
+		setTask(client, args[0], *workID, toggledStatus)
+	case "promote", "promote-phase":
+		if len(args) < 2 {
+			fmt.Println("Usage: tasks promote <source-id> <task> | tasks promote-phase <source-id> <phase>")
+			os.Exit(1)
+		}
+		promote := client.PromoteTask
+		if command == "promote-phase" {
+			promote = client.PromotePhase
+		}
+		work, err := promote(args[0], strings.Join(args[1:], " "))
+		if err != nil {
+			log.Fatalf("Failed to promote: %v", err)
+		}
+		fmt.Printf("⇪ Created %s (%s) in %s\n", work.Title, work.ID, strings.ToUpper(work.Schedule))
+		fmt.Printf("   ↳ %s now links to it; completing it ticks the link\n", args[0])
+	default:
+		fmt.Printf("Unknown command: %s\n", command)
+		os.Exit(1)
+	}
+}
+
+func listTasks(client *storage.CentralizedClient, filter data.TaskFilter, group string) {
+	entries, err := client.GetAllTasks()
+	if err != nil {
+		log.Fatalf("Failed to load tasks: %v", err)
+	}
+	entries = data.FilterTasks(entries, filter)
+	if len(entries) == 0 {
+		fmt.Println("No matching tasks")
+		return
+	}
+
+	open := 0
+	for _, entry := range entries {
+		if entry.IsOpen() {
+			open++
+		}
+	}
+	fmt.Printf("☑ %d tasks, %d open\n", len(entries), open)
+
+	for _, g := range data.GroupTasks(entries, group, time.Now()) {
+		fmt.Printf("\n%s (%d)\n", g.Name, len(g.Entries))
+		for _, entry := range g.Entries {
+			task := entry.Task
+			line := fmt.Sprintf("  %s %-45s", task.GetStatusIcon(), truncate(task.Title, 45))
+			if meta := taskMeta(task); meta != "" {
+				line += "  " + meta
+			}
+			fmt.Printf("%s  — %s (%s) [%s]\n", line, truncate(entry.Work.Title, 30), entry.ProjectName, task.ID)
+		}
+	}
+}
+
+// setTask finds a task across all projects and saves the status next returns for it
+func setTask(client *storage.CentralizedClient, ref, workID string, next func(models.TaskStatus) models.TaskStatus) {
+	entries, err := client.GetAllTasks()
+	if err != nil {
+		log.Fatalf("Failed to load tasks: %v", err)
+	}
+	entry := findTask(entries, ref, workID)
+
+	status := next(entry.Task.Status)
+	if status == entry.Task.Status {
+		fmt.Printf("%s is already %s\n", entry.Task.Title, strings.ToLower(entry.Task.GetDisplayStatus()))
+		return
+	}
+	if _, err := client.SetTaskStatus(entry.ProjectID, entry.Work.ID, entry.Task.ID, status); err != nil {
+		log.Fatalf("Failed to update task: %v", err)
+	}
+	changed := models.Task{Status: status}
+	fmt.Printf("✅ %s %s → %s (%s)\n", changed.GetStatusIcon(), entry.Task.Title, changed.GetDisplayStatus(), entry.Work.Title)
+}
+
+// findTask resolves a task by ID, title or a unique title fragment
+func findTask(entries []data.TaskEntry, ref, workID string) data.TaskEntry {
+	var candidates []data.TaskEntry
+	for _, entry := range entries {
+		if workID == "" || entry.Work.ID == workID {
+			candidates = append(candidates, entry)
+		}
+	}
+
+	var matches []data.TaskEntry
+	for _, match := range []func(data.TaskEntry) bool{
+		func(e data.TaskEntry) bool { return e.Task.ID == ref },
+		func(e data.TaskEntry) bool { return strings.EqualFold(e.Task.Title, ref) },
+		func(e data.TaskEntry) bool {
+			return strings.Contains(strings.ToLower(e.Task.Title), strings.ToLower(ref))
+		},
+	} {
+		for _, entry := range candidates {
+			if match(entry) {
+				matches = append(matches, entry)
+			}
+		}
+		if len(matches) > 0 {
+			break
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		log.Fatalf("Task not found: %s", ref)
+	case 1:
+		return matches[0]
+	}
+	fmt.Printf("⚠️  %q matches %d tasks, pick one by ID or pass --work:\n", ref, len(matches))
+	for _, entry := range matches {
+		fmt.Printf("  %s %s — %s [%s, work %s]\n", entry.Task.GetStatusIcon(), entry.Task.Title, entry.Work.Title, entry.Task.ID, entry.Work.ID)
+	}
+	os.Exit(1)
+	return data.TaskEntry{}
+}
+
+// toggledStatus ticks open tasks and reopens finished ones
+func toggledStatus(status models.TaskStatus) models.TaskStatus {
+	if status == models.TaskStatusCompleted || status == models.TaskStatusCancelled {
+		return models.TaskStatusTodo
+	}
+	return models.TaskStatusCompleted
+}
+
+func taskMeta(task *models.Task) string {
+	var parts []string
+	if task.AssignedTo != "" {
+		parts = append(parts, "@"+task.AssignedTo)
+	}
+	if task.DueAt != nil {
+		parts = append(parts, "due "+task.DueAt.Format("2006-01-02"))
+	}
+	if task.Phase != "" {
+		parts = append(parts, task.Phase)
+	}
+	return strings.Join(parts, " • ")
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
@@ -10,6 +10,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"claude-work-tracker-ui/internal/automation"
+	"claude-work-tracker-ui/internal/daemon"
 	"claude-work-tracker-ui/internal/models"
 	"claude-work-tracker-ui/internal/storage"
 	"claude-work-tracker-ui/internal/views"
@@ -25,6 +27,23 @@ type CentralizedApp struct {
 	quitting        bool
 	projectSwitcher *ProjectSwitcherModel
 	showProjects    bool
+	syncConflicts   *SyncConflictsModel
+	showConflicts   bool
+	hookAudit       *HookAuditModel
+	showHookAudit   bool
+	inbox           *TransitionInboxModel
+	showInbox       bool
+	myTasks         *MyTasksModel
+	showMyTasks     bool
+	report          *ReportModel
+	showReport      bool
+	syncing         bool
+	syncError       error
+	lastSync        *time.Time // Read from the sync config at startup, then from each finished sync
+	daemonStatus    *daemon.Status // Nil unless the project's daemon is running
+	branchWarnings  int // In-progress items whose branch was merged or deleted
+	statusMessage   string // Reminder or background error, cleared on the next key press in the list
+	statusIsError   bool
 }
 
 // NewCentralizedApp creates a new app with centralized storage
@@ -47,12 +66,30 @@ func NewCentralizedApp() (*CentralizedApp, error) {
 	// Create project switcher
 	projectSwitcher := NewProjectSwitcherModel(client)
 
+	// Approved transitions write their create_update entries to the item's updates
+	engine := automation.NewTransitionEngine(client.GetHookSystem(), automation.DefaultTransitionConfig())
+	engine.SetUpdateSink(func(work *models.Work, update *models.Update) {
+		if err := client.CreateUpdate(work.ID, update); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	})
+
 	app := &CentralizedApp{
 		client:          client,
 		currentView:     FancyListView,
 		fancyListView:   fancyListView,
 		projectSwitcher: projectSwitcher,
 		showProjects:    false,
+		syncConflicts:   NewSyncConflictsModel(client.GetGitSync()),
+		hookAudit:       NewHookAuditModel(client.GetHookSystem()),
+		inbox:           NewTransitionInboxModel(client, engine),
+		myTasks:         NewMyTasksModel(client),
+		report:          NewReportModel(client),
+	}
+	app.syncConflicts.Refresh()
+	app.inbox.Refresh()
+	if gitSync := client.GetGitSync(); gitSync != nil {
+		app.lastSync = gitSync.GetConfig().LastSync
 	}
 
 	// Cleanup old repository storage
@@ -64,7 +101,19 @@ func NewCentralizedApp() (*CentralizedApp, error) {
 }
 
 func (a *CentralizedApp) Init() tea.Cmd {
-	return a.fancyListView.Init()
+	cmds := []tea.Cmd{a.fancyListView.Init(), loadBranchContext(a.client), waitForProposedTransition(a.client), checkReminders(a.client, 0), watchDaemonStatus(a.client, 0)}
+
+	// Pull remote changes on startup when git sync is configured
+	if gitSync := a.client.GetGitSync(); gitSync != nil && gitSync.IsEnabled() &&
+		gitSync.GetConfig().PullOnStart && gitSync.GetConfig().Remote != "" {
+		a.syncing = true
+		cmds = append(cmds, runSync(gitSync))
+	}
+	if gitSync := a.client.GetGitSync(); gitSync != nil {
+		cmds = append(cmds, waitForSyncError(gitSync))
+	}
+
+	return tea.Batch(cmds...)
 }
 
 func (a *CentralizedApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -84,8 +133,88 @@ func (a *CentralizedApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		
 		a.projectSwitcher.SetSize(msg.Width, msg.Height)
+		a.syncConflicts.SetSize(msg.Width, msg.Height)
+		a.hookAudit.SetSize(msg.Width, msg.Height)
+		a.inbox.SetSize(msg.Width, msg.Height)
+		a.myTasks.SetSize(msg.Width, msg.Height)
+		a.report.SetSize(msg.Width, msg.Height)
+
+	case syncCompletedMsg:
+		a.syncing = false
+		a.syncError = msg.err
+		a.syncConflicts.Refresh()
+		if msg.result != nil && msg.result.SyncedAt != nil {
+			a.lastSync = msg.result.SyncedAt
+		}
+		if msg.err != nil {
+			a.setStatusError(fmt.Errorf("sync failed: %w", msg.err))
+		}
+
+		// Reload the list if the merge brought in changes
+		if msg.result != nil && msg.result.Pulled {
+			cmds = append(cmds, a.fancyListView.Init())
+		}
+
+	case syncErrorMsg:
+		a.syncError = msg.err
+		a.setStatusError(msg.err)
+		cmds = append(cmds, waitForSyncError(a.client.GetGitSync()))
+
+	case transitionProposedMsg:
+		a.inbox.Refresh()
+		a.setStatusInfo(fmt.Sprintf("📥 %s proposes %s → %s for %s (ctrl+t to review)",
+			msg.pending.Rule, msg.pending.FromStatus, msg.pending.ToStatus, msg.pending.Work.Title))
+		cmds = append(cmds, waitForProposedTransition(a.client))
+
+	case daemonStatusMsg:
+		a.daemonStatus = msg.status
+		cmds = append(cmds, watchDaemonStatus(a.client, daemonStatusInterval))
+
+	case remindersCheckedMsg:
+		a.setStatusError(msg.err)
+		if len(msg.reminders) > 0 {
+			var messages []string
+			for _, reminder := range msg.reminders {
+				messages = append(messages, reminder.Message)
+			}
+			a.setStatusInfo("⏰ " + strings.Join(messages, " • "))
+		}
+		cmds = append(cmds, checkReminders(a.client, automation.DefaultReminderConfig().CheckInterval))
+
+	case branchContextMsg:
+		if msg.focusID != "" && msg.focusID != a.fancyListView.PinnedWorkID() {
+			a.fancyListView.FocusWork(msg.focusID)
+		}
+		a.fancyListView.SetBranchWarnings(msg.warnings)
+		a.branchWarnings = len(msg.warnings)
+
+	case hookRerunMsg:
+		m, cmd := a.hookAudit.Update(msg)
+		a.hookAudit = m.(*HookAuditModel)
+		return a, cmd
 
 	case tea.KeyMsg:
+		// The hook audit search box takes every key while it has focus
+		if a.showHookAudit && a.hookAudit.IsEditing() {
+			m, cmd := a.hookAudit.Update(msg)
+			a.hookAudit = m.(*HookAuditModel)
+			return a, cmd
+		}
+		if a.showMyTasks && a.myTasks.IsEditing() {
+			m, cmd := a.myTasks.Update(msg)
+			a.myTasks = m.(*MyTasksModel)
+			return a, cmd
+		}
+		listShown := !a.showProjects && !a.showConflicts && !a.showHookAudit && !a.showInbox && !a.showMyTasks && !a.showReport
+		if listShown {
+			a.statusMessage = ""
+		}
+		if listShown && a.fancyListView.IsEditing() {
+			m, cmd := a.fancyListView.Update(msg)
+			a.fancyListView = m.(*views.FancyListView)
+			return a, cmd
+		}
+
 		// Global hotkeys
 		switch {
 		case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+p"))):
@@ -95,16 +224,144 @@ func (a *CentralizedApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmds = append(cmds, a.projectSwitcher.Init())
 			}
 			return a, tea.Batch(cmds...)
+
+		case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+s"))):
+			// Sync with the configured remote
+			if gitSync := a.client.GetGitSync(); gitSync != nil && gitSync.IsEnabled() && !a.syncing {
+				a.syncing = true
+				cmds = append(cmds, runSync(gitSync))
+			}
+			return a, tea.Batch(cmds...)
+
+		case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+o"))):
+			// Toggle sync conflicts panel
+			a.showConflicts = !a.showConflicts
+			if a.showConflicts {
+				cmds = append(cmds, a.syncConflicts.Init())
+			}
+			return a, tea.Batch(cmds...)
+
+		case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+l"))):
+			// Toggle hook runs panel
+			a.showHookAudit = !a.showHookAudit
+			if a.showHookAudit {
+				cmds = append(cmds, a.hookAudit.Init())
+			}
+			return a, tea.Batch(cmds...)
+
+		case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+t"))):
+			// Toggle proposed transitions inbox
+			a.showInbox = !a.showInbox
+			if a.showInbox {
+				cmds = append(cmds, a.inbox.Init())
+			} else {
+				cmds = append(cmds, a.fancyListView.Init())
+			}
+			return a, tea.Batch(cmds...)
+
+		case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+k"))):
+			// Toggle the cross-project task list
+			a.showMyTasks = !a.showMyTasks
+			if a.showMyTasks {
+				cmds = append(cmds, a.myTasks.Init())
+			} else {
+				cmds = append(cmds, a.fancyListView.Init())
+			}
+			return a, tea.Batch(cmds...)
+
+		case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+w"))):
+			// Toggle the standup / weekly report
+			a.showReport = !a.showReport
+			if a.showReport {
+				cmds = append(cmds, a.report.Init())
+			}
+			return a, tea.Batch(cmds...)
 			
 		case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c"))):
 			if a.showProjects {
 				a.showProjects = false
 				return a, nil
 			}
+			if a.showConflicts {
+				a.showConflicts = false
+				return a, nil
+			}
+			if a.showHookAudit {
+				a.showHookAudit = false
+				return a, nil
+			}
+			if a.showInbox {
+				a.showInbox = false
+				return a, a.fancyListView.Init()
+			}
+			if a.showMyTasks {
+				a.showMyTasks = false
+				return a, a.fancyListView.Init()
+			}
+			if a.showReport {
+				a.showReport = false
+				return a, nil
+			}
 			a.quitting = true
 			return a, tea.Quit
 		}
 
+		if a.showConflicts {
+			if msg.String() == "esc" {
+				a.showConflicts = false
+				// Resolved conflicts may have rewritten work files
+				cmds = append(cmds, a.fancyListView.Init())
+				return a, tea.Batch(cmds...)
+			}
+			m, cmd := a.syncConflicts.Update(msg)
+			a.syncConflicts = m.(*SyncConflictsModel)
+			return a, cmd
+		}
+
+		if a.showHookAudit {
+			if msg.String() == "esc" {
+				a.showHookAudit = false
+				return a, nil
+			}
+			m, cmd := a.hookAudit.Update(msg)
+			a.hookAudit = m.(*HookAuditModel)
+			return a, cmd
+		}
+
+		if a.showInbox {
+			if msg.String() == "esc" {
+				a.showInbox = false
+				// Approved transitions rewrite work files
+				cmds = append(cmds, a.fancyListView.Init())
+				return a, tea.Batch(cmds...)
+			}
+			m, cmd := a.inbox.Update(msg)
+			a.inbox = m.(*TransitionInboxModel)
+			return a, cmd
+		}
+
+		if a.showMyTasks {
+			if msg.String() == "esc" {
+				a.showMyTasks = false
+				// Ticked tasks rewrite work files
+				cmds = append(cmds, a.fancyListView.Init())
+				return a, tea.Batch(cmds...)
+			}
+			m, cmd := a.myTasks.Update(msg)
+			a.myTasks = m.(*MyTasksModel)
+			return a, cmd
+		}
+
+		if a.showReport {
+			if msg.String() == "esc" {
+				a.showReport = false
+				return a, nil
+			}
+			m, cmd := a.report.Update(msg)
+			a.report = m.(*ReportModel)
+			return a, cmd
+		}
+
 		// Handle project switcher input
 		if a.showProjects {
 			m, cmd := a.projectSwitcher.Update(msg)
@@ -119,7 +376,7 @@ func (a *CentralizedApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					// Recreate views with new project
 					adapter := &CentralizedWorkAdapter{client: a.client}
 					a.fancyListView = views.NewFancyListViewWithAdapter(adapter)
-					cmds = append(cmds, a.fancyListView.Init())
+					cmds = append(cmds, a.fancyListView.Init(), loadBranchContext(a.client))
 				}
 				a.showProjects = false
 			}
@@ -162,6 +419,31 @@ func (a *CentralizedApp) View() string {
 		return a.projectSwitcher.View()
 	}
 
+	// Show sync conflicts overlay
+	if a.showConflicts {
+		return a.syncConflicts.View()
+	}
+
+	// Show hook runs overlay
+	if a.showHookAudit {
+		return a.hookAudit.View()
+	}
+
+	// Show proposed transitions overlay
+	if a.showInbox {
+		return a.inbox.View()
+	}
+
+	// Show cross-project tasks overlay
+	if a.showMyTasks {
+		return a.myTasks.View()
+	}
+
+	// Show standup / weekly report overlay
+	if a.showReport {
+		return a.report.View()
+	}
+
 	// Show current view with project info header
 	project := a.client.GetCurrentProject()
 	
@@ -171,13 +453,108 @@ func (a *CentralizedApp) View() string {
 		Padding(0, 1).
 		Width(a.width)
 		
-	header := headerStyle.Render(fmt.Sprintf("📁 %s • %s", project.Name, project.ActiveBranch))
+	headerText := fmt.Sprintf("📁 %s • %s", project.Name, project.ActiveBranch)
+	if syncStatus := a.syncStatusText(); syncStatus != "" {
+		headerText += " • " + syncStatus
+	}
+	if pending := a.inbox.Count(); pending > 0 {
+		headerText += fmt.Sprintf(" • 📥 %d proposed (ctrl+t)", pending)
+	}
+	if a.branchWarnings > 0 {
+		headerText += fmt.Sprintf(" • ⚠ %d merged/deleted branches", a.branchWarnings)
+	}
+	if daemonStatus := a.daemonStatusText(); daemonStatus != "" {
+		headerText += " • " + daemonStatus
+	}
+	header := headerStyle.Render(headerText)
 	
 	content := a.fancyListView.View()
+	if a.statusMessage != "" {
+		return lipgloss.JoinVertical(lipgloss.Top, header, a.renderStatusMessage(), content)
+	}
 	
 	return lipgloss.JoinVertical(lipgloss.Top, header, content)
 }
 
+// setStatusError shows a background error below the header
+func (a *CentralizedApp) setStatusError(err error) {
+	if err == nil {
+		return
+	}
+	a.statusMessage = "✗ " + err.Error()
+	a.statusIsError = true
+}
+
+// setStatusInfo shows a reminder or other notice below the header
+func (a *CentralizedApp) setStatusInfo(message string) {
+	a.statusMessage = message
+	a.statusIsError = false
+}
+
+// renderStatusMessage renders the last reminder or background error
+func (a *CentralizedApp) renderStatusMessage() string {
+	color := lipgloss.Color("214")
+	if a.statusIsError {
+		color = lipgloss.Color("196")
+	}
+	return lipgloss.NewStyle().
+		Foreground(color).
+		Padding(0, 1).
+		Width(a.width).
+		Render(a.statusMessage)
+}
+
+// daemonStatusInterval is how often the header re-reads the daemon's status file
+const daemonStatusInterval = 5 * time.Second
+
+// daemonStatusMsg carries the status of the project's daemon, nil when it isn't running
+type daemonStatusMsg struct {
+	status *daemon.Status
+}
+
+// watchDaemonStatus reads the status file of the current project's daemon after delay
+func watchDaemonStatus(client *storage.CentralizedClient, delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		status, err := daemon.ReadStatus(daemon.StatusPath(client.GetCurrentProject().ID))
+		if err != nil || !status.IsLive() {
+			return daemonStatusMsg{}
+		}
+		return daemonStatusMsg{status: status}
+	})
+}
+
+// daemonStatusText summarizes the background daemon for the header
+func (a *CentralizedApp) daemonStatusText() string {
+	if a.daemonStatus == nil {
+		return ""
+	}
+
+	text := "🤖 daemon"
+	if idle := len(a.daemonStatus.Inactive); idle > 0 {
+		text += fmt.Sprintf(" (💤 %d idle)", idle)
+	}
+	return text
+}
+
+// syncStatusText summarizes git sync state for the header
+func (a *CentralizedApp) syncStatusText() string {
+	gitSync := a.client.GetGitSync()
+	if gitSync == nil || !gitSync.IsEnabled() {
+		return ""
+	}
+
+	switch {
+	case a.syncing:
+		return "⇅ syncing..."
+	case a.syncConflicts.Count() > 0:
+		return fmt.Sprintf("⚠ %d sync conflicts (ctrl+o)", a.syncConflicts.Count())
+	case a.syncError != nil:
+		return "✗ sync failed (ctrl+s to retry)"
+	default:
+		return "⇅ " + formatSyncAge(a.lastSync)
+	}
+}
+
 // CentralizedWorkAdapter adapts the centralized client to the WorkDataProvider interface
 type CentralizedWorkAdapter struct {
 	client *storage.CentralizedClient
@@ -229,6 +606,40 @@ func (a *CentralizedWorkAdapter) CompleteWork(workID string) error {
 	return fmt.Errorf("work item not found: %s", workID)
 }
 
+// BlockWork marks a work item as blocked
+func (a *CentralizedWorkAdapter) BlockWork(workID string) error {
+	work, err := a.findWork(workID)
+	if err != nil {
+		return err
+	}
+	work.MarkAsBlocked()
+	return a.client.UpdateWork(work)
+}
+
+// UnblockWork restores a blocked work item to the status it had before
+func (a *CentralizedWorkAdapter) UnblockWork(workID string) error {
+	work, err := a.findWork(workID)
+	if err != nil {
+		return err
+	}
+	work.Unblock()
+	return a.client.UpdateWork(work)
+}
+
+// findWork looks up a work item in the current project
+func (a *CentralizedWorkAdapter) findWork(workID string) (*models.Work, error) {
+	allWork, err := a.client.GetAllWork()
+	if err != nil {
+		return nil, err
+	}
+	for _, work := range allWork {
+		if work.ID == workID {
+			return work, nil
+		}
+	}
+	return nil, fmt.Errorf("work item not found: %s", workID)
+}
+
 func (a *CentralizedWorkAdapter) SearchWork(query string) ([]*models.Work, error) {
 	// For now, search only in current project
 	// TODO: Add cross-project search support
@@ -0,0 +1,154 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"claude-work-tracker-ui/internal/automation"
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+// newTestApp creates an app for a fresh project in a temporary home, running the given
+// rules file or the built-in rules if it's empty
+func newTestApp(t *testing.T, rules string) *CentralizedApp {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	if rules != "" {
+		path := automation.DefaultRulesPath()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(rules), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	app, err := NewCentralizedApp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	return app
+}
+
+func TestRemindersShowInView(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  remindersCheckedMsg
+		want []string
+	}{
+		{
+			name: "due reminder",
+			msg: remindersCheckedMsg{reminders: []automation.Reminder{
+				{WorkID: "work-1", Message: "Ship the release is due tomorrow"},
+			}},
+			want: []string{"⏰ Ship the release is due tomorrow"},
+		},
+		{
+			name: "several reminders",
+			msg: remindersCheckedMsg{reminders: []automation.Reminder{
+				{WorkID: "work-1", Message: "Docs are overdue"},
+				{WorkID: "work-2", Message: "Tests should have started"},
+			}},
+			want: []string{"Docs are overdue • Tests should have started"},
+		},
+		{
+			name: "failed check",
+			msg:  remindersCheckedMsg{err: errors.New("failed to check reminders: disk full")},
+			want: []string{"✗ failed to check reminders: disk full"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t, "")
+			app.Update(tt.msg)
+
+			view := app.View()
+			for _, want := range tt.want {
+				if !strings.Contains(view, want) {
+					t.Errorf("view doesn't show %q:\n%s", want, view)
+				}
+			}
+
+			// The next key press in the list clears it
+			app.Update(tea.KeyMsg{Type: tea.KeyDown})
+			if view := app.View(); strings.Contains(view, tt.want[0]) {
+				t.Errorf("%q is still shown after a key press", tt.want[0])
+			}
+		})
+	}
+}
+
+func TestSyncAgeComesFromFinishedSyncs(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	app := newTestApp(t, "")
+	if err := app.client.GetGitSync().Init("", "main"); err != nil {
+		t.Fatal(err)
+	}
+	if view := app.View(); !strings.Contains(view, "never synced") {
+		t.Errorf("header before any sync:\n%s", view)
+	}
+
+	syncedAt := time.Now().Add(-2 * time.Hour)
+	app.Update(syncCompletedMsg{result: &storage.SyncResult{SyncedAt: &syncedAt}})
+	if view := app.View(); !strings.Contains(view, "synced 2h ago") {
+		t.Errorf("header after a sync:\n%s", view)
+	}
+}
+
+func TestSyncFailuresShowInView(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  tea.Msg
+		want string
+	}{
+		{
+			name: "sync",
+			msg:  syncCompletedMsg{err: errors.New("failed to push: rejected")},
+			want: "✗ sync failed: failed to push: rejected",
+		},
+		{
+			name: "background auto-commit",
+			msg:  syncErrorMsg{err: errors.New("auto-commit failed: index.lock exists")},
+			want: "✗ auto-commit failed: index.lock exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t, "")
+			app.Update(tt.msg)
+			if view := app.View(); !strings.Contains(view, tt.want) {
+				t.Errorf("view doesn't show %q:\n%s", tt.want, view)
+			}
+		})
+	}
+}
+
+func TestProposedTransitionShowsInView(t *testing.T) {
+	app := newTestApp(t, "")
+	work := &models.Work{ID: "work-1", Title: "Ship the release"}
+	app.Update(transitionProposedMsg{pending: automation.PendingTransition{
+		Work:       work,
+		Rule:       "start_at_half",
+		FromStatus: models.WorkStatusActive,
+		ToStatus:   models.WorkStatusInProgress,
+	}})
+
+	want := "📥 start_at_half proposes active → in_progress for Ship the release (ctrl+t to review)"
+	if view := app.View(); !strings.Contains(view, want) {
+		t.Errorf("view doesn't show %q:\n%s", want, view)
+	}
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"claude-work-tracker-ui/internal/git"
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+// branchContextMsg carries the work item for the checked out branch and the in-progress
+// items whose branch has been merged or deleted
+type branchContextMsg struct {
+	focusID  string
+	warnings map[string]string
+}
+
+// loadBranchContext matches the current checkout to a work item and checks the branches of
+// in-progress items
+func loadBranchContext(client *storage.CentralizedClient) tea.Cmd {
+	return func() tea.Msg {
+		works, err := client.GetAllWork()
+		if err != nil {
+			return branchContextMsg{}
+		}
+
+		ctx := context.Background()
+		cm := git.NewContextManager()
+		project := client.GetCurrentProject()
+		defaultBranch := cm.DefaultBranch(ctx, project.Path)
+		msg := branchContextMsg{warnings: make(map[string]string)}
+
+		// Only focus an item when the TUI was started inside this project's repository
+		if wd, err := os.Getwd(); err == nil {
+			if worktree, err := cm.CurrentWorktree(ctx, wd); err == nil && samePath(worktree.MainRoot, project.Path) {
+				if work := git.FindBranchWork(works, worktree, defaultBranch); work != nil {
+					msg.focusID = work.ID
+				}
+			}
+		}
+
+		for _, work := range works {
+			if work.Metadata.Status != models.WorkStatusInProgress || work.GitContext.Branch == "" {
+				continue
+			}
+			switch cm.BranchStatus(ctx, project.Path, work, defaultBranch) {
+			case git.BranchMerged:
+				msg.warnings[work.ID] = "branch " + work.GitContext.Branch + " merged"
+			case git.BranchDeleted:
+				msg.warnings[work.ID] = "branch " + work.GitContext.Branch + " deleted"
+			}
+		}
+		return msg
+	}
+}
+
+// samePath compares two directories after resolving symlinks
+func samePath(a, b string) bool {
+	if resolved, err := filepath.EvalSymlinks(a); err == nil {
+		a = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(b); err == nil {
+		b = resolved
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
+// CreateWorkBranch creates work/<id> from the default branch, either switching the current
+// checkout to it or in a new worktree next to the repository, and records it on the item
+func (a *CentralizedWorkAdapter) CreateWorkBranch(workID string, worktree bool) (string, error) {
+	work, err := a.findWork(workID)
+	if err != nil {
+		return "", err
+	}
+
+	ctx := context.Background()
+	cm := git.NewContextManager()
+	project := a.client.GetCurrentProject()
+	dir, err := os.Getwd()
+	if err != nil || !samePath(mainRoot(ctx, cm, dir), project.Path) {
+		dir = project.Path
+	}
+
+	branch := git.WorkBranchName(work)
+	base := cm.DefaultBranch(ctx, dir)
+	location := branch
+	if worktree {
+		location = git.WorktreePath(project.Path, branch)
+		if err := cm.AddWorktree(ctx, dir, location, branch, base); err != nil {
+			return "", err
+		}
+		work.GitContext.Worktree = location
+		work.GitContext.WorkingDirectory = location
+	} else {
+		if err := cm.CreateBranch(ctx, dir, branch, base); err != nil {
+			return "", err
+		}
+		if current, err := cm.CurrentWorktree(ctx, dir); err == nil {
+			work.GitContext.WorkingDirectory = current.Root
+		}
+	}
+	work.GitContext.Branch = branch
+
+	if err := a.client.UpdateWork(work); err != nil {
+		return "", err
+	}
+	return location, nil
+}
+
+// mainRoot returns the main checkout of the repository containing dir, or "" outside git
+func mainRoot(ctx context.Context, cm *git.ContextManager, dir string) string {
+	worktree, err := cm.CurrentWorktree(ctx, dir)
+	if err != nil {
+		return ""
+	}
+	return worktree.MainRoot
+}
@@ -0,0 +1,298 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"claude-work-tracker-ui/internal/hooks"
+)
+
+// hookAuditLimit is how many recent runs the panel loads
+const hookAuditLimit = 200
+
+// hookAuditFilter narrows the hook run list by outcome
+type hookAuditFilter int
+
+const (
+	auditFilterAll hookAuditFilter = iota
+	auditFilterFailed
+	auditFilterDenied
+)
+
+func (f hookAuditFilter) String() string {
+	switch f {
+	case auditFilterFailed:
+		return "failed"
+	case auditFilterDenied:
+		return "denied"
+	default:
+		return "all"
+	}
+}
+
+// hookRerunMsg is sent when a re-run of a failed hook finishes
+type hookRerunMsg struct {
+	result hooks.HookResult
+	err    error
+}
+
+// rerunHook runs a hook again in the background against its recorded context
+func rerunHook(hookSystem *hooks.HookSystem, entry hooks.AuditEntry) tea.Cmd {
+	return func() tea.Msg {
+		result, err := hookSystem.Rerun(context.Background(), entry)
+		return hookRerunMsg{result: result, err: err}
+	}
+}
+
+// HookAuditModel lists recent hook runs and lets the user re-run failed ones
+type HookAuditModel struct {
+	hookSystem *hooks.HookSystem
+	entries    []hooks.AuditEntry
+	visible    []hooks.AuditEntry
+	filter     hookAuditFilter
+	search     string
+	searching  bool
+	running    bool
+	cursor     int
+	message    string
+	width      int
+	height     int
+}
+
+// NewHookAuditModel creates a hook audit panel for the given hook system
+func NewHookAuditModel(hookSystem *hooks.HookSystem) *HookAuditModel {
+	return &HookAuditModel{hookSystem: hookSystem}
+}
+
+func (m *HookAuditModel) Init() tea.Cmd {
+	m.message = ""
+	m.Refresh()
+	return nil
+}
+
+// Refresh reloads recent hook runs from the audit log
+func (m *HookAuditModel) Refresh() {
+	m.entries = nil
+	if m.hookSystem == nil || m.hookSystem.GetAuditLog() == nil {
+		m.applyFilter()
+		return
+	}
+
+	entries, err := m.hookSystem.GetAuditLog().Recent(hookAuditLimit)
+	if err != nil {
+		m.message = fmt.Sprintf("Failed to load hook runs: %v", err)
+	}
+	m.entries = entries
+	m.applyFilter()
+}
+
+// applyFilter rebuilds the visible list from the outcome filter and search text
+func (m *HookAuditModel) applyFilter() {
+	search := strings.ToLower(m.search)
+	m.visible = m.visible[:0]
+	for _, entry := range m.entries {
+		switch m.filter {
+		case auditFilterFailed:
+			if entry.Success {
+				continue
+			}
+		case auditFilterDenied:
+			if entry.Decision != string(hooks.ActionDeny) {
+				continue
+			}
+		}
+		if search != "" {
+			haystack := strings.ToLower(entry.Hook + " " + string(entry.Event) + " " + entry.WorkID)
+			if !strings.Contains(haystack, search) {
+				continue
+			}
+		}
+		m.visible = append(m.visible, entry)
+	}
+	if m.cursor >= len(m.visible) {
+		m.cursor = max(0, len(m.visible)-1)
+	}
+}
+
+// IsEditing reports whether the search box has focus, so global keys should be passed through
+func (m *HookAuditModel) IsEditing() bool {
+	return m.searching
+}
+
+func (m *HookAuditModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case hookRerunMsg:
+		m.running = false
+		switch {
+		case msg.err != nil:
+			m.message = fmt.Sprintf("Re-run failed: %v", msg.err)
+		case msg.result.Success:
+			m.message = fmt.Sprintf("✓ %s succeeded (%s)", msg.result.HookName, msg.result.Duration.Round(time.Millisecond))
+		default:
+			m.message = fmt.Sprintf("✗ %s failed again: %v", msg.result.HookName, msg.result.Error)
+		}
+		m.Refresh()
+
+	case tea.KeyMsg:
+		if m.searching {
+			switch msg.Type {
+			case tea.KeyEnter, tea.KeyEsc:
+				m.searching = false
+			case tea.KeyBackspace:
+				if len(m.search) > 0 {
+					m.search = m.search[:len(m.search)-1]
+				}
+			case tea.KeyRunes, tea.KeySpace:
+				m.search += string(msg.Runes)
+			}
+			m.applyFilter()
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.visible)-1 {
+				m.cursor++
+			}
+		case "f":
+			m.filter = (m.filter + 1) % 3
+			m.applyFilter()
+		case "/":
+			m.searching = true
+		case "R":
+			m.Refresh()
+		case "r":
+			if m.cursor >= len(m.visible) || m.running {
+				break
+			}
+			entry := m.visible[m.cursor]
+			if entry.Success {
+				m.message = "Only failed runs can be re-run"
+				break
+			}
+			m.running = true
+			m.message = fmt.Sprintf("Re-running %s...", entry.Hook)
+			return m, rerunHook(m.hookSystem, entry)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *HookAuditModel) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("214")).
+		MarginBottom(1)
+
+	itemStyle := lipgloss.NewStyle().
+		PaddingLeft(2)
+
+	selectedStyle := lipgloss.NewStyle().
+		PaddingLeft(2).
+		Foreground(lipgloss.Color("214")).
+		Background(lipgloss.Color("235"))
+
+	detailStyle := lipgloss.NewStyle().
+		PaddingLeft(6).
+		Foreground(lipgloss.Color("245"))
+
+	failedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+
+	var s strings.Builder
+	s.WriteString(titleStyle.Render(fmt.Sprintf("🪝 Hook Runs (%d of %d) • filter: %s", len(m.visible), len(m.entries), m.filter)))
+	s.WriteString("\n")
+
+	if m.searching || m.search != "" {
+		cursor := ""
+		if m.searching {
+			cursor = "█"
+		}
+		s.WriteString(itemStyle.Render("Search: " + m.search + cursor))
+		s.WriteString("\n")
+	}
+	s.WriteString("\n")
+
+	if len(m.visible) == 0 {
+		s.WriteString(itemStyle.Render("No hook runs recorded"))
+		s.WriteString("\n")
+	}
+
+	// Keep the selection on screen, leaving room for the title, details and help
+	rows := max(1, m.height-14)
+	start := 0
+	if m.cursor >= rows {
+		start = m.cursor - rows + 1
+	}
+	end := min(len(m.visible), start+rows)
+
+	for i := start; i < end; i++ {
+		entry := m.visible[i]
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "▸ "
+		}
+
+		icon := "✓"
+		switch {
+		case entry.Decision == string(hooks.ActionDeny):
+			icon = "🚫"
+		case !entry.Success:
+			icon = "✗"
+		}
+
+		line := fmt.Sprintf("%s%s %s  %-22s %-24s %s",
+			cursor, icon, entry.Time.Format("01-02 15:04:05"), entry.Hook, entry.Event, entry.WorkID)
+		if entry.Rerun {
+			line += " (re-run)"
+		}
+
+		switch {
+		case i == m.cursor:
+			s.WriteString(selectedStyle.Render(line))
+		case !entry.Success:
+			s.WriteString(itemStyle.Render(failedStyle.Render(line)))
+		default:
+			s.WriteString(itemStyle.Render(line))
+		}
+		s.WriteString("\n")
+
+		if i == m.cursor {
+			s.WriteString(detailStyle.Render(fmt.Sprintf("duration: %s", entry.Duration.Round(time.Millisecond))))
+			s.WriteString("\n")
+			if entry.Decision != "" {
+				s.WriteString(detailStyle.Render("decision: " + entry.Decision))
+				s.WriteString("\n")
+			}
+			if entry.Error != "" {
+				s.WriteString(detailStyle.Render("error: " + formatConflictValue(entry.Error)))
+				s.WriteString("\n")
+			}
+		}
+	}
+
+	if m.message != "" {
+		s.WriteString("\n")
+		s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Render(m.message))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(lipgloss.NewStyle().Faint(true).Render("↑/↓: Navigate • f: Filter • /: Search • r: Re-run failed • R: Reload • Esc: Close"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, s.String())
+}
+
+func (m *HookAuditModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -0,0 +1,294 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"claude-work-tracker-ui/internal/data"
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+// MyTasksModel lists the checklist tasks of all work items across all projects, grouped and
+// filtered, and lets the user tick them off in place
+type MyTasksModel struct {
+	client    *storage.CentralizedClient
+	entries   []data.TaskEntry // Every task, unfiltered
+	groups    []data.TaskGroup
+	visible   []data.TaskEntry // Tasks in display order, for the cursor
+	grouping  int              // Index into data.TaskGroupings
+	openOnly  bool
+	mineOnly  bool
+	search    string
+	searching bool
+	cursor    int
+	message   string
+	width     int
+	height    int
+}
+
+// NewMyTasksModel creates the task list for the given storage client
+func NewMyTasksModel(client *storage.CentralizedClient) *MyTasksModel {
+	return &MyTasksModel{
+		client:   client,
+		openOnly: true,
+	}
+}
+
+func (m *MyTasksModel) Init() tea.Cmd {
+	m.message = ""
+	m.Refresh()
+	return nil
+}
+
+// Refresh reloads the tasks of every project
+func (m *MyTasksModel) Refresh() {
+	entries, err := m.client.GetAllTasks()
+	if err != nil {
+		m.entries = nil
+		m.message = fmt.Sprintf("Failed to load tasks: %v", err)
+	} else {
+		m.entries = entries
+	}
+	m.applyFilter()
+}
+
+// IsEditing reports whether the search box has focus, so global keys should be passed through
+func (m *MyTasksModel) IsEditing() bool {
+	return m.searching
+}
+
+// applyFilter rebuilds the groups from the filters, keeping the cursor on the same task
+func (m *MyTasksModel) applyFilter() {
+	var selected *data.TaskEntry
+	if m.cursor < len(m.visible) {
+		entry := m.visible[m.cursor]
+		selected = &entry
+	}
+
+	filter := data.TaskFilter{OpenOnly: m.openOnly, Query: m.search}
+	if m.mineOnly {
+		filter.Assignee = "me"
+	}
+	m.groups = data.GroupTasks(data.FilterTasks(m.entries, filter), data.TaskGroupings[m.grouping], time.Now())
+
+	m.visible = nil
+	for _, group := range m.groups {
+		m.visible = append(m.visible, group.Entries...)
+	}
+	for i, entry := range m.visible {
+		if selected != nil && sameTask(entry, *selected) {
+			m.cursor = i
+		}
+	}
+	if m.cursor >= len(m.visible) {
+		m.cursor = max(0, len(m.visible)-1)
+	}
+}
+
+// sameTask reports whether two entries are the same task of the same work item
+func sameTask(a, b data.TaskEntry) bool {
+	return a.ProjectID == b.ProjectID && a.Work.ID == b.Work.ID && a.Task.ID == b.Task.ID
+}
+
+// setStatus saves a new status for the selected task in its work file
+func (m *MyTasksModel) setStatus(status models.TaskStatus) {
+	if m.cursor >= len(m.visible) {
+		return
+	}
+	entry := m.visible[m.cursor]
+	if _, err := m.client.SetTaskStatus(entry.ProjectID, entry.Work.ID, entry.Task.ID, status); err != nil {
+		m.message = fmt.Sprintf("Failed to update task: %v", err)
+		return
+	}
+
+	changed := models.Task{Status: status}
+	m.message = fmt.Sprintf("%s %s → %s", changed.GetStatusIcon(), entry.Task.Title, changed.GetDisplayStatus())
+	m.Refresh()
+}
+
+func (m *MyTasksModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.searching {
+		switch keyMsg.Type {
+		case tea.KeyEnter, tea.KeyEsc:
+			m.searching = false
+		case tea.KeyBackspace:
+			if runes := []rune(m.search); len(runes) > 0 {
+				m.search = string(runes[:len(runes)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			m.search += string(keyMsg.Runes)
+		}
+		m.applyFilter()
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.visible)-1 {
+			m.cursor++
+		}
+	case " ", "x":
+		if m.cursor < len(m.visible) {
+			status := models.TaskStatusCompleted
+			if !m.visible[m.cursor].IsOpen() {
+				status = models.TaskStatusTodo
+			}
+			m.setStatus(status)
+		}
+	case "i":
+		m.setStatus(models.TaskStatusInProgress)
+	case "b":
+		m.setStatus(models.TaskStatusBlocked)
+	case "g":
+		m.grouping = (m.grouping + 1) % len(data.TaskGroupings)
+		m.applyFilter()
+	case "o":
+		m.openOnly = !m.openOnly
+		m.applyFilter()
+	case "m":
+		m.mineOnly = !m.mineOnly
+		m.applyFilter()
+	case "/":
+		m.searching = true
+	case "R":
+		m.message = ""
+		m.Refresh()
+	}
+
+	return m, nil
+}
+
+func (m *MyTasksModel) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("214")).
+		MarginBottom(1)
+
+	itemStyle := lipgloss.NewStyle().
+		PaddingLeft(2)
+
+	selectedStyle := lipgloss.NewStyle().
+		PaddingLeft(2).
+		Foreground(lipgloss.Color("214")).
+		Background(lipgloss.Color("235"))
+
+	groupStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39"))
+
+	detailStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("245"))
+
+	scope := "all tasks"
+	if m.openOnly {
+		scope = "open tasks"
+	}
+	if m.mineOnly {
+		scope += " for @" + models.CurrentActor()
+	}
+
+	var s strings.Builder
+	s.WriteString(titleStyle.Render(fmt.Sprintf("☑ My Tasks (%d) • %s • by %s", len(m.visible), scope, data.TaskGroupings[m.grouping])))
+	s.WriteString("\n")
+
+	if m.searching || m.search != "" {
+		cursor := ""
+		if m.searching {
+			cursor = "█"
+		}
+		s.WriteString(itemStyle.Render("Filter: " + m.search + cursor))
+		s.WriteString("\n")
+	}
+	s.WriteString("\n")
+
+	if len(m.visible) == 0 {
+		s.WriteString(itemStyle.Render("No matching tasks"))
+		s.WriteString("\n")
+	}
+
+	// Lay out group headings and tasks, then scroll so the selection stays on screen
+	var lines []string
+	cursorLine, index := 0, 0
+	for _, group := range m.groups {
+		lines = append(lines, groupStyle.Render(fmt.Sprintf("%s (%d)", group.Name, len(group.Entries))))
+		for _, entry := range group.Entries {
+			title := entry.Task.Title
+			if len(title) > 50 {
+				title = title[:47] + "..."
+			}
+			line := fmt.Sprintf("%s %-50s", entry.Task.GetStatusIcon(), title)
+			detail := entry.Work.Title + " • " + entry.ProjectName
+			if meta := taskMeta(entry.Task); meta != "" {
+				detail = meta + " • " + detail
+			}
+
+			if index == m.cursor {
+				cursorLine = len(lines)
+				lines = append(lines, selectedStyle.Render("▸ "+line)+"  "+detailStyle.Render(detail))
+			} else {
+				lines = append(lines, itemStyle.Render("  "+line)+"  "+detailStyle.Render(detail))
+			}
+			index++
+		}
+	}
+
+	rows := max(1, m.height-12)
+	start := 0
+	if cursorLine >= rows {
+		start = cursorLine - rows + 1
+	}
+	end := min(len(lines), start+rows)
+	for _, line := range lines[start:end] {
+		s.WriteString(line)
+		s.WriteString("\n")
+	}
+
+	if m.message != "" {
+		s.WriteString("\n")
+		s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Render(m.message))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	help := "↑/↓: Navigate • space: Done/undo • i: In progress • b: Blocked • g: Group • o: Open/all • m: Mine • /: Filter • R: Reload • Esc: Close"
+	if m.searching {
+		help = "Type to filter by task, phase, work or project • Enter: Done"
+	}
+	s.WriteString(lipgloss.NewStyle().Faint(true).Render(help))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, s.String())
+}
+
+func (m *MyTasksModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// taskMeta lists a task's owner, due date and phase for display
+func taskMeta(task *models.Task) string {
+	var parts []string
+	if task.AssignedTo != "" {
+		parts = append(parts, "@"+task.AssignedTo)
+	}
+	if task.DueAt != nil {
+		parts = append(parts, "due "+task.DueAt.Format("Jan 2"))
+	}
+	if task.Phase != "" {
+		parts = append(parts, task.Phase)
+	}
+	return strings.Join(parts, " • ")
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"claude-work-tracker-ui/internal/automation"
+	"claude-work-tracker-ui/internal/daemon"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+// remindersCheckedMsg reports the deadline reminders a check sent
+type remindersCheckedMsg struct {
+	reminders []automation.Reminder
+	err       error
+}
+
+// checkReminders sends the due reminders of the current project after delay. A running
+// daemon sends them itself, and the state file shared with it keeps either from repeating
+// what the other sent.
+func checkReminders(client *storage.CentralizedClient, delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(now time.Time) tea.Msg {
+		projectID := client.GetCurrentProject().ID
+		if status, err := daemon.ReadStatus(daemon.StatusPath(projectID)); err == nil && status.IsLive() {
+			return remindersCheckedMsg{}
+		}
+
+		works, err := client.GetAllWork()
+		if err != nil {
+			return remindersCheckedMsg{err: fmt.Errorf("failed to check reminders: %w", err)}
+		}
+		config := automation.DefaultReminderConfig()
+		config.StatePath = daemon.RemindersPath(projectID)
+		sent, err := automation.NewReminderService(client.GetHookSystem(), config).Check(context.Background(), works, now)
+		return remindersCheckedMsg{reminders: sent, err: err}
+	})
+}
@@ -0,0 +1,211 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"claude-work-tracker-ui/internal/report"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+// reportPeriods lists the periods the report panel cycles through
+var reportPeriods = []string{report.PeriodStandup, report.PeriodWeekly}
+
+// ReportModel shows a done / doing / blocked / next summary of the current project or of all
+// projects, and can save it as markdown
+type ReportModel struct {
+	client   *storage.CentralizedClient
+	summary  *report.Summary
+	period   int // Index into reportPeriods
+	grouping int // Index into report.SummaryGroupings
+	all      bool
+	offset   int // First line shown
+	message  string
+	width    int
+	height   int
+}
+
+// NewReportModel creates the report panel for the given storage client
+func NewReportModel(client *storage.CentralizedClient) *ReportModel {
+	return &ReportModel{client: client}
+}
+
+func (m *ReportModel) Init() tea.Cmd {
+	m.message = ""
+	m.Refresh()
+	return nil
+}
+
+// Refresh summarizes the selected period again
+func (m *ReportModel) Refresh() {
+	options := report.SummaryOptions{
+		Period:  reportPeriods[m.period],
+		GroupBy: report.SummaryGroupings[m.grouping],
+	}
+	if m.all {
+		options.Projects = []string{"all"}
+	}
+	if options.Period == report.PeriodWeekly {
+		options.Since, options.Until = report.WeeklyRange(time.Now())
+	} else {
+		options.Since, options.Until = report.StandupRange(time.Now())
+	}
+
+	summary, err := report.Summarize(m.client, options)
+	if err != nil {
+		m.summary = nil
+		m.message = fmt.Sprintf("Failed to build report: %v", err)
+		return
+	}
+	m.summary = summary
+	m.offset = 0
+}
+
+// save writes the report as markdown to the current directory
+func (m *ReportModel) save() {
+	if m.summary == nil {
+		return
+	}
+	name := fmt.Sprintf("%s-%s.md", m.summary.Period, time.Now().Format("2006-01-02"))
+	if err := os.WriteFile(name, []byte(report.RenderSummaryMarkdown(m.summary)), 0644); err != nil {
+		m.message = fmt.Sprintf("Failed to save report: %v", err)
+		return
+	}
+	m.message = "✅ Saved " + name
+}
+
+func (m *ReportModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	m.message = ""
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.offset > 0 {
+			m.offset--
+		}
+	case "down", "j":
+		m.offset++
+	case "p":
+		m.period = (m.period + 1) % len(reportPeriods)
+		m.Refresh()
+	case "g":
+		m.grouping = (m.grouping + 1) % len(report.SummaryGroupings)
+		m.Refresh()
+	case "a":
+		m.all = !m.all
+		m.Refresh()
+	case "w":
+		m.save()
+	case "R":
+		m.Refresh()
+	}
+	return m, nil
+}
+
+func (m *ReportModel) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("214")).
+		MarginBottom(1)
+
+	itemStyle := lipgloss.NewStyle().
+		PaddingLeft(4)
+
+	groupStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39"))
+
+	sectionStyle := lipgloss.NewStyle().
+		PaddingLeft(2).
+		Bold(true)
+
+	detailStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("245"))
+
+	scope := "this project"
+	if m.all {
+		scope = "all projects"
+	}
+
+	var s strings.Builder
+	period := reportPeriods[m.period]
+	title := fmt.Sprintf("📋 %s Report • %s • by %s", strings.ToUpper(period[:1])+period[1:], scope, report.SummaryGroupings[m.grouping])
+	if m.summary != nil {
+		last := m.summary.Until.Add(-time.Nanosecond)
+		title += fmt.Sprintf(" • %s – %s", m.summary.Since.Format("Jan 2"), last.Format("Jan 2"))
+	}
+	s.WriteString(titleStyle.Render(title))
+	s.WriteString("\n\n")
+
+	// Lay out groups and their sections, then show the scrolled window
+	var lines []string
+	if m.summary != nil {
+		for _, group := range m.summary.Groups {
+			if len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, groupStyle.Render(group.Name))
+			sections := []struct {
+				heading string
+				items   []report.SummaryItem
+			}{
+				{"✅ Done", group.Done},
+				{"🔄 Doing", group.Doing},
+				{"🚧 Blocked", group.Blocked},
+				{"⏭️ Next", group.Next},
+			}
+			for _, section := range sections {
+				if len(section.items) == 0 {
+					continue
+				}
+				lines = append(lines, sectionStyle.Render(fmt.Sprintf("%s (%d)", section.heading, len(section.items))))
+				for _, item := range section.items {
+					line := item.Text
+					if item.Text != item.Work && m.summary.GroupBy != report.GroupByWork {
+						line += detailStyle.Render(" • " + item.Work)
+					}
+					if item.Detail != "" {
+						line += detailStyle.Render(" — " + item.Detail)
+					}
+					lines = append(lines, itemStyle.Render(line))
+				}
+			}
+		}
+		if len(lines) == 0 {
+			lines = append(lines, itemStyle.Render("Nothing to report for this period"))
+		}
+	}
+
+	rows := max(1, m.height-10)
+	m.offset = min(m.offset, max(0, len(lines)-rows))
+	end := min(len(lines), m.offset+rows)
+	for _, line := range lines[m.offset:end] {
+		s.WriteString(line)
+		s.WriteString("\n")
+	}
+
+	if m.message != "" {
+		s.WriteString("\n")
+		s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Render(m.message))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(lipgloss.NewStyle().Faint(true).Render("↑/↓: Scroll • p: Standup/weekly • g: Group • a: This/all projects • w: Save markdown • R: Reload • Esc: Close"))
+
+	// Pad the lines to one width so the report stays left aligned inside the centered block
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, lipgloss.NewStyle().Render(s.String()))
+}
+
+func (m *ReportModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
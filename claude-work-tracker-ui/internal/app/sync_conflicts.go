@@ -0,0 +1,205 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"claude-work-tracker-ui/internal/storage"
+)
+
+// syncCompletedMsg is sent when a background sync finishes
+type syncCompletedMsg struct {
+	result *storage.SyncResult
+	err    error
+}
+
+// runSync performs a git sync in the background
+func runSync(gitSync *storage.GitSync) tea.Cmd {
+	return func() tea.Msg {
+		result, err := gitSync.Sync()
+		return syncCompletedMsg{result: result, err: err}
+	}
+}
+
+// syncErrorMsg reports a failed background auto-commit or push
+type syncErrorMsg struct {
+	err error
+}
+
+// waitForSyncError delivers the next failure of a background auto-commit or push
+func waitForSyncError(gitSync *storage.GitSync) tea.Cmd {
+	return func() tea.Msg {
+		return syncErrorMsg{err: <-gitSync.Errors()}
+	}
+}
+
+// SyncConflictsModel lists sync conflicts and lets the user pick a side
+type SyncConflictsModel struct {
+	gitSync   *storage.GitSync
+	conflicts []storage.SyncConflict
+	cursor    int
+	message   string
+	width     int
+	height    int
+}
+
+// NewSyncConflictsModel creates a conflicts panel for the given sync manager
+func NewSyncConflictsModel(gitSync *storage.GitSync) *SyncConflictsModel {
+	return &SyncConflictsModel{gitSync: gitSync}
+}
+
+func (m *SyncConflictsModel) Init() tea.Cmd {
+	m.Refresh()
+	return nil
+}
+
+// Refresh reloads the conflict list from disk
+func (m *SyncConflictsModel) Refresh() {
+	if m.gitSync == nil {
+		m.conflicts = nil
+		return
+	}
+
+	conflicts, err := m.gitSync.LoadConflicts()
+	if err != nil {
+		m.message = fmt.Sprintf("Failed to load conflicts: %v", err)
+		return
+	}
+	m.conflicts = conflicts
+	if m.cursor >= len(m.conflicts) {
+		m.cursor = max(0, len(m.conflicts)-1)
+	}
+}
+
+// Count returns the number of open conflicts
+func (m *SyncConflictsModel) Count() int {
+	return len(m.conflicts)
+}
+
+func (m *SyncConflictsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.conflicts)-1 {
+				m.cursor++
+			}
+		case "o", "t":
+			if m.cursor < len(m.conflicts) {
+				takeTheirs := msg.String() == "t"
+				if err := m.gitSync.ResolveConflict(m.cursor, takeTheirs); err != nil {
+					m.message = fmt.Sprintf("Failed to resolve: %v", err)
+				} else if takeTheirs {
+					m.message = "Applied remote value"
+				} else {
+					m.message = "Kept local value"
+				}
+				m.Refresh()
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m *SyncConflictsModel) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("214")).
+		MarginBottom(1)
+
+	itemStyle := lipgloss.NewStyle().
+		PaddingLeft(2)
+
+	selectedStyle := lipgloss.NewStyle().
+		PaddingLeft(2).
+		Foreground(lipgloss.Color("214")).
+		Background(lipgloss.Color("235"))
+
+	valueStyle := lipgloss.NewStyle().
+		PaddingLeft(6).
+		Foreground(lipgloss.Color("245"))
+
+	var s strings.Builder
+	s.WriteString(titleStyle.Render(fmt.Sprintf("⚠  Sync Conflicts (%d)", len(m.conflicts))))
+	s.WriteString("\n\n")
+
+	if len(m.conflicts) == 0 {
+		s.WriteString(itemStyle.Render("No open conflicts"))
+	}
+
+	for i, conflict := range m.conflicts {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "▸ "
+		}
+
+		line := fmt.Sprintf("%s%s • %s", cursor, conflict.Path, conflict.Field)
+		if i == m.cursor {
+			s.WriteString(selectedStyle.Render(line))
+			s.WriteString("\n")
+			s.WriteString(valueStyle.Render("local:  " + formatConflictValue(conflict.Ours)))
+			s.WriteString("\n")
+			s.WriteString(valueStyle.Render("remote: " + formatConflictValue(conflict.Theirs)))
+		} else {
+			s.WriteString(itemStyle.Render(line))
+		}
+		s.WriteString("\n")
+	}
+
+	if m.message != "" {
+		s.WriteString("\n")
+		s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Render(m.message))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(lipgloss.NewStyle().Faint(true).Render("↑/↓: Navigate • o: Keep local • t: Take remote • Esc: Close"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, s.String())
+}
+
+func (m *SyncConflictsModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// formatConflictValue renders a conflicting value on a single line
+func formatConflictValue(value interface{}) string {
+	if value == nil {
+		return "(unset)"
+	}
+
+	text := strings.ReplaceAll(fmt.Sprintf("%v", value), "\n", " ")
+	if len(text) > 60 {
+		text = text[:57] + "..."
+	}
+	return text
+}
+
+// formatSyncAge renders how long ago the last sync happened
+func formatSyncAge(t *time.Time) string {
+	if t == nil {
+		return "never synced"
+	}
+
+	age := time.Since(*t)
+	switch {
+	case age < time.Minute:
+		return "synced just now"
+	case age < time.Hour:
+		return fmt.Sprintf("synced %dm ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("synced %dh ago", int(age.Hours()))
+	default:
+		return fmt.Sprintf("synced %dd ago", int(age.Hours()/24))
+	}
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"fmt"
+
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/parser"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+// SetTaskStatus changes the status of a task in a work item's checklist
+func (a *CentralizedWorkAdapter) SetTaskStatus(workID, taskID string, status models.TaskStatus) (*models.Work, error) {
+	return a.client.SetTaskStatus(a.client.GetCurrentProject().ID, workID, taskID, status)
+}
+
+// AddTask adds a todo task after another task, or at the end of the checklist
+func (a *CentralizedWorkAdapter) AddTask(workID, afterTaskID, title string) (*models.Work, string, error) {
+	var taskID string
+	work, err := a.editTasks(workID, func(work *models.Work, p *parser.TaskParser) (*models.Update, error) {
+		if title == "" {
+			return nil, fmt.Errorf("task title is empty")
+		}
+		work.Content, taskID = p.InsertTaskInMarkdown(work.Content, afterTaskID, title)
+		return &models.Update{Summary: "Added: " + title, TasksAdded: []string{title}}, nil
+	})
+	return work, taskID, err
+}
+
+// MoveTask moves a task above or below its neighbouring sibling
+func (a *CentralizedWorkAdapter) MoveTask(workID, taskID string, delta int) (*models.Work, error) {
+	return a.editTasks(workID, func(work *models.Work, p *parser.TaskParser) (*models.Update, error) {
+		task := p.FindTask(work.Content, taskID)
+		if task == nil {
+			return nil, fmt.Errorf("task not found: %s", taskID)
+		}
+		moved := p.MoveTaskInMarkdown(work.Content, task.Task.ID, delta)
+		if moved == work.Content {
+			return nil, nil // Already first or last among its siblings
+		}
+		work.Content = moved
+
+		direction := "down"
+		if delta < 0 {
+			direction = "up"
+		}
+		return &models.Update{Summary: fmt.Sprintf("Moved %s: %s", direction, task.Task.Title)}, nil
+	})
+}
+
+// DeleteTask removes a task and its subtasks from a work item's checklist
+func (a *CentralizedWorkAdapter) DeleteTask(workID, taskID string) (*models.Work, error) {
+	return a.editTasks(workID, func(work *models.Work, p *parser.TaskParser) (*models.Update, error) {
+		task := p.FindTask(work.Content, taskID)
+		if task == nil {
+			return nil, fmt.Errorf("task not found: %s", taskID)
+		}
+		work.Content = p.DeleteTaskInMarkdown(work.Content, task.Task.ID)
+		return &models.Update{Summary: "Removed: " + task.Task.Title}, nil
+	})
+}
+
+// editTasks applies a checklist edit to a work item of the current project
+func (a *CentralizedWorkAdapter) editTasks(workID string, edit storage.TaskEdit) (*models.Work, error) {
+	return a.client.EditTasks(a.client.GetCurrentProject().ID, workID, edit)
+}
+
+// PromoteTask spins a task and its subtasks out into a new work item
+func (a *CentralizedWorkAdapter) PromoteTask(workID, taskID string) (*models.Work, *models.Work, error) {
+	child, err := a.client.PromoteTask(workID, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent, err := a.findWork(workID)
+	return parent, child, err
+}
+
+// PromotePhase spins everything under a phase heading out into a new work item
+func (a *CentralizedWorkAdapter) PromotePhase(workID, phase string) (*models.Work, *models.Work, error) {
+	child, err := a.client.PromotePhase(workID, phase)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent, err := a.findWork(workID)
+	return parent, child, err
+}
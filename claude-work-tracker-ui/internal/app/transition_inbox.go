@@ -0,0 +1,258 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"claude-work-tracker-ui/internal/automation"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+// snoozeOptions are the periods a rejected transition can be snoozed for
+var snoozeOptions = []time.Duration{
+	24 * time.Hour,
+	3 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+}
+
+// formatSnooze renders a snooze period in days
+func formatSnooze(d time.Duration) string {
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+// transitionProposedMsg reports a transition that a save made possible and that needs
+// confirmation
+type transitionProposedMsg struct {
+	pending automation.PendingTransition
+}
+
+// waitForProposedTransition delivers the next transition a save proposes
+func waitForProposedTransition(client *storage.CentralizedClient) tea.Cmd {
+	return func() tea.Msg {
+		return transitionProposedMsg{pending: <-client.ProposedTransitions()}
+	}
+}
+
+// TransitionInboxModel lists the automatic transitions proposed for all work items and lets
+// the user approve or reject them
+type TransitionInboxModel struct {
+	client  *storage.CentralizedClient
+	engine  *automation.TransitionEngine
+	pending []automation.PendingTransition
+	snooze  int // Index into snoozeOptions
+	cursor  int
+	message string
+	width   int
+	height  int
+}
+
+// NewTransitionInboxModel creates a transition inbox for the given storage client
+func NewTransitionInboxModel(client *storage.CentralizedClient, engine *automation.TransitionEngine) *TransitionInboxModel {
+	return &TransitionInboxModel{
+		client: client,
+		engine: engine,
+		snooze: 2,
+	}
+}
+
+func (m *TransitionInboxModel) Init() tea.Cmd {
+	m.message = ""
+	m.Refresh()
+	return nil
+}
+
+// Refresh re-evaluates the rules against all work items
+func (m *TransitionInboxModel) Refresh() {
+	works, err := m.client.GetAllWork()
+	if err != nil {
+		m.pending = nil
+		m.message = fmt.Sprintf("Failed to load work: %v", err)
+		return
+	}
+
+	m.pending = m.engine.PendingTransitions(works, time.Now())
+	if m.cursor >= len(m.pending) {
+		m.cursor = max(0, len(m.pending)-1)
+	}
+}
+
+// Count returns the number of proposed transitions
+func (m *TransitionInboxModel) Count() int {
+	return len(m.pending)
+}
+
+// approve applies a proposed transition and saves the work item
+func (m *TransitionInboxModel) approve(pending automation.PendingTransition) error {
+	work, err := m.engine.ConfirmTransition(pending.Work, pending.Rule)
+	if err != nil {
+		return err
+	}
+	if err := m.client.UpdateWork(work); err != nil {
+		return fmt.Errorf("failed to save %s: %w", work.ID, err)
+	}
+	return nil
+}
+
+// reject snoozes a proposed transition and saves the work item
+func (m *TransitionInboxModel) reject(pending automation.PendingTransition) error {
+	m.engine.RejectTransition(pending.Work, pending.Rule, snoozeOptions[m.snooze])
+	if err := m.client.UpdateWork(pending.Work); err != nil {
+		return fmt.Errorf("failed to save %s: %w", pending.Work.ID, err)
+	}
+	return nil
+}
+
+func (m *TransitionInboxModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.pending)-1 {
+			m.cursor++
+		}
+	case "z":
+		m.snooze = (m.snooze + 1) % len(snoozeOptions)
+	case "R":
+		m.message = ""
+		m.Refresh()
+	case "a", "enter":
+		if m.cursor >= len(m.pending) {
+			break
+		}
+		pending := m.pending[m.cursor]
+		if err := m.approve(pending); err != nil {
+			m.message = fmt.Sprintf("Failed to approve: %v", err)
+		} else {
+			m.message = fmt.Sprintf("✓ Applied %s to %s", pending.Rule, pending.Work.ID)
+		}
+		m.Refresh()
+	case "r":
+		if m.cursor >= len(m.pending) {
+			break
+		}
+		pending := m.pending[m.cursor]
+		if err := m.reject(pending); err != nil {
+			m.message = fmt.Sprintf("Failed to reject: %v", err)
+		} else {
+			m.message = fmt.Sprintf("✗ Snoozed %s for %s on %s", pending.Rule, formatSnooze(snoozeOptions[m.snooze]), pending.Work.ID)
+		}
+		m.Refresh()
+	case "A":
+		applied, failed := 0, 0
+		for _, pending := range m.pending {
+			if err := m.approve(pending); err != nil {
+				failed++
+				continue
+			}
+			applied++
+		}
+		m.message = fmt.Sprintf("✓ Applied %d transitions", applied)
+		if failed > 0 {
+			m.message += fmt.Sprintf(", %d failed", failed)
+		}
+		m.Refresh()
+	}
+
+	return m, nil
+}
+
+func (m *TransitionInboxModel) View() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("214")).
+		MarginBottom(1)
+
+	itemStyle := lipgloss.NewStyle().
+		PaddingLeft(2)
+
+	selectedStyle := lipgloss.NewStyle().
+		PaddingLeft(2).
+		Foreground(lipgloss.Color("214")).
+		Background(lipgloss.Color("235"))
+
+	detailStyle := lipgloss.NewStyle().
+		PaddingLeft(6).
+		Foreground(lipgloss.Color("245"))
+
+	var s strings.Builder
+	s.WriteString(titleStyle.Render(fmt.Sprintf("📥 Proposed Transitions (%d) • snooze: %s", len(m.pending), formatSnooze(snoozeOptions[m.snooze]))))
+	s.WriteString("\n\n")
+
+	if len(m.pending) == 0 {
+		s.WriteString(itemStyle.Render("No transitions waiting for review"))
+		s.WriteString("\n")
+	}
+
+	// Keep the selection on screen, leaving room for the title, details and help
+	rows := max(1, m.height-14)
+	start := 0
+	if m.cursor >= rows {
+		start = m.cursor - rows + 1
+	}
+	end := min(len(m.pending), start+rows)
+
+	for i := start; i < end; i++ {
+		pending := m.pending[i]
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "▸ "
+		}
+
+		change := fmt.Sprintf("%s → %s", pending.FromStatus, pending.ToStatus)
+		if pending.FromSchedule != pending.ToSchedule {
+			change += fmt.Sprintf(", %s → %s", pending.FromSchedule, pending.ToSchedule)
+		}
+		title := pending.Work.Title
+		if len(title) > 40 {
+			title = title[:37] + "..."
+		}
+		line := fmt.Sprintf("%s%-40s %s", cursor, title, change)
+		if pending.NeedsConfirmation {
+			line += " • needs approval"
+		}
+
+		if i == m.cursor {
+			s.WriteString(selectedStyle.Render(line))
+		} else {
+			s.WriteString(itemStyle.Render(line))
+		}
+		s.WriteString("\n")
+
+		if i == m.cursor {
+			s.WriteString(detailStyle.Render(fmt.Sprintf("rule: %s (%s)", pending.Rule, pending.Reason)))
+			s.WriteString("\n")
+			for _, action := range pending.Actions {
+				s.WriteString(detailStyle.Render("→ " + action))
+				s.WriteString("\n")
+			}
+		}
+	}
+
+	if m.message != "" {
+		s.WriteString("\n")
+		s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Render(m.message))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(lipgloss.NewStyle().Faint(true).Render("↑/↓: Navigate • a: Approve • r: Reject • z: Snooze period • A: Approve all • R: Reload • Esc: Close"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, s.String())
+}
+
+func (m *TransitionInboxModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
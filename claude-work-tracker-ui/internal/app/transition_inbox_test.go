@@ -0,0 +1,92 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// confirmRules proposes starting active work at half way, waiting for approval
+const confirmRules = `rules:
+  - name: start_at_half
+    description: Start work at half way
+    confirm: true
+    when:
+      - status == active
+      - progress >= 50
+    then:
+      - set_status: in_progress
+`
+
+// newTestInbox creates an app whose inbox holds a proposal for work-1
+func newTestInbox(t *testing.T) *CentralizedApp {
+	t.Helper()
+	app := newTestApp(t, confirmRules)
+	work := &models.Work{ID: "work-1", Title: "Half done", Schedule: models.ScheduleNow}
+	work.Metadata.Status = models.WorkStatusActive
+	work.Metadata.ProgressPercent = 60
+	if err := app.client.CreateWork(work); err != nil {
+		t.Fatal(err)
+	}
+	app.inbox.Refresh()
+	if app.inbox.Count() != 1 {
+		t.Fatalf("inbox holds %d proposals, want 1", app.inbox.Count())
+	}
+	return app
+}
+
+// pressKeys sends keys to the inbox
+func pressKeys(m *TransitionInboxModel, keys ...string) {
+	for _, k := range keys {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	}
+}
+
+func TestTransitionInbox(t *testing.T) {
+	tests := []struct {
+		name       string
+		keys       []string
+		wantStatus string
+		wantSnooze time.Duration // Zero when the rule shouldn't be snoozed
+	}{
+		{"approve", []string{"a"}, models.WorkStatusInProgress, 0},
+		{"approve all", []string{"A"}, models.WorkStatusInProgress, 0},
+		{"reject snoozes for the default period", []string{"r"}, models.WorkStatusActive, 7 * 24 * time.Hour},
+		{"reject with a longer snooze", []string{"z", "r"}, models.WorkStatusActive, 30 * 24 * time.Hour},
+		{"snooze period wraps around", []string{"z", "z", "r"}, models.WorkStatusActive, 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestInbox(t)
+			pressKeys(app.inbox, tt.keys...)
+
+			if app.inbox.Count() != 0 {
+				t.Errorf("inbox still holds %d proposals: %s", app.inbox.Count(), app.inbox.message)
+			}
+			work, err := app.client.GetProjectWorkByID(app.client.GetCurrentProject().ID, "work-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if work.Metadata.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", work.Metadata.Status, tt.wantStatus)
+			}
+
+			if tt.wantSnooze == 0 {
+				if len(work.SnoozedTransitions) != 0 {
+					t.Errorf("snoozed = %+v, want none", work.SnoozedTransitions)
+				}
+				return
+			}
+			if len(work.SnoozedTransitions) != 1 || work.SnoozedTransitions[0].Rule != "start_at_half" {
+				t.Fatalf("snoozed = %+v, want start_at_half", work.SnoozedTransitions)
+			}
+			if until := time.Until(work.SnoozedTransitions[0].Until); until < tt.wantSnooze-time.Minute || until > tt.wantSnooze {
+				t.Errorf("snoozed for %v, want %v", until, tt.wantSnooze)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package app
+
+import (
+	"claude-work-tracker-ui/internal/models"
+)
+
+// GetUpdates returns the updates of a work item, newest first
+func (a *CentralizedWorkAdapter) GetUpdates(workID string) ([]*models.Update, error) {
+	return a.client.GetUpdates(workID)
+}
+
+// CreateUpdate adds an update to a work item
+func (a *CentralizedWorkAdapter) CreateUpdate(workID string, update *models.Update) error {
+	return a.client.CreateUpdate(workID, update)
+}
+
+// EditUpdate replaces the update with the same ID
+func (a *CentralizedWorkAdapter) EditUpdate(workID string, update *models.Update) error {
+	return a.client.EditUpdate(workID, update)
+}
+
+// DeleteUpdate removes an update from a work item
+func (a *CentralizedWorkAdapter) DeleteUpdate(workID, updateID string) error {
+	return a.client.DeleteUpdate(workID, updateID)
+}
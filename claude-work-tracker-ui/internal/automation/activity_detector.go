@@ -3,6 +3,7 @@ package automation
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -77,18 +78,28 @@ func NewActivityDetector(hookSystem *hooks.HookSystem, config *ActivityConfig) *
 
 // RecordActivity logs an activity event
 func (ad *ActivityDetector) RecordActivity(workID string, eventType string, metadata map[string]interface{}) {
+	ad.RecordActivityAt(workID, eventType, time.Now(), metadata)
+}
+
+// RecordActivityAt logs an activity event that happened at the given time, such as a commit
+func (ad *ActivityDetector) RecordActivityAt(workID string, eventType string, at time.Time, metadata map[string]interface{}) {
 	ad.mu.Lock()
 	defer ad.mu.Unlock()
 
 	event := ActivityEvent{
 		WorkID:    workID,
-		Timestamp: time.Now(),
+		Timestamp: at,
 		EventType: eventType,
 		Metadata:  metadata,
 	}
 
-	// Add to activity log
-	ad.activityLog[workID] = append(ad.activityLog[workID], event)
+	// Add to activity log, keeping it in time order when older events arrive late
+	events := ad.activityLog[workID]
+	i := sort.Search(len(events), func(i int) bool { return events[i].Timestamp.After(at) })
+	events = append(events, ActivityEvent{})
+	copy(events[i+1:], events[i:])
+	events[i] = event
+	ad.activityLog[workID] = events
 
 	// Update or create focus session
 	ad.updateFocusSession(workID, event)
@@ -268,6 +279,11 @@ func (ad *ActivityDetector) registerHooks() {
 
 	// Track Git commits
 	ad.hookSystem.Register(hooks.CommitDetected, "commit_tracker", func(ctx context.Context, hookCtx *hooks.HookContext) error {
+		// Scanned commits carry their commit time, which may be well before they were seen
+		if committedAt, ok := hookCtx.Metadata["timestamp"].(time.Time); ok {
+			ad.RecordActivityAt(hookCtx.WorkItem.ID, "commit", committedAt, hookCtx.Metadata)
+			return nil
+		}
 		ad.RecordActivity(hookCtx.WorkItem.ID, "commit", hookCtx.Metadata)
 		return nil
 	})
@@ -0,0 +1,272 @@
+package automation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"claude-work-tracker-ui/internal/hooks"
+	"claude-work-tracker-ui/internal/models"
+)
+
+// ReminderConfig contains configuration for due date reminders
+type ReminderConfig struct {
+	DueSoonWindow  time.Duration // How far ahead of a due date to send a due-soon reminder
+	CheckInterval  time.Duration // How often Start re-checks work items
+	RepeatInterval time.Duration // Re-send overdue reminders after this long, 0 sends them once
+	StatePath      string        // File remembering sent reminders across restarts, empty keeps them in memory
+}
+
+// DefaultReminderConfig returns default configuration
+func DefaultReminderConfig() *ReminderConfig {
+	return &ReminderConfig{
+		DueSoonWindow:  models.DueSoonWindow,
+		CheckInterval:  15 * time.Minute,
+		RepeatInterval: 24 * time.Hour,
+	}
+}
+
+// Reminder describes a deadline event emitted for a work item
+type Reminder struct {
+	WorkID   string
+	Title    string
+	Type     hooks.HookType // DueSoon, Overdue or StartByPassed
+	Deadline time.Time
+	Message  string
+}
+
+// WorkProvider returns the work items reminders are checked against
+type WorkProvider func() ([]*models.Work, error)
+
+// ReminderService emits deadline hooks when work approaches or passes its due dates
+type ReminderService struct {
+	mu         sync.Mutex
+	config     *ReminderConfig
+	hookSystem *hooks.HookSystem
+	sent       map[string]time.Time // Reminder key -> last time it was sent
+}
+
+// NewReminderService creates a new reminder service
+func NewReminderService(hookSystem *hooks.HookSystem, config *ReminderConfig) *ReminderService {
+	if config == nil {
+		config = DefaultReminderConfig()
+	}
+	return &ReminderService{
+		config:     config,
+		hookSystem: hookSystem,
+		sent:       make(map[string]time.Time),
+	}
+}
+
+// Check evaluates work items and executes hooks for reminders that are due. Reminders
+// another process already sent, as recorded in the state file, aren't sent again. The
+// error reports a state file that couldn't be read or saved; the reminders went out anyway.
+func (rs *ReminderService) Check(ctx context.Context, works []*models.Work, now time.Time) ([]Reminder, error) {
+	loadErr := rs.load()
+
+	var reminders []Reminder
+	for _, work := range works {
+		for _, reminder := range rs.evaluate(work, now) {
+			if !rs.shouldSend(reminder, now) {
+				continue
+			}
+			reminders = append(reminders, reminder)
+
+			if rs.hookSystem != nil {
+				rs.hookSystem.Execute(ctx, &hooks.HookContext{
+					WorkItem:  work,
+					EventType: reminder.Type,
+					Timestamp: now,
+					Metadata: map[string]interface{}{
+						"deadline": reminder.Deadline,
+						"message":  reminder.Message,
+					},
+				})
+			}
+		}
+	}
+
+	if len(reminders) > 0 {
+		if err := rs.save(); err != nil {
+			return reminders, err
+		}
+	}
+	return reminders, loadErr
+}
+
+// Start runs Check on every CheckInterval until the context is canceled
+func (rs *ReminderService) Start(ctx context.Context, provider WorkProvider) {
+	check := func() {
+		works, err := provider()
+		if err != nil {
+			return
+		}
+		rs.Check(ctx, works, time.Now()) // A state file that can't be saved only risks a repeat
+	}
+
+	check()
+
+	ticker := time.NewTicker(rs.config.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
+
+// Reset forgets which reminders have been sent
+func (rs *ReminderService) Reset() {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.sent = make(map[string]time.Time)
+}
+
+// load merges the sent reminders recorded in the state file into memory, keeping the later
+// time for reminders known to both
+func (rs *ReminderService) load() error {
+	if rs.config.StatePath == "" {
+		return nil
+	}
+	content, err := ioutil.ReadFile(rs.config.StatePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read reminder state: %w", err)
+	}
+
+	var sent map[string]time.Time
+	if err := json.Unmarshal(content, &sent); err != nil {
+		return fmt.Errorf("failed to parse reminder state: %w", err)
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	for key, at := range sent {
+		if at.After(rs.sent[key]) {
+			rs.sent[key] = at
+		}
+	}
+	return nil
+}
+
+// save writes the sent reminders to the state file through a temporary file, so a reader
+// never sees it half written
+func (rs *ReminderService) save() error {
+	if rs.config.StatePath == "" {
+		return nil
+	}
+
+	rs.mu.Lock()
+	content, err := json.MarshalIndent(rs.sent, "", "  ")
+	rs.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal reminder state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(rs.config.StatePath), 0755); err != nil {
+		return fmt.Errorf("failed to create reminder state directory: %w", err)
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(rs.config.StatePath), filepath.Base(rs.config.StatePath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write reminder state: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // Gone after the rename
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), rs.config.StatePath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write reminder state: %w", err)
+	}
+	return nil
+}
+
+// evaluate returns the reminders that apply to a work item right now
+func (rs *ReminderService) evaluate(work *models.Work, now time.Time) []Reminder {
+	if work.IsClosed() {
+		return nil
+	}
+
+	var reminders []Reminder
+
+	if work.DueAt != nil {
+		due := *work.DueAt
+		switch {
+		case now.After(due):
+			reminders = append(reminders, Reminder{
+				WorkID:   work.ID,
+				Title:    work.Title,
+				Type:     hooks.Overdue,
+				Deadline: due,
+				Message:  fmt.Sprintf("%s is overdue by %s", work.Title, formatDuration(now.Sub(due))),
+			})
+		case due.Sub(now) <= rs.config.DueSoonWindow:
+			reminders = append(reminders, Reminder{
+				WorkID:   work.ID,
+				Title:    work.Title,
+				Type:     hooks.DueSoon,
+				Deadline: due,
+				Message:  fmt.Sprintf("%s is due in %s", work.Title, formatDuration(due.Sub(now))),
+			})
+		}
+	}
+
+	started := work.StartedAt != nil || work.Metadata.Status == models.WorkStatusInProgress
+	if work.StartBy != nil && !started && now.After(*work.StartBy) {
+		reminders = append(reminders, Reminder{
+			WorkID:   work.ID,
+			Title:    work.Title,
+			Type:     hooks.StartByPassed,
+			Deadline: *work.StartBy,
+			Message:  fmt.Sprintf("%s should have started %s ago", work.Title, formatDuration(now.Sub(*work.StartBy))),
+		})
+	}
+
+	return reminders
+}
+
+// shouldSend records a reminder and reports whether it is new or due to repeat.
+// Keys include the deadline so moving a due date re-arms its reminders.
+func (rs *ReminderService) shouldSend(reminder Reminder, now time.Time) bool {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	key := fmt.Sprintf("%s|%s|%d", reminder.WorkID, reminder.Type, reminder.Deadline.Unix())
+	if last, ok := rs.sent[key]; ok {
+		if reminder.Type == hooks.DueSoon || rs.config.RepeatInterval <= 0 || now.Sub(last) < rs.config.RepeatInterval {
+			return false
+		}
+	}
+
+	rs.sent[key] = now
+	return true
+}
+
+// formatDuration renders a duration in days or hours
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%d days", int(d.Hours()/24))
+	case d >= 2*time.Hour:
+		return fmt.Sprintf("%d hours", int(d.Hours()))
+	case d >= time.Hour:
+		return "1 hour"
+	case d >= 2*time.Minute:
+		return fmt.Sprintf("%d minutes", int(d.Minutes()))
+	default:
+		return "1 minute"
+	}
+}
@@ -0,0 +1,149 @@
+package automation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"claude-work-tracker-ui/internal/hooks"
+	"claude-work-tracker-ui/internal/models"
+)
+
+// dueWork creates an open work item due at the given time
+func dueWork(id string, due time.Time) *models.Work {
+	work := &models.Work{ID: id, Title: id, DueAt: &due}
+	work.Metadata.Status = models.WorkStatusActive
+	return work
+}
+
+// reminderTypes returns the hook types of reminders
+func reminderTypes(reminders []Reminder) []hooks.HookType {
+	var types []hooks.HookType
+	for _, reminder := range reminders {
+		types = append(types, reminder.Type)
+	}
+	return types
+}
+
+func TestReminderCheck(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	startBy := now.Add(-time.Hour)
+
+	tests := []struct {
+		name string
+		work *models.Work
+		want []hooks.HookType
+	}{
+		{"far off", dueWork("work-1", now.Add(30*24*time.Hour)), nil},
+		{"due soon", dueWork("work-1", now.Add(time.Hour)), []hooks.HookType{hooks.DueSoon}},
+		{"overdue", dueWork("work-1", now.Add(-time.Hour)), []hooks.HookType{hooks.Overdue}},
+		{"closed", func() *models.Work {
+			work := dueWork("work-1", now.Add(-time.Hour))
+			work.Metadata.Status = models.WorkStatusCompleted
+			return work
+		}(), nil},
+		{"start by passed", &models.Work{ID: "work-1", StartBy: &startBy, Metadata: models.WorkMetadata{Status: models.WorkStatusActive}}, []hooks.HookType{hooks.StartByPassed}},
+		{"started in time", &models.Work{ID: "work-1", StartBy: &startBy, Metadata: models.WorkMetadata{Status: models.WorkStatusInProgress}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := NewReminderService(nil, nil)
+			sent, err := rs.Check(context.Background(), []*models.Work{tt.work}, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := reminderTypes(sent); len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+				t.Errorf("reminders = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReminderRepeats(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	overdue := dueWork("overdue", now.Add(-time.Hour))
+	dueSoon := dueWork("due-soon", now.Add(time.Hour))
+
+	tests := []struct {
+		name  string
+		work  *models.Work
+		later time.Duration
+		want  int
+	}{
+		{"overdue isn't repeated straight away", overdue, time.Hour, 0},
+		{"overdue repeats after the interval", overdue, 25 * time.Hour, 1},
+		{"due soon is sent once", dueSoon, 30 * time.Minute, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := NewReminderService(nil, nil)
+			if sent, _ := rs.Check(context.Background(), []*models.Work{tt.work}, now); len(sent) != 1 {
+				t.Fatalf("first check sent %d reminders, want 1", len(sent))
+			}
+			if sent, _ := rs.Check(context.Background(), []*models.Work{tt.work}, now.Add(tt.later)); len(sent) != tt.want {
+				t.Errorf("second check sent %d reminders, want %d", len(sent), tt.want)
+			}
+		})
+	}
+
+	// Moving the due date re-arms its reminders
+	rs := NewReminderService(nil, nil)
+	rs.Check(context.Background(), []*models.Work{dueSoon}, now)
+	moved := dueWork("due-soon", now.Add(2*time.Hour))
+	if sent, _ := rs.Check(context.Background(), []*models.Work{moved}, now); len(sent) != 1 {
+		t.Errorf("moved due date sent %d reminders, want 1", len(sent))
+	}
+}
+
+func TestRemindersSurviveRestart(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	works := []*models.Work{dueWork("work-1", now.Add(-time.Hour)), dueWork("work-2", now.Add(time.Hour))}
+	config := DefaultReminderConfig()
+	config.StatePath = filepath.Join(t.TempDir(), "state", "reminders.json")
+
+	sent, err := NewReminderService(nil, config).Check(context.Background(), works, now)
+	if err != nil || len(sent) != 2 {
+		t.Fatalf("first run sent %d reminders, err %v", len(sent), err)
+	}
+
+	// A new service, as after a restart, or another process sharing the state file
+	restarted := NewReminderService(nil, config)
+	if sent, err := restarted.Check(context.Background(), works, now.Add(time.Hour)); err != nil || len(sent) != 0 {
+		t.Errorf("restart re-sent %d reminders, err %v", len(sent), err)
+	}
+	if sent, _ := restarted.Check(context.Background(), works[:1], now.Add(25*time.Hour)); len(sent) != 1 {
+		t.Errorf("overdue repeat after restart = %+v", sent)
+	}
+
+	leftovers, _ := filepath.Glob(filepath.Join(filepath.Dir(config.StatePath), "*.tmp"))
+	if len(leftovers) > 0 {
+		t.Errorf("left behind %v", leftovers)
+	}
+}
+
+func TestRemindersWithUnreadableState(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	config := DefaultReminderConfig()
+	config.StatePath = filepath.Join(t.TempDir(), "reminders.json")
+	if err := os.WriteFile(config.StatePath, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	works := []*models.Work{dueWork("work-1", now.Add(-time.Hour))}
+	sent, err := NewReminderService(nil, config).Check(context.Background(), works, now)
+	if len(sent) != 1 {
+		t.Errorf("sent %d reminders, want the reminder sent anyway", len(sent))
+	}
+	if err == nil {
+		t.Error("the broken state file wasn't reported")
+	}
+
+	// The save replaced the broken file
+	if sent, err := NewReminderService(nil, config).Check(context.Background(), works, now.Add(time.Hour)); err != nil || len(sent) != 0 {
+		t.Errorf("after the save: %d reminders, err %v", len(sent), err)
+	}
+}
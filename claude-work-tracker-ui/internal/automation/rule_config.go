@@ -0,0 +1,705 @@
+package automation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// RulesFile is the on-disk format of declarative transition rules
+type RulesFile struct {
+	Rules []RuleSpec `yaml:"rules"`
+}
+
+// RuleSpec declares a transition rule as conditions over work fields and a list of actions
+type RuleSpec struct {
+	Name        string       `yaml:"name"`
+	Description string       `yaml:"description,omitempty"`
+	Priority    int          `yaml:"priority,omitempty"` // Higher priority rules are evaluated first
+	Disabled    bool         `yaml:"disabled,omitempty"`
+	When        []string     `yaml:"when,omitempty"`     // All conditions must hold, e.g. "progress >= 100"
+	WhenAny     []string     `yaml:"when_any,omitempty"` // At least one must hold, if given
+	Then        []ActionSpec `yaml:"then"`
+	Confirm     bool         `yaml:"confirm,omitempty"` // Wait for approval in the transition inbox instead of applying
+}
+
+// ActionSpec is one step of a rule. Each entry sets a single key.
+type ActionSpec struct {
+	SetStatus    string `yaml:"set_status,omitempty"`
+	MoveSchedule string `yaml:"move_schedule,omitempty"`
+	SetPriority  string `yaml:"set_priority,omitempty"`
+	AddTag       string `yaml:"add_tag,omitempty"`
+	RemoveTag    string `yaml:"remove_tag,omitempty"`
+	CreateUpdate string `yaml:"create_update,omitempty"` // Update text, {field} placeholders are filled in
+	Unblock      bool   `yaml:"unblock,omitempty"`       // Restore the status the work had before it was blocked
+}
+
+// DefaultRulesYAML reproduces the built-in transitions. $variables come from TransitionConfig.
+const DefaultRulesYAML = `rules:
+  - name: draft_to_active
+    description: Move draft items to active when progress > 0
+    priority: 100
+    when:
+      - status == draft
+      - progress > 0
+    then:
+      - set_status: active
+
+  - name: active_to_in_progress
+    description: Move active items to in_progress when progress > 20%
+    priority: 90
+    when:
+      - status == active
+      - progress > 20
+    then:
+      - set_status: in_progress
+
+  - name: in_progress_to_completed
+    description: Complete items when progress reaches 100%
+    priority: 80
+    when:
+      - status == in_progress
+      - progress >= 100
+    then:
+      - set_status: completed
+      - move_schedule: closed
+
+  - name: complete_on_merge
+    description: Propose completing items whose branch was merged into the default branch
+    priority: 75
+    confirm: true
+    when:
+      - branch_merged == true
+      - status in [draft, active, in_progress, blocked]
+    then:
+      - set_status: completed
+      - move_schedule: closed
+      - create_update: "Branch {branch} merged into the default branch"
+
+  - name: stale_to_blocked
+    description: Mark stale in_progress items as blocked
+    priority: 70
+    when:
+      - status == in_progress
+      - schedule == now
+      - days_since_activity > $stale_threshold_days
+    then:
+      - set_status: blocked
+
+  - name: unblock_when_active
+    description: Restore items blocked for inactivity once work resumes
+    priority: 85
+    when:
+      - status == blocked
+      - blocked_by_count == 0
+      - blocked_by_rule == stale_to_blocked
+      - days_since_activity <= $stale_threshold_days
+    then:
+      - unblock: true
+
+  - name: auto_archive_old
+    description: Archive very old closed items
+    priority: 60
+    when:
+      - schedule == closed
+      - status != archived
+      - days_since_completed > $auto_archive_threshold_days
+    then:
+      - set_status: archived
+`
+
+// DefaultRulesPath returns ~/.claude/config/rules.yaml
+func DefaultRulesPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".claude", "config", "rules.yaml")
+}
+
+// fieldKind is the value type of a rule field
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindNumber
+	kindBool
+	kindList
+)
+
+func (k fieldKind) String() string {
+	switch k {
+	case kindNumber:
+		return "number"
+	case kindBool:
+		return "bool"
+	case kindList:
+		return "list"
+	default:
+		return "string"
+	}
+}
+
+// ruleField reads a value from a work item. A nil result means the value is unset.
+type ruleField struct {
+	kind fieldKind
+	get  func(w *models.Work, now time.Time) interface{}
+}
+
+// daysSince returns the days since t, or nil if t is unset
+func daysSince(t *time.Time, now time.Time) interface{} {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return now.Sub(*t).Hours() / 24
+}
+
+// ruleFields lists the work fields rules can test
+var ruleFields = map[string]ruleField{
+	"status":   {kindString, func(w *models.Work, _ time.Time) interface{} { return w.Metadata.Status }},
+	"schedule": {kindString, func(w *models.Work, _ time.Time) interface{} { return w.Schedule }},
+	"priority": {kindString, func(w *models.Work, _ time.Time) interface{} { return w.Metadata.Priority }},
+	"effort":   {kindString, func(w *models.Work, _ time.Time) interface{} { return w.Metadata.EstimatedEffort }},
+	"title":    {kindString, func(w *models.Work, _ time.Time) interface{} { return w.Title }},
+	"group":    {kindString, func(w *models.Work, _ time.Time) interface{} { return w.GroupID }},
+	"branch":   {kindString, func(w *models.Work, _ time.Time) interface{} { return w.GitContext.Branch }},
+	"blocked_by_rule": {kindString, func(w *models.Work, _ time.Time) interface{} {
+		return w.BlockedByRule()
+	}},
+	"due_state": {kindString, func(w *models.Work, now time.Time) interface{} {
+		return w.GetDueState(now)
+	}},
+	"progress": {kindNumber, func(w *models.Work, _ time.Time) interface{} {
+		return float64(w.Metadata.ProgressPercent)
+	}},
+	"activity_score": {kindNumber, func(w *models.Work, _ time.Time) interface{} { return w.Metadata.ActivityScore }},
+	"artifact_count": {kindNumber, func(w *models.Work, _ time.Time) interface{} {
+		return float64(w.Metadata.ArtifactCount)
+	}},
+	"blocked_by_count": {kindNumber, func(w *models.Work, _ time.Time) interface{} {
+		return float64(len(w.Metadata.BlockedBy))
+	}},
+	"tasks_total": {kindNumber, func(w *models.Work, _ time.Time) interface{} {
+		return float64(len(w.Metadata.CompletedTasks) + len(w.Metadata.PendingTasks))
+	}},
+	"tasks_pending": {kindNumber, func(w *models.Work, _ time.Time) interface{} {
+		return float64(len(w.Metadata.PendingTasks))
+	}},
+	"task_completion": {kindNumber, func(w *models.Work, _ time.Time) interface{} {
+		total := len(w.Metadata.CompletedTasks) + len(w.Metadata.PendingTasks)
+		if total == 0 {
+			return nil
+		}
+		return float64(len(w.Metadata.CompletedTasks)) / float64(total)
+	}},
+	"days_since_activity": {kindNumber, func(w *models.Work, now time.Time) interface{} {
+		last := w.GetLastUpdateTime()
+		return daysSince(&last, now)
+	}},
+	"hours_since_activity": {kindNumber, func(w *models.Work, now time.Time) interface{} {
+		last := w.GetLastUpdateTime()
+		if last.IsZero() {
+			return nil
+		}
+		return now.Sub(last).Hours()
+	}},
+	"days_since_created": {kindNumber, func(w *models.Work, now time.Time) interface{} {
+		return daysSince(&w.CreatedAt, now)
+	}},
+	"days_since_started": {kindNumber, func(w *models.Work, now time.Time) interface{} {
+		return daysSince(w.StartedAt, now)
+	}},
+	"days_since_completed": {kindNumber, func(w *models.Work, now time.Time) interface{} {
+		return daysSince(w.CompletedAt, now)
+	}},
+	"days_until_due": {kindNumber, func(w *models.Work, now time.Time) interface{} {
+		if w.DueAt == nil {
+			return nil
+		}
+		return w.DueAt.Sub(now).Hours() / 24
+	}},
+	"overdue":         {kindBool, func(w *models.Work, now time.Time) interface{} { return w.GetDueState(now) == models.DueStateOverdue }},
+	"review_required": {kindBool, func(w *models.Work, _ time.Time) interface{} { return w.Metadata.ReviewRequired }},
+	"branch_merged":   {kindBool, func(w *models.Work, _ time.Time) interface{} { return w.BranchMergedAt != nil }},
+	"progress_from_tasks": {kindBool, func(w *models.Work, _ time.Time) interface{} {
+		return w.Metadata.ProgressFromTasks
+	}},
+	"tags":            {kindList, func(w *models.Work, _ time.Time) interface{} { return w.TechnicalTags }},
+	"blocked_by":      {kindList, func(w *models.Work, _ time.Time) interface{} { return w.Metadata.BlockedBy }},
+}
+
+// RuleFieldNames lists the fields rule conditions can test, with their types
+func RuleFieldNames() []string {
+	var names []string
+	for name, field := range ruleFields {
+		names = append(names, fmt.Sprintf("%s (%s)", name, field.kind))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ruleVariables returns the $variables conditions may compare against
+func ruleVariables(config *TransitionConfig) map[string]float64 {
+	return map[string]float64{
+		"stale_threshold_days":        float64(config.StaleThresholdDays),
+		"inactivity_threshold_hours":  float64(config.InactivityThresholdHours),
+		"auto_archive_threshold_days": float64(config.AutoArchiveThresholdDays),
+	}
+}
+
+var knownStatuses = []string{
+	models.WorkStatusDraft, models.WorkStatusActive, models.WorkStatusInProgress,
+	models.WorkStatusCompleted, models.WorkStatusArchived, models.WorkStatusBlocked,
+	models.WorkStatusOnHold, models.WorkStatusCanceled,
+}
+
+var knownSchedules = []string{
+	models.ScheduleNow, models.ScheduleNext, models.ScheduleLater, models.ScheduleClosed,
+}
+
+var conditionPattern = regexp.MustCompile(`^\s*([a-z_]+)\s*(==|!=|>=|<=|>|<|\bnot in\b|\bin\b|\bcontains\b)\s*(.*?)\s*$`)
+
+// condition is a parsed "field op value" test
+type condition struct {
+	expr     string
+	field    string
+	op       string
+	values   []string // Literal operands, one unless op is in/not in
+	variable string   // Set when the operand is a $variable
+}
+
+// ConditionResult explains how one condition evaluated
+type ConditionResult struct {
+	Expr   string
+	Actual string // The work's value for the field
+	Passed bool
+}
+
+// parseCondition parses and type-checks a condition
+func parseCondition(expr string) (condition, error) {
+	match := conditionPattern.FindStringSubmatch(expr)
+	if match == nil {
+		return condition{}, fmt.Errorf("condition %q: expected \"<field> <op> <value>\"", expr)
+	}
+	cond := condition{expr: strings.TrimSpace(expr), field: match[1], op: match[2]}
+	operand := match[3]
+
+	field, ok := ruleFields[cond.field]
+	if !ok {
+		return cond, fmt.Errorf("condition %q: unknown field %q", expr, cond.field)
+	}
+	if operand == "" {
+		return cond, fmt.Errorf("condition %q: missing value", expr)
+	}
+
+	switch cond.op {
+	case "in", "not in":
+		if !strings.HasPrefix(operand, "[") || !strings.HasSuffix(operand, "]") {
+			return cond, fmt.Errorf("condition %q: %s needs a list like [a, b]", expr, cond.op)
+		}
+		for _, item := range strings.Split(strings.Trim(operand, "[]"), ",") {
+			if item = unquote(item); item != "" {
+				cond.values = append(cond.values, item)
+			}
+		}
+	default:
+		if strings.HasPrefix(operand, "$") {
+			cond.variable = operand[1:]
+		} else {
+			cond.values = []string{unquote(operand)}
+		}
+	}
+
+	// Check the operator against the field type
+	switch cond.op {
+	case ">", ">=", "<", "<=":
+		if field.kind != kindNumber {
+			return cond, fmt.Errorf("condition %q: %s only works on numbers, %s is a %s", expr, cond.op, cond.field, field.kind)
+		}
+	case "contains":
+		if field.kind != kindList && field.kind != kindString {
+			return cond, fmt.Errorf("condition %q: contains only works on lists and text", expr)
+		}
+	case "in", "not in":
+		if field.kind != kindString && field.kind != kindNumber {
+			return cond, fmt.Errorf("condition %q: %s only works on text and numbers", expr, cond.op)
+		}
+	case "==", "!=":
+		if field.kind == kindList {
+			return cond, fmt.Errorf("condition %q: use contains to test %s", expr, cond.field)
+		}
+	}
+
+	// Check literal operands against the field type
+	for _, value := range cond.values {
+		switch field.kind {
+		case kindNumber:
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return cond, fmt.Errorf("condition %q: %q is not a number", expr, value)
+			}
+		case kindBool:
+			if _, err := strconv.ParseBool(value); err != nil {
+				return cond, fmt.Errorf("condition %q: %q is not true or false", expr, value)
+			}
+		}
+		switch cond.field {
+		case "status":
+			if !containsString(knownStatuses, value) {
+				return cond, fmt.Errorf("condition %q: unknown status %q", expr, value)
+			}
+		case "schedule":
+			if !containsString(knownSchedules, value) {
+				return cond, fmt.Errorf("condition %q: unknown schedule %q", expr, value)
+			}
+		}
+	}
+
+	if cond.variable != "" {
+		if field.kind != kindNumber {
+			return cond, fmt.Errorf("condition %q: $%s can only be compared with numbers", expr, cond.variable)
+		}
+		if _, ok := ruleVariables(DefaultTransitionConfig())[cond.variable]; !ok {
+			return cond, fmt.Errorf("condition %q: unknown variable $%s", expr, cond.variable)
+		}
+	}
+
+	return cond, nil
+}
+
+// eval tests the condition against a work item
+func (c condition) eval(w *models.Work, now time.Time, vars map[string]float64) ConditionResult {
+	field := ruleFields[c.field]
+	value := field.get(w, now)
+	result := ConditionResult{Expr: c.expr, Actual: formatRuleValue(value)}
+
+	operands := c.values
+	if c.variable != "" {
+		operands = []string{strconv.FormatFloat(vars[c.variable], 'f', -1, 64)}
+		result.Expr = fmt.Sprintf("%s (%s=%s)", c.expr, c.variable, operands[0])
+	}
+	if value == nil {
+		return result // Unset values never match
+	}
+
+	switch v := value.(type) {
+	case float64:
+		result.Passed = compareNumber(v, c.op, operands)
+	case bool:
+		want, _ := strconv.ParseBool(operands[0])
+		result.Passed = (v == want) == (c.op == "==")
+	case []string:
+		result.Passed = c.op == "contains" && containsString(v, operands[0])
+	case string:
+		switch c.op {
+		case "==":
+			result.Passed = v == operands[0]
+		case "!=":
+			result.Passed = v != operands[0]
+		case "in":
+			result.Passed = containsString(operands, v)
+		case "not in":
+			result.Passed = !containsString(operands, v)
+		case "contains":
+			result.Passed = strings.Contains(strings.ToLower(v), strings.ToLower(operands[0]))
+		}
+	}
+	return result
+}
+
+// compareNumber applies a numeric operator
+func compareNumber(v float64, op string, operands []string) bool {
+	if op == "in" || op == "not in" {
+		found := false
+		for _, operand := range operands {
+			if n, _ := strconv.ParseFloat(operand, 64); n == v {
+				found = true
+			}
+		}
+		return found == (op == "in")
+	}
+
+	n, _ := strconv.ParseFloat(operands[0], 64)
+	switch op {
+	case "==":
+		return v == n
+	case "!=":
+		return v != n
+	case ">":
+		return v > n
+	case ">=":
+		return v >= n
+	case "<":
+		return v < n
+	case "<=":
+		return v <= n
+	}
+	return false
+}
+
+// Validate checks that an action sets exactly one known key
+func (a ActionSpec) Validate() error {
+	set := 0
+	for _, value := range []string{a.SetStatus, a.MoveSchedule, a.SetPriority, a.AddTag, a.RemoveTag, a.CreateUpdate} {
+		if value != "" {
+			set++
+		}
+	}
+	if a.Unblock {
+		set++
+	}
+	if set != 1 {
+		return fmt.Errorf("each action must set exactly one of set_status, move_schedule, set_priority, add_tag, remove_tag, create_update, unblock")
+	}
+	if a.SetStatus != "" && !containsString(knownStatuses, a.SetStatus) {
+		return fmt.Errorf("unknown status %q", a.SetStatus)
+	}
+	if a.MoveSchedule != "" && !containsString(knownSchedules, a.MoveSchedule) {
+		return fmt.Errorf("unknown schedule %q", a.MoveSchedule)
+	}
+	return nil
+}
+
+// Describe returns a short human-readable form of the action
+func (a ActionSpec) Describe() string {
+	switch {
+	case a.SetStatus != "":
+		return "set status to " + a.SetStatus
+	case a.MoveSchedule != "":
+		return "move to " + strings.ToUpper(a.MoveSchedule)
+	case a.SetPriority != "":
+		return "set priority to " + a.SetPriority
+	case a.AddTag != "":
+		return "add tag " + a.AddTag
+	case a.RemoveTag != "":
+		return "remove tag " + a.RemoveTag
+	case a.Unblock:
+		return "restore the status from before it was blocked"
+	default:
+		return fmt.Sprintf("create update %q", a.CreateUpdate)
+	}
+}
+
+// apply performs the action on a work item
+func (a ActionSpec) apply(w *models.Work, now time.Time) {
+	switch {
+	case a.SetStatus != "":
+		w.Metadata.Status = a.SetStatus
+		if a.SetStatus == models.WorkStatusCompleted && w.CompletedAt == nil {
+			completed := now
+			w.CompletedAt = &completed
+		}
+	case a.MoveSchedule != "":
+		w.Schedule = a.MoveSchedule
+	case a.SetPriority != "":
+		w.Metadata.Priority = a.SetPriority
+	case a.AddTag != "":
+		if !containsString(w.TechnicalTags, a.AddTag) {
+			w.TechnicalTags = append(w.TechnicalTags, a.AddTag)
+		}
+	case a.Unblock:
+		w.Unblock()
+	case a.RemoveTag != "":
+		tags := w.TechnicalTags[:0:0]
+		for _, tag := range w.TechnicalTags {
+			if tag != a.RemoveTag {
+				tags = append(tags, tag)
+			}
+		}
+		w.TechnicalTags = tags
+	}
+}
+
+var placeholderPattern = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// renderUpdate fills {field} placeholders in update text from the work item
+func renderUpdate(text string, w *models.Work, now time.Time) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		field, ok := ruleFields[placeholder[1:len(placeholder)-1]]
+		if !ok {
+			return placeholder
+		}
+		return formatRuleValue(field.get(w, now))
+	})
+}
+
+// compiledRule is a validated RuleSpec ready to evaluate
+type compiledRule struct {
+	spec    RuleSpec
+	all     []condition
+	any     []condition
+	updates []string
+}
+
+// compileRule validates a rule spec and parses its conditions, reporting every problem
+func compileRule(spec RuleSpec) (*compiledRule, error) {
+	if spec.Name == "" {
+		return nil, fmt.Errorf("rule is missing a name")
+	}
+
+	var problems []error
+	if len(spec.When) == 0 && len(spec.WhenAny) == 0 {
+		problems = append(problems, fmt.Errorf("rule %q: needs at least one condition in when or when_any", spec.Name))
+	}
+	if len(spec.Then) == 0 {
+		problems = append(problems, fmt.Errorf("rule %q: needs at least one action in then", spec.Name))
+	}
+
+	rule := &compiledRule{spec: spec}
+	for _, expr := range spec.When {
+		cond, err := parseCondition(expr)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("rule %q: %w", spec.Name, err))
+		}
+		rule.all = append(rule.all, cond)
+	}
+	for _, expr := range spec.WhenAny {
+		cond, err := parseCondition(expr)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("rule %q: %w", spec.Name, err))
+		}
+		rule.any = append(rule.any, cond)
+	}
+	for i, action := range spec.Then {
+		if err := action.Validate(); err != nil {
+			problems = append(problems, fmt.Errorf("rule %q: action #%d: %w", spec.Name, i+1, err))
+		}
+		if action.CreateUpdate != "" {
+			rule.updates = append(rule.updates, action.CreateUpdate)
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.Join(problems...)
+	}
+	return rule, nil
+}
+
+// explain evaluates every condition, reporting whether the rule matches
+func (r *compiledRule) explain(w *models.Work, now time.Time, vars map[string]float64) ([]ConditionResult, bool) {
+	var results []ConditionResult
+	matched := true
+	for _, cond := range r.all {
+		result := cond.eval(w, now, vars)
+		results = append(results, result)
+		matched = matched && result.Passed
+	}
+	if len(r.any) > 0 {
+		anyPassed := false
+		for _, cond := range r.any {
+			result := cond.eval(w, now, vars)
+			result.Expr = "any: " + result.Expr
+			results = append(results, result)
+			anyPassed = anyPassed || result.Passed
+		}
+		matched = matched && anyPassed
+	}
+	return results, matched
+}
+
+// toTransitionRule wraps a compiled rule for the engine
+func (r *compiledRule) toTransitionRule(config *TransitionConfig) TransitionRule {
+	return TransitionRule{
+		Name:        r.spec.Name,
+		Description: r.spec.Description,
+		Priority:    r.spec.Priority,
+		Condition: func(w *models.Work) bool {
+			_, matched := r.explain(w, time.Now(), ruleVariables(config))
+			return matched
+		},
+		Action: func(w *models.Work) *models.Work {
+			now := time.Now()
+			for _, action := range r.spec.Then {
+				action.apply(w, now)
+			}
+			return w
+		},
+		compiled: r,
+	}
+}
+
+// ParseRules decodes and validates a rules document, reporting every problem found
+func ParseRules(data []byte) ([]RuleSpec, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var file RulesFile
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to parse rules: %w", err)
+	}
+
+	var problems []error
+	seen := make(map[string]bool)
+	for _, spec := range file.Rules {
+		if seen[spec.Name] {
+			problems = append(problems, fmt.Errorf("rule %q: defined more than once", spec.Name))
+		}
+		seen[spec.Name] = true
+		if _, err := compileRule(spec); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	if len(problems) > 0 {
+		return nil, errors.Join(problems...)
+	}
+	return file.Rules, nil
+}
+
+// LoadRulesFile reads and validates a rules file
+func LoadRulesFile(path string) ([]RuleSpec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+	return ParseRules(data)
+}
+
+// formatRuleValue renders a field value for explanations and update text
+func formatRuleValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "unset"
+	case float64:
+		return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+	case []string:
+		return "[" + strings.Join(v, ", ") + "]"
+	case string:
+		if v == "" {
+			return `""`
+		}
+		return v
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// unquote trims whitespace and surrounding quotes from a literal
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
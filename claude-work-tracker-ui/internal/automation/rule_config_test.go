@@ -0,0 +1,235 @@
+package automation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+func TestParseConditionErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"progress", `expected "<field> <op> <value>"`},
+		{"colour == red", `unknown field "colour"`},
+		{"status ==", "missing value"},
+		{"title > b", "> only works on numbers, title is a string"},
+		{"overdue contains true", "contains only works on lists and text"},
+		{"overdue in [true]", "in only works on text and numbers"},
+		{"tags == urgent", "use contains to test tags"},
+		{"blocked_by != work-1", "use contains to test blocked_by"},
+		{"status in active, draft", "in needs a list like [a, b]"},
+		{"status not in draft", "not in needs a list like [a, b]"},
+		{"progress >= half", `"half" is not a number`},
+		{"progress in [10, many]", `"many" is not a number`},
+		{"overdue == maybe", `"maybe" is not true or false`},
+		{"status == finished", `unknown status "finished"`},
+		{"status in [active, finished]", `unknown status "finished"`},
+		{"schedule == someday", `unknown schedule "someday"`},
+		{"days_since_activity > $forever", "unknown variable $forever"},
+		{"status == $stale_threshold_days", "$stale_threshold_days can only be compared with numbers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := parseCondition(tt.expr)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseCondition(%q) = %v, want %q", tt.expr, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		expr     string
+		field    string
+		op       string
+		values   []string
+		variable string
+	}{
+		{"status == draft", "status", "==", []string{"draft"}, ""},
+		{"  progress>=100  ", "progress", ">=", []string{"100"}, ""},
+		{`title contains "fix bug"`, "title", "contains", []string{"fix bug"}, ""},
+		{"status in [active, 'in_progress']", "status", "in", []string{"active", "in_progress"}, ""},
+		{"priority not in [low]", "priority", "not in", []string{"low"}, ""},
+		{"tags contains urgent", "tags", "contains", []string{"urgent"}, ""},
+		{"overdue == true", "overdue", "==", []string{"true"}, ""},
+		{"days_since_activity > $stale_threshold_days", "days_since_activity", ">", nil, "stale_threshold_days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cond, err := parseCondition(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cond.field != tt.field || cond.op != tt.op || cond.variable != tt.variable ||
+				strings.Join(cond.values, "|") != strings.Join(tt.values, "|") {
+				t.Errorf("parsed %+v, want %s %s %v $%s", cond, tt.field, tt.op, tt.values, tt.variable)
+			}
+		})
+	}
+}
+
+func TestConditionEval(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	lastWeek := now.AddDate(0, 0, -7)
+
+	work := &models.Work{
+		ID:            "work-1",
+		Title:         "Fix the Login page",
+		Schedule:      models.ScheduleNow,
+		TechnicalTags: []string{"frontend", "urgent"},
+		StartedAt:     &lastWeek,
+		Metadata: models.WorkMetadata{
+			Status:          models.WorkStatusInProgress,
+			ProgressPercent: 60,
+			ReviewRequired:  true,
+		},
+	}
+	vars := map[string]float64{"stale_threshold_days": 5}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"status == in_progress", true},
+		{"status != in_progress", false},
+		{"status in [active, in_progress]", true},
+		{"status not in [active, in_progress]", false},
+		{"title contains login", true},
+		{"progress > 50", true},
+		{"progress <= 50", false},
+		{"progress in [20, 60]", true},
+		{"progress not in [20, 60]", false},
+		{"review_required == true", true},
+		{"review_required != true", false},
+		{"tags contains urgent", true},
+		{"tags contains backend", false},
+		{"days_since_started > $stale_threshold_days", true},
+		{"days_since_started < 7", false},
+		// Unset values never match, whichever way the test is put
+		{"days_since_completed < 1", false},
+		{"days_since_completed >= 0", false},
+		{"days_until_due > 0", false},
+		{"days_until_due <= 0", false},
+		{"task_completion < 1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cond, err := parseCondition(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := cond.eval(work, now, vars); got.Passed != tt.want {
+				t.Errorf("eval = %+v, want passed %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+		want  []string // Problems every reported error must mention, none if valid
+	}{
+		{
+			name:  "default rules",
+			rules: DefaultRulesYAML,
+		},
+		{
+			name: "valid rule",
+			rules: `rules:
+  - name: finish
+    when_any:
+      - progress >= 100
+      - tags contains done
+    then:
+      - set_status: completed
+      - create_update: "Finished {title}"
+`,
+		},
+		{
+			name:  "unknown key",
+			rules: "rules:\n  - name: finish\n    when: [progress >= 100]\n    then: [{set_status: completed}]\n    unless: [overdue == true]\n",
+			want:  []string{"failed to parse rules", "unless"},
+		},
+		{
+			name:  "missing name",
+			rules: "rules:\n  - when: [progress >= 100]\n    then: [{set_status: completed}]\n",
+			want:  []string{"rule is missing a name"},
+		},
+		{
+			name:  "no conditions or actions",
+			rules: "rules:\n  - name: empty\n",
+			want:  []string{"needs at least one condition", "needs at least one action"},
+		},
+		{
+			name:  "duplicate names",
+			rules: "rules:\n  - name: a\n    when: [progress > 0]\n    then: [{set_status: active}]\n  - name: a\n    when: [progress > 0]\n    then: [{set_status: active}]\n",
+			want:  []string{`rule "a": defined more than once`},
+		},
+		{
+			name:  "every problem is reported",
+			rules: "rules:\n  - name: bad\n    when: [colour == red]\n    when_any: [progress > lots]\n    then: [{set_status: finished}]\n",
+			want:  []string{`unknown field "colour"`, `"lots" is not a number`, `action #1: unknown status "finished"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRules([]byte(tt.rules))
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("no error, want %q", tt.want)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q doesn't mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestActionSpecValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		action ActionSpec
+		want   string
+	}{
+		{"status", ActionSpec{SetStatus: models.WorkStatusCompleted}, ""},
+		{"schedule", ActionSpec{MoveSchedule: models.ScheduleClosed}, ""},
+		{"unblock", ActionSpec{Unblock: true}, ""},
+		{"nothing set", ActionSpec{}, "exactly one"},
+		{"two keys", ActionSpec{AddTag: "a", Unblock: true}, "exactly one"},
+		{"unknown status", ActionSpec{SetStatus: "finished"}, `unknown status "finished"`},
+		{"unknown schedule", ActionSpec{MoveSchedule: "someday"}, `unknown schedule "someday"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.action.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
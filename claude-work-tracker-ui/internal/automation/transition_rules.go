@@ -3,6 +3,9 @@ package automation
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"claude-work-tracker-ui/internal/hooks"
@@ -16,13 +19,21 @@ type TransitionRule struct {
 	Condition   func(w *models.Work) bool
 	Action      func(w *models.Work) *models.Work
 	Priority    int // Higher priority rules are evaluated first
+
+	compiled *compiledRule // Set for rules loaded from YAML
 }
 
 // TransitionEngine manages automatic transitions based on rules
 type TransitionEngine struct {
-	rules      []TransitionRule
-	hookSystem *hooks.HookSystem
-	config     *TransitionConfig
+	mu           sync.RWMutex
+	rules        []TransitionRule
+	customRules  []TransitionRule // Added with AddRule
+	hookSystem   *hooks.HookSystem
+	config       *TransitionConfig
+	rulesModTime time.Time
+	rulesErr     error
+	updateSink   func(work *models.Work, update *models.Update)
+	workTree     *models.WorkTree // Hierarchy EvaluateWork checks completions against
 }
 
 // TransitionConfig contains configuration for the transition engine
@@ -32,6 +43,7 @@ type TransitionConfig struct {
 	StaleThresholdDays        int
 	InactivityThresholdHours  int
 	AutoArchiveThresholdDays  int
+	RulesPath                 string // YAML rules file, reloaded when it changes
 }
 
 // DefaultTransitionConfig returns default configuration
@@ -42,6 +54,7 @@ func DefaultTransitionConfig() *TransitionConfig {
 		StaleThresholdDays:        7,
 		InactivityThresholdHours:  48,
 		AutoArchiveThresholdDays:  90,
+		RulesPath:                 DefaultRulesPath(),
 	}
 }
 
@@ -62,205 +75,398 @@ func NewTransitionEngine(hookSystem *hooks.HookSystem, config *TransitionConfig)
 	return engine
 }
 
-// initializeDefaultRules sets up the standard transition rules
+// initializeDefaultRules sets up the standard transition rules, replaced by the
+// rules file when one exists
 func (te *TransitionEngine) initializeDefaultRules() {
-	te.rules = []TransitionRule{
-		// Draft to Active when progress starts
-		{
-			Name:        "draft_to_active",
-			Description: "Move draft items to active when progress > 0",
-			Priority:    100,
-			Condition: func(w *models.Work) bool {
-				return w.Metadata.Status == "draft" && w.Metadata.ProgressPercent > 0
-			},
-			Action: func(w *models.Work) *models.Work {
-				w.Metadata.Status = "active"
-				// Add automation metadata - would need to extend WorkMetadata struct
-				// For now, just update the basic fields
-				return w
-			},
-		},
-
-		// Active to In Progress when significant progress
-		{
-			Name:        "active_to_in_progress",
-			Description: "Move active items to in_progress when progress > 20%",
-			Priority:    90,
-			Condition: func(w *models.Work) bool {
-				return w.Metadata.Status == "active" && w.Metadata.ProgressPercent > 20
-			},
-			Action: func(w *models.Work) *models.Work {
-				w.Metadata.Status = "in_progress"
-				// Auto-transition flag would be added to WorkMetadata struct
-				// Transition reason would be added to WorkMetadata struct: "Significant progress made"
-				return w
-			},
-		},
-
-		// In Progress to Completed when progress = 100%
-		{
-			Name:        "in_progress_to_completed",
-			Description: "Complete items when progress reaches 100%",
-			Priority:    80,
-			Condition: func(w *models.Work) bool {
-				return w.Metadata.Status == "in_progress" && w.Metadata.ProgressPercent >= 100
-			},
-			Action: func(w *models.Work) *models.Work {
-				w.Metadata.Status = "completed"
-				w.Schedule = "closed"
-				now := time.Now()
-			w.CompletedAt = &now
-				// Auto-transition flag would be added to WorkMetadata struct
-				// Transition reason would be added to WorkMetadata struct: "Progress reached 100%"
-				return w
-			},
-		},
-
-		// Stale In Progress to Blocked
-		{
-			Name:        "stale_to_blocked",
-			Description: "Mark stale in_progress items as blocked",
-			Priority:    70,
-			Condition: func(w *models.Work) bool {
-				if w.Metadata.Status != "in_progress" || w.Schedule != "now" {
-					return false
-				}
-				daysSinceActivity := time.Since(*w.Metadata.LastActivityAt).Hours() / 24
-				return daysSinceActivity > float64(te.config.StaleThresholdDays)
-			},
-			Action: func(w *models.Work) *models.Work {
-				w.Metadata.Status = "blocked"
-				// Auto-transition flag would be added to WorkMetadata struct
-				// Transition reason would be added to WorkMetadata struct: fmt.Sprintf("No activity for %d days", te.config.StaleThresholdDays)
-				// Previous status would be added to WorkMetadata struct: "in_progress"
-				return w
-			},
-		},
-
-		// Auto-archive very old closed items
-		{
-			Name:        "auto_archive_old",
-			Description: "Archive very old closed items",
-			Priority:    60,
-			Condition: func(w *models.Work) bool {
-				if w.Schedule != "closed" || w.Metadata.Status == "archived" {
-					return false
-				}
-				if w.CompletedAt == nil {
-					return false
-				}
-				daysSinceCompleted := time.Since(*w.CompletedAt).Hours() / 24
-				return daysSinceCompleted > float64(te.config.AutoArchiveThresholdDays)
-			},
-			Action: func(w *models.Work) *models.Work {
-				w.Metadata.Status = "archived"
-				// Auto-transition flag would be added to WorkMetadata struct
-				// Transition reason would be added to WorkMetadata struct: fmt.Sprintf("Completed %d days ago", te.config.AutoArchiveThresholdDays)
-				// Archived timestamp would be added to WorkMetadata struct: time.Now().Format(time.RFC3339)
-				return w
-			},
-		},
-
-		// Blocked items with resolved dependencies
-		{
-			Name:        "unblock_resolved",
-			Description: "Unblock items when dependencies are resolved",
-			Priority:    85,
-			Condition: func(w *models.Work) bool {
-				if w.Metadata.Status != "blocked" || len(w.Metadata.BlockedBy) == 0 {
-					return false
-				}
-				// This would need access to other work items to check if blockers are resolved
-				// For now, we'll skip this rule and implement it later with proper context
-				return false
-			},
-			Action: func(w *models.Work) *models.Work {
-				// Previous status would be stored in WorkMetadata field
-				w.Metadata.Status = "active" // Simplified for now
-				// Auto-transition flag would be added to WorkMetadata struct
-				// Transition reason would be added to WorkMetadata struct: "Dependencies resolved"
-				// Previous status field would be cleared in WorkMetadata
-				return w
-			},
-		},
+	specs, err := ParseRules([]byte(DefaultRulesYAML))
+	if err != nil {
+		panic(fmt.Sprintf("invalid default rules: %v", err))
+	}
+	te.rules = te.compileRules(specs)
+
+	if te.config.RulesPath != "" {
+		te.reloadIfChanged()
 	}
 }
 
-// EvaluateWork checks if any transition rules apply to a work item
+// compileRules turns validated specs into engine rules, sorted by priority
+func (te *TransitionEngine) compileRules(specs []RuleSpec) []TransitionRule {
+	var rules []TransitionRule
+	for _, spec := range specs {
+		if spec.Disabled {
+			continue
+		}
+		compiled, err := compileRule(spec)
+		if err != nil {
+			continue // ParseRules already rejected invalid specs
+		}
+		rules = append(rules, compiled.toTransitionRule(te.config))
+	}
+	sortRules(rules)
+	return rules
+}
+
+// reloadIfChanged re-reads the rules file when its modification time changes. An invalid
+// file keeps the previous rules and is reported by RulesError; a deleted one restores the defaults.
+func (te *TransitionEngine) reloadIfChanged() {
+	if te.config.RulesPath == "" {
+		return
+	}
+
+	info, err := os.Stat(te.config.RulesPath)
+	te.mu.Lock()
+	defer te.mu.Unlock()
+
+	if err != nil {
+		if !te.rulesModTime.IsZero() {
+			specs, _ := ParseRules([]byte(DefaultRulesYAML))
+			te.rules = append(te.compileRules(specs), te.customRules...)
+			sortRules(te.rules)
+			te.rulesModTime = time.Time{}
+			te.rulesErr = nil
+		}
+		return
+	}
+	if info.ModTime().Equal(te.rulesModTime) {
+		return
+	}
+	te.rulesModTime = info.ModTime()
+
+	specs, err := LoadRulesFile(te.config.RulesPath)
+	if err != nil {
+		te.rulesErr = err
+		return
+	}
+	te.rules = append(te.compileRules(specs), te.customRules...)
+	sortRules(te.rules)
+	te.rulesErr = nil
+}
+
+// RulesError returns the error from the last rules file load, if it was rejected
+func (te *TransitionEngine) RulesError() error {
+	te.reloadIfChanged()
+	te.mu.RLock()
+	defer te.mu.RUnlock()
+	return te.rulesErr
+}
+
+// GetRules returns the active rules in evaluation order
+func (te *TransitionEngine) GetRules() []TransitionRule {
+	te.reloadIfChanged()
+	te.mu.RLock()
+	defer te.mu.RUnlock()
+	return append([]TransitionRule(nil), te.rules...)
+}
+
+// SetUpdateSink receives the updates created by create_update actions, with the work item
+// they're for
+func (te *TransitionEngine) SetUpdateSink(sink func(work *models.Work, update *models.Update)) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	te.updateSink = sink
+}
+
+// emitUpdates renders a rule's create_update actions and hands them to the update sink
+func (te *TransitionEngine) emitUpdates(rule TransitionRule, work *models.Work, now time.Time) {
+	te.mu.RLock()
+	sink := te.updateSink
+	te.mu.RUnlock()
+	if sink == nil || rule.compiled == nil {
+		return
+	}
+
+	for _, text := range rule.compiled.updates {
+		sink(work, &models.Update{
+			ID:         models.NewUpdateID(),
+			WorkID:     work.ID,
+			Timestamp:  now,
+			Title:      fmt.Sprintf("Rule %s", rule.Name),
+			Summary:    renderUpdate(text, work, now),
+			Author:     "automation",
+			UpdateType: "automatic",
+		})
+	}
+}
+
+// SetWorkTree gives EvaluateWork the hierarchy of the work it evaluates, so rules can't
+// complete a work item while work below it is still open
+func (te *TransitionEngine) SetWorkTree(tree *models.WorkTree) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	te.workTree = tree
+}
+
+// completesOverOpenChildren reports whether a rule's change would complete a work item that
+// still has open work below it
+func completesOverOpenChildren(tree *models.WorkTree, oldWork, newWork *models.Work) bool {
+	if tree == nil || oldWork.IsCompleted() || !newWork.IsCompleted() {
+		return false
+	}
+	return len(tree.OpenDescendants(oldWork.ID)) > 0
+}
+
+// EvaluateWork checks if any transition rules apply to a work item. Transitions that need
+// confirmation are left for the transition inbox and the work is returned unchanged.
 func (te *TransitionEngine) EvaluateWork(ctx context.Context, work *models.Work) (*models.Work, bool, error) {
 	if !te.config.Enabled {
 		return work, false, nil
 	}
 
-	// Sort rules by priority (highest first)
-	for _, rule := range te.rules {
-		if rule.Condition(work) {
-			// Create a copy to preserve original for hooks
-			oldWork := *work
+	now := time.Now()
+	te.mu.RLock()
+	tree := te.workTree
+	te.mu.RUnlock()
 
-			// Apply the transition
-			newWork := rule.Action(work)
+	// Rules are kept sorted by priority (highest first)
+	for _, rule := range te.GetRules() {
+		if work.IsTransitionSnoozed(rule.Name, now) || !rule.Condition(work) {
+			continue
+		}
 
-			// Special handling for NOW transitions
-			if oldWork.Schedule != "now" && newWork.Schedule == "now" && te.config.RequireUserConfirmation {
-				// Mark for confirmation instead of auto-transitioning
-				// Would need to add pending_transition field to WorkMetadata
-				return newWork, false, nil
-			}
+		// Apply the transition to a copy so a pending one leaves the work untouched
+		newWork := rule.Action(copyWork(work))
+		if completesOverOpenChildren(tree, work, newWork) {
+			continue
+		}
+		if te.needsConfirmation(rule, work, newWork) {
+			return work, false, nil
+		}
 
-			// Execute hooks
-			hookCtx := &hooks.HookContext{
-				WorkItem:    newWork,
-				OldWorkItem: &oldWork,
-				EventType:   hooks.AfterStatusChange,
-				Timestamp:   time.Now(),
-				Metadata: map[string]interface{}{
-					"rule_name":        rule.Name,
-					"rule_description": rule.Description,
-				},
-			}
+		newWork.RecordTransitionFrom(work, rule.Name, rule.Description, models.TransitionActorAutomation)
 
-			_, err := te.hookSystem.Execute(ctx, hookCtx)
-			if err != nil {
-				return work, false, fmt.Errorf("hook execution failed: %w", err)
-			}
+		// Execute hooks
+		hookCtx := &hooks.HookContext{
+			WorkItem:    newWork,
+			OldWorkItem: work,
+			EventType:   hooks.AfterStatusChange,
+			Timestamp:   now,
+			Metadata: map[string]interface{}{
+				"rule_name":        rule.Name,
+				"rule_description": rule.Description,
+			},
+		}
 
-			return newWork, true, nil
+		_, err := te.hookSystem.Execute(ctx, hookCtx)
+		if err != nil {
+			return work, false, fmt.Errorf("hook execution failed: %w", err)
 		}
+
+		te.emitUpdates(rule, newWork, now)
+		return newWork, true, nil
 	}
 
 	return work, false, nil
 }
 
-// AddRule adds a custom transition rule
+// needsConfirmation reports whether a rule's change must be approved before it is applied
+func (te *TransitionEngine) needsConfirmation(rule TransitionRule, oldWork, newWork *models.Work) bool {
+	if rule.compiled != nil && rule.compiled.spec.Confirm {
+		return true
+	}
+	return te.config.RequireUserConfirmation &&
+		oldWork.Schedule != models.ScheduleNow && newWork.Schedule == models.ScheduleNow
+}
+
+// RuleEvaluation explains whether a rule matches a work item
+type RuleEvaluation struct {
+	Rule        string
+	Description string
+	Priority    int
+	Matched     bool
+	Conditions  []ConditionResult // Empty for rules added in Go
+	Actions     []string
+}
+
+// Explain evaluates every rule against a work item without changing it. Rules are
+// returned in evaluation order, so the first match is the one that would fire.
+func (te *TransitionEngine) Explain(work *models.Work, now time.Time) []RuleEvaluation {
+	vars := ruleVariables(te.config)
+
+	var evaluations []RuleEvaluation
+	for _, rule := range te.GetRules() {
+		evaluation := RuleEvaluation{
+			Rule:        rule.Name,
+			Description: rule.Description,
+			Priority:    rule.Priority,
+		}
+		if rule.compiled != nil {
+			evaluation.Conditions, evaluation.Matched = rule.compiled.explain(work, now, vars)
+			for _, action := range rule.compiled.spec.Then {
+				evaluation.Actions = append(evaluation.Actions, action.Describe())
+			}
+		} else {
+			probe := copyWork(work)
+			evaluation.Matched = rule.Condition(probe)
+		}
+		evaluations = append(evaluations, evaluation)
+	}
+	return evaluations
+}
+
+// DryRun returns the work item as the first matching rule would leave it, or nil if no rule fires
+func (te *TransitionEngine) DryRun(work *models.Work, now time.Time) (*models.Work, *TransitionRule) {
+	for _, rule := range te.GetRules() {
+		probe := copyWork(work)
+		matched := false
+		if rule.compiled != nil {
+			_, matched = rule.compiled.explain(probe, now, ruleVariables(te.config))
+		} else {
+			matched = rule.Condition(probe)
+		}
+		if matched {
+			return rule.Action(probe), &rule
+		}
+	}
+	return nil, nil
+}
+
+// copyWork copies a work item deeply enough that rule actions can't touch the original
+func copyWork(work *models.Work) *models.Work {
+	dup := *work
+	dup.TechnicalTags = append([]string(nil), work.TechnicalTags...)
+	dup.Metadata.BlockedBy = append([]string(nil), work.Metadata.BlockedBy...)
+	dup.Transitions = append([]models.Transition(nil), work.Transitions...)
+	dup.SnoozedTransitions = append([]models.SnoozedTransition(nil), work.SnoozedTransitions...)
+	return &dup
+}
+
+// AddRule adds a custom transition rule, kept across rules file reloads
 func (te *TransitionEngine) AddRule(rule TransitionRule) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	te.customRules = append(te.customRules, rule)
 	te.rules = append(te.rules, rule)
-	// Re-sort by priority
-	te.sortRulesByPriority()
+	sortRules(te.rules)
 }
 
-// sortRulesByPriority sorts rules by priority (highest first)
-func (te *TransitionEngine) sortRulesByPriority() {
+// sortRules sorts rules by priority (highest first)
+func sortRules(rules []TransitionRule) {
 	// Simple insertion sort for small number of rules
-	for i := 1; i < len(te.rules); i++ {
+	for i := 1; i < len(rules); i++ {
 		j := i
-		for j > 0 && te.rules[j].Priority > te.rules[j-1].Priority {
-			te.rules[j], te.rules[j-1] = te.rules[j-1], te.rules[j]
+		for j > 0 && rules[j].Priority > rules[j-1].Priority {
+			rules[j], rules[j-1] = rules[j-1], rules[j]
 			j--
 		}
 	}
 }
 
-// GetPendingTransition checks if a work item has a pending transition
+// PendingTransition is an automatic transition proposed for a work item
+type PendingTransition struct {
+	Work              *models.Work
+	Rule              string
+	Reason            string
+	FromStatus        string
+	ToStatus          string
+	FromSchedule      string
+	ToSchedule        string
+	Actions           []string
+	NeedsConfirmation bool // False for transitions that would be applied on the next write anyway
+}
+
+// PendingTransitions returns the transition the rules propose for each work item, skipping
+// snoozed rules and rules whose actions wouldn't change anything
+func (te *TransitionEngine) PendingTransitions(works []*models.Work, now time.Time) []PendingTransition {
+	if !te.config.Enabled {
+		return nil
+	}
+
+	rules := te.GetRules()
+	tree := models.NewWorkTree(works)
+	var pending []PendingTransition
+	for _, work := range works {
+		if proposal, ok := te.proposeTransition(rules, tree, work, now); ok {
+			pending = append(pending, proposal)
+		}
+	}
+	return pending
+}
+
+// ProposeTransition returns the transition the rules propose for a single work item,
+// checked against the hierarchy in tree
+func (te *TransitionEngine) ProposeTransition(tree *models.WorkTree, work *models.Work, now time.Time) (PendingTransition, bool) {
+	if !te.config.Enabled {
+		return PendingTransition{}, false
+	}
+	return te.proposeTransition(te.GetRules(), tree, work, now)
+}
+
+// proposeTransition finds the first rule that would change a work item
+func (te *TransitionEngine) proposeTransition(rules []TransitionRule, tree *models.WorkTree, work *models.Work, now time.Time) (PendingTransition, bool) {
+	for _, rule := range rules {
+		if work.IsTransitionSnoozed(rule.Name, now) || !rule.Condition(copyWork(work)) {
+			continue
+		}
+		newWork := rule.Action(copyWork(work))
+		if !transitionChanges(work, newWork) || completesOverOpenChildren(tree, work, newWork) {
+			continue
+		}
+
+		proposal := PendingTransition{
+			Work:              work,
+			Rule:              rule.Name,
+			Reason:            rule.Description,
+			FromStatus:        work.Metadata.Status,
+			ToStatus:          newWork.Metadata.Status,
+			FromSchedule:      work.Schedule,
+			ToSchedule:        newWork.Schedule,
+			NeedsConfirmation: te.needsConfirmation(rule, work, newWork),
+		}
+		if rule.compiled != nil {
+			for _, action := range rule.compiled.spec.Then {
+				proposal.Actions = append(proposal.Actions, action.Describe())
+			}
+		}
+		return proposal, true
+	}
+	return PendingTransition{}, false
+}
+
+// transitionChanges reports whether a rule action changed anything worth proposing
+func transitionChanges(oldWork, newWork *models.Work) bool {
+	return oldWork.Metadata.Status != newWork.Metadata.Status ||
+		oldWork.Schedule != newWork.Schedule ||
+		oldWork.Metadata.Priority != newWork.Metadata.Priority ||
+		strings.Join(oldWork.TechnicalTags, ",") != strings.Join(newWork.TechnicalTags, ",")
+}
+
+// GetPendingTransition returns the rule proposing a transition for a work item, if any
 func (te *TransitionEngine) GetPendingTransition(work *models.Work) (string, bool) {
-	// This would require adding a PendingTransition field to WorkMetadata
-	// For now, return false to indicate no pending transitions
-	return "", false
+	te.mu.RLock()
+	tree := te.workTree
+	te.mu.RUnlock()
+	proposal, ok := te.proposeTransition(te.GetRules(), tree, work, time.Now())
+	return proposal.Rule, ok
+}
+
+// findRule returns the active rule with the given name
+func (te *TransitionEngine) findRule(name string) (TransitionRule, bool) {
+	for _, rule := range te.GetRules() {
+		if rule.Name == name {
+			return rule, true
+		}
+	}
+	return TransitionRule{}, false
 }
 
-// ConfirmTransition applies a pending transition
-func (te *TransitionEngine) ConfirmTransition(work *models.Work) bool {
-	// This would require adding pending transition fields to WorkMetadata
-	// For now, return false to indicate no pending transitions to confirm
-	return false
-}
\ No newline at end of file
+// ConfirmTransition applies an approved transition and returns the changed work item, which
+// the caller persists. It fails if the rule no longer applies.
+func (te *TransitionEngine) ConfirmTransition(work *models.Work, ruleName string) (*models.Work, error) {
+	rule, ok := te.findRule(ruleName)
+	if !ok {
+		return nil, fmt.Errorf("rule %s no longer exists", ruleName)
+	}
+	if !rule.Condition(copyWork(work)) {
+		return nil, fmt.Errorf("rule %s no longer applies to %s", ruleName, work.ID)
+	}
+
+	newWork := rule.Action(copyWork(work))
+	newWork.RecordTransitionFrom(work, rule.Name, rule.Description+" (approved)", models.CurrentActor())
+	te.emitUpdates(rule, newWork, time.Now())
+	return newWork, nil
+}
+
+// RejectTransition snoozes a rule for a work item so it isn't proposed again until the snooze ends
+func (te *TransitionEngine) RejectTransition(work *models.Work, ruleName string, snooze time.Duration) {
+	work.SnoozeTransition(ruleName, time.Now().Add(snooze), models.CurrentActor())
+}
@@ -0,0 +1,229 @@
+package automation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"claude-work-tracker-ui/internal/hooks"
+	"claude-work-tracker-ui/internal/models"
+)
+
+// newTestEngine creates a transition engine running the given rules file
+func newTestEngine(t *testing.T, rules string) *TransitionEngine {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	path := DefaultRulesPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(rules), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := NewTransitionEngine(hooks.NewHookSystem(nil), nil)
+	if err := engine.RulesError(); err != nil {
+		t.Fatal(err)
+	}
+	return engine
+}
+
+func TestRuleUpdatesGetDistinctIDs(t *testing.T) {
+	engine := newTestEngine(t, `rules:
+  - name: finish
+    description: Finish at full progress
+    when:
+      - status == in_progress
+      - progress >= 100
+    then:
+      - set_status: completed
+      - create_update: "Finished {title}"
+      - create_update: "Closed out"
+`)
+	var updates []*models.Update
+	engine.SetUpdateSink(func(work *models.Work, update *models.Update) {
+		updates = append(updates, update)
+	})
+
+	// Approving many transitions at once emits their updates within the same instant
+	for _, id := range []string{"work-1", "work-2", "work-3"} {
+		work := &models.Work{ID: id, Title: id}
+		work.Metadata.Status = models.WorkStatusInProgress
+		work.Metadata.ProgressPercent = 100
+		if _, applied, err := engine.EvaluateWork(context.Background(), work); err != nil || !applied {
+			t.Fatalf("rule wasn't applied to %s: %v", id, err)
+		}
+	}
+
+	if len(updates) != 6 {
+		t.Fatalf("got %d updates, want 6", len(updates))
+	}
+	seen := make(map[string]bool)
+	for _, update := range updates {
+		if seen[update.ID] {
+			t.Errorf("update ID %s was used twice", update.ID)
+		}
+		seen[update.ID] = true
+	}
+	if updates[0].Summary != "Finished work-1" {
+		t.Errorf("summary = %q", updates[0].Summary)
+	}
+}
+
+// pendingRules propose starting work at half way after approval, and finish complete work
+const pendingRules = `rules:
+  - name: start_at_half
+    description: Start work at half way
+    confirm: true
+    when:
+      - status == active
+      - progress >= 50
+    then:
+      - set_status: in_progress
+  - name: finish
+    description: Finish at full progress
+    when:
+      - status == in_progress
+      - progress >= 100
+    then:
+      - set_status: completed
+  - name: keep_done_tag
+    when:
+      - tags contains done
+    then:
+      - add_tag: done
+`
+
+// pendingWork creates a work item for the pending transition tests
+func pendingWork(id, parentID, status string, progress int, tags ...string) *models.Work {
+	work := &models.Work{ID: id, Title: id, ParentID: parentID, Schedule: models.ScheduleNow, TechnicalTags: tags}
+	work.Metadata.Status = status
+	work.Metadata.ProgressPercent = progress
+	return work
+}
+
+// snoozed snoozes a rule for a work item until the given time
+func snoozed(work *models.Work, rule string, until time.Time) *models.Work {
+	work.SnoozedTransitions = append(work.SnoozedTransitions, models.SnoozedTransition{Rule: rule, Until: until})
+	return work
+}
+
+func TestPendingTransitions(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name  string
+		works []*models.Work
+		want  []string // work:rule, with ! when the transition needs approval
+	}{
+		{
+			name:  "transition that needs approval",
+			works: []*models.Work{pendingWork("work-1", "", models.WorkStatusActive, 60)},
+			want:  []string{"work-1:start_at_half!"},
+		},
+		{
+			name:  "transition the next write applies anyway",
+			works: []*models.Work{pendingWork("work-1", "", models.WorkStatusInProgress, 100)},
+			want:  []string{"work-1:finish"},
+		},
+		{
+			name:  "rule that changes nothing",
+			works: []*models.Work{pendingWork("work-1", "", models.WorkStatusActive, 0, "done")},
+		},
+		{
+			name:  "snoozed rule",
+			works: []*models.Work{snoozed(pendingWork("work-1", "", models.WorkStatusActive, 60), "start_at_half", now.Add(time.Hour))},
+		},
+		{
+			name:  "expired snooze",
+			works: []*models.Work{snoozed(pendingWork("work-1", "", models.WorkStatusActive, 60), "start_at_half", now.Add(-time.Hour))},
+			want:  []string{"work-1:start_at_half!"},
+		},
+		{
+			name:  "snooze of another rule",
+			works: []*models.Work{snoozed(pendingWork("work-1", "", models.WorkStatusInProgress, 100), "start_at_half", now.Add(time.Hour))},
+			want:  []string{"work-1:finish"},
+		},
+		{
+			name: "completing a parent with open children",
+			works: []*models.Work{
+				pendingWork("epic", "", models.WorkStatusInProgress, 100),
+				pendingWork("task", "epic", models.WorkStatusInProgress, 100),
+			},
+			want: []string{"task:finish"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newTestEngine(t, pendingRules)
+
+			var got []string
+			for _, pending := range engine.PendingTransitions(tt.works, now) {
+				entry := pending.Work.ID + ":" + pending.Rule
+				if pending.NeedsConfirmation {
+					entry += "!"
+				}
+				got = append(got, entry)
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("pending = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPendingTransitionDetails(t *testing.T) {
+	engine := newTestEngine(t, pendingRules)
+	pending := engine.PendingTransitions([]*models.Work{pendingWork("work-1", "", models.WorkStatusActive, 60)}, time.Now())
+	if len(pending) != 1 {
+		t.Fatalf("pending = %+v, want one", pending)
+	}
+
+	p := pending[0]
+	if p.FromStatus != models.WorkStatusActive || p.ToStatus != models.WorkStatusInProgress ||
+		p.Reason != "Start work at half way" || strings.Join(p.Actions, ",") != "set status to in_progress" {
+		t.Errorf("pending = %+v", p)
+	}
+	if p.Work.Metadata.Status != models.WorkStatusActive {
+		t.Error("proposing a transition changed the work item")
+	}
+
+	engine.config.Enabled = false
+	if pending := engine.PendingTransitions([]*models.Work{p.Work}, time.Now()); pending != nil {
+		t.Errorf("disabled engine proposed %+v", pending)
+	}
+}
+
+func TestRejectAndConfirmTransition(t *testing.T) {
+	engine := newTestEngine(t, pendingRules)
+	work := pendingWork("work-1", "", models.WorkStatusActive, 60)
+
+	engine.RejectTransition(work, "start_at_half", 24*time.Hour)
+	if pending := engine.PendingTransitions([]*models.Work{work}, time.Now()); len(pending) != 0 {
+		t.Errorf("rejected transition was proposed again: %+v", pending)
+	}
+	if pending := engine.PendingTransitions([]*models.Work{work}, time.Now().Add(25*time.Hour)); len(pending) != 1 {
+		t.Errorf("transition wasn't proposed after the snooze ended: %+v", pending)
+	}
+
+	confirmed, err := engine.ConfirmTransition(work, "start_at_half")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if confirmed.Metadata.Status != models.WorkStatusInProgress || work.Metadata.Status != models.WorkStatusActive {
+		t.Errorf("confirmed %s, original %s", confirmed.Metadata.Status, work.Metadata.Status)
+	}
+	if last := confirmed.LastTransition(); last == nil || last.Rule != "start_at_half" {
+		t.Errorf("last transition = %+v", last)
+	}
+
+	if _, err := engine.ConfirmTransition(confirmed, "start_at_half"); err == nil {
+		t.Error("confirmed a rule that no longer applies")
+	}
+	if _, err := engine.ConfirmTransition(work, "missing"); err == nil {
+		t.Error("confirmed a rule that doesn't exist")
+	}
+}
@@ -0,0 +1,424 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/automation"
+	"claude-work-tracker-ui/internal/data"
+	"claude-work-tracker-ui/internal/git"
+	"claude-work-tracker-ui/internal/hooks"
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+// DaemonConfig controls how often the daemon runs each job
+type DaemonConfig struct {
+	RulesInterval          time.Duration // Evaluate transition rules, reminders and inactivity
+	GitInterval            time.Duration // Look for new commits
+	ScoreInterval          time.Duration // Refresh activity scores and decay health
+	InactivityWarningHours int           // NOW items idle this long raise an inactivity warning
+	PIDPath                string        // Defaults to PIDPath(project)
+	StatusPath             string        // Defaults to StatusPath(project)
+}
+
+// DefaultDaemonConfig returns default intervals
+func DefaultDaemonConfig() *DaemonConfig {
+	return &DaemonConfig{
+		RulesInterval:          5 * time.Minute,
+		GitInterval:            time.Minute,
+		ScoreInterval:          time.Hour,
+		InactivityWarningHours: automation.DefaultActivityConfig().InactivityWarningHours,
+	}
+}
+
+// Daemon runs the automation jobs for one project in the background
+type Daemon struct {
+	client    *storage.CentralizedClient
+	config    *DaemonConfig
+	engine    *automation.TransitionEngine
+	reminders *automation.ReminderService
+	lifecycle *data.LifecycleManager
+	git       *git.ContextManager
+	activity  *automation.ActivityDetector // Listens on the client's hooks for commits and status changes
+	status    Status
+	lastScan  *time.Time           // When commits were last scanned
+	lastHead  string               // Last HEAD commit seen
+	warned    map[string]time.Time // Work ID -> when its inactivity warning was raised
+}
+
+// NewDaemon creates a daemon for the client's current project
+func NewDaemon(client *storage.CentralizedClient, config *DaemonConfig) *Daemon {
+	if config == nil {
+		config = DefaultDaemonConfig()
+	}
+	project := client.GetCurrentProject()
+	if config.PIDPath == "" {
+		config.PIDPath = PIDPath(project.ID)
+	}
+	if config.StatusPath == "" {
+		config.StatusPath = StatusPath(project.ID)
+	}
+
+	// Saving through the client runs the configured hooks, so the engine gets a
+	// hook system of its own to avoid firing status hooks twice
+	engine := automation.NewTransitionEngine(hooks.NewHookSystem(hooks.DefaultHookConfig()), automation.DefaultTransitionConfig())
+	engine.SetUpdateSink(func(work *models.Work, update *models.Update) {
+		if err := client.CreateUpdate(work.ID, update); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	})
+
+	reminderConfig := automation.DefaultReminderConfig()
+	reminderConfig.StatePath = RemindersPath(project.ID)
+
+	markdownIO := data.NewMarkdownIO(client.GetWorkDir())
+	lifecycle := data.NewLifecycleManager(markdownIO, data.NewAssociationManager(markdownIO), data.NewGroupManager(markdownIO, client.GetWorkDir()))
+
+	return &Daemon{
+		client:    client,
+		config:    config,
+		engine:    engine,
+		reminders: automation.NewReminderService(client.GetHookSystem(), reminderConfig),
+		lifecycle: lifecycle,
+		git:       git.NewContextManager(),
+		activity:  automation.NewActivityDetector(client.GetHookSystem(), nil),
+		warned:    make(map[string]time.Time),
+		status: Status{
+			PID:         os.Getpid(),
+			ProjectID:   project.ID,
+			ProjectName: project.Name,
+		},
+	}
+}
+
+// Run executes the jobs on their intervals until the context is canceled. The PID file
+// is held while running and the status file is marked stopped on the way out.
+func (d *Daemon) Run(ctx context.Context) error {
+	if err := acquirePIDFile(d.config.PIDPath); err != nil {
+		return err
+	}
+	defer os.Remove(d.config.PIDPath)
+
+	d.status.Running = true
+	d.status.StartedAt = time.Now()
+	log.Printf("🤖 Daemon started for %s (pid %d)", d.status.ProjectName, d.status.PID)
+
+	// Run every job once up front so the status file is useful straight away
+	d.runJob(ctx, "rules", d.evaluateRules)
+	d.runJob(ctx, "git", d.recordGitActivity)
+	d.runJob(ctx, "scores", d.refreshScores)
+
+	rulesTicker := time.NewTicker(d.config.RulesInterval)
+	defer rulesTicker.Stop()
+	gitTicker := time.NewTicker(d.config.GitInterval)
+	defer gitTicker.Stop()
+	scoreTicker := time.NewTicker(d.config.ScoreInterval)
+	defer scoreTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			now := time.Now()
+			d.status.Running = false
+			d.status.StoppedAt = &now
+			d.status.UpdatedAt = now
+			if err := writeStatus(d.config.StatusPath, &d.status); err != nil {
+				log.Printf("Warning: %v", err)
+			}
+			log.Printf("👋 Daemon stopped")
+			return nil
+		case <-rulesTicker.C:
+			d.runJob(ctx, "rules", d.evaluateRules)
+		case <-gitTicker.C:
+			d.runJob(ctx, "git", d.recordGitActivity)
+		case <-scoreTicker.C:
+			d.runJob(ctx, "scores", d.refreshScores)
+		}
+	}
+}
+
+// runJob runs one job and publishes the resulting status
+func (d *Daemon) runJob(ctx context.Context, name string, job func(ctx context.Context, now time.Time) error) {
+	now := time.Now()
+	if err := job(ctx, now); err != nil {
+		d.status.LastError = fmt.Sprintf("%s: %v", name, err)
+		log.Printf("❌ %s", d.status.LastError)
+	} else if strings.HasPrefix(d.status.LastError, name+":") {
+		d.status.LastError = ""
+	}
+
+	d.status.UpdatedAt = time.Now()
+	if err := writeStatus(d.config.StatusPath, &d.status); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
+
+// evaluateRules applies automatic transitions, sends deadline reminders and raises inactivity warnings
+func (d *Daemon) evaluateRules(ctx context.Context, now time.Time) error {
+	works, err := d.client.GetAllWork()
+	if err != nil {
+		return fmt.Errorf("failed to load work: %w", err)
+	}
+	if err := d.engine.RulesError(); err != nil {
+		log.Printf("Warning: transition rules file rejected: %v", err)
+	}
+	d.engine.SetWorkTree(models.NewWorkTree(works))
+
+	for _, work := range works {
+		// Checklist edits made outside the tracker are saved as they are, the client syncs
+		// the progress, records the completed tasks and runs the rules against the result
+		if checklistMoved(work) {
+			status := work.Metadata.Status
+			if err := d.client.UpdateWork(work); err != nil {
+				log.Printf("Warning: failed to save %s: %v", work.ID, err)
+				continue
+			}
+			log.Printf("📊 %s: progress %d%% from the checklist", work.ID, work.Metadata.ProgressPercent)
+			if work.Metadata.Status != status {
+				d.status.TransitionsApplied++
+				log.Printf("🔁 %s: %s → %s", work.ID, status, work.Metadata.Status)
+			}
+			continue
+		}
+
+		transitioned, applied, err := d.engine.EvaluateWork(ctx, work)
+		if err != nil {
+			log.Printf("Warning: rules failed for %s: %v", work.ID, err)
+			continue
+		}
+		if !applied {
+			continue
+		}
+		if err := d.client.UpdateWork(transitioned); err != nil {
+			log.Printf("Warning: failed to save %s: %v", work.ID, err)
+			continue
+		}
+		d.status.TransitionsApplied++
+		log.Printf("🔁 %s: %s → %s", work.ID, work.Metadata.Status, transitioned.Metadata.Status)
+	}
+
+	// Re-read so pending transitions reflect what was just applied
+	works, err = d.client.GetAllWork()
+	if err != nil {
+		return fmt.Errorf("failed to load work: %w", err)
+	}
+
+	d.status.WorkItems = len(works)
+	d.status.PendingTransitions = len(d.engine.PendingTransitions(works, now))
+	reminders, err := d.reminders.Check(ctx, works, now)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	d.status.RemindersSent += len(reminders)
+	d.checkInactivity(ctx, works, now)
+	d.status.LastRulesRun = &now
+	return nil
+}
+
+// checklistMoved reports whether a work item's checklist was edited outside the tracker
+// in a way that moves its progress. The work item itself is left untouched, so saving it
+// still sees which tasks were completed since the last sync.
+func checklistMoved(work *models.Work) bool {
+	if !work.Metadata.ProgressFromTasks {
+		return false
+	}
+	probe := *work
+	return data.SyncTaskProgress(&probe).Changed()
+}
+
+// checkInactivity lists idle NOW items and fires an inactivity warning once per idle stretch
+func (d *Daemon) checkInactivity(ctx context.Context, works []*models.Work, now time.Time) {
+	threshold := time.Duration(d.config.InactivityWarningHours) * time.Hour
+
+	var inactive []InactiveWork
+	for _, work := range works {
+		idle := now.Sub(work.GetLastUpdateTime())
+		if work.IsClosed() || work.Schedule != models.ScheduleNow || idle < threshold {
+			delete(d.warned, work.ID)
+			continue
+		}
+
+		inactive = append(inactive, InactiveWork{
+			ID:        work.ID,
+			Title:     work.Title,
+			IdleHours: math.Round(idle.Hours()),
+		})
+		if _, warned := d.warned[work.ID]; warned {
+			continue
+		}
+		d.warned[work.ID] = now
+
+		log.Printf("💤 %s has been idle for %.0f hours", work.ID, idle.Hours())
+		d.client.GetHookSystem().Execute(ctx, &hooks.HookContext{
+			WorkItem:  work,
+			EventType: hooks.InactivityWarning,
+			Timestamp: now,
+			Metadata: map[string]interface{}{
+				"idle_hours": idle.Hours(),
+			},
+		})
+	}
+
+	sort.Slice(inactive, func(i, j int) bool {
+		return inactive[i].IdleHours > inactive[j].IdleHours
+	})
+	d.status.Inactive = inactive
+}
+
+// recordGitActivity links new commits to work items by trailer, ID, branch and mentioned
+// files, applies their Progress and Completes-Task trailers, and records them as activity.
+// A new HEAD commit nothing claims counts as activity for the in-progress NOW items that
+// aren't tied to a branch. Merged branches are marked so the rules can propose completion.
+func (d *Daemon) recordGitActivity(ctx context.Context, now time.Time) error {
+	projectPath := d.client.GetCurrentProject().Path
+	head, err := d.git.GetCommitInfo(ctx, projectPath)
+	if err != nil {
+		return nil // Not a git repository
+	}
+
+	works, err := d.client.GetAllWork()
+	if err != nil {
+		return fmt.Errorf("failed to load work: %w", err)
+	}
+
+	// Rescan a little history each time so commits that arrive late, e.g. from a pull, are caught
+	config := git.DefaultScanConfig()
+	if d.lastScan != nil {
+		config.Since = d.lastScan.Add(-time.Hour)
+	}
+	links, err := git.NewCommitScanner(d.git, projectPath, config).Scan(ctx, works)
+	if err != nil {
+		return err
+	}
+	d.lastScan = &now
+
+	changed, updates := git.ApplyLinks(works, links)
+	for _, work := range changed {
+		if err := d.client.UpdateWork(work); err != nil {
+			log.Printf("Warning: failed to save %s: %v", work.ID, err)
+		}
+	}
+	for _, update := range updates {
+		log.Printf("📈 %s: %s", update.WorkID, strings.ReplaceAll(update.Summary, "\n", "; "))
+		if err := d.client.CreateUpdate(update.WorkID, update); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+	for _, link := range links {
+		d.status.CommitsRecorded++
+		log.Printf("📝 %s: commit %.8s linked by %s", link.WorkID, link.Commit.SHA, link.MatchedBy)
+		d.recordCommit(ctx, findWork(works, link.WorkID), link.Commit, link.MatchedBy, now)
+	}
+
+	for _, work := range d.git.DetectMerges(ctx, projectPath, works) {
+		log.Printf("🔀 %s: branch %s merged", work.ID, work.GitContext.Branch)
+		if err := d.client.UpdateWork(work); err != nil {
+			log.Printf("Warning: failed to save %s: %v", work.ID, err)
+		}
+	}
+
+	if head["hash"] != d.lastHead {
+		d.lastHead = head["hash"]
+		d.creditHeadCommit(ctx, works, head, now)
+	}
+
+	d.status.LastGitCheck = &now
+	return nil
+}
+
+// creditHeadCommit bumps the last activity of unbranched in-progress NOW items for a HEAD
+// commit that no work item has linked
+func (d *Daemon) creditHeadCommit(ctx context.Context, works []*models.Work, head map[string]string, now time.Time) {
+	for _, work := range works {
+		if work.HasCommit(head["hash"]) {
+			return
+		}
+	}
+
+	timestamp, _ := strconv.ParseInt(head["timestamp"], 10, 64)
+	committedAt := time.Unix(timestamp, 0)
+	commit := git.Commit{SHA: head["hash"], Author: head["author"], Subject: head["message"], Timestamp: committedAt}
+
+	for _, work := range works {
+		if work.IsClosed() || work.GitContext.Branch != "" ||
+			work.Schedule != models.ScheduleNow || work.Metadata.Status != models.WorkStatusInProgress {
+			continue
+		}
+		if last := work.Metadata.LastActivityAt; last != nil && !committedAt.After(*last) {
+			continue
+		}
+
+		work.Metadata.LastActivityAt = &committedAt
+		if err := d.client.UpdateWork(work); err != nil {
+			log.Printf("Warning: failed to save %s: %v", work.ID, err)
+			continue
+		}
+		d.status.CommitsRecorded++
+		log.Printf("📝 %s: commit %.8s", work.ID, commit.SHA)
+		d.recordCommit(ctx, work, commit, "", now)
+	}
+}
+
+// recordCommit fires the commit_detected hook, which also feeds the activity detector
+func (d *Daemon) recordCommit(ctx context.Context, work *models.Work, commit git.Commit, matchedBy string, now time.Time) {
+	if work == nil {
+		return
+	}
+	d.client.GetHookSystem().Execute(ctx, &hooks.HookContext{
+		WorkItem:  work,
+		EventType: hooks.CommitDetected,
+		Timestamp: now,
+		Metadata: map[string]interface{}{
+			"hash":       commit.SHA,
+			"message":    commit.Message(),
+			"author":     commit.Author,
+			"timestamp":  commit.Timestamp,
+			"matched_by": matchedBy,
+		},
+	})
+}
+
+// findWork returns the work item with the given ID, or nil
+func findWork(works []*models.Work, id string) *models.Work {
+	for _, work := range works {
+		if work.ID == id {
+			return work
+		}
+	}
+	return nil
+}
+
+// refreshScores recalculates activity scores, saving only the ones that moved, and updates decay health
+func (d *Daemon) refreshScores(ctx context.Context, now time.Time) error {
+	works, err := d.client.GetAllWork()
+	if err != nil {
+		return fmt.Errorf("failed to load work: %w", err)
+	}
+
+	for _, work := range works {
+		previous := work.Metadata.ActivityScore
+		if math.Abs(work.CalculateActivityScore()-previous) < 0.05 {
+			continue
+		}
+		if err := d.client.UpdateWork(work); err != nil {
+			log.Printf("Warning: failed to save %s: %v", work.ID, err)
+		}
+	}
+
+	analysis, err := d.lifecycle.AnalyzeDecay()
+	if err != nil {
+		return fmt.Errorf("failed to analyze decay: %w", err)
+	}
+	d.status.HealthScore = analysis.Summary.OverallHealthScore
+	d.status.StaleWork = len(analysis.StaleWork)
+	d.status.LastScoreRefresh = &now
+	return nil
+}
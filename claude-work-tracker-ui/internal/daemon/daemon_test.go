@@ -0,0 +1,88 @@
+package daemon
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"claude-work-tracker-ui/internal/data"
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+// newTestDaemon returns a daemon for a fresh project under a temporary home
+func newTestDaemon(t *testing.T) *Daemon {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Chdir(t.TempDir())
+
+	client, err := storage.NewCentralizedClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewDaemon(client, &DaemonConfig{
+		InactivityWarningHours: 24,
+		PIDPath:                filepath.Join(home, "daemon.pid"),
+		StatusPath:             filepath.Join(home, "daemon.json"),
+	})
+}
+
+func TestEvaluateRulesRecordsChecklistEdits(t *testing.T) {
+	d := newTestDaemon(t)
+	work := &models.Work{
+		ID:       "work-1",
+		Title:    "Checklist",
+		Schedule: models.ScheduleNow,
+		Content:  "- [x] One\n- [ ] Two\n- [ ] Three\n",
+		Metadata: models.WorkMetadata{Status: models.WorkStatusInProgress, ProgressFromTasks: true},
+	}
+	if err := d.client.CreateWork(work); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tick a task behind the tracker's back, the way an editor would
+	stored, err := d.client.GetProjectWorkByID(d.client.GetCurrentProject().ID, "work-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored.Content = strings.Replace(stored.Content, "- [ ] Two", "- [x] Two", 1)
+	if err := data.NewMarkdownIO(d.client.GetWorkDir()).WriteWork(stored); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.evaluateRules(context.Background(), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := d.client.GetProjectWorkByID(d.client.GetCurrentProject().ID, "work-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Metadata.ProgressPercent != 67 {
+		t.Errorf("progress = %d, want 67", saved.Metadata.ProgressPercent)
+	}
+
+	updates, err := d.client.GetUpdates("work-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var checklist *models.Update
+	for _, update := range updates {
+		if update.Title == "Checklist progress" {
+			checklist = update
+			break
+		}
+	}
+	if checklist == nil {
+		t.Fatalf("no checklist update in %d updates", len(updates))
+	}
+	if strings.Join(checklist.TasksCompleted, ",") != "Two" {
+		t.Errorf("tasks completed = %q, want [Two]", checklist.TasksCompleted)
+	}
+	if checklist.ProgressBefore != 33 || checklist.ProgressAfter != 67 {
+		t.Errorf("progress %d%% → %d%%, want 33%% → 67%%", checklist.ProgressBefore, checklist.ProgressAfter)
+	}
+}
@@ -0,0 +1,203 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Dir returns ~/.claude/daemon, where PID and status files are kept
+func Dir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".claude", "daemon")
+}
+
+// PIDPath returns the PID file of the daemon for a project
+func PIDPath(projectID string) string {
+	return filepath.Join(Dir(), projectID+".pid")
+}
+
+// StatusPath returns the status file of the daemon for a project
+func StatusPath(projectID string) string {
+	return filepath.Join(Dir(), projectID+".status.json")
+}
+
+// RemindersPath returns the file remembering which deadline reminders were sent for a
+// project, shared by the daemon and the TUI
+func RemindersPath(projectID string) string {
+	return filepath.Join(Dir(), projectID+".reminders.json")
+}
+
+// InactiveWork is a NOW item that has gone quiet past the inactivity threshold
+type InactiveWork struct {
+	ID        string  `json:"id"`
+	Title     string  `json:"title"`
+	IdleHours float64 `json:"idle_hours"`
+}
+
+// Status is what the daemon reports to the TUI and shell prompt
+type Status struct {
+	PID         int        `json:"pid"`
+	ProjectID   string     `json:"project_id"`
+	ProjectName string     `json:"project_name"`
+	Running     bool       `json:"running"`
+	StartedAt   time.Time  `json:"started_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	StoppedAt   *time.Time `json:"stopped_at,omitempty"`
+
+	LastRulesRun     *time.Time `json:"last_rules_run,omitempty"`
+	LastGitCheck     *time.Time `json:"last_git_check,omitempty"`
+	LastScoreRefresh *time.Time `json:"last_score_refresh,omitempty"`
+
+	WorkItems          int            `json:"work_items"`
+	TransitionsApplied int            `json:"transitions_applied"` // Since the daemon started
+	PendingTransitions int            `json:"pending_transitions"`
+	CommitsRecorded    int            `json:"commits_recorded"` // Since the daemon started
+	RemindersSent      int            `json:"reminders_sent"`   // Since the daemon started
+	Inactive           []InactiveWork `json:"inactive,omitempty"`
+	HealthScore        float64        `json:"health_score"`
+	StaleWork          int            `json:"stale_work"`
+	LastError          string         `json:"last_error,omitempty"`
+}
+
+// IsLive reports whether the daemon that wrote the status is still running
+func (s *Status) IsLive() bool {
+	return s.Running && processAlive(s.PID)
+}
+
+// PromptSummary renders a short summary for shell prompts, empty when the daemon isn't running
+func (s *Status) PromptSummary() string {
+	if !s.IsLive() {
+		return ""
+	}
+
+	parts := []string{"🤖"}
+	if s.PendingTransitions > 0 {
+		parts = append(parts, fmt.Sprintf("📥%d", s.PendingTransitions))
+	}
+	if len(s.Inactive) > 0 {
+		parts = append(parts, fmt.Sprintf("💤%d", len(s.Inactive)))
+	}
+	if s.LastError != "" {
+		parts = append(parts, "⚠")
+	}
+	return strings.Join(parts, " ")
+}
+
+// ReadStatus loads a status file
+func ReadStatus(path string) (*Status, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var status Status
+	if err := json.Unmarshal(content, &status); err != nil {
+		return nil, fmt.Errorf("failed to parse daemon status: %w", err)
+	}
+	return &status, nil
+}
+
+// writeStatus replaces the status file atomically so readers never see a partial write
+func writeStatus(path string, status *Status) error {
+	content, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode daemon status: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create daemon directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, content, 0644); err != nil {
+		return fmt.Errorf("failed to write daemon status: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write daemon status: %w", err)
+	}
+	return nil
+}
+
+// ReadPID returns the PID recorded in a PID file
+func ReadPID(path string) (int, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid PID file %s: %w", path, err)
+	}
+	return pid, nil
+}
+
+// pidWriteGrace is how long a PID file may stay without a PID while its daemon starts up
+const pidWriteGrace = 5 * time.Second
+
+// acquirePIDFile creates the PID file exclusively, so only one daemon runs per project. A
+// file left behind by a daemon that's gone is removed once its PID has been checked.
+func acquirePIDFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create daemon directory: %w", err)
+	}
+
+	for attempt := 0; attempt < 3; attempt++ {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, err = fmt.Fprintf(file, "%d\n", os.Getpid())
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(path)
+				return fmt.Errorf("failed to write PID file: %w", err)
+			}
+			return nil
+		}
+		if !os.IsExist(err) {
+			return fmt.Errorf("failed to create PID file: %w", err)
+		}
+
+		pid, err := ReadPID(path)
+		switch {
+		case os.IsNotExist(err):
+			continue // Removed in the meantime
+		case err != nil:
+			// Another daemon may have created the file and not written its PID yet
+			if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) < pidWriteGrace {
+				return fmt.Errorf("daemon already starting (%s)", path)
+			}
+		case pid != os.Getpid() && processAlive(pid):
+			return fmt.Errorf("daemon already running (pid %d)", pid)
+		}
+
+		// Only remove the file if it still names the daemon that's gone
+		if current, _ := ReadPID(path); current == pid {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove stale PID file: %w", err)
+			}
+		}
+	}
+	return fmt.Errorf("failed to acquire PID file %s", path)
+}
+
+// processAlive reports whether a process with the given PID exists
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
@@ -0,0 +1,72 @@
+package daemon
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// deadPID returns the PID of a process that has exited
+func deadPID(t *testing.T) int {
+	t.Helper()
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Skip("no true command to start")
+	}
+	return cmd.Process.Pid
+}
+
+func TestAcquirePIDFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string        // Existing PID file, none when empty
+		age     time.Duration // How long ago the existing file was written
+		wantErr string
+	}{
+		{name: "no PID file"},
+		{name: "live daemon", content: strconv.Itoa(os.Getppid()), wantErr: "daemon already running"},
+		{name: "daemon gone", content: strconv.Itoa(deadPID(t))},
+		{name: "our own PID", content: strconv.Itoa(os.Getpid())},
+		{name: "daemon still writing its PID", content: " ", wantErr: "daemon already starting"},
+		{name: "garbage left behind", content: "garbage", age: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "daemon", "project.pid")
+			if tt.content != "" {
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+				written := time.Now().Add(-tt.age)
+				if err := os.Chtimes(path, written, written); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := acquirePIDFile(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				if content, _ := os.ReadFile(path); string(content) != tt.content {
+					t.Errorf("PID file of the other daemon was replaced with %q", content)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pid, err := ReadPID(path); err != nil || pid != os.Getpid() {
+				t.Errorf("PID file holds %d (%v), want %d", pid, err, os.Getpid())
+			}
+		})
+	}
+}
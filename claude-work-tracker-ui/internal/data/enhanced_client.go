@@ -969,7 +969,8 @@ func (c *EnhancedClient) ExtractTasksFromArtifact(artifactID string) ([]*models.
 	return nil, fmt.Errorf("artifact not found: %s", artifactID)
 }
 
-// UpdateTaskStatus updates a task's status in a Work item
+// UpdateTaskStatus updates a task's status in a Work item. The task is referenced by its
+// stable ID or by its title.
 func (c *EnhancedClient) UpdateTaskStatus(workID, taskID string, newStatus models.TaskStatus) error {
 	if !c.useHierarchy {
 		return fmt.Errorf("hierarchy not enabled")
@@ -983,8 +984,13 @@ func (c *EnhancedClient) UpdateTaskStatus(workID, taskID string, newStatus model
 	
 	for _, work := range allWork {
 		if work.ID == workID {
+			task := c.taskParser.FindTask(work.Content, taskID)
+			if task == nil {
+				return fmt.Errorf("task not found: %s", taskID)
+			}
+			
 			// Update the task in markdown content
-			updatedContent := c.taskParser.UpdateTaskInMarkdown(work.Content, taskID, newStatus)
+			updatedContent := c.taskParser.UpdateTaskInMarkdown(work.Content, task.Task.ID, newStatus)
 			work.Content = updatedContent
 			work.UpdatedAt = time.Now()
 			
@@ -1035,6 +1041,24 @@ func (c *EnhancedClient) GetUpdates(workID string) ([]*models.Update, error) {
 	return c.updatesManager.GetUpdates(workID)
 }
 
+// EditUpdate replaces an update of a Work item, matched by ID
+func (c *EnhancedClient) EditUpdate(workID string, update *models.Update) error {
+	if !c.useHierarchy {
+		return fmt.Errorf("hierarchy not enabled")
+	}
+	
+	return c.updatesManager.EditUpdate(workID, update)
+}
+
+// DeleteUpdate removes an update from a Work item
+func (c *EnhancedClient) DeleteUpdate(workID, updateID string) error {
+	if !c.useHierarchy {
+		return fmt.Errorf("hierarchy not enabled")
+	}
+	
+	return c.updatesManager.DeleteUpdate(workID, updateID)
+}
+
 // CreateAutomaticUpdate creates an update from Claude session completion
 func (c *EnhancedClient) CreateAutomaticUpdate(workID, sessionID, summary string, tasksCompleted []string, progressBefore, progressAfter int) error {
 	if !c.useHierarchy {
@@ -3,6 +3,7 @@ package data
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"claude-work-tracker-ui/internal/automation"
@@ -44,6 +45,7 @@ func NewEnhancedMarkdownIO(baseDir string, config *EnhancedIOConfig) *EnhancedMa
 
 	// Initialize components
 	hookSystem := hooks.NewHookSystem(hooks.DefaultHookConfig())
+	hookSystem.SetAuditLog(hooks.NewAuditLog(hooks.DefaultAuditLogPath(), hooks.DefaultAuditConfig()))
 	transitionEngine := automation.NewTransitionEngine(hookSystem, automation.DefaultTransitionConfig())
 	gitManager := git.NewContextManager()
 
@@ -59,12 +61,32 @@ func NewEnhancedMarkdownIO(baseDir string, config *EnhancedIOConfig) *EnhancedMa
 	// Register default hooks
 	enhanced.registerDefaultHooks()
 
+	// Persist updates created by rule actions
+	updatesManager := NewUpdatesManager(baseDir)
+	transitionEngine.SetUpdateSink(func(work *models.Work, update *models.Update) {
+		updatesManager.CreateUpdate(work.ID, update)
+	})
+	if err := transitionEngine.RulesError(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: transition rules file rejected: %v\n", err)
+	}
+
+	// Register user-defined script hooks
+	if _, err := hookSystem.LoadExternalHooks(hooks.DefaultExternalHooksPath()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load hook scripts: %v\n", err)
+	}
+
 	return enhanced
 }
 
 // WriteWork writes a Work container with automation
 func (e *EnhancedMarkdownIO) WriteWork(ctx context.Context, work *models.Work) error {
-	oldWork := *work // Copy for comparison
+	// Compare against the stored version so hooks only fire for real changes
+	var oldWork *models.Work
+	if work.Filepath != "" {
+		if stored, err := e.MarkdownIO.ReadWork(work.Filepath); err == nil {
+			oldWork = stored
+		}
+	}
 
 	// Update Git context if enabled
 	if e.config.EnableGitTracking {
@@ -74,23 +96,17 @@ func (e *EnhancedMarkdownIO) WriteWork(ctx context.Context, work *models.Work) e
 		}
 	}
 
-	// Execute before hooks
-	hookCtx := &hooks.HookContext{
-		WorkItem:    work,
-		OldWorkItem: &oldWork,
-		EventType:   hooks.BeforeStatusChange,
-		Timestamp:   time.Now(),
-	}
-
-	if results, err := e.hookSystem.ExecuteSync(ctx, hookCtx); err != nil {
+	// Execute before hooks, which may deny or patch the change
+	if _, err := e.hookSystem.RunBeforeChange(ctx, hooks.DiffWork(oldWork, work)); err != nil {
 		return fmt.Errorf("before hooks failed: %w", err)
-	} else {
-		for _, result := range results {
-			if !result.Success {
-				fmt.Printf("Hook %s warning: %v\n", result.HookName, result.Error)
-			}
-		}
 	}
+	requestedStatus := work.Metadata.Status
+	if oldWork != nil {
+		work.RecordStatusChange(oldWork.Metadata.Status, models.CurrentActor(), "")
+	}
+
+	// Follow the checklist before evaluating rules, so transitions see the new progress
+	SyncTaskProgress(work)
 
 	// Apply automatic transitions if enabled
 	if e.config.EnableAutomation {
@@ -101,14 +117,14 @@ func (e *EnhancedMarkdownIO) WriteWork(ctx context.Context, work *models.Work) e
 		if applied {
 			work = transitioned
 			// Generate update if status changed
-			if e.config.GenerateUpdates && oldWork.Metadata.Status != work.Metadata.Status {
-				e.generateStatusUpdate(work, oldWork.Metadata.Status, work.Metadata.Status)
+			if e.config.GenerateUpdates && requestedStatus != work.Metadata.Status {
+				e.generateStatusUpdate(work, requestedStatus, work.Metadata.Status)
 			}
 		}
 	}
 
 	// Handle schedule changes (file moves)
-	if oldWork.Schedule != work.Schedule && oldWork.Filepath != "" {
+	if oldWork != nil && oldWork.Schedule != work.Schedule {
 		// Need to move file to new directory
 		if err := e.moveWorkFile(work, oldWork.Schedule, work.Schedule); err != nil {
 			return fmt.Errorf("failed to move work file: %w", err)
@@ -121,20 +137,15 @@ func (e *EnhancedMarkdownIO) WriteWork(ctx context.Context, work *models.Work) e
 	}
 
 	// Execute after hooks
-	hookCtx.EventType = hooks.AfterStatusChange
-	hookCtx.Timestamp = time.Now()
-
-	if results, err := e.hookSystem.Execute(ctx, hookCtx); err != nil {
-		// Log but don't fail after write
-		fmt.Printf("After hooks error: %v\n", err)
-	} else {
-		for _, result := range results {
-			if !result.Success {
-				fmt.Printf("Hook %s warning: %v\n", result.HookName, result.Error)
-			}
-		}
+	change := hooks.DiffWork(oldWork, work)
+	if oldWork != nil && oldWork.Metadata.Status == requestedStatus {
+		// Status only changed through automation, the transition engine already announced it
+		change.StatusChanged = false
 	}
 
+	// Failures don't fail the write, they are recorded in the hook audit log
+	e.hookSystem.RunAfterChange(ctx, change)
+
 	return nil
 }
 
@@ -149,20 +160,7 @@ func (e *EnhancedMarkdownIO) UpdateProgress(ctx context.Context, work *models.Wo
 		e.generateProgressUpdate(work, oldProgress, progress)
 	}
 
-	// Trigger progress hook
-	hookCtx := &hooks.HookContext{
-		WorkItem:  work,
-		EventType: hooks.ProgressUpdated,
-		Timestamp: time.Now(),
-		Metadata: map[string]interface{}{
-			"old_progress": oldProgress,
-			"new_progress": progress,
-		},
-	}
-
-	e.hookSystem.Execute(ctx, hookCtx)
-
-	// Write with potential transitions
+	// Write with potential transitions, WriteWork fires ProgressUpdated
 	return e.WriteWork(ctx, work)
 }
 
@@ -276,24 +274,11 @@ func (e *EnhancedMarkdownIO) GetTransitionEngine() *automation.TransitionEngine
 	return e.transitionEngine
 }
 
-// CheckPendingTransitions checks all work items for pending transitions
-func (e *EnhancedMarkdownIO) CheckPendingTransitions(ctx context.Context) ([]models.Work, error) {
-	var pendingWorks []models.Work
-
-	// Check all schedules - would need ReadWorksBySchedule method
-	// for _, schedule := range []string{"now", "next", "later"} {
-	//     works, err := e.ReadWorksBySchedule(schedule)
-	//     if err != nil {
-	//         continue
-	//     }
-
-	//     for _, work := range works {
-	//         if pending, hasPending := e.transitionEngine.GetPendingTransition(&work); hasPending {
-	//             work.Metadata.Metadata["pending_transition_target"] = pending
-	//             pendingWorks = append(pendingWorks, work)
-	//         }
-	//     }
-	// }
-
-	return pendingWorks, nil
+// CheckPendingTransitions returns the automatic transitions proposed for all work items
+func (e *EnhancedMarkdownIO) CheckPendingTransitions(ctx context.Context) ([]automation.PendingTransition, error) {
+	works, err := e.ListAllWork()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list work: %w", err)
+	}
+	return e.transitionEngine.PendingTransitions(works, time.Now()), nil
 }
\ No newline at end of file
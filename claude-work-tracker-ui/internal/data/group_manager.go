@@ -5,12 +5,12 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
 	"time"
 
 	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/parser"
 	"gopkg.in/yaml.v3"
 )
 
@@ -35,16 +35,11 @@ func (gm *GroupManager) ReadGroup(filepath string) (*models.Group, error) {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	// Use the same frontmatter regex from MarkdownIO
-	frontmatterRegex := regexp.MustCompile(`(?s)^---\n(.*?)\n---\n(.*)$`)
-	
-	matches := frontmatterRegex.FindSubmatch(content)
-	if len(matches) < 3 {
+	frontmatter, _, ok := parser.SplitFrontmatter(content)
+	if !ok {
 		return nil, fmt.Errorf("invalid markdown format: no frontmatter found")
 	}
 
-	frontmatter := matches[1]
-
 	// Parse YAML frontmatter
 	var group models.Group
 	if err := yaml.Unmarshal(frontmatter, &group); err != nil {
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/parser"
 	"gopkg.in/yaml.v3"
 )
 
@@ -26,9 +27,6 @@ func NewMarkdownIO(baseDir string) *MarkdownIO {
 	}
 }
 
-// frontmatterRegex matches YAML frontmatter in markdown files
-var frontmatterRegex = regexp.MustCompile(`(?s)^---\n(.*?)\n---\n(.*)$`)
-
 // ReadMarkdownWorkItem reads a markdown work item from a file
 func (m *MarkdownIO) ReadMarkdownWorkItem(filepath string) (*models.MarkdownWorkItem, error) {
 	content, err := ioutil.ReadFile(filepath)
@@ -36,13 +34,11 @@ func (m *MarkdownIO) ReadMarkdownWorkItem(filepath string) (*models.MarkdownWork
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	matches := frontmatterRegex.FindSubmatch(content)
-	if len(matches) < 3 {
+	frontmatter, body, ok := parser.SplitFrontmatter(content)
+	if !ok {
 		return nil, fmt.Errorf("invalid markdown format: no frontmatter found")
 	}
-
-	frontmatter := matches[1]
-	markdownContent := strings.TrimSpace(string(matches[2]))
+	markdownContent := strings.TrimSpace(string(body))
 
 	// Parse YAML frontmatter
 	var item models.MarkdownWorkItem
@@ -317,13 +313,11 @@ func (m *MarkdownIO) ReadWork(filepath string) (*models.Work, error) {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	matches := frontmatterRegex.FindSubmatch(content)
-	if len(matches) < 3 {
+	frontmatter, body, ok := parser.SplitFrontmatter(content)
+	if !ok {
 		return nil, fmt.Errorf("invalid markdown format: no frontmatter found")
 	}
-
-	frontmatter := matches[1]
-	markdownContent := strings.TrimSpace(string(matches[2]))
+	markdownContent := strings.TrimSpace(string(body))
 
 	// Parse YAML frontmatter
 	var work models.Work
@@ -358,6 +352,15 @@ func (m *MarkdownIO) WriteWork(work *models.Work) error {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
+	// Pin task IDs with markers, carrying IDs over to tasks renamed since the last write
+	previous := ""
+	if oldFilepath != "" {
+		if old, err := m.ReadWork(oldFilepath); err == nil {
+			previous = old.Content
+		}
+	}
+	work.Content = parser.NewTaskParser().AssignTaskIDs(work.Content, previous)
+
 	// Generate markdown content
 	content, err := m.generateWorkContent(work)
 	if err != nil {
@@ -411,15 +414,45 @@ func (m *MarkdownIO) generateWorkContent(work *models.Work) ([]byte, error) {
 	if work.CompletedAt != nil {
 		frontmatter["completed_at"] = *work.CompletedAt
 	}
+	if work.DueAt != nil {
+		frontmatter["due_at"] = *work.DueAt
+	}
+	if work.StartBy != nil {
+		frontmatter["start_by"] = *work.StartBy
+	}
 	if work.GroupID != "" {
 		frontmatter["group_id"] = work.GroupID
 	}
+	if work.BranchMergedAt != nil {
+		frontmatter["branch_merged_at"] = *work.BranchMergedAt
+	}
+	if work.ParentID != "" {
+		frontmatter["parent_id"] = work.ParentID
+	}
+	if len(work.ChildIDs) > 0 {
+		frontmatter["child_ids"] = work.ChildIDs
+	}
+	if work.PromotedFrom != "" {
+		frontmatter["promoted_from"] = work.PromotedFrom
+	}
 	if work.OverviewUpdated != nil {
 		frontmatter["overview_updated"] = *work.OverviewUpdated
 	}
 	if work.UpdatesRef != "" {
 		frontmatter["updates_ref"] = work.UpdatesRef
 	}
+	if work.External != nil {
+		frontmatter["external"] = work.External
+	}
+	if len(work.Transitions) > 0 {
+		frontmatter["transitions"] = work.Transitions
+	}
+	if len(work.SnoozedTransitions) > 0 {
+		frontmatter["snoozed_transitions"] = work.SnoozedTransitions
+	}
+	if len(work.Commits) > 0 {
+		frontmatter["commits"] = work.Commits
+	}
 	
 	if err := encoder.Encode(frontmatter); err != nil {
 		return nil, fmt.Errorf("failed to encode frontmatter: %w", err)
@@ -487,13 +520,11 @@ func (m *MarkdownIO) ReadArtifact(filepath string) (*models.Artifact, error) {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	matches := frontmatterRegex.FindSubmatch(content)
-	if len(matches) < 3 {
+	frontmatter, body, ok := parser.SplitFrontmatter(content)
+	if !ok {
 		return nil, fmt.Errorf("invalid markdown format: no frontmatter found")
 	}
-
-	frontmatter := matches[1]
-	markdownContent := strings.TrimSpace(string(matches[2]))
+	markdownContent := strings.TrimSpace(string(body))
 
 	// Parse YAML frontmatter
 	var artifact models.Artifact
@@ -0,0 +1,243 @@
+package data
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/parser"
+)
+
+// TaskEntry is a checklist task together with the work item and project it lives in
+type TaskEntry struct {
+	Task        *models.Task
+	Work        *models.Work
+	ProjectID   string
+	ProjectName string
+}
+
+// IsOpen reports whether the task still needs doing
+func (e TaskEntry) IsOpen() bool {
+	return e.Task.Status != models.TaskStatusCompleted && e.Task.Status != models.TaskStatusCancelled
+}
+
+// CollectTasks extracts the checklists of a project's work items, in checklist order
+func CollectTasks(projectID, projectName string, works []*models.Work) []TaskEntry {
+	taskParser := parser.NewTaskParser()
+	var entries []TaskEntry
+	for _, work := range works {
+		for _, task := range taskParser.ExtractTasksFromMarkdown(work.Content, work.ID).Tasks {
+			entries = append(entries, TaskEntry{
+				Task:        task.Task,
+				Work:        work,
+				ProjectID:   projectID,
+				ProjectName: projectName,
+			})
+		}
+	}
+	return entries
+}
+
+// TaskFilter narrows down aggregated tasks. Empty fields match everything.
+type TaskFilter struct {
+	Statuses      []models.TaskStatus
+	OpenOnly      bool   // Leave out completed and cancelled tasks
+	Assignee      string // Owner without the @, "me" for the current user
+	Tag           string
+	Project       string // Project name or ID
+	Query         string // Matched against the task, phase and work titles
+	DueBefore     *time.Time
+	IncludeClosed bool // Include tasks of closed work items
+}
+
+// Matches reports whether an entry passes the filter
+func (f TaskFilter) Matches(entry TaskEntry) bool {
+	task := entry.Task
+	if !f.IncludeClosed && entry.Work.IsClosed() {
+		return false
+	}
+	if f.OpenOnly && !entry.IsOpen() {
+		return false
+	}
+	if len(f.Statuses) > 0 {
+		found := false
+		for _, status := range f.Statuses {
+			found = found || task.Status == status
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if assignee := strings.TrimPrefix(f.Assignee, "@"); assignee != "" {
+		if assignee == "me" {
+			assignee = models.CurrentActor()
+		}
+		if !strings.EqualFold(task.AssignedTo, assignee) {
+			return false
+		}
+	}
+	if tag := strings.TrimPrefix(f.Tag, "#"); tag != "" {
+		found := false
+		for _, t := range task.Tags {
+			found = found || strings.EqualFold(t, tag)
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.Project != "" && !strings.EqualFold(entry.ProjectName, f.Project) && entry.ProjectID != f.Project {
+		return false
+	}
+	if f.DueBefore != nil && (task.DueAt == nil || !task.DueAt.Before(*f.DueBefore)) {
+		return false
+	}
+	if query := strings.ToLower(strings.TrimSpace(f.Query)); query != "" {
+		text := strings.ToLower(strings.Join([]string{task.Title, task.Phase, entry.Work.Title, entry.ProjectName}, " "))
+		if !strings.Contains(text, query) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterTasks returns the entries that pass the filter
+func FilterTasks(entries []TaskEntry, filter TaskFilter) []TaskEntry {
+	var matched []TaskEntry
+	for _, entry := range entries {
+		if filter.Matches(entry) {
+			matched = append(matched, entry)
+		}
+	}
+	return matched
+}
+
+// Ways of grouping aggregated tasks
+const (
+	TaskGroupStatus   = "status"
+	TaskGroupPhase    = "phase"
+	TaskGroupAssignee = "assignee"
+	TaskGroupDue      = "due"
+)
+
+// TaskGroupings lists the supported groupings in the order the UI cycles through them
+var TaskGroupings = []string{TaskGroupStatus, TaskGroupPhase, TaskGroupAssignee, TaskGroupDue}
+
+// TaskGroup is a named set of tasks
+type TaskGroup struct {
+	Name    string
+	Entries []TaskEntry
+}
+
+// taskStatusOrder is the order status groups are listed in, most actionable first
+var taskStatusOrder = []models.TaskStatus{
+	models.TaskStatusInProgress,
+	models.TaskStatusTodo,
+	models.TaskStatusBlocked,
+	models.TaskStatusCompleted,
+	models.TaskStatusCancelled,
+}
+
+// Due date buckets, in the order they are listed
+var dueBuckets = []string{"Overdue", "Today", "This week", "Later", "Past", "No due date"}
+
+// GroupTasks groups entries by status, phase, assignee or due date. Entries keep their order
+// within a group, except by due date where they are sorted by it. Unknown groupings put
+// everything in one group.
+func GroupTasks(entries []TaskEntry, by string, now time.Time) []TaskGroup {
+	var order []string
+	key := func(TaskEntry) string { return "All tasks" }
+
+	switch by {
+	case TaskGroupStatus:
+		for _, status := range taskStatusOrder {
+			order = append(order, (&models.Task{Status: status}).GetDisplayStatus())
+		}
+		key = func(e TaskEntry) string { return e.Task.GetDisplayStatus() }
+	case TaskGroupPhase:
+		key = func(e TaskEntry) string {
+			if e.Task.Phase == "" {
+				return "No phase"
+			}
+			return e.Task.Phase
+		}
+	case TaskGroupAssignee:
+		key = func(e TaskEntry) string {
+			if e.Task.AssignedTo == "" {
+				return "Unassigned"
+			}
+			return "@" + e.Task.AssignedTo
+		}
+	case TaskGroupDue:
+		order = dueBuckets
+		key = func(e TaskEntry) string { return dueBucket(e, now) }
+		entries = append([]TaskEntry(nil), entries...)
+		sort.SliceStable(entries, func(i, j int) bool {
+			a, b := entries[i].Task.DueAt, entries[j].Task.DueAt
+			return a != nil && (b == nil || a.Before(*b))
+		})
+	}
+
+	groups := make(map[string]*TaskGroup)
+	var names []string
+	for _, entry := range entries {
+		name := key(entry)
+		group, exists := groups[name]
+		if !exists {
+			group = &TaskGroup{Name: name}
+			groups[name] = group
+			names = append(names, name)
+		}
+		group.Entries = append(group.Entries, entry)
+	}
+
+	// Fixed orders first, then the rest alphabetically with the catch-all groups last
+	rank := func(name string) int {
+		for i, known := range order {
+			if known == name {
+				return i
+			}
+		}
+		return len(order)
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		ri, rj := rank(names[i]), rank(names[j])
+		if ri != rj {
+			return ri < rj
+		}
+		ci, cj := names[i] == "No phase" || names[i] == "Unassigned", names[j] == "No phase" || names[j] == "Unassigned"
+		if ci != cj {
+			return cj
+		}
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+
+	result := make([]TaskGroup, 0, len(names))
+	for _, name := range names {
+		result = append(result, *groups[name])
+	}
+	return result
+}
+
+// dueBucket places a task's due date relative to now. Finished tasks are never overdue.
+func dueBucket(entry TaskEntry, now time.Time) string {
+	due := entry.Task.DueAt
+	if due == nil {
+		return "No due date"
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch {
+	case due.Before(today) && entry.IsOpen():
+		return "Overdue"
+	case due.Before(today):
+		return "Past"
+	case due.Before(today.AddDate(0, 0, 1)):
+		return "Today"
+	case due.Before(today.AddDate(0, 0, 7)):
+		return "This week"
+	default:
+		return "Later"
+	}
+}
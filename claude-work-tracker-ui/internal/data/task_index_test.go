@@ -0,0 +1,160 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// taskWorks returns two work items with checklists, the second one closed
+func taskWorks() []*models.Work {
+	login := &models.Work{
+		ID:    "work-1",
+		Title: "Login page",
+		Content: "## Phase 1: Build\n\n- [x] Form @sam #ui\n- […] Redirect @alex due:2026-03-09\n\n" +
+			"## Phase 2: Ship\n\n- [ ] Release notes due:2026-03-12\n- [-] Old banner\n- [!] Deploy @sam #ops due:2026-03-30\n",
+	}
+	archive := &models.Work{
+		ID:       "work-2",
+		Title:    "Archive",
+		Schedule: models.ScheduleClosed,
+		Content:  "- [ ] Leftover\n",
+	}
+	archive.Metadata.Status = models.WorkStatusCompleted
+	return []*models.Work{login, archive, {ID: "work-3", Title: "No checklist"}}
+}
+
+// taskTitles lists the titles of the entries' tasks
+func taskTitles(entries []TaskEntry) []string {
+	var titles []string
+	for _, entry := range entries {
+		titles = append(titles, entry.Task.Title)
+	}
+	return titles
+}
+
+func TestCollectTasks(t *testing.T) {
+	works := taskWorks()
+	entries := CollectTasks("tracker-1a2b", "Tracker", works)
+
+	want := []string{"Form", "Redirect", "Release notes", "Old banner", "Deploy", "Leftover"}
+	if got := taskTitles(entries); !reflect.DeepEqual(got, want) {
+		t.Fatalf("tasks = %q, want %q", got, want)
+	}
+	for _, entry := range entries {
+		if entry.ProjectID != "tracker-1a2b" || entry.ProjectName != "Tracker" {
+			t.Errorf("%s: entry = %+v", entry.Task.Title, entry)
+		}
+	}
+	if entries[0].Work != works[0] || entries[5].Work != works[1] {
+		t.Error("entries don't point at the work items they came from")
+	}
+
+	redirect := entries[1].Task
+	if redirect.Status != models.TaskStatusInProgress || redirect.AssignedTo != "alex" || redirect.Phase != "Build" || redirect.DueAt == nil {
+		t.Errorf("Redirect = %+v", redirect)
+	}
+
+	open := 0
+	for _, entry := range entries {
+		if entry.IsOpen() {
+			open++
+		}
+	}
+	if open != 4 {
+		t.Errorf("%d open tasks, want 4", open)
+	}
+}
+
+func TestFilterTasks(t *testing.T) {
+	entries := CollectTasks("tracker-1a2b", "Tracker", taskWorks())
+	before := time.Date(2026, 3, 20, 0, 0, 0, 0, time.Local)
+	t.Setenv("USER", "sam")
+
+	tests := []struct {
+		name   string
+		filter TaskFilter
+		want   []string
+	}{
+		{"everything on open work", TaskFilter{}, []string{"Form", "Redirect", "Release notes", "Old banner", "Deploy"}},
+		{"closed work included", TaskFilter{IncludeClosed: true, OpenOnly: true}, []string{"Redirect", "Release notes", "Deploy", "Leftover"}},
+		{"open only", TaskFilter{OpenOnly: true}, []string{"Redirect", "Release notes", "Deploy"}},
+		{"status", TaskFilter{Statuses: []models.TaskStatus{models.TaskStatusBlocked, models.TaskStatusTodo}}, []string{"Release notes", "Deploy"}},
+		{"assignee", TaskFilter{Assignee: "@alex"}, []string{"Redirect"}},
+		{"assigned to me", TaskFilter{Assignee: "me"}, []string{"Form", "Deploy"}},
+		{"tag", TaskFilter{Tag: "#OPS"}, []string{"Deploy"}},
+		{"project by name", TaskFilter{Project: "tracker", OpenOnly: true}, []string{"Redirect", "Release notes", "Deploy"}},
+		{"project by ID", TaskFilter{Project: "tracker-1a2b", Tag: "ui"}, []string{"Form"}},
+		{"other project", TaskFilter{Project: "elsewhere"}, nil},
+		{"due before", TaskFilter{DueBefore: &before}, []string{"Redirect", "Release notes"}},
+		{"query matches the task", TaskFilter{Query: "release"}, []string{"Release notes"}},
+		{"query matches the phase", TaskFilter{Query: " ship "}, []string{"Release notes", "Old banner", "Deploy"}},
+		{"query matches the work", TaskFilter{Query: "login", OpenOnly: true}, []string{"Redirect", "Release notes", "Deploy"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := taskTitles(FilterTasks(entries, tt.filter)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tasks = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupTasks(t *testing.T) {
+	entries := CollectTasks("tracker-1a2b", "Tracker", taskWorks())
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		by   string
+		want map[string][]string // Group name -> task titles
+		keys []string            // Group names in order
+	}{
+		{
+			by:   TaskGroupStatus,
+			keys: []string{"In Progress", "To Do", "Blocked", "Completed", "Cancelled"},
+		},
+		{
+			by:   TaskGroupPhase,
+			keys: []string{"Build", "Ship", "No phase"},
+			want: map[string][]string{"Build": {"Form", "Redirect"}, "Ship": {"Release notes", "Old banner", "Deploy"}, "No phase": {"Leftover"}},
+		},
+		{
+			by:   TaskGroupAssignee,
+			keys: []string{"@alex", "@sam", "Unassigned"},
+			want: map[string][]string{"@alex": {"Redirect"}, "@sam": {"Form", "Deploy"}},
+		},
+		{
+			by:   TaskGroupDue,
+			keys: []string{"Overdue", "This week", "Later", "No due date"},
+			want: map[string][]string{"Overdue": {"Redirect"}, "This week": {"Release notes"}, "Later": {"Deploy"}},
+		},
+		{
+			by:   "unknown",
+			keys: []string{"All tasks"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			groups := GroupTasks(entries, tt.by, now)
+			var keys []string
+			total := 0
+			for _, group := range groups {
+				keys = append(keys, group.Name)
+				total += len(group.Entries)
+				if want, ok := tt.want[group.Name]; ok && !reflect.DeepEqual(taskTitles(group.Entries), want) {
+					t.Errorf("group %s = %q, want %q", group.Name, taskTitles(group.Entries), want)
+				}
+			}
+			if !reflect.DeepEqual(keys, tt.keys) {
+				t.Errorf("groups = %q, want %q", keys, tt.keys)
+			}
+			if total != len(entries) {
+				t.Errorf("groups hold %d tasks, want %d", total, len(entries))
+			}
+		})
+	}
+}
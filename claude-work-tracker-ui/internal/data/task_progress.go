@@ -0,0 +1,89 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/parser"
+)
+
+// TaskProgressChange describes what syncing a work item with its checklist changed
+type TaskProgressChange struct {
+	ProgressBefore int
+	ProgressAfter  int
+	Completed      int      // Completed tasks in the checklist
+	Total          int      // Tasks in the checklist, cancelled ones left out
+	NewlyCompleted []string // Titles of tasks completed since the last sync
+}
+
+// Changed reports whether the progress percentage moved
+func (c TaskProgressChange) Changed() bool {
+	return c.ProgressBefore != c.ProgressAfter
+}
+
+// SyncTaskProgress mirrors a work item's checklist into its metadata. CompletedTasks and
+// PendingTasks list the task titles, and when ProgressFromTasks is set the progress
+// percentage follows the checklist, weighted by subtasks and estimates. Items without a
+// checklist are left alone.
+func SyncTaskProgress(work *models.Work) TaskProgressChange {
+	change := TaskProgressChange{
+		ProgressBefore: work.Metadata.ProgressPercent,
+		ProgressAfter:  work.Metadata.ProgressPercent,
+	}
+
+	result := parser.NewTaskParser().ExtractTasksFromMarkdown(work.Content, work.ID)
+	if len(result.Tasks) == 0 {
+		return change
+	}
+
+	previouslyCompleted := make(map[string]bool)
+	for _, title := range work.Metadata.CompletedTasks {
+		previouslyCompleted[title] = true
+	}
+
+	var completed, pending []string
+	for _, task := range result.Tasks {
+		switch task.Task.Status {
+		case models.TaskStatusCancelled:
+			continue
+		case models.TaskStatusCompleted:
+			completed = append(completed, task.Task.Title)
+			if !previouslyCompleted[task.Task.Title] {
+				change.NewlyCompleted = append(change.NewlyCompleted, task.Task.Title)
+			}
+		default:
+			pending = append(pending, task.Task.Title)
+		}
+	}
+	work.Metadata.CompletedTasks = completed
+	work.Metadata.PendingTasks = pending
+	change.Completed = len(completed)
+	change.Total = len(completed) + len(pending)
+
+	if work.Metadata.ProgressFromTasks {
+		if progress, ok := result.Progress(); ok {
+			work.Metadata.ProgressPercent = int(math.Round(progress * 100))
+			change.ProgressAfter = work.Metadata.ProgressPercent
+		}
+	}
+	return change
+}
+
+// TaskProgressUpdate returns the automatic update recording a change in checklist progress
+func TaskProgressUpdate(work *models.Work, change TaskProgressChange) *models.Update {
+	now := time.Now()
+	return &models.Update{
+		ID:             models.NewUpdateID(),
+		WorkID:         work.ID,
+		Timestamp:      now,
+		Title:          "Checklist progress",
+		Summary:        fmt.Sprintf("Progress %d%% → %d%% from the checklist (%d of %d tasks done)", change.ProgressBefore, change.ProgressAfter, change.Completed, change.Total),
+		Author:         models.CurrentActor(),
+		UpdateType:     "automatic",
+		TasksCompleted: change.NewlyCompleted,
+		ProgressBefore: change.ProgressBefore,
+		ProgressAfter:  change.ProgressAfter,
+	}
+}
@@ -0,0 +1,102 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// ParseUpdateLog reads an update log: one JSON update per line, oldest first
+func ParseUpdateLog(content []byte) ([]*models.Update, error) {
+	var updates []*models.Update
+	for i, line := range bytes.Split(content, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var update models.Update
+		if err := json.Unmarshal(line, &update); err != nil {
+			return nil, fmt.Errorf("failed to parse update log line %d: %w", i+1, err)
+		}
+		updates = append(updates, &update)
+	}
+	return updates, nil
+}
+
+// MarshalUpdateLog writes updates as an update log, one JSON update per line
+func MarshalUpdateLog(updates []*models.Update) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, update := range updates {
+		line, err := json.Marshal(update)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode update %s: %w", update.ID, err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// MergeUpdateLogs merges two versions of an update log that changed since base. Updates
+// are matched by ID: new ones from both sides are kept, one deleted on either side stays
+// deleted, and when both sides edited an update the later edit wins.
+func MergeUpdateLogs(base, ours, theirs []byte) ([]byte, error) {
+	baseUpdates, err := ParseUpdateLog(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base version: %w", err)
+	}
+	oursUpdates, err := ParseUpdateLog(ours)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse local version: %w", err)
+	}
+	theirsUpdates, err := ParseUpdateLog(theirs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse remote version: %w", err)
+	}
+
+	inBase := updatesByID(baseUpdates)
+	inOurs := updatesByID(oursUpdates)
+	inTheirs := updatesByID(theirsUpdates)
+
+	var merged []*models.Update
+	for _, update := range append(oursUpdates, theirsUpdates...) {
+		mine, remote := inOurs[update.ID], inTheirs[update.ID]
+		if mine != nil && remote != nil {
+			if update == remote {
+				continue // Already taken when going through ours
+			}
+			if lastChange(remote).After(lastChange(mine)) {
+				update = remote
+			}
+		} else if inBase[update.ID] != nil {
+			continue // Deleted on the other side
+		}
+		merged = append(merged, update)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Timestamp.Before(merged[j].Timestamp)
+	})
+	return MarshalUpdateLog(merged)
+}
+
+// updatesByID indexes updates by their ID
+func updatesByID(updates []*models.Update) map[string]*models.Update {
+	byID := make(map[string]*models.Update, len(updates))
+	for _, update := range updates {
+		byID[update.ID] = update
+	}
+	return byID
+}
+
+// lastChange returns when an update was last written
+func lastChange(update *models.Update) time.Time {
+	if update.EditedAt != nil {
+		return *update.EditedAt
+	}
+	return update.Timestamp
+}
@@ -0,0 +1,123 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+func TestMergeUpdateLogs(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 10, 0, 0, 0, time.UTC) }
+	update := func(id string, d int, title string) *models.Update {
+		return &models.Update{ID: id, WorkID: "work-1", Timestamp: day(d), Title: title}
+	}
+	edited := func(u *models.Update, d int, title string) *models.Update {
+		copy := *u
+		at := day(d)
+		copy.EditedAt = &at
+		copy.Title = title
+		return &copy
+	}
+
+	first := update("update-1", 1, "First")
+	second := update("update-2", 2, "Second")
+	third := update("update-3", 3, "Third")
+
+	tests := []struct {
+		name   string
+		base   []*models.Update
+		ours   []*models.Update
+		theirs []*models.Update
+		want   []string // Titles, oldest first
+	}{
+		{
+			name:   "new updates from both sides are kept in time order",
+			base:   []*models.Update{first},
+			ours:   []*models.Update{first, third},
+			theirs: []*models.Update{first, second},
+			want:   []string{"First", "Second", "Third"},
+		},
+		{
+			name:   "deleted locally stays deleted",
+			base:   []*models.Update{first, second},
+			ours:   []*models.Update{second},
+			theirs: []*models.Update{first, second, third},
+			want:   []string{"Second", "Third"},
+		},
+		{
+			name:   "deleted remotely stays deleted",
+			base:   []*models.Update{first, second},
+			ours:   []*models.Update{first, second, third},
+			theirs: []*models.Update{first},
+			want:   []string{"First", "Third"},
+		},
+		{
+			name:   "delete wins over an edit",
+			base:   []*models.Update{first},
+			ours:   []*models.Update{edited(first, 5, "Edited")},
+			theirs: []*models.Update{},
+			want:   nil,
+		},
+		{
+			name:   "latest edit wins",
+			base:   []*models.Update{first},
+			ours:   []*models.Update{edited(first, 4, "Ours")},
+			theirs: []*models.Update{edited(first, 5, "Theirs")},
+			want:   []string{"Theirs"},
+		},
+		{
+			name:   "local edit wins over an untouched remote",
+			base:   []*models.Update{first},
+			ours:   []*models.Update{edited(first, 4, "Ours")},
+			theirs: []*models.Update{first},
+			want:   []string{"Ours"},
+		},
+		{
+			name:   "no base, both sides kept",
+			ours:   []*models.Update{first},
+			theirs: []*models.Update{second},
+			want:   []string{"First", "Second"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, _ := MarshalUpdateLog(tt.base)
+			ours, _ := MarshalUpdateLog(tt.ours)
+			theirs, _ := MarshalUpdateLog(tt.theirs)
+
+			merged, err := MergeUpdateLogs(base, ours, theirs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			updates, err := ParseUpdateLog(merged)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var titles []string
+			for _, u := range updates {
+				titles = append(titles, u.Title)
+			}
+			if !reflect.DeepEqual(titles, tt.want) {
+				t.Errorf("titles = %v, want %v", titles, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUpdateLog(t *testing.T) {
+	updates, err := ParseUpdateLog([]byte("{\"id\":\"update-1\"}\n\n  \n{\"id\":\"update-2\"}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 2 || updates[0].ID != "update-1" || updates[1].ID != "update-2" {
+		t.Errorf("updates = %v", updates)
+	}
+
+	if _, err := ParseUpdateLog([]byte("{\"id\":\"update-1\"}\n{broken\n")); err == nil {
+		t.Error("expected an error for a broken line")
+	}
+}
@@ -1,16 +1,25 @@
 package data
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/parser"
+	"github.com/yuin/goldmark/ast"
 )
 
-// UpdatesManager handles creation and management of updates documents
+// UpdatesManager handles creation and management of updates documents. Each Work item's
+// updates are kept as structured records in updates/<work-id>.jsonl, one JSON update per
+// line, and rendered to updates/<work-id>.md beside it for reading and embedding. Items whose
+// updates predate the log are read from the markdown, which becomes the log on the next write.
 type UpdatesManager struct {
 	baseDir string
 }
@@ -24,70 +33,198 @@ func NewUpdatesManager(baseDir string) *UpdatesManager {
 
 // CreateUpdate adds a new update to a Work item's updates document
 func (um *UpdatesManager) CreateUpdate(workID string, update *models.Update) error {
-	updatesPath := um.getUpdatesPath(workID)
-	
-	// Ensure updates directory exists
-	if err := os.MkdirAll(filepath.Dir(updatesPath), 0755); err != nil {
-		return fmt.Errorf("failed to create updates directory: %w", err)
+	unlock, err := um.lock(workID)
+	if err != nil {
+		return err
 	}
+	defer unlock()
 	
-	// Read existing updates
-	existingContent := ""
-	if content, err := os.ReadFile(updatesPath); err == nil {
-		existingContent = string(content)
+	updates, err := um.readLog(workID)
+	if err != nil {
+		return err
 	}
 	
-	// Generate new update content
-	newUpdateContent := um.renderUpdate(update)
+	if update.ID == "" {
+		update.ID = models.NewUpdateID()
+	}
+	if update.Timestamp.IsZero() {
+		update.Timestamp = time.Now()
+	}
+	if update.Author == "" {
+		update.Author = models.CurrentActor()
+	}
+	update.WorkID = workID
 	
-	// Prepend new update to existing content
-	var finalContent string
-	if existingContent == "" {
-		// First update - create new file with frontmatter
-		finalContent = fmt.Sprintf(`---
-work_id: %s
----
-
-%s`, workID, newUpdateContent)
-	} else {
-		// Prepend to existing content
-		parts := strings.SplitN(existingContent, "---\n", 3)
-		if len(parts) == 3 {
-			// Has frontmatter
-			frontmatter := fmt.Sprintf("---\n%s---\n", parts[1])
-			existingUpdates := parts[2]
-			finalContent = frontmatter + newUpdateContent + "\n---\n\n" + existingUpdates
-		} else {
-			// No frontmatter, add it
-			finalContent = fmt.Sprintf(`---
-work_id: %s
----
+	return um.writeLog(workID, append(updates, update))
+}
 
-%s
+// GetUpdates retrieves all updates for a Work item, newest first
+func (um *UpdatesManager) GetUpdates(workID string) ([]*models.Update, error) {
+	updates, err := um.readLog(workID)
+	if err != nil {
+		return nil, err
+	}
+	return newestFirst(updates), nil
+}
 
----
+// GetUpdate retrieves a single update of a Work item
+func (um *UpdatesManager) GetUpdate(workID, updateID string) (*models.Update, error) {
+	updates, err := um.readLog(workID)
+	if err != nil {
+		return nil, err
+	}
+	for _, update := range updates {
+		if update.ID == updateID {
+			return update, nil
+		}
+	}
+	return nil, fmt.Errorf("update not found: %s", updateID)
+}
 
-%s`, workID, newUpdateContent, existingContent)
+// EditUpdate replaces an update, matched by ID, and marks it as edited. Its time and
+// session stay as first recorded.
+func (um *UpdatesManager) EditUpdate(workID string, update *models.Update) error {
+	unlock, err := um.lock(workID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	
+	updates, err := um.readLog(workID)
+	if err != nil {
+		return err
+	}
+	for i, existing := range updates {
+		if existing.ID != update.ID {
+			continue
 		}
+		now := time.Now()
+		update.WorkID = workID
+		update.Timestamp = existing.Timestamp
+		update.SessionID = existing.SessionID
+		update.EditedAt = &now
+		updates[i] = update
+		return um.writeLog(workID, updates)
+	}
+	return fmt.Errorf("update not found: %s", update.ID)
+}
+
+// DeleteUpdate removes an update from a Work item's updates
+func (um *UpdatesManager) DeleteUpdate(workID, updateID string) error {
+	unlock, err := um.lock(workID)
+	if err != nil {
+		return err
 	}
+	defer unlock()
 	
-	// Write to file
-	return os.WriteFile(updatesPath, []byte(finalContent), 0644)
+	updates, err := um.readLog(workID)
+	if err != nil {
+		return err
+	}
+	for i, existing := range updates {
+		if existing.ID == updateID {
+			return um.writeLog(workID, append(updates[:i], updates[i+1:]...))
+		}
+	}
+	return fmt.Errorf("update not found: %s", updateID)
 }
 
-// GetUpdates retrieves all updates for a Work item
-func (um *UpdatesManager) GetUpdates(workID string) ([]*models.Update, error) {
-	updatesPath := um.getUpdatesPath(workID)
+// lock serializes changes to a Work item's updates; the daemon and the TUI both write them
+func (um *UpdatesManager) lock(workID string) (func(), error) {
+	return lockFile(um.getLogPath(workID))
+}
+
+// readLog returns a Work item's updates in the order they were recorded
+func (um *UpdatesManager) readLog(workID string) ([]*models.Update, error) {
+	content, err := os.ReadFile(um.getLogPath(workID))
+	if err == nil {
+		return ParseUpdateLog(content)
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read update log: %w", err)
+	}
 	
-	content, err := os.ReadFile(updatesPath)
+	// No log yet, fall back to the markdown
+	content, err = os.ReadFile(um.getUpdatesPath(workID))
 	if err != nil {
 		if os.IsNotExist(err) {
 			return []*models.Update{}, nil // No updates yet
 		}
 		return nil, fmt.Errorf("failed to read updates file: %w", err)
 	}
+	updates, err := um.parseUpdates(string(content), workID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// The markdown lists the newest first
+	for i, j := 0, len(updates)-1; i < j; i, j = i+1, j-1 {
+		updates[i], updates[j] = updates[j], updates[i]
+	}
+	return updates, nil
+}
+
+// writeLog saves a Work item's updates to its log and renders its updates document. Both are
+// replaced atomically; callers hold the lock.
+func (um *UpdatesManager) writeLog(workID string, updates []*models.Update) error {
+	logPath := um.getLogPath(workID)
+	
+	// Ensure updates directory exists
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return fmt.Errorf("failed to create updates directory: %w", err)
+	}
+	
+	content, err := MarshalUpdateLog(updates)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(logPath, content); err != nil {
+		return fmt.Errorf("failed to write update log: %w", err)
+	}
 	
-	return um.parseUpdates(string(content), workID)
+	document, err := um.RenderDocument(workID)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(um.getUpdatesPath(workID), document); err != nil {
+		return fmt.Errorf("failed to write updates file: %w", err)
+	}
+	return nil
+}
+
+// RenderDocument renders a Work item's updates document from its update log, keeping the
+// frontmatter of the current document
+func (um *UpdatesManager) RenderDocument(workID string) ([]byte, error) {
+	updates, err := um.readLog(workID)
+	if err != nil {
+		return nil, err
+	}
+	
+	frontmatter := []byte(fmt.Sprintf("work_id: %s\n", workID))
+	if existing, err := os.ReadFile(um.getUpdatesPath(workID)); err == nil {
+		if current, _, ok := parser.SplitFrontmatter(existing); ok {
+			frontmatter = current
+		}
+	}
+	
+	var entries []string
+	for _, update := range newestFirst(updates) {
+		entries = append(entries, um.renderUpdate(update))
+	}
+	return []byte("---\n" + string(frontmatter) + "---\n\n" + strings.Join(entries, "\n---\n\n")), nil
+}
+
+// newestFirst returns updates sorted newest first; updates recorded at the same time keep
+// the newest recorded first
+func newestFirst(updates []*models.Update) []*models.Update {
+	sorted := make([]*models.Update, 0, len(updates))
+	for i := len(updates) - 1; i >= 0; i-- {
+		sorted = append(sorted, updates[i])
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.After(sorted[j].Timestamp)
+	})
+	return sorted
 }
 
 // GetUpdatesRef returns the relative path to the updates file for a Work item
@@ -100,6 +237,17 @@ func (um *UpdatesManager) getUpdatesPath(workID string) string {
 	return filepath.Join(um.baseDir, "updates", fmt.Sprintf("%s.md", workID))
 }
 
+// getLogPath returns the full path to the update log
+func (um *UpdatesManager) getLogPath(workID string) string {
+	return filepath.Join(um.baseDir, "updates", fmt.Sprintf("%s.jsonl", workID))
+}
+
+// updateMarker matches the hidden ID marker on an update's heading: <!-- id:update-123 -->
+var updateMarker = regexp.MustCompile(`\s*<!--\s*id:\s*([A-Za-z0-9_-]+)\s*-->`)
+
+// attachmentLink matches the link of an attachment line: 📎 [[artifacts/plans/plan.md]]
+var attachmentLink = regexp.MustCompile(`\[\[([^\]]+)\]\]`)
+
 // renderUpdate converts an Update to markdown format
 func (um *UpdatesManager) renderUpdate(update *models.Update) string {
 	var content strings.Builder
@@ -109,13 +257,19 @@ func (um *UpdatesManager) renderUpdate(update *models.Update) string {
 	if update.SessionID != "" {
 		content.WriteString(fmt.Sprintf(" (Session: %s)", update.SessionID))
 	}
+	if update.ID != "" {
+		content.WriteString(" <!-- id:" + update.ID + " -->")
+	}
 	content.WriteString("\n")
 	
-	// Author and type
+	// Author, type and kind
 	content.WriteString(fmt.Sprintf("**Author**: %s", update.Author))
 	if update.UpdateType != "" {
 		content.WriteString(fmt.Sprintf(" | **Type**: %s", update.UpdateType))
 	}
+	if update.GetKind() != models.UpdateKindProgress {
+		content.WriteString(fmt.Sprintf(" | **Kind**: %s", update.Kind))
+	}
 	content.WriteString("\n")
 	
 	// Progress change if available
@@ -129,6 +283,10 @@ func (um *UpdatesManager) renderUpdate(update *models.Update) string {
 		content.WriteString(fmt.Sprintf("**Status**: %s\n", update.Title))
 	}
 	
+	if update.EditedAt != nil {
+		content.WriteString(fmt.Sprintf("**Edited**: %s\n", update.EditedAt.Format("2006-01-02 15:04")))
+	}
+	
 	content.WriteString("\n")
 	
 	// Main summary
@@ -150,132 +308,221 @@ func (um *UpdatesManager) renderUpdate(update *models.Update) string {
 		}
 	}
 	
+	if len(update.Attachments) > 0 {
+		content.WriteString("\n**Attachments:**\n")
+		for _, attachment := range update.Attachments {
+			content.WriteString(fmt.Sprintf("- 📎 [[%s]]", attachment.Ref))
+			if attachment.Title != "" {
+				content.WriteString(" " + attachment.Title)
+			}
+			content.WriteString("\n")
+		}
+	}
+	
 	return content.String()
 }
 
-// parseUpdates extracts updates from markdown content
+// parseUpdates extracts updates from markdown content. Each update starts at a "## Update"
+// heading and runs to the next one; the horizontal rule written between updates is dropped,
+// while rules, lists and code inside a summary are kept as written.
 func (um *UpdatesManager) parseUpdates(content, workID string) ([]*models.Update, error) {
 	var updates []*models.Update
 	
-	// Split content by update separators
-	parts := strings.Split(content, "---\n")
+	_, body, _ := parser.SplitFrontmatter([]byte(content))
+	doc := parser.ParseMarkdown(string(body))
 	
-	// Skip frontmatter (first part if it exists)
-	startIndex := 0
-	if strings.HasPrefix(content, "---\n") {
-		startIndex = 2 // Skip frontmatter
+	var heading *ast.Heading
+	var blocks []ast.Node
+	flush := func() {
+		if heading == nil {
+			return
+		}
+		for len(blocks) > 0 && blocks[len(blocks)-1].Kind() == ast.KindThematicBreak {
+			blocks = blocks[:len(blocks)-1]
+		}
+		updates = append(updates, um.parseUpdate(doc, heading, blocks, workID))
+		heading, blocks = nil, nil
 	}
 	
-	for i := startIndex; i < len(parts); i += 2 {
-		if i >= len(parts) {
-			break
-		}
-		
-		updateContent := strings.TrimSpace(parts[i])
-		if updateContent == "" {
+	for node := doc.Root.FirstChild(); node != nil; node = node.NextSibling() {
+		if h, ok := node.(*ast.Heading); ok && h.Level == 2 && strings.HasPrefix(nodeText(doc, h), "Update ") {
+			flush()
+			heading = h
 			continue
 		}
-		
-		update := um.parseUpdate(updateContent, workID)
-		if update != nil {
-			updates = append(updates, update)
+		blocks = append(blocks, node)
+	}
+	flush()
+	
+	// Updates written before IDs were recorded get one from their content, the same on
+	// every read
+	taken := make(map[string]bool)
+	for _, update := range updates {
+		if update.ID == "" {
+			update.ID = contentUpdateID(update)
+			for n := 2; taken[update.ID]; n++ {
+				update.ID = fmt.Sprintf("%s-%d", contentUpdateID(update), n)
+			}
 		}
+		taken[update.ID] = true
 	}
 	
 	return updates, nil
 }
 
-// parseUpdate parses a single update from markdown content
-func (um *UpdatesManager) parseUpdate(content, workID string) *models.Update {
-	lines := strings.Split(content, "\n")
-	if len(lines) == 0 {
-		return nil
-	}
-	
+// contentUpdateID derives an update ID from its time, title and summary
+func contentUpdateID(update *models.Update) string {
+	sum := sha1.Sum([]byte(update.Timestamp.Format(time.RFC3339) + "\n" + update.Title + "\n" + update.Summary))
+	return "update-" + hex.EncodeToString(sum[:4])
+}
+
+// parseUpdate reads an update from its heading and the blocks below it: a paragraph of
+// metadata, the summary, and the lists of completed and added tasks
+func (um *UpdatesManager) parseUpdate(doc *parser.Document, heading *ast.Heading, blocks []ast.Node, workID string) *models.Update {
 	update := &models.Update{
-		ID:     fmt.Sprintf("update-%d", time.Now().UnixNano()),
 		WorkID: workID,
 	}
 	
-	// Parse header for timestamp and session
-	headerLine := lines[0]
-	if strings.Contains(headerLine, "## Update") {
-		// Extract timestamp
-		if timestampMatch := strings.Index(headerLine, "Update "); timestampMatch >= 0 {
-			timestampStr := headerLine[timestampMatch+7:]
-			if sessionMatch := strings.Index(timestampStr, " (Session:"); sessionMatch >= 0 {
-				timestampStr = timestampStr[:sessionMatch]
-			}
-			
-			if timestamp, err := time.Parse("2006-01-02 15:04", timestampStr); err == nil {
-				update.Timestamp = timestamp
+	// Parse header for ID, timestamp and session
+	headerLine := nodeText(doc, heading)
+	if marker := updateMarker.FindStringSubmatch(headerLine); marker != nil {
+		update.ID = marker[1]
+		headerLine = strings.TrimSpace(updateMarker.ReplaceAllString(headerLine, ""))
+	}
+	timestampStr := strings.TrimPrefix(headerLine, "Update ")
+	if sessionMatch := strings.Index(timestampStr, " (Session:"); sessionMatch >= 0 {
+		timestampStr = timestampStr[:sessionMatch]
+	}
+	if timestamp, err := time.Parse("2006-01-02 15:04", timestampStr); err == nil {
+		update.Timestamp = timestamp
+	}
+	
+	// Extract session ID
+	if sessionStart := strings.Index(headerLine, "(Session: "); sessionStart >= 0 {
+		sessionEnd := strings.Index(headerLine[sessionStart:], ")")
+		if sessionEnd > 0 {
+			update.SessionID = headerLine[sessionStart+10 : sessionStart+sessionEnd]
+		}
+	}
+	
+	list := "" // Label of the list that follows: tasks completed, tasks added or attachments
+	firstSummary, lastSummary := 0, 0
+	for i, block := range blocks {
+		text := nodeText(doc, block)
+		switch {
+		case i == 0 && block.Kind() == ast.KindParagraph && strings.HasPrefix(text, "**"):
+			um.parseMetadata(text, update)
+			continue
+		case text == "**Tasks Completed:**" || text == "**Tasks Added:**" || text == "**Attachments:**":
+			list = text
+			continue
+		case list != "" && block.Kind() == ast.KindList:
+			for item := block.FirstChild(); item != nil; item = item.NextSibling() {
+				if item.FirstChild() == nil {
+					continue
+				}
+				line := nodeText(doc, item.FirstChild())
+				switch list {
+				case "**Tasks Completed:**":
+					update.TasksCompleted = append(update.TasksCompleted, strings.TrimSpace(strings.TrimPrefix(line, "✅")))
+				case "**Tasks Added:**":
+					update.TasksAdded = append(update.TasksAdded, strings.TrimSpace(strings.TrimPrefix(line, "➕")))
+				case "**Attachments:**":
+					if link := attachmentLink.FindStringSubmatchIndex(line); link != nil {
+						update.Attachments = append(update.Attachments, models.UpdateAttachment{
+							Ref:   line[link[2]:link[3]],
+							Title: strings.TrimSpace(line[link[1]:]),
+						})
+					}
+				}
 			}
+			list = ""
+			continue
 		}
 		
-		// Extract session ID
-		if sessionStart := strings.Index(headerLine, "(Session: "); sessionStart >= 0 {
-			sessionEnd := strings.Index(headerLine[sessionStart:], ")")
-			if sessionEnd > 0 {
-				update.SessionID = headerLine[sessionStart+10 : sessionStart+sessionEnd]
-			}
+		list = ""
+		first, last := doc.NodeLines(block)
+		if first == 0 {
+			continue
 		}
+		if firstSummary == 0 {
+			firstSummary = first
+		}
+		lastSummary = last
 	}
 	
-	// Parse metadata and summary
-	inSummary := false
-	var summaryLines []string
+	// The summary is kept as written, from its first block to its last
+	if firstSummary > 0 {
+		update.Summary = strings.Join(doc.Lines()[firstSummary-1:lastSummary], "\n")
+	}
 	
-	for _, line := range lines[1:] {
+	return update
+}
+
+// parseMetadata reads the author, type, kind, progress, status and edit time lines below an
+// update's heading
+func (um *UpdatesManager) parseMetadata(text string, update *models.Update) {
+	for _, line := range strings.Split(text, "\n") {
 		line = strings.TrimSpace(line)
 		
-		if strings.HasPrefix(line, "**Author**:") {
-			update.Author = strings.TrimSpace(strings.Split(line, "|")[0][11:])
-			if typeIndex := strings.Index(line, "**Type**:"); typeIndex >= 0 {
-				update.UpdateType = strings.TrimSpace(line[typeIndex+9:])
+		// The status is free text, everything else is | separated fields
+		if strings.HasPrefix(line, "**Status**:") {
+			update.Title = strings.TrimSpace(line[len("**Status**:"):])
+			continue
+		}
+		
+		for _, field := range strings.Split(line, "|") {
+			name, value, ok := strings.Cut(strings.TrimSpace(field), ":")
+			if !ok {
+				continue
 			}
-		} else if strings.HasPrefix(line, "**Status**:") {
-			update.Title = strings.TrimSpace(line[11:])
-		} else if strings.HasPrefix(line, "**Progress**:") {
-			// Parse progress change
-			progressStr := strings.TrimSpace(line[13:])
-			if arrowIndex := strings.Index(progressStr, " → "); arrowIndex >= 0 {
-				beforeStr := strings.TrimSpace(progressStr[:arrowIndex])
-				afterStr := strings.TrimSpace(progressStr[arrowIndex+3:])
-				
-				if before, err := parsePercentage(beforeStr); err == nil {
-					update.ProgressBefore = before
+			value = strings.TrimSpace(value)
+			
+			switch name {
+			case "**Author**":
+				update.Author = value
+			case "**Type**":
+				update.UpdateType = value
+			case "**Kind**":
+				if kind, err := models.ParseUpdateKind(value); err == nil {
+					update.Kind = kind
+				}
+			case "**Edited**":
+				if edited, err := time.Parse("2006-01-02 15:04", value); err == nil {
+					update.EditedAt = &edited
 				}
-				if after, err := parsePercentage(afterStr); err == nil {
-					update.ProgressAfter = after
+			case "**Progress**":
+				// Parse progress change
+				if before, after, ok := strings.Cut(value, "→"); ok {
+					if percent, err := parsePercentage(strings.TrimSpace(before)); err == nil {
+						update.ProgressBefore = percent
+					}
+					if percent, err := parsePercentage(strings.TrimSpace(after)); err == nil {
+						update.ProgressAfter = percent
+					}
 				}
 			}
-		} else if strings.HasPrefix(line, "**Tasks Completed:**") {
-			// Parse completed tasks
-			continue
-		} else if strings.HasPrefix(line, "**Tasks Added:**") {
-			// Parse added tasks
-			continue
-		} else if strings.HasPrefix(line, "- ✅") {
-			update.TasksCompleted = append(update.TasksCompleted, strings.TrimSpace(line[4:]))
-		} else if strings.HasPrefix(line, "- ➕") {
-			update.TasksAdded = append(update.TasksAdded, strings.TrimSpace(line[4:]))
-		} else if line != "" && !strings.HasPrefix(line, "**") {
-			inSummary = true
-			summaryLines = append(summaryLines, line)
-		} else if inSummary && line == "" {
-			break // End of summary
 		}
 	}
-	
-	update.Summary = strings.Join(summaryLines, "\n")
-	
-	return update
+}
+
+// nodeText returns the text of a block, trimmed: the content of a heading or paragraph, or
+// the source lines of anything else
+func nodeText(doc *parser.Document, node ast.Node) string {
+	if node.Kind() == ast.KindHeading || node.Kind() == ast.KindParagraph || node.Kind() == ast.KindTextBlock {
+		return strings.TrimSpace(string(node.Lines().Value(doc.Source)))
+	}
+	first, last := doc.NodeLines(node)
+	if first == 0 {
+		return ""
+	}
+	return strings.TrimSpace(strings.Join(doc.Lines()[first-1:last], "\n"))
 }
 
 // CreateAutomaticUpdate creates an update from Claude session completion
 func (um *UpdatesManager) CreateAutomaticUpdate(workID, sessionID string, summary string, tasksCompleted []string, progressBefore, progressAfter int) error {
 	update := &models.Update{
-		ID:             fmt.Sprintf("update-%d", time.Now().UnixNano()),
+		ID:             models.NewUpdateID(),
 		WorkID:         workID,
 		Timestamp:      time.Now(),
 		Title:          "Session Update",
@@ -294,7 +541,7 @@ func (um *UpdatesManager) CreateAutomaticUpdate(workID, sessionID string, summar
 // CreateManualUpdate creates a manual update
 func (um *UpdatesManager) CreateManualUpdate(workID, title, summary, author string) error {
 	update := &models.Update{
-		ID:         fmt.Sprintf("update-%d", time.Now().UnixNano()),
+		ID:         models.NewUpdateID(),
 		WorkID:     workID,
 		Timestamp:  time.Now(),
 		Title:      title,
@@ -0,0 +1,174 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// legacyUpdates is an updates document from before the update log, without ID markers
+const legacyUpdates = `---
+work_id: work-1
+---
+
+## Update 2024-01-02 10:00 (Session: s1)
+**Author**: Claude | **Type**: automatic
+**Progress**: 10% → 50%
+**Status**: Session Update
+
+Did things.
+
+**Tasks Completed:**
+- ✅ Task A
+
+---
+
+## Update 2024-01-01 09:00
+**Author**: me | **Type**: manual
+**Status**: Kickoff
+
+Started.
+`
+
+// writeLegacyUpdates writes an updates document without an update log beside it
+func writeLegacyUpdates(t *testing.T, content string) *UpdatesManager {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "updates"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "updates", "work-1.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return NewUpdatesManager(dir)
+}
+
+func TestLegacyUpdatesMigrateToLog(t *testing.T) {
+	um := writeLegacyUpdates(t, legacyUpdates)
+
+	before, err := um.GetUpdates("work-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(before) != 2 {
+		t.Fatalf("got %d updates, want 2", len(before))
+	}
+	session := before[0]
+	if session.Title != "Session Update" || session.SessionID != "s1" || session.Author != "Claude" ||
+		session.UpdateType != "automatic" || session.ProgressBefore != 10 || session.ProgressAfter != 50 ||
+		session.Summary != "Did things." || len(session.TasksCompleted) != 1 || session.TasksCompleted[0] != "Task A" {
+		t.Errorf("session update = %+v", session)
+	}
+	if before[1].Title != "Kickoff" || before[1].Summary != "Started." {
+		t.Errorf("manual update = %+v", before[1])
+	}
+
+	if err := um.CreateUpdate("work-1", &models.Update{Title: "New"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(um.getLogPath("work-1")); err != nil {
+		t.Fatalf("update log wasn't written: %v", err)
+	}
+
+	after, err := um.GetUpdates("work-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(after) != 3 || after[0].Title != "New" {
+		t.Fatalf("updates after the write = %v", after)
+	}
+	for i, update := range before {
+		if after[i+1].ID != update.ID {
+			t.Errorf("legacy update %q changed ID from %s to %s", update.Title, update.ID, after[i+1].ID)
+		}
+	}
+
+	document, err := os.ReadFile(um.getUpdatesPath("work-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, update := range after {
+		if !strings.Contains(string(document), "<!-- id:"+update.ID+" -->") {
+			t.Errorf("updates document is missing the marker of %s", update.ID)
+		}
+	}
+}
+
+func TestContentUpdateIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		distinct int
+	}{
+		{name: "different updates", content: legacyUpdates, distinct: 2},
+		{
+			name:     "identical updates are numbered",
+			content:  "## Update 2024-01-01 09:00\n**Author**: me\n\nSame.\n\n---\n\n## Update 2024-01-01 09:00\n**Author**: me\n\nSame.\n",
+			distinct: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			um := writeLegacyUpdates(t, tt.content)
+			first, err := um.GetUpdates("work-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := um.GetUpdates("work-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make(map[string]bool)
+			for i, update := range first {
+				if !strings.HasPrefix(update.ID, "update-") {
+					t.Errorf("ID %q isn't an update ID", update.ID)
+				}
+				if second[i].ID != update.ID {
+					t.Errorf("ID changed between reads: %s, %s", update.ID, second[i].ID)
+				}
+				ids[update.ID] = true
+			}
+			if len(ids) != tt.distinct {
+				t.Errorf("got %d distinct IDs, want %d: %v", len(ids), tt.distinct, ids)
+			}
+		})
+	}
+}
+
+func TestConcurrentUpdatesAreAllKept(t *testing.T) {
+	um := NewUpdatesManager(t.TempDir())
+
+	const writers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := um.CreateUpdate("work-1", &models.Update{Title: fmt.Sprintf("Update %d", i)}); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	updates, err := um.GetUpdates("work-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != writers {
+		t.Errorf("got %d updates, want %d", len(updates), writers)
+	}
+
+	leftovers, _ := filepath.Glob(filepath.Join(filepath.Dir(um.getLogPath("work-1")), "*.tmp"))
+	locks, _ := filepath.Glob(filepath.Join(filepath.Dir(um.getLogPath("work-1")), "*.lock"))
+	if len(leftovers)+len(locks) > 0 {
+		t.Errorf("left behind: %v %v", leftovers, locks)
+	}
+}
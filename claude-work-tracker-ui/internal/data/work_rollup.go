@@ -0,0 +1,101 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// WorkRollupChange describes what rolling a work item's children up into it changed
+type WorkRollupChange struct {
+	Rollup         models.WorkRollup
+	ProgressBefore int
+	ProgressAfter  int
+	EffortBefore   string
+	EffortAfter    string
+	StatusBefore   string
+	StatusAfter    string
+}
+
+// Changed reports whether the roll-up moved the item's progress, effort or status
+func (c WorkRollupChange) Changed() bool {
+	return c.ProgressBefore != c.ProgressAfter || c.EffortBefore != c.EffortAfter || c.StatusBefore != c.StatusAfter
+}
+
+// SyncWorkRollup rolls the children of a work item up into it. Progress follows the
+// effort-weighted progress of the children unless it comes from the item's own checklist,
+// the effort estimate grows to cover the children's, and an item that hasn't started moves
+// to in progress once a child has. Items without children are left alone.
+func SyncWorkRollup(work *models.Work, tree *models.WorkTree) WorkRollupChange {
+	change := WorkRollupChange{
+		ProgressBefore: work.Metadata.ProgressPercent,
+		EffortBefore:   work.Metadata.EstimatedEffort,
+		StatusBefore:   work.Metadata.Status,
+	}
+
+	rollup, ok := tree.Rollup(work.ID)
+	change.Rollup = rollup
+	if ok && !work.IsClosed() {
+		if !work.Metadata.ProgressFromTasks {
+			work.Metadata.ProgressPercent = rollup.Progress
+		}
+		if rollup.EffortDays > work.EffortDays() {
+			work.Metadata.EstimatedEffort = rollup.Effort
+		}
+		if rollup.InProgress && (work.Metadata.Status == models.WorkStatusDraft || work.Metadata.Status == models.WorkStatusActive) {
+			now := time.Now()
+			work.Metadata.Status = models.WorkStatusInProgress
+			if work.StartedAt == nil {
+				work.StartedAt = &now
+			}
+			work.RecordTransition(models.Transition{
+				From:   change.StatusBefore,
+				To:     work.Metadata.Status,
+				Reason: "A child work item is in progress",
+				Actor:  models.TransitionActorAutomation,
+			})
+		}
+	}
+
+	change.ProgressAfter = work.Metadata.ProgressPercent
+	change.EffortAfter = work.Metadata.EstimatedEffort
+	change.StatusAfter = work.Metadata.Status
+	return change
+}
+
+// WorkRollupUpdate returns the automatic update recording what a roll-up changed
+func WorkRollupUpdate(work *models.Work, change WorkRollupChange) *models.Update {
+	now := time.Now()
+	var parts []string
+	if change.ProgressBefore != change.ProgressAfter {
+		parts = append(parts, fmt.Sprintf("progress %d%% → %d%%", change.ProgressBefore, change.ProgressAfter))
+	}
+	if change.EffortBefore != change.EffortAfter {
+		parts = append(parts, fmt.Sprintf("effort %s → %s", displayEffort(change.EffortBefore), change.EffortAfter))
+	}
+	if change.StatusBefore != change.StatusAfter {
+		parts = append(parts, fmt.Sprintf("status %s → %s", change.StatusBefore, change.StatusAfter))
+	}
+
+	return &models.Update{
+		ID:             models.NewUpdateID(),
+		WorkID:         work.ID,
+		Timestamp:      now,
+		Title:          "Child work rolled up",
+		Summary:        fmt.Sprintf("Rolled up from child work: %s (%d of %d done, %d open below)", strings.Join(parts, ", "), change.Rollup.Completed, change.Rollup.Children, change.Rollup.Open),
+		Author:         models.CurrentActor(),
+		UpdateType:     "automatic",
+		ProgressBefore: change.ProgressBefore,
+		ProgressAfter:  change.ProgressAfter,
+	}
+}
+
+// displayEffort names an effort estimate, including the unset one
+func displayEffort(effort string) string {
+	if effort == "" {
+		return "unset"
+	}
+	return effort
+}
@@ -0,0 +1,98 @@
+package data
+
+import (
+	"testing"
+
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/models/modeltest"
+)
+
+func TestSyncWorkRollup(t *testing.T) {
+	tests := []struct {
+		name         string
+		parent       *models.Work
+		children     []*models.Work
+		wantProgress int
+		wantEffort   string
+		wantStatus   string
+		wantChanged  bool
+	}{
+		{
+			name:   "progress and status follow the children",
+			parent: modeltest.Work("epic", "", models.WorkStatusActive, "", 0),
+			children: []*models.Work{
+				modeltest.Work("a", "epic", models.WorkStatusInProgress, models.WorkEffortMedium, 60), // 10 days
+				modeltest.Work("b", "epic", models.WorkStatusActive, models.WorkEffortSmall, 0),       // 2 days
+			},
+			wantProgress: 50,
+			wantEffort:   models.WorkEffortLarge, // An unset estimate counts as medium
+			wantStatus:   models.WorkStatusInProgress,
+			wantChanged:  true,
+		},
+		{
+			name:   "a larger estimate is kept",
+			parent: modeltest.Work("epic", "", models.WorkStatusActive, models.WorkEffortLarge, 0),
+			children: []*models.Work{
+				modeltest.Work("a", "epic", models.WorkStatusCompleted, models.WorkEffortSmall, 100),
+			},
+			wantProgress: 100,
+			wantEffort:   models.WorkEffortLarge,
+			wantStatus:   models.WorkStatusActive,
+			wantChanged:  true,
+		},
+		{
+			name: "checklist progress is left alone",
+			parent: func() *models.Work {
+				work := modeltest.Work("epic", "", models.WorkStatusInProgress, models.WorkEffortSmall, 30)
+				work.Metadata.ProgressFromTasks = true
+				return work
+			}(),
+			children: []*models.Work{
+				modeltest.Work("a", "epic", models.WorkStatusCompleted, models.WorkEffortSmall, 100),
+			},
+			wantProgress: 30,
+			wantEffort:   models.WorkEffortSmall,
+			wantStatus:   models.WorkStatusInProgress,
+		},
+		{
+			name:   "closed items are left alone",
+			parent: modeltest.Work("epic", "", models.WorkStatusCompleted, "", 100),
+			children: []*models.Work{
+				modeltest.Work("a", "epic", models.WorkStatusInProgress, models.WorkEffortLarge, 10),
+			},
+			wantProgress: 100,
+			wantStatus:   models.WorkStatusCompleted,
+		},
+		{
+			name:         "no children",
+			parent:       modeltest.Work("epic", "", models.WorkStatusActive, "", 20),
+			wantProgress: 20,
+			wantStatus:   models.WorkStatusActive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := models.NewWorkTree(append([]*models.Work{tt.parent}, tt.children...))
+			change := SyncWorkRollup(tt.parent, tree)
+
+			if tt.parent.Metadata.ProgressPercent != tt.wantProgress {
+				t.Errorf("progress = %d%%, want %d%%", tt.parent.Metadata.ProgressPercent, tt.wantProgress)
+			}
+			if tt.parent.Metadata.EstimatedEffort != tt.wantEffort {
+				t.Errorf("effort = %q, want %q", tt.parent.Metadata.EstimatedEffort, tt.wantEffort)
+			}
+			if tt.parent.Metadata.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", tt.parent.Metadata.Status, tt.wantStatus)
+			}
+			if change.Changed() != tt.wantChanged {
+				t.Errorf("changed = %v, want %v: %+v", change.Changed(), tt.wantChanged, change)
+			}
+
+			started := change.StatusBefore != change.StatusAfter
+			if last := tt.parent.LastTransition(); started != (last != nil) {
+				t.Errorf("transition recorded = %+v, status moved %v", last, started)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// BranchStatus describes what has happened to a work item's branch
+type BranchStatus string
+
+const (
+	BranchActive  BranchStatus = "active"
+	BranchMerged  BranchStatus = "merged"
+	BranchDeleted BranchStatus = "deleted"
+)
+
+// Worktree describes the checkout a directory belongs to
+type Worktree struct {
+	Root     string // Top level of the checkout
+	MainRoot string // Top level of the main checkout, the same as Root unless Linked
+	Branch   string // Checked out branch, empty when HEAD is detached
+	Linked   bool   // True for worktrees added with git worktree add
+}
+
+// CurrentWorktree returns the checkout containing dir
+func (cm *ContextManager) CurrentWorktree(ctx context.Context, dir string) (*Worktree, error) {
+	output, err := cm.execGitCommand(ctx, dir, "rev-parse", "--show-toplevel", "--absolute-git-dir", "--git-common-dir")
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a git repository", dir)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) < 3 {
+		return nil, fmt.Errorf("unexpected git rev-parse output: %q", output)
+	}
+
+	commonDir := lines[2]
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(lines[0], commonDir)
+	}
+	worktree := &Worktree{
+		Root:     lines[0],
+		MainRoot: filepath.Dir(filepath.Clean(commonDir)),
+		Linked:   filepath.Clean(lines[1]) != filepath.Clean(commonDir),
+	}
+	if branch, err := cm.execGitCommand(ctx, dir, "symbolic-ref", "--short", "-q", "HEAD"); err == nil {
+		worktree.Branch = strings.TrimSpace(branch)
+	}
+	return worktree, nil
+}
+
+// FindBranchWork returns the open work item that belongs to a checkout: the item created in
+// that worktree, else the item recorded on its branch, else one whose ID the branch names.
+// Nothing is returned on the default branch, where most work starts out.
+func FindBranchWork(works []*models.Work, worktree *Worktree, defaultBranch string) *models.Work {
+	if worktree == nil {
+		return nil
+	}
+
+	var byBranch, byName *models.Work
+	for _, work := range works {
+		if work.IsClosed() {
+			continue
+		}
+		if worktree.Linked && work.GitContext.Worktree != "" &&
+			filepath.Clean(work.GitContext.Worktree) == filepath.Clean(worktree.Root) {
+			return work
+		}
+		if worktree.Branch == "" || worktree.Branch == defaultBranch {
+			continue
+		}
+		if byBranch == nil && work.GitContext.Branch == worktree.Branch {
+			byBranch = work
+		} else if byName == nil && BranchBelongsTo(worktree.Branch, work) {
+			byName = work
+		}
+	}
+	if byBranch != nil {
+		return byBranch
+	}
+	return byName
+}
+
+// branchSlugChars matches runs of characters that don't belong in a branch name
+var branchSlugChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// WorkBranchName returns the branch name used for a work item, work/<id>
+func WorkBranchName(work *models.Work) string {
+	slug := strings.Trim(branchSlugChars.ReplaceAllString(strings.ToLower(work.ID), "-"), "-.")
+	return "work/" + slug
+}
+
+// WorktreePath returns where a worktree for a branch is created: next to the repository,
+// as <repo>-<branch>
+func WorktreePath(root, branch string) string {
+	name := strings.ReplaceAll(branch, "/", "-")
+	return filepath.Join(filepath.Dir(root), filepath.Base(root)+"-"+name)
+}
+
+// CreateBranch creates a branch from base and checks it out in dir
+func (cm *ContextManager) CreateBranch(ctx context.Context, dir, branch, base string) error {
+	args := []string{"switch", "-c", branch}
+	if base != "" {
+		args = append(args, base)
+	}
+	if err := runGitVerbose(ctx, dir, args...); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// AddWorktree creates a branch from base checked out in a new worktree at path
+func (cm *ContextManager) AddWorktree(ctx context.Context, dir, path, branch, base string) error {
+	args := []string{"worktree", "add", "-b", branch, path}
+	if base != "" {
+		args = append(args, base)
+	}
+	if err := runGitVerbose(ctx, dir, args...); err != nil {
+		return fmt.Errorf("failed to create worktree %s: %w", path, err)
+	}
+	return nil
+}
+
+// BranchStatus reports whether a work item's branch still exists and whether it has been
+// merged into the default branch. A branch only counts as merged once it has commits of its
+// own, so a fresh branch that nobody committed to isn't flagged.
+func (cm *ContextManager) BranchStatus(ctx context.Context, dir string, work *models.Work, defaultBranch string) BranchStatus {
+	branch := work.GitContext.Branch
+	if branch == "" || branch == defaultBranch || branch == "HEAD" {
+		return BranchActive
+	}
+
+	ref, ok := cm.branchRef(ctx, dir, branch)
+	if !ok {
+		return BranchDeleted
+	}
+	if defaultBranch == "" {
+		return BranchActive
+	}
+
+	tip, err := cm.execGitCommand(ctx, dir, "rev-parse", ref)
+	if err != nil {
+		return BranchActive
+	}
+	base, err := cm.execGitCommand(ctx, dir, "rev-parse", defaultBranch)
+	if err != nil || strings.TrimSpace(tip) == strings.TrimSpace(base) {
+		return BranchActive
+	}
+	if _, err := cm.execGitCommand(ctx, dir, "merge-base", "--is-ancestor", ref, defaultBranch); err != nil {
+		return BranchActive
+	}
+
+	// A branch with no commits of its own still points at the commit it was created from
+	if reflog, err := cm.execGitCommand(ctx, dir, "reflog", "show", "--format=%H", ref); err == nil {
+		if entries := strings.Fields(reflog); len(entries) > 0 {
+			if entries[len(entries)-1] == strings.TrimSpace(tip) {
+				return BranchActive
+			}
+			return BranchMerged
+		}
+	}
+
+	// Without a reflog, fall back to whether the tip was committed after the work started
+	committed, err := cm.execGitCommand(ctx, dir, "log", "-1", "--format=%ct", ref)
+	if err != nil {
+		return BranchActive
+	}
+	seconds, _ := strconv.ParseInt(strings.TrimSpace(committed), 10, 64)
+	started := work.CreatedAt
+	if work.StartedAt != nil {
+		started = *work.StartedAt
+	}
+	if time.Unix(seconds, 0).Before(started) {
+		return BranchActive
+	}
+	return BranchMerged
+}
+
+// DetectMerges records when each open work item's branch was merged into the default
+// branch, and returns the items newly marked as merged
+func (cm *ContextManager) DetectMerges(ctx context.Context, dir string, works []*models.Work) []*models.Work {
+	defaultBranch := cm.DefaultBranch(ctx, dir)
+	if defaultBranch == "" {
+		return nil
+	}
+
+	var merged []*models.Work
+	for _, work := range works {
+		if work.IsClosed() || work.BranchMergedAt != nil || work.GitContext.Branch == "" {
+			continue
+		}
+		if cm.BranchStatus(ctx, dir, work, defaultBranch) != BranchMerged {
+			continue
+		}
+		mergedAt := cm.mergeTime(ctx, dir, work.GitContext.Branch, defaultBranch)
+		work.BranchMergedAt = &mergedAt
+		merged = append(merged, work)
+	}
+	return merged
+}
+
+// mergeTime returns when a merged branch landed: the first default branch commit built on
+// the branch tip, or the tip itself after a fast-forward
+func (cm *ContextManager) mergeTime(ctx context.Context, dir, branch, defaultBranch string) time.Time {
+	ref, ok := cm.branchRef(ctx, dir, branch)
+	if !ok {
+		return time.Now()
+	}
+	output, err := cm.execGitCommand(ctx, dir, "log", "--ancestry-path", "--reverse", "--format=%ct", ref+".."+defaultBranch)
+	if err != nil || strings.TrimSpace(output) == "" {
+		output, err = cm.execGitCommand(ctx, dir, "log", "-1", "--format=%ct", ref)
+	}
+	fields := strings.Fields(output)
+	if err != nil || len(fields) == 0 {
+		return time.Now()
+	}
+	seconds, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return time.Now()
+	}
+	return time.Unix(seconds, 0)
+}
+
+// branchRef resolves a branch to its local ref, or to origin's copy when it only exists there
+func (cm *ContextManager) branchRef(ctx context.Context, dir, branch string) (string, bool) {
+	for _, ref := range []string{"refs/heads/" + branch, "refs/remotes/origin/" + branch} {
+		if _, err := cm.execGitCommand(ctx, dir, "rev-parse", "--verify", "--quiet", ref); err == nil {
+			return ref, true
+		}
+	}
+	return "", false
+}
+
+// runGitVerbose runs a git command that changes the repository, returning git's own
+// message when it fails
+func runGitVerbose(ctx context.Context, dir string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if message := strings.TrimSpace(string(output)); message != "" {
+			return fmt.Errorf("%s", message)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package git
+
+import (
+	"context"
+	"testing"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+func TestFindBranchWork(t *testing.T) {
+	works := []*models.Work{
+		{ID: "work-1111", Title: "Closed", GitContext: models.GitContext{Branch: "feature"}, Metadata: models.WorkMetadata{Status: models.WorkStatusCompleted}},
+		{ID: "work-2222", Title: "Named"},
+		{ID: "work-3333", Title: "Recorded", GitContext: models.GitContext{Branch: "feature"}},
+		{ID: "work-4444", Title: "Worktree", GitContext: models.GitContext{Worktree: "/src/repo-work-4444"}},
+	}
+
+	tests := []struct {
+		name     string
+		worktree *Worktree
+		want     string // Title of the work found, empty for none
+	}{
+		{name: "no checkout"},
+		{name: "default branch", worktree: &Worktree{Root: "/src/repo", Branch: "main"}},
+		{name: "detached HEAD", worktree: &Worktree{Root: "/src/repo"}},
+		{name: "recorded branch beats a closed item", worktree: &Worktree{Root: "/src/repo", Branch: "feature"}, want: "Recorded"},
+		{name: "branch named after the work", worktree: &Worktree{Root: "/src/repo", Branch: "work/work-2222"}, want: "Named"},
+		{name: "branch naming a longer ID", worktree: &Worktree{Root: "/src/repo", Branch: "work/work-22223"}},
+		{name: "linked worktree", worktree: &Worktree{Root: "/src/repo-work-4444/", Branch: "feature", Linked: true}, want: "Worktree"},
+		{name: "main checkout at the worktree path", worktree: &Worktree{Root: "/src/repo-work-4444", Branch: "main"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindBranchWork(works, tt.worktree, "main")
+			title := ""
+			if got != nil {
+				title = got.Title
+			}
+			if title != tt.want {
+				t.Errorf("found %q, want %q", title, tt.want)
+			}
+		})
+	}
+}
+
+// branchRepo returns a repository with a commit on main and a branch for each scenario:
+// work/merged was committed to and merged, work/open was committed to and not merged, and
+// work/fresh was created without commits of its own
+func branchRepo(t *testing.T) *testRepo {
+	t.Helper()
+	repo := newTestRepo(t)
+	repo.commit("README.md", "Initial commit")
+
+	repo.git("switch", "--quiet", "-c", "work/merged")
+	repo.commit("merged.txt", "Merged change")
+	repo.git("switch", "--quiet", "main")
+	repo.git("merge", "--quiet", "--no-ff", "-m", "Merge work/merged", "work/merged")
+
+	repo.git("switch", "--quiet", "-c", "work/open")
+	repo.commit("open.txt", "Open change")
+	repo.git("switch", "--quiet", "main")
+
+	repo.git("branch", "work/fresh")
+	return repo
+}
+
+func TestBranchStatus(t *testing.T) {
+	repo := branchRepo(t)
+	cm := NewContextManager()
+
+	tests := []struct {
+		branch string
+		want   BranchStatus
+	}{
+		{"", BranchActive},
+		{"main", BranchActive},
+		{"work/merged", BranchMerged},
+		{"work/open", BranchActive},
+		{"work/fresh", BranchActive},
+		{"work/gone", BranchDeleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			work := &models.Work{ID: "work-1234", GitContext: models.GitContext{Branch: tt.branch}}
+			if got := cm.BranchStatus(context.Background(), repo.dir, work, "main"); got != tt.want {
+				t.Errorf("status = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectMerges(t *testing.T) {
+	repo := branchRepo(t)
+	cm := NewContextManager()
+
+	merged := &models.Work{ID: "work-1111", GitContext: models.GitContext{Branch: "work/merged"}}
+	open := &models.Work{ID: "work-2222", GitContext: models.GitContext{Branch: "work/open"}}
+	fresh := &models.Work{ID: "work-3333", GitContext: models.GitContext{Branch: "work/fresh"}}
+	closed := &models.Work{ID: "work-4444", GitContext: models.GitContext{Branch: "work/merged"}, Metadata: models.WorkMetadata{Status: models.WorkStatusCompleted}}
+	works := []*models.Work{merged, open, fresh, closed}
+
+	found := cm.DetectMerges(context.Background(), repo.dir, works)
+	if len(found) != 1 || found[0] != merged {
+		t.Fatalf("merges found for %d items, want only %s", len(found), merged.ID)
+	}
+	if merged.BranchMergedAt == nil || merged.BranchMergedAt.IsZero() {
+		t.Error("merge time not recorded")
+	}
+	for _, work := range []*models.Work{open, fresh, closed} {
+		if work.BranchMergedAt != nil {
+			t.Errorf("%s marked merged", work.ID)
+		}
+	}
+
+	// A merge is only reported once
+	if again := cm.DetectMerges(context.Background(), repo.dir, works); len(again) != 0 {
+		t.Errorf("second pass found %d merges, want none", len(again))
+	}
+}
+
+func TestScanLinksBranchCommits(t *testing.T) {
+	repo := newTestRepo(t)
+	repo.commit("README.md", "Initial commit")
+	repo.git("switch", "--quiet", "-c", "work/work-1234")
+	repo.commit("login.go", "Add login redirect")
+	repo.git("switch", "--quiet", "-c", "feature")
+	repo.commit("logout.go", "Add logout")
+	repo.git("switch", "--quiet", "main")
+
+	named := &models.Work{ID: "work-1234"}
+	recorded := &models.Work{ID: "work-5678", GitContext: models.GitContext{Branch: "feature"}}
+
+	got := linkSummary(repo.scan([]*models.Work{named, recorded}))
+	want := []string{
+		"work-1234 Add login redirect " + models.CommitMatchBranch,
+		"work-5678 Add login redirect " + models.CommitMatchBranch,
+		"work-5678 Add logout " + models.CommitMatchBranch,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("links = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("link %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
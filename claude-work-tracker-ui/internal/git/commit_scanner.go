@@ -0,0 +1,346 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// Commit is one entry from git log
+type Commit struct {
+	SHA       string
+	Author    string
+	Timestamp time.Time
+	Subject   string
+	Body      string
+	Files     []string
+}
+
+// Message returns the full commit message
+func (c Commit) Message() string {
+	if c.Body == "" {
+		return c.Subject
+	}
+	return c.Subject + "\n\n" + c.Body
+}
+
+// CommitLink attributes a commit to a work item
+type CommitLink struct {
+	WorkID    string
+	Commit    Commit
+	MatchedBy string // models.CommitMatchID, CommitMatchTrailer, CommitMatchBranch or CommitMatchFile
+}
+
+// LinkedCommit converts the link into the form stored on the work item
+func (l CommitLink) LinkedCommit() models.LinkedCommit {
+	return models.LinkedCommit{
+		SHA:       l.Commit.SHA,
+		Author:    l.Commit.Author,
+		Subject:   l.Commit.Subject,
+		Timestamp: l.Commit.Timestamp,
+		MatchedBy: l.MatchedBy,
+	}
+}
+
+// ScanConfig controls how far back the commit scanner looks
+type ScanConfig struct {
+	Since      time.Time // Ignore commits before this, zero scans all history
+	MaxCommits int       // Cap on commits read per git log call
+	MatchFiles bool      // Link commits touching files the work mentions
+}
+
+// DefaultScanConfig returns the default scan settings: the last 90 days, file matching on
+func DefaultScanConfig() *ScanConfig {
+	return &ScanConfig{
+		Since:      time.Now().AddDate(0, 0, -90),
+		MaxCommits: 2000,
+		MatchFiles: true,
+	}
+}
+
+// CommitScanner links commits in a repository to work items
+type CommitScanner struct {
+	cm     *ContextManager
+	dir    string
+	config *ScanConfig
+}
+
+// NewCommitScanner creates a scanner for the repository containing dir
+func NewCommitScanner(cm *ContextManager, dir string, config *ScanConfig) *CommitScanner {
+	if config == nil {
+		config = DefaultScanConfig()
+	}
+	return &CommitScanner{
+		cm:     cm,
+		dir:    dir,
+		config: config,
+	}
+}
+
+// Field and record separators for git log output
+const (
+	logFieldSep  = "\x1f"
+	logRecordSep = "\x1e"
+)
+
+// Log reads commits reachable from the given revisions, newest first. Without revisions
+// it reads every branch.
+func (cm *ContextManager) Log(ctx context.Context, dir string, since time.Time, limit int, revisions ...string) ([]Commit, error) {
+	args := []string{"log", "--name-only", "--format=" + logRecordSep + "%H" + logFieldSep + "%an" + logFieldSep + "%at" + logFieldSep + "%s" + logFieldSep + "%b" + logFieldSep}
+	if limit > 0 {
+		args = append(args, fmt.Sprintf("--max-count=%d", limit))
+	}
+	if !since.IsZero() {
+		args = append(args, fmt.Sprintf("--since=%d", since.Unix()))
+	}
+	if len(revisions) == 0 {
+		args = append(args, "--all")
+	}
+	args = append(args, revisions...)
+
+	output, err := cm.execGitCommand(ctx, dir, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git log: %w", err)
+	}
+
+	var commits []Commit
+	for _, record := range strings.Split(output, logRecordSep) {
+		fields := strings.SplitN(record, logFieldSep, 6)
+		if len(fields) < 6 {
+			continue
+		}
+		timestamp, _ := strconv.ParseInt(fields[2], 10, 64)
+		commit := Commit{
+			SHA:       fields[0],
+			Author:    fields[1],
+			Timestamp: time.Unix(timestamp, 0),
+			Subject:   fields[3],
+			Body:      strings.TrimSpace(fields[4]),
+		}
+		for _, file := range strings.Split(fields[5], "\n") {
+			if file = strings.TrimSpace(file); file != "" {
+				commit.Files = append(commit.Files, file)
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// DefaultBranch returns the repository's main branch: origin's HEAD, else main or master
+func (cm *ContextManager) DefaultBranch(ctx context.Context, dir string) string {
+	if ref, err := cm.execGitCommand(ctx, dir, "symbolic-ref", "--short", "refs/remotes/origin/HEAD"); err == nil {
+		return strings.TrimPrefix(strings.TrimSpace(ref), "origin/")
+	}
+	for _, branch := range []string{"main", "master"} {
+		if _, err := cm.execGitCommand(ctx, dir, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+			return branch
+		}
+	}
+	return ""
+}
+
+// Branches returns the local branch names
+func (cm *ContextManager) Branches(ctx context.Context, dir string) ([]string, error) {
+	output, err := cm.execGitCommand(ctx, dir, "for-each-ref", "--format=%(refname:short)", "refs/heads")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+	return strings.Fields(output), nil
+}
+
+// Scan matches commits to work items by Work: trailer, by work ID in the message, by branch,
+// and by files the work mentions. Commits already linked to a work item are skipped.
+func (s *CommitScanner) Scan(ctx context.Context, works []*models.Work) ([]CommitLink, error) {
+	root, err := s.cm.execGitCommand(ctx, s.dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a git repository", s.dir)
+	}
+	root = strings.TrimSpace(root)
+
+	commits, err := s.cm.Log(ctx, root, s.config.Since, s.config.MaxCommits)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []CommitLink
+	linked := make(map[string]bool) // work ID + SHA
+	add := func(work *models.Work, commit Commit, matchedBy string) {
+		key := work.ID + ":" + commit.SHA
+		if linked[key] || work.HasCommit(commit.SHA) {
+			return
+		}
+		linked[key] = true
+		links = append(links, CommitLink{WorkID: work.ID, Commit: commit, MatchedBy: matchedBy})
+	}
+
+	// Work: <id> trailers
+	for _, commit := range commits {
+		trailers := ParseTrailers(commit.Message())
+		for _, work := range works {
+			if trailers.NamesWork(work.ID) {
+				add(work, commit, models.CommitMatchTrailer)
+			}
+		}
+	}
+
+	// Work IDs in commit messages
+	for _, work := range works {
+		pattern := workIDPattern(work.ID)
+		if pattern == nil {
+			continue
+		}
+		for _, commit := range commits {
+			if pattern.MatchString(commit.Message()) {
+				add(work, commit, models.CommitMatchID)
+			}
+		}
+	}
+
+	// Commits made on the work's branch but not yet on the default branch
+	if err := s.scanBranches(ctx, root, works, add); err != nil {
+		return links, err
+	}
+
+	// Commits touching files the work mentions, while the work was open
+	if s.config.MatchFiles {
+		for _, work := range works {
+			files := mentionedFiles(root, work)
+			if len(files) == 0 {
+				continue
+			}
+			for _, commit := range commits {
+				if commit.Timestamp.Before(work.CreatedAt) ||
+					(work.CompletedAt != nil && commit.Timestamp.After(*work.CompletedAt)) {
+					continue
+				}
+				if touchesAny(commit, files) {
+					add(work, commit, models.CommitMatchFile)
+				}
+			}
+		}
+	}
+
+	return links, nil
+}
+
+// scanBranches links commits on branches that belong to a work item: its recorded branch,
+// or any branch whose name contains the work ID
+func (s *CommitScanner) scanBranches(ctx context.Context, root string, works []*models.Work, add func(*models.Work, Commit, string)) error {
+	branches, err := s.cm.Branches(ctx, root)
+	if err != nil {
+		return err
+	}
+	defaultBranch := s.cm.DefaultBranch(ctx, root)
+
+	for _, branch := range branches {
+		if branch == defaultBranch {
+			continue
+		}
+		for _, work := range works {
+			if !BranchBelongsTo(branch, work) {
+				continue
+			}
+			revisions := []string{branch}
+			if defaultBranch != "" {
+				revisions = []string{defaultBranch + ".." + branch}
+			}
+			commits, err := s.cm.Log(ctx, root, s.config.Since, s.config.MaxCommits, revisions...)
+			if err != nil {
+				return err
+			}
+			for _, commit := range commits {
+				add(work, commit, models.CommitMatchBranch)
+			}
+		}
+	}
+	return nil
+}
+
+// BranchBelongsTo reports whether a branch is the work's recorded branch or is named after the work
+func BranchBelongsTo(branch string, work *models.Work) bool {
+	if work.GitContext.Branch != "" && work.GitContext.Branch == branch {
+		return true
+	}
+	pattern := workIDPattern(work.ID)
+	return pattern != nil && pattern.MatchString(branch)
+}
+
+// workIDPattern matches a work ID as a whole word, or nil for IDs too short to match safely
+func workIDPattern(id string) *regexp.Regexp {
+	if len(id) < 4 {
+		return nil
+	}
+	return regexp.MustCompile(`(^|[^A-Za-z0-9_-])` + regexp.QuoteMeta(id) + `($|[^A-Za-z0-9_-])`)
+}
+
+// filePathPattern finds path-like tokens such as internal/git/context_manager.go or README.md
+var filePathPattern = regexp.MustCompile("[A-Za-z0-9_.-]+(?:/[A-Za-z0-9_.-]+)*\\.[A-Za-z0-9]+")
+
+// mentionedFiles returns repository files named in a work's description or body
+func mentionedFiles(root string, work *models.Work) []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, token := range filePathPattern.FindAllString(work.Description+"\n"+work.Content, -1) {
+		token = strings.TrimPrefix(token, "./")
+		if seen[token] {
+			continue
+		}
+		seen[token] = true
+		if info, err := os.Stat(filepath.Join(root, token)); err == nil && !info.IsDir() {
+			files = append(files, token)
+		}
+	}
+	return files
+}
+
+// touchesAny reports whether a commit changed any of the files
+func touchesAny(commit Commit, files []string) bool {
+	for _, changed := range commit.Files {
+		for _, file := range files {
+			if changed == file {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ApplyLinks stores linked commits on their work items, moves each item's last activity up
+// to its newest commit, and applies Progress and Completes-Task trailers. It returns the
+// items that changed and an automatic update for each commit whose trailers changed one.
+func ApplyLinks(works []*models.Work, links []CommitLink) ([]*models.Work, []*models.Update) {
+	byID := make(map[string]*models.Work, len(works))
+	for _, work := range works {
+		byID[work.ID] = work
+	}
+
+	changed := make(map[string]bool)
+	var result []*models.Work
+	var applied []CommitLink
+	for _, link := range links {
+		work, ok := byID[link.WorkID]
+		if !ok || !work.LinkCommit(link.LinkedCommit()) {
+			continue
+		}
+		applied = append(applied, link)
+		committedAt := link.Commit.Timestamp
+		if work.Metadata.LastActivityAt == nil || committedAt.After(*work.Metadata.LastActivityAt) {
+			work.Metadata.LastActivityAt = &committedAt
+		}
+		if !changed[work.ID] {
+			changed[work.ID] = true
+			result = append(result, work)
+		}
+	}
+
+	// Only commits linked just now, so a commit's trailers are applied once
+	return result, applyTrailers(byID, applied)
+}
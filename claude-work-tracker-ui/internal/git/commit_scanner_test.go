@@ -0,0 +1,213 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// testRepo is a throwaway repository on branch main
+type testRepo struct {
+	t   *testing.T
+	dir string
+}
+
+// newTestRepo creates an empty repository with a fixed committer identity
+func newTestRepo(t *testing.T) *testRepo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "Dev")
+	t.Setenv("GIT_AUTHOR_EMAIL", "dev@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Dev")
+	t.Setenv("GIT_COMMITTER_EMAIL", "dev@example.com")
+
+	repo := &testRepo{t: t, dir: t.TempDir()}
+	repo.git("init", "--quiet", "-b", "main")
+	return repo
+}
+
+// git runs a git command in the repository and returns its trimmed output
+func (r *testRepo) git(args ...string) string {
+	r.t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		r.t.Fatalf("git %s: %s: %v", strings.Join(args, " "), out, err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// commit writes a file and commits it with the message, returning the new SHA
+func (r *testRepo) commit(file, message string) string {
+	r.t.Helper()
+	path := filepath.Join(r.dir, file)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		r.t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(message), 0644); err != nil {
+		r.t.Fatal(err)
+	}
+	r.git("add", "--", file)
+	r.git("commit", "--quiet", "-m", message)
+	return r.git("rev-parse", "HEAD")
+}
+
+// scan links the repository's commits to the work items
+func (r *testRepo) scan(works []*models.Work) []CommitLink {
+	r.t.Helper()
+	links, err := NewCommitScanner(NewContextManager(), r.dir, &ScanConfig{MaxCommits: 100}).Scan(context.Background(), works)
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	return links
+}
+
+// linkSummary lists links as "<work ID> <subject> <matched by>", sorted
+func linkSummary(links []CommitLink) []string {
+	var summary []string
+	for _, link := range links {
+		summary = append(summary, link.WorkID+" "+link.Commit.Subject+" "+link.MatchedBy)
+	}
+	sort.Strings(summary)
+	return summary
+}
+
+func TestScanLinksCommitsByWorkID(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []string
+	}{
+		{
+			name:    "ID in the subject",
+			message: "Fix work-1234 login redirect",
+			want:    []string{"work-1234 Fix work-1234 login redirect " + models.CommitMatchID},
+		},
+		{
+			name:    "ID in the body",
+			message: "Fix login redirect\n\nPart of work-1234.",
+			want:    []string{"work-1234 Fix login redirect " + models.CommitMatchID},
+		},
+		{
+			name:    "Work trailer",
+			message: "Fix login redirect\n\nWork: work-1234",
+			want:    []string{"work-1234 Fix login redirect " + models.CommitMatchTrailer},
+		},
+		{
+			name:    "ID of both items",
+			message: "Share the session between work-1234 and work-5678",
+			want: []string{
+				"work-1234 Share the session between work-1234 and work-5678 " + models.CommitMatchID,
+				"work-5678 Share the session between work-1234 and work-5678 " + models.CommitMatchID,
+			},
+		},
+		{
+			name:    "unknown ID",
+			message: "Fix work-9999 logout",
+		},
+		{
+			name:    "ID inside a longer ID",
+			message: "Fix work-12345 and work-1234-b",
+		},
+		{
+			name:    "no ID",
+			message: "Tidy up",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepo(t)
+			repo.commit("notes.txt", tt.message)
+			works := []*models.Work{{ID: "work-1234"}, {ID: "work-5678"}, {ID: "w-1"}}
+
+			got := linkSummary(repo.scan(works))
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("links = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanLinksEachCommitOnce(t *testing.T) {
+	repo := newTestRepo(t)
+	first := repo.commit("a.txt", "Start work-1234\n\nWork: work-1234\nProgress: 30%")
+	work := &models.Work{ID: "work-1234"}
+
+	links := repo.scan([]*models.Work{work})
+	if len(links) != 1 || links[0].Commit.SHA != first {
+		t.Fatalf("first scan links = %q, want one link to %s", linkSummary(links), first)
+	}
+	changed, updates := ApplyLinks([]*models.Work{work}, links)
+	if len(changed) != 1 || len(updates) != 1 || work.Metadata.ProgressPercent != 30 {
+		t.Fatalf("first apply changed %d items with %d updates, progress %d%%", len(changed), len(updates), work.Metadata.ProgressPercent)
+	}
+
+	// A rescan only finds the new commit, and applying old links again changes nothing
+	second := repo.commit("b.txt", "More on work-1234")
+	links = repo.scan([]*models.Work{work})
+	if len(links) != 1 || links[0].Commit.SHA != second {
+		t.Fatalf("rescan links = %q, want one link to %s", linkSummary(links), second)
+	}
+	changed, updates = ApplyLinks([]*models.Work{work}, append(links, CommitLink{WorkID: work.ID, Commit: Commit{SHA: first}, MatchedBy: models.CommitMatchTrailer}))
+	if len(changed) != 1 || len(updates) != 0 {
+		t.Errorf("rescan apply changed %d items with %d updates, want 1 and 0", len(changed), len(updates))
+	}
+	if len(work.Commits) != 2 || !work.HasCommit(first) || !work.HasCommit(second) {
+		t.Errorf("commits = %+v, want both", work.Commits)
+	}
+
+	if links := repo.scan([]*models.Work{work}); len(links) != 0 {
+		t.Errorf("scan with nothing new links %q", linkSummary(links))
+	}
+}
+
+func TestApplyLinksSkipsUnknownWork(t *testing.T) {
+	work := &models.Work{ID: "work-1234"}
+	links := []CommitLink{{WorkID: "work-9999", Commit: Commit{SHA: "abc", Subject: "Fix work-9999"}, MatchedBy: models.CommitMatchID}}
+
+	changed, updates := ApplyLinks([]*models.Work{work}, links)
+	if len(changed) != 0 || len(updates) != 0 || len(work.Commits) != 0 {
+		t.Errorf("changed %d items with %d updates, want none", len(changed), len(updates))
+	}
+}
+
+func TestWorkIDPattern(t *testing.T) {
+	tests := []struct {
+		id   string
+		text string
+		want bool
+	}{
+		{"work-1234", "work-1234", true},
+		{"work-1234", "(work-1234)", true},
+		{"work-1234", "work/work-1234", true},
+		{"work-1234", "fixes work-1234.", true},
+		{"work-1234", "work-12345", false},
+		{"work-1234", "my-work-1234", false},
+		{"work-1234", "work-1234_b", false},
+		{"work.1234", "workx1234", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id+" in "+tt.text, func(t *testing.T) {
+			if got := workIDPattern(tt.id).MatchString(tt.text); got != tt.want {
+				t.Errorf("match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if workIDPattern("w-1") != nil {
+		t.Error("short IDs should have no pattern")
+	}
+}
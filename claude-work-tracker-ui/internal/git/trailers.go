@@ -0,0 +1,163 @@
+package git
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/parser"
+)
+
+// Trailers are the work tracker's commit message trailers:
+//
+//	Work: work-123
+//	Progress: 60%
+//	Completes-Task: Add login redirect
+type Trailers struct {
+	WorkIDs        []string // Work items the commit is for
+	Progress       int      // New progress percentage, -1 when not given
+	CompletesTasks []string // Task titles or IDs to tick off
+}
+
+// ParseTrailers reads trailers from the last paragraph of a commit message, as git does.
+// Keys are case-insensitive.
+func ParseTrailers(message string) Trailers {
+	trailers := Trailers{Progress: -1}
+
+	paragraphs := strings.Split(strings.TrimSpace(message), "\n\n")
+	if len(paragraphs) < 2 {
+		return trailers // A subject line alone has no trailers
+	}
+
+	for _, line := range strings.Split(paragraphs[len(paragraphs)-1], "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			continue
+		}
+
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "work":
+			for _, id := range strings.Split(value, ",") {
+				if id = strings.TrimSpace(id); id != "" {
+					trailers.WorkIDs = append(trailers.WorkIDs, id)
+				}
+			}
+		case "progress":
+			if percent, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(value, "%"))); err == nil {
+				if percent < 0 {
+					percent = 0
+				} else if percent > 100 {
+					percent = 100
+				}
+				trailers.Progress = percent
+			}
+		case "completes-task":
+			trailers.CompletesTasks = append(trailers.CompletesTasks, value)
+		}
+	}
+	return trailers
+}
+
+// HasUpdates reports whether the trailers change progress or tasks
+func (t Trailers) HasUpdates() bool {
+	return t.Progress >= 0 || len(t.CompletesTasks) > 0
+}
+
+// NamesWork reports whether a Work trailer names the work ID
+func (t Trailers) NamesWork(id string) bool {
+	for _, workID := range t.WorkIDs {
+		if workID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTrailers updates progress and ticks tasks from the trailers of newly linked commits,
+// oldest first so the latest progress wins. Trailers apply to the items named by a Work
+// trailer, or else to the items the commit was linked to by ID or branch. Each commit that
+// changes an item produces an automatic update.
+func applyTrailers(byID map[string]*models.Work, links []CommitLink) []*models.Update {
+	sorted := append([]CommitLink(nil), links...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Commit.Timestamp.Before(sorted[j].Commit.Timestamp)
+	})
+
+	taskParser := parser.NewTaskParser()
+	var updates []*models.Update
+	for _, link := range sorted {
+		work, ok := byID[link.WorkID]
+		if !ok || link.MatchedBy == models.CommitMatchFile {
+			continue
+		}
+		trailers := ParseTrailers(link.Commit.Message())
+		if !trailers.HasUpdates() || (len(trailers.WorkIDs) > 0 && !trailers.NamesWork(work.ID)) {
+			continue
+		}
+
+		update := &models.Update{
+			ID:             models.NewUpdateID(),
+			WorkID:         work.ID,
+			Timestamp:      link.Commit.Timestamp,
+			Title:          fmt.Sprintf("Commit %.8s", link.Commit.SHA),
+			Author:         link.Commit.Author,
+			UpdateType:     "automatic",
+			ProgressBefore: work.Metadata.ProgressPercent,
+			ProgressAfter:  work.Metadata.ProgressPercent,
+		}
+		summary := []string{link.Commit.Subject}
+
+		if trailers.Progress >= 0 && trailers.Progress != work.Metadata.ProgressPercent {
+			summary = append(summary, fmt.Sprintf("Progress %d%% → %d%%", work.Metadata.ProgressPercent, trailers.Progress))
+			work.Metadata.ProgressPercent = trailers.Progress
+			update.ProgressAfter = trailers.Progress
+		}
+
+		for _, ref := range trailers.CompletesTasks {
+			task := taskParser.FindTask(work.Content, ref)
+			if task == nil {
+				summary = append(summary, fmt.Sprintf("No task matches %q", ref))
+				continue
+			}
+			if task.Task.Status == models.TaskStatusCompleted {
+				continue
+			}
+			work.Content = taskParser.UpdateTaskInMarkdown(work.Content, task.Task.ID, models.TaskStatusCompleted)
+			work.Metadata.CompletedTasks = appendUnique(work.Metadata.CompletedTasks, task.Task.Title)
+			work.Metadata.PendingTasks = removeString(work.Metadata.PendingTasks, task.Task.Title)
+			update.TasksCompleted = append(update.TasksCompleted, task.Task.Title)
+			summary = append(summary, "Completed: "+task.Task.Title)
+		}
+
+		if update.ProgressAfter == update.ProgressBefore && len(update.TasksCompleted) == 0 {
+			continue
+		}
+		update.Summary = strings.Join(summary, "\n")
+		updates = append(updates, update)
+	}
+	return updates
+}
+
+// appendUnique appends s unless the list already has it
+func appendUnique(list []string, s string) []string {
+	for _, item := range list {
+		if item == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// removeString returns the list without s
+func removeString(list []string, s string) []string {
+	var result []string
+	for _, item := range list {
+		if item != s {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,240 @@
+package git
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+func TestParseTrailers(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		workIDs  []string
+		progress int
+		tasks    []string
+	}{
+		{
+			name:     "subject only",
+			message:  "Work: work-1234",
+			progress: -1,
+		},
+		{
+			name:     "all trailers",
+			message:  "Add login\n\nSome detail.\n\nWork: work-1234\nProgress: 60%\nCompletes-Task: Add login redirect",
+			workIDs:  []string{"work-1234"},
+			progress: 60,
+			tasks:    []string{"Add login redirect"},
+		},
+		{
+			name:     "keys are case-insensitive",
+			message:  "Add login\n\nwork: work-1234\nPROGRESS: 40\ncompletes-task: One",
+			workIDs:  []string{"work-1234"},
+			progress: 40,
+			tasks:    []string{"One"},
+		},
+		{
+			name:     "several work IDs and tasks",
+			message:  "Add login\n\nWork: work-1234, work-5678,\nCompletes-Task: One\nCompletes-Task: Two",
+			workIDs:  []string{"work-1234", "work-5678"},
+			progress: -1,
+			tasks:    []string{"One", "Two"},
+		},
+		{
+			name:     "only the last paragraph counts",
+			message:  "Add login\n\nWork: work-1234\n\nSigned-off-by: someone",
+			progress: -1,
+		},
+		{
+			name:     "progress above 100 is clamped",
+			message:  "Add login\n\nProgress: 140%",
+			progress: 100,
+		},
+		{
+			name:     "progress below 0 is clamped",
+			message:  "Add login\n\nProgress: -20%",
+			progress: 0,
+		},
+		{
+			name:     "progress that isn't a number is ignored",
+			message:  "Add login\n\nProgress: most",
+			progress: -1,
+		},
+		{
+			name:     "unknown and empty trailers are ignored",
+			message:  "Add login\n\nReviewed-by: someone\nWork:\nNot a trailer\nCompletes-Task:   ",
+			progress: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseTrailers(tt.message)
+			if strings.Join(got.WorkIDs, "|") != strings.Join(tt.workIDs, "|") {
+				t.Errorf("work IDs = %q, want %q", got.WorkIDs, tt.workIDs)
+			}
+			if got.Progress != tt.progress {
+				t.Errorf("progress = %d, want %d", got.Progress, tt.progress)
+			}
+			if strings.Join(got.CompletesTasks, "|") != strings.Join(tt.tasks, "|") {
+				t.Errorf("tasks = %q, want %q", got.CompletesTasks, tt.tasks)
+			}
+		})
+	}
+}
+
+// trailerLink links a commit with the message to work-1234
+func trailerLink(message string, matchedBy string, at time.Time) CommitLink {
+	subject, body, _ := strings.Cut(message, "\n\n")
+	return CommitLink{
+		WorkID:    "work-1234",
+		MatchedBy: matchedBy,
+		Commit: Commit{
+			SHA:       "0123456789abcdef",
+			Author:    "dev",
+			Timestamp: at,
+			Subject:   subject,
+			Body:      body,
+		},
+	}
+}
+
+func TestApplyTrailers(t *testing.T) {
+	at := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		links     []CommitLink
+		progress  int      // Progress afterwards
+		completed []string // Tasks ticked by the updates
+		pending   []string // Pending tasks afterwards
+		summaries []string // One entry per update, each must be in its summary
+	}{
+		{
+			name:      "progress",
+			links:     []CommitLink{trailerLink("Add login\n\nProgress: 60%", models.CommitMatchID, at)},
+			progress:  60,
+			pending:   []string{"Add login redirect", "Write tests"},
+			summaries: []string{"Progress 20% → 60%"},
+		},
+		{
+			name:     "unchanged progress makes no update",
+			links:    []CommitLink{trailerLink("Add login\n\nProgress: 20%", models.CommitMatchID, at)},
+			progress: 20,
+			pending:  []string{"Add login redirect", "Write tests"},
+		},
+		{
+			name:      "task matched by title",
+			links:     []CommitLink{trailerLink("Add login\n\nCompletes-Task: add login redirect", models.CommitMatchID, at)},
+			progress:  20,
+			completed: []string{"Add login redirect"},
+			pending:   []string{"Write tests"},
+			summaries: []string{"Completed: Add login redirect"},
+		},
+		{
+			name:      "task matched by fragment",
+			links:     []CommitLink{trailerLink("Add tests\n\nCompletes-Task: tests", models.CommitMatchID, at)},
+			progress:  20,
+			completed: []string{"Write tests"},
+			pending:   []string{"Add login redirect"},
+			summaries: []string{"Completed: Write tests"},
+		},
+		{
+			name:      "unknown task is noted with the progress",
+			links:     []CommitLink{trailerLink("Add login\n\nProgress: 50\nCompletes-Task: Deploy", models.CommitMatchID, at)},
+			progress:  50,
+			pending:   []string{"Add login redirect", "Write tests"},
+			summaries: []string{`No task matches "Deploy"`},
+		},
+		{
+			name:     "ambiguous task is not ticked",
+			links:    []CommitLink{trailerLink("Add login\n\nCompletes-Task: e", models.CommitMatchID, at)},
+			progress: 20,
+			pending:  []string{"Add login redirect", "Write tests"},
+		},
+		{
+			name:     "trailers naming another item are skipped",
+			links:    []CommitLink{trailerLink("Add login\n\nWork: work-5678\nProgress: 90", models.CommitMatchBranch, at)},
+			progress: 20,
+			pending:  []string{"Add login redirect", "Write tests"},
+		},
+		{
+			name:     "file matches don't apply trailers",
+			links:    []CommitLink{trailerLink("Add login\n\nProgress: 90", models.CommitMatchFile, at)},
+			progress: 20,
+			pending:  []string{"Add login redirect", "Write tests"},
+		},
+		{
+			name: "latest commit's progress wins",
+			links: []CommitLink{
+				trailerLink("Later\n\nProgress: 80", models.CommitMatchID, at.Add(time.Hour)),
+				trailerLink("Earlier\n\nProgress: 40", models.CommitMatchID, at),
+			},
+			progress:  80,
+			pending:   []string{"Add login redirect", "Write tests"},
+			summaries: []string{"Progress 20% → 40%", "Progress 40% → 80%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			work := &models.Work{
+				ID:      "work-1234",
+				Content: "- [ ] Add login redirect\n- [ ] Write tests\n",
+				Metadata: models.WorkMetadata{
+					ProgressPercent: 20,
+					PendingTasks:    []string{"Add login redirect", "Write tests"},
+				},
+			}
+			updates := applyTrailers(map[string]*models.Work{work.ID: work}, tt.links)
+
+			if work.Metadata.ProgressPercent != tt.progress {
+				t.Errorf("progress = %d, want %d", work.Metadata.ProgressPercent, tt.progress)
+			}
+			if strings.Join(work.Metadata.PendingTasks, "|") != strings.Join(tt.pending, "|") {
+				t.Errorf("pending tasks = %q, want %q", work.Metadata.PendingTasks, tt.pending)
+			}
+			if strings.Join(work.Metadata.CompletedTasks, "|") != strings.Join(tt.completed, "|") {
+				t.Errorf("completed tasks = %q, want %q", work.Metadata.CompletedTasks, tt.completed)
+			}
+			for _, task := range tt.completed {
+				if !strings.Contains(work.Content, "- [x] "+task) {
+					t.Errorf("%q isn't ticked in %q", task, work.Content)
+				}
+			}
+
+			if len(updates) != len(tt.summaries) {
+				t.Fatalf("%d updates, want %d", len(updates), len(tt.summaries))
+			}
+			for i, update := range updates {
+				if !strings.Contains(update.Summary, tt.summaries[i]) {
+					t.Errorf("update %d summary %q doesn't mention %q", i, update.Summary, tt.summaries[i])
+				}
+				if update.WorkID != work.ID || update.UpdateType != "automatic" {
+					t.Errorf("update %d = %+v, want an automatic update for %s", i, update, work.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyTrailersGivesEachUpdateItsOwnID(t *testing.T) {
+	at := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	first := &models.Work{ID: "work-1234"}
+	second := &models.Work{ID: "work-5678"}
+
+	// One commit moving two items at once
+	link := trailerLink("Add login\n\nWork: work-1234, work-5678\nProgress: 50", models.CommitMatchTrailer, at)
+	other := link
+	other.WorkID = second.ID
+
+	updates := applyTrailers(map[string]*models.Work{first.ID: first, second.ID: second}, []CommitLink{link, other})
+	if len(updates) != 2 {
+		t.Fatalf("%d updates, want 2", len(updates))
+	}
+	if updates[0].ID == updates[1].ID {
+		t.Errorf("both updates have ID %s", updates[0].ID)
+	}
+}
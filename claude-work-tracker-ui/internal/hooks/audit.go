@@ -0,0 +1,296 @@
+package hooks
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// AuditEntry records a single hook run
+type AuditEntry struct {
+	ID       string        `json:"id"`
+	Time     time.Time     `json:"time"`
+	Hook     string        `json:"hook"`
+	Event    HookType      `json:"event"`
+	WorkID   string        `json:"work_id,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Success  bool          `json:"success"`
+	Error    string        `json:"error,omitempty"`
+	Decision string        `json:"decision,omitempty"`
+	Rerun    bool          `json:"rerun,omitempty"`
+	Context  *AuditContext `json:"context,omitempty"` // Kept for failed runs so they can be re-run
+}
+
+// AuditContext is the hook context saved with a failed run
+type AuditContext struct {
+	WorkItem    *models.Work           `json:"work_item,omitempty"`
+	OldWorkItem *models.Work           `json:"old_work_item,omitempty"`
+	Timestamp   time.Time              `json:"timestamp"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// AuditConfig controls audit log rotation
+type AuditConfig struct {
+	MaxSize  int64 // Rotate once the active log exceeds this many bytes
+	MaxFiles int   // Rotated files to keep, e.g. hook-audit.jsonl.1 .. .N
+}
+
+// DefaultAuditConfig returns default rotation settings
+func DefaultAuditConfig() *AuditConfig {
+	return &AuditConfig{
+		MaxSize:  2 * 1024 * 1024,
+		MaxFiles: 3,
+	}
+}
+
+// DefaultAuditLogPath returns ~/.claude/logs/hook-audit.jsonl
+func DefaultAuditLogPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".claude", "logs", "hook-audit.jsonl")
+}
+
+// AuditLog appends hook runs to a size-rotated JSON lines file
+type AuditLog struct {
+	mu     sync.Mutex
+	path   string
+	config *AuditConfig
+	seq    int
+}
+
+// NewAuditLog creates an audit log writing to path
+func NewAuditLog(path string, config *AuditConfig) *AuditLog {
+	if config == nil {
+		config = DefaultAuditConfig()
+	}
+	return &AuditLog{
+		path:   path,
+		config: config,
+	}
+}
+
+// Path returns the active log file
+func (a *AuditLog) Path() string {
+	return a.path
+}
+
+// Record appends an entry, rotating the log when it grows past MaxSize
+func (a *AuditLog) Record(entry AuditEntry) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if entry.ID == "" {
+		a.seq++
+		entry.ID = fmt.Sprintf("%d-%d", entry.Time.UnixNano(), a.seq)
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(a.path), 0755); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	if info, err := os.Stat(a.path); err == nil && info.Size()+int64(len(line)) > a.config.MaxSize {
+		if err := a.rotate(); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(a.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// rotate shifts hook-audit.jsonl to .1, .1 to .2 and so on, dropping the oldest
+func (a *AuditLog) rotate() error {
+	if a.config.MaxFiles <= 0 {
+		return os.Remove(a.path)
+	}
+
+	os.Remove(fmt.Sprintf("%s.%d", a.path, a.config.MaxFiles))
+	for i := a.config.MaxFiles - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", a.path, i), fmt.Sprintf("%s.%d", a.path, i+1))
+	}
+	if err := os.Rename(a.path, a.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate audit log: %w", err)
+	}
+	return nil
+}
+
+// Recent returns up to limit entries, newest first, reading rotated files as needed
+func (a *AuditLog) Recent(limit int) ([]AuditEntry, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	files := []string{a.path}
+	for i := 1; i <= a.config.MaxFiles; i++ {
+		files = append(files, fmt.Sprintf("%s.%d", a.path, i))
+	}
+
+	var entries []AuditEntry
+	for _, path := range files {
+		fileEntries, err := readAuditFile(path)
+		if err != nil {
+			return nil, err
+		}
+		// Files hold entries oldest first
+		for i := len(fileEntries) - 1; i >= 0; i-- {
+			entries = append(entries, fileEntries[i])
+			if limit > 0 && len(entries) >= limit {
+				return entries, nil
+			}
+		}
+	}
+	return entries, nil
+}
+
+// readAuditFile reads one log file, skipping lines that fail to parse
+func readAuditFile(path string) ([]AuditEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return entries, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// SetAuditLog records every hook run to the given log, nil disables auditing
+func (hs *HookSystem) SetAuditLog(log *AuditLog) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	hs.audit = log
+}
+
+// GetAuditLog returns the audit log, or nil if auditing is off
+func (hs *HookSystem) GetAuditLog() *AuditLog {
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+	return hs.audit
+}
+
+// record writes a hook result to the audit log
+func (hs *HookSystem) record(hookCtx *HookContext, result HookResult, rerun bool) {
+	hs.mu.RLock()
+	log := hs.audit
+	hs.mu.RUnlock()
+	if log == nil {
+		return
+	}
+
+	entry := AuditEntry{
+		Time:     time.Now(),
+		Hook:     result.HookName,
+		Event:    hookCtx.EventType,
+		Duration: result.Duration,
+		Success:  result.Success,
+		Rerun:    rerun,
+	}
+	if hookCtx.WorkItem != nil {
+		entry.WorkID = hookCtx.WorkItem.ID
+	}
+	if result.Error != nil {
+		entry.Error = result.Error.Error()
+	}
+	if result.Decision != nil {
+		entry.Decision = string(result.Decision.Action)
+	}
+	if !result.Success {
+		entry.Context = &AuditContext{
+			WorkItem:    hookCtx.WorkItem,
+			OldWorkItem: hookCtx.OldWorkItem,
+			Timestamp:   hookCtx.Timestamp,
+			Metadata:    hookCtx.Metadata,
+		}
+	}
+
+	// Auditing must never break the change that triggered the hook
+	log.Record(entry)
+}
+
+// RecordFailure audits a step that failed alongside a change, such as a roll-up after the
+// change was saved, so it's listed with the hook runs instead of getting lost
+func (hs *HookSystem) RecordFailure(step string, event HookType, workID string, err error) {
+	hs.mu.RLock()
+	log := hs.audit
+	hs.mu.RUnlock()
+	if log == nil || err == nil {
+		return
+	}
+
+	log.Record(AuditEntry{
+		Time:   time.Now(),
+		Hook:   step,
+		Event:  event,
+		WorkID: workID,
+		Error:  err.Error(),
+	})
+}
+
+// Rerun runs the hook from an audit entry again against its saved context
+func (hs *HookSystem) Rerun(ctx context.Context, entry AuditEntry) (HookResult, error) {
+	if entry.Context == nil {
+		return HookResult{}, fmt.Errorf("no saved context for %s, only failed runs can be re-run", entry.Hook)
+	}
+
+	hs.mu.RLock()
+	var handler *namedHandler
+	for _, h := range hs.handlers[entry.Event] {
+		if h.name == entry.Hook {
+			h := h
+			handler = &h
+			break
+		}
+	}
+	hs.mu.RUnlock()
+
+	if handler == nil {
+		return HookResult{}, fmt.Errorf("hook %s is no longer registered for %s", entry.Hook, entry.Event)
+	}
+
+	hookCtx := &HookContext{
+		WorkItem:    entry.Context.WorkItem,
+		OldWorkItem: entry.Context.OldWorkItem,
+		EventType:   entry.Event,
+		Timestamp:   entry.Context.Timestamp,
+		Metadata:    entry.Context.Metadata,
+	}
+
+	result := hs.invoke(ctx, *handler, hookCtx)
+	hs.record(hookCtx, result, true)
+	return result, nil
+}
@@ -0,0 +1,158 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// auditEntry returns the nth entry written by the tests, roughly 100 bytes once encoded
+func auditEntry(n int) AuditEntry {
+	return AuditEntry{
+		Time:    time.Date(2026, 3, 10, 12, 0, n, 0, time.UTC),
+		Hook:    fmt.Sprintf("hook-%02d", n),
+		Event:   AfterStatusChange,
+		WorkID:  "work-1",
+		Success: true,
+	}
+}
+
+func TestAuditLogRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "hook-audit.jsonl")
+	log := NewAuditLog(path, &AuditConfig{MaxSize: 400, MaxFiles: 2})
+
+	for n := 1; n <= 20; n++ {
+		if err := log.Record(auditEntry(n)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, file := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(file)
+		if err != nil {
+			t.Fatalf("missing %s: %v", filepath.Base(file), err)
+		}
+		if info.Size() > 400 {
+			t.Errorf("%s is %d bytes, over the 400 byte limit", filepath.Base(file), info.Size())
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("kept more than two rotated files: %v", err)
+	}
+
+	// Newest first, across the rotated files, ending where the oldest kept file starts
+	entries, err := log.Recent(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) == 0 || len(entries) >= 20 {
+		t.Fatalf("%d entries kept, want some dropped by rotation", len(entries))
+	}
+	for i, entry := range entries {
+		if want := fmt.Sprintf("hook-%02d", 20-i); entry.Hook != want {
+			t.Fatalf("entry %d is %s, want %s", i, entry.Hook, want)
+		}
+		if entry.ID == "" {
+			t.Errorf("entry %s has no ID", entry.Hook)
+		}
+	}
+
+	limited, err := log.Recent(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limited) != 5 || limited[0].Hook != "hook-20" || limited[4].Hook != "hook-16" {
+		t.Errorf("Recent(5) = %d entries from %s", len(limited), limited[0].Hook)
+	}
+}
+
+func TestAuditLogWithoutRotatedFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hook-audit.jsonl")
+	log := NewAuditLog(path, &AuditConfig{MaxSize: 250, MaxFiles: 0})
+
+	for n := 1; n <= 5; n++ {
+		if err := log.Record(auditEntry(n)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := log.Recent(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) == 0 || entries[0].Hook != "hook-05" {
+		t.Fatalf("entries = %+v, want the latest first", entries)
+	}
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Errorf("rotated file kept with MaxFiles 0: %v", err)
+	}
+}
+
+func TestAuditLogSkipsBrokenLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hook-audit.jsonl")
+	log := NewAuditLog(path, nil)
+	if err := log.Record(auditEntry(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString("{\"hook\": truncated\n")
+	file.Close()
+
+	if err := log.Record(auditEntry(2)); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := log.Recent(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Hook != "hook-02" || entries[1].Hook != "hook-01" {
+		t.Errorf("entries = %+v", entries)
+	}
+}
+
+func TestRerunFromAudit(t *testing.T) {
+	hs := NewHookSystem(nil)
+	hs.SetAuditLog(NewAuditLog(filepath.Join(t.TempDir(), "hook-audit.jsonl"), nil))
+
+	fail := true
+	hs.Register(AfterStatusChange, "notify", func(ctx context.Context, hookCtx *HookContext) error {
+		if fail {
+			return errors.New("server down")
+		}
+		return nil
+	})
+	hs.ExecuteSync(context.Background(), &HookContext{EventType: AfterStatusChange, WorkItem: &models.Work{ID: "work-1"}})
+
+	entries, err := hs.GetAuditLog().Recent(1)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("entries = %+v, %v", entries, err)
+	}
+	failed := entries[0]
+	if failed.Success || failed.Error != "server down" || failed.Context == nil || failed.Context.WorkItem.ID != "work-1" {
+		t.Fatalf("failed run recorded as %+v", failed)
+	}
+
+	fail = false
+	result, err := hs.Rerun(context.Background(), failed)
+	if err != nil || !result.Success {
+		t.Fatalf("rerun = %+v, %v", result, err)
+	}
+	entries, _ = hs.GetAuditLog().Recent(1)
+	if !entries[0].Rerun || !entries[0].Success || entries[0].Context != nil {
+		t.Errorf("rerun recorded as %+v", entries[0])
+	}
+
+	if _, err := hs.Rerun(context.Background(), entries[0]); err == nil {
+		t.Error("re-ran a successful run")
+	}
+}
@@ -0,0 +1,260 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// BeforeAction is a before hook's verdict on a pending change
+type BeforeAction string
+
+const (
+	ActionAllow BeforeAction = "allow" // Let the change through unchanged
+	ActionDeny  BeforeAction = "deny"  // Reject the change with a reason
+	ActionPatch BeforeAction = "patch" // Let the change through with extra field edits
+)
+
+// BeforeResult is the typed outcome of a before hook
+type BeforeResult struct {
+	Action BeforeAction           `json:"decision"`
+	Reason string                 `json:"reason,omitempty"`
+	Patch  map[string]interface{} `json:"patch,omitempty"` // Dotted frontmatter field -> new value, e.g. "metadata.priority"
+}
+
+// Allow lets a change through
+func Allow() BeforeResult {
+	return BeforeResult{Action: ActionAllow}
+}
+
+// Deny rejects a change with a reason shown to the user
+func Deny(reason string) BeforeResult {
+	return BeforeResult{Action: ActionDeny, Reason: reason}
+}
+
+// Patch lets a change through after applying field edits
+func Patch(fields map[string]interface{}) BeforeResult {
+	return BeforeResult{Action: ActionPatch, Patch: fields}
+}
+
+// BeforeHandler is a hook that can allow, deny or patch a pending change
+type BeforeHandler func(ctx context.Context, hookCtx *HookContext) BeforeResult
+
+// RegisterBefore adds a before hook handler for a specific hook type
+func (hs *HookSystem) RegisterBefore(hookType HookType, name string, handler BeforeHandler) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	hs.handlers[hookType] = append(hs.handlers[hookType], namedHandler{
+		name:   name,
+		before: handler,
+	})
+}
+
+// WorkChange describes which tracked fields differ between two versions of a work item
+type WorkChange struct {
+	Old             *models.Work
+	New             *models.Work
+	StatusChanged   bool
+	ScheduleChanged bool
+	ProgressChanged bool
+}
+
+// DiffWork compares the stored and pending versions of a work item. New items have no changes.
+func DiffWork(oldWork, newWork *models.Work) WorkChange {
+	change := WorkChange{Old: oldWork, New: newWork}
+	if oldWork == nil || newWork == nil {
+		return change
+	}
+	change.StatusChanged = oldWork.Metadata.Status != newWork.Metadata.Status
+	change.ScheduleChanged = oldWork.Schedule != newWork.Schedule
+	change.ProgressChanged = oldWork.Metadata.ProgressPercent != newWork.Metadata.ProgressPercent
+	return change
+}
+
+// HasChanges reports whether any tracked field changed
+func (c WorkChange) HasChanges() bool {
+	return c.StatusChanged || c.ScheduleChanged || c.ProgressChanged
+}
+
+// RunBeforeChange runs BeforeStatusChange and BeforeScheduleChange hooks for the fields that
+// changed. Patches are applied to change.New; a denial is returned as *VetoError.
+func (hs *HookSystem) RunBeforeChange(ctx context.Context, change WorkChange) ([]HookResult, error) {
+	var results []HookResult
+
+	if change.StatusChanged {
+		statusResults, err := hs.ExecuteSync(ctx, &HookContext{
+			WorkItem:    change.New,
+			OldWorkItem: change.Old,
+			EventType:   BeforeStatusChange,
+			Timestamp:   time.Now(),
+			Metadata: map[string]interface{}{
+				"old_status": change.Old.Metadata.Status,
+				"new_status": change.New.Metadata.Status,
+			},
+		})
+		results = append(results, statusResults...)
+		if err != nil {
+			return results, err
+		}
+	}
+
+	// A status hook may have patched the schedule
+	if change.Old != nil && change.Old.Schedule != change.New.Schedule {
+		scheduleResults, err := hs.ExecuteSync(ctx, &HookContext{
+			WorkItem:    change.New,
+			OldWorkItem: change.Old,
+			EventType:   BeforeScheduleChange,
+			Timestamp:   time.Now(),
+			Metadata: map[string]interface{}{
+				"old_schedule": change.Old.Schedule,
+				"new_schedule": change.New.Schedule,
+			},
+		})
+		results = append(results, scheduleResults...)
+		if err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
+// RunAfterChange runs AfterStatusChange, AfterScheduleChange and ProgressUpdated hooks
+// for the fields that changed
+func (hs *HookSystem) RunAfterChange(ctx context.Context, change WorkChange) ([]HookResult, error) {
+	var results []HookResult
+	var firstErr error
+
+	run := func(event HookType, metadata map[string]interface{}) {
+		eventResults, err := hs.Execute(ctx, &HookContext{
+			WorkItem:    change.New,
+			OldWorkItem: change.Old,
+			EventType:   event,
+			Timestamp:   time.Now(),
+			Metadata:    metadata,
+		})
+		results = append(results, eventResults...)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if change.StatusChanged {
+		run(AfterStatusChange, map[string]interface{}{
+			"old_status": change.Old.Metadata.Status,
+			"new_status": change.New.Metadata.Status,
+		})
+	}
+	if change.ScheduleChanged {
+		run(AfterScheduleChange, map[string]interface{}{
+			"old_schedule": change.Old.Schedule,
+			"new_schedule": change.New.Schedule,
+		})
+	}
+	if change.ProgressChanged {
+		run(ProgressUpdated, map[string]interface{}{
+			"old_progress": change.Old.Metadata.ProgressPercent,
+			"new_progress": change.New.Metadata.ProgressPercent,
+		})
+	}
+
+	return results, firstErr
+}
+
+// ApplyPatch sets dotted frontmatter fields on a work item, e.g. "schedule" or "metadata.priority"
+func ApplyPatch(work *models.Work, patch map[string]interface{}) error {
+	if len(patch) == 0 {
+		return nil
+	}
+
+	data, err := yaml.Marshal(work)
+	if err != nil {
+		return fmt.Errorf("failed to encode work item: %w", err)
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to decode work item: %w", err)
+	}
+
+	for field, value := range patch {
+		if field == "id" {
+			return fmt.Errorf("hooks cannot change the work item ID")
+		}
+		if err := setField(doc, strings.Split(field, "."), value); err != nil {
+			return fmt.Errorf("failed to patch %s: %w", field, err)
+		}
+	}
+
+	data, err = yaml.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode patched work item: %w", err)
+	}
+	var patched models.Work
+	if err := yaml.Unmarshal(data, &patched); err != nil {
+		return fmt.Errorf("invalid patch: %w", err)
+	}
+
+	// Fields outside the frontmatter survive the round trip
+	patched.Content = work.Content
+	patched.Filename = work.Filename
+	patched.Filepath = work.Filepath
+	patched.SourceDirectory = work.SourceDirectory
+	patched.SourcePath = work.SourcePath
+
+	*work = patched
+	return nil
+}
+
+// setField assigns value at a nested key path, creating intermediate maps
+func setField(doc map[string]interface{}, path []string, value interface{}) error {
+	for i, key := range path {
+		if i == len(path)-1 {
+			doc[key] = value
+			return nil
+		}
+		next, ok := doc[key].(map[string]interface{})
+		if !ok {
+			if doc[key] != nil {
+				return fmt.Errorf("%s is not an object", key)
+			}
+			next = make(map[string]interface{})
+			doc[key] = next
+		}
+		doc = next
+	}
+	return nil
+}
+
+// parseBeforeResult reads a decision printed as JSON by a script hook. Scripts that
+// print nothing or plain text allow the change.
+func parseBeforeResult(stdout string) (BeforeResult, error) {
+	stdout = strings.TrimSpace(stdout)
+	if !strings.HasPrefix(stdout, "{") {
+		return Allow(), nil
+	}
+
+	var result BeforeResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		return Allow(), fmt.Errorf("invalid decision output: %w", err)
+	}
+
+	switch result.Action {
+	case "":
+		if len(result.Patch) > 0 {
+			result.Action = ActionPatch
+		} else {
+			result.Action = ActionAllow
+		}
+	case ActionAllow, ActionDeny, ActionPatch:
+	default:
+		return Allow(), fmt.Errorf("unknown decision %q", result.Action)
+	}
+	return result, nil
+}
@@ -0,0 +1,239 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// statusChange returns a change moving a work item from active to in_progress
+func statusChange() WorkChange {
+	old := &models.Work{
+		ID:       "work-1",
+		Title:    "Login",
+		Schedule: models.ScheduleNow,
+		Metadata: models.WorkMetadata{Status: models.WorkStatusActive, Priority: "medium"},
+	}
+	updated := *old
+	updated.Metadata.Status = models.WorkStatusInProgress
+	return DiffWork(old, &updated)
+}
+
+func TestRunBeforeChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		veto     string // Reason the change is vetoed with, empty when it goes through
+		failed   string // Error recorded on the hook's result, the change still goes through
+		priority string // Priority afterwards
+		schedule string
+	}{
+		{
+			name:     "allowed by silence",
+			command:  "true",
+			priority: "medium",
+			schedule: models.ScheduleNow,
+		},
+		{
+			name:     "allowed by decision",
+			command:  `echo '{"decision":"allow"}'`,
+			priority: "medium",
+			schedule: models.ScheduleNow,
+		},
+		{
+			name:    "vetoed by a non-zero exit",
+			command: "echo 'tests are failing' >&2; exit 1",
+			veto:    "tests are failing",
+		},
+		{
+			name:    "vetoed by decision",
+			command: `echo '{"decision":"deny","reason":"needs review"}'`,
+			veto:    "needs review",
+		},
+		{
+			name:     "patched",
+			command:  `echo '{"decision":"patch","patch":{"metadata.priority":"high"}}'`,
+			priority: "high",
+			schedule: models.ScheduleNow,
+		},
+		{
+			name:     "patch without a decision",
+			command:  `echo '{"patch":{"schedule":"next"}}'`,
+			priority: "medium",
+			schedule: models.ScheduleNext,
+		},
+		{
+			name:     "patch touching the ID",
+			command:  `echo '{"decision":"patch","patch":{"id":"work-2","metadata.priority":"high"}}'`,
+			failed:   "cannot change the work item ID",
+			priority: "medium",
+			schedule: models.ScheduleNow,
+		},
+		{
+			name:     "unknown decision",
+			command:  `echo '{"decision":"maybe"}'`,
+			failed:   `unknown decision "maybe"`,
+			priority: "medium",
+			schedule: models.ScheduleNow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hs := NewHookSystem(nil)
+			if err := hs.RegisterExternal(ExternalHook{Name: "gate", Event: BeforeStatusChange, Command: tt.command}); err != nil {
+				t.Fatal(err)
+			}
+			change := statusChange()
+
+			results, err := hs.RunBeforeChange(context.Background(), change)
+			if tt.veto != "" {
+				var veto *VetoError
+				if !errors.As(err, &veto) || veto.HookName != "gate" || veto.Reason != tt.veto {
+					t.Fatalf("error = %v, want a veto from gate: %s", err, tt.veto)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(results) != 1 {
+				t.Fatalf("%d results, want 1", len(results))
+			}
+			if tt.failed == "" && results[0].Error != nil {
+				t.Errorf("hook failed: %v", results[0].Error)
+			}
+			if tt.failed != "" && (results[0].Error == nil || !strings.Contains(results[0].Error.Error(), tt.failed)) {
+				t.Errorf("hook error = %v, want %q", results[0].Error, tt.failed)
+			}
+
+			if change.New.ID != "work-1" || change.New.Metadata.Status != models.WorkStatusInProgress {
+				t.Errorf("work is %s in %s, want work-1 in in_progress", change.New.ID, change.New.Metadata.Status)
+			}
+			if change.New.Metadata.Priority != tt.priority || change.New.Schedule != tt.schedule {
+				t.Errorf("priority %s in %s, want %s in %s", change.New.Metadata.Priority, change.New.Schedule, tt.priority, tt.schedule)
+			}
+		})
+	}
+}
+
+func TestRunBeforeChangeChecksPatchedSchedule(t *testing.T) {
+	hs := NewHookSystem(nil)
+	hs.RegisterBefore(BeforeStatusChange, "move", func(ctx context.Context, hookCtx *HookContext) BeforeResult {
+		return Patch(map[string]interface{}{"schedule": "closed"})
+	})
+	hs.RegisterBefore(BeforeScheduleChange, "keep-open", func(ctx context.Context, hookCtx *HookContext) BeforeResult {
+		return Deny("closed items need a summary")
+	})
+
+	_, err := hs.RunBeforeChange(context.Background(), statusChange())
+	var veto *VetoError
+	if !errors.As(err, &veto) || veto.HookName != "keep-open" || veto.Event != BeforeScheduleChange {
+		t.Errorf("error = %v, want the schedule hook's veto", err)
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	due := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	work := &models.Work{
+		ID:              "work-1",
+		Title:           "Login",
+		Description:     "Fix the login page",
+		Schedule:        models.ScheduleNow,
+		TechnicalTags:   []string{"frontend"},
+		DueAt:           &due,
+		Content:         "# Login\n\n- [ ] Redirect\n",
+		Filename:        "work-1.md",
+		Filepath:        "/work/now/work-1.md",
+		SourceDirectory: "/src",
+		SourcePath:      "/src/notes.md",
+		Metadata:        models.WorkMetadata{Status: models.WorkStatusActive, Priority: "medium", ProgressPercent: 40},
+	}
+
+	err := ApplyPatch(work, map[string]interface{}{
+		"metadata.priority": "high",
+		"schedule":          "next",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if work.Metadata.Priority != "high" || work.Schedule != models.ScheduleNext {
+		t.Errorf("priority %s in %s, want high in next", work.Metadata.Priority, work.Schedule)
+	}
+	// Everything else survives the round trip through YAML
+	if work.ID != "work-1" || work.Title != "Login" || work.Description != "Fix the login page" ||
+		work.Metadata.Status != models.WorkStatusActive || work.Metadata.ProgressPercent != 40 ||
+		strings.Join(work.TechnicalTags, ",") != "frontend" || work.DueAt == nil || !work.DueAt.Equal(due) {
+		t.Errorf("frontmatter changed: %+v", work)
+	}
+	if work.Content != "# Login\n\n- [ ] Redirect\n" || work.Filename != "work-1.md" || work.Filepath != "/work/now/work-1.md" ||
+		work.SourceDirectory != "/src" || work.SourcePath != "/src/notes.md" {
+		t.Errorf("fields outside the frontmatter changed: %+v", work)
+	}
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch map[string]interface{}
+		want  string
+	}{
+		{"ID", map[string]interface{}{"id": "work-2"}, "cannot change the work item ID"},
+		{"through a value", map[string]interface{}{"title.text": "x"}, "title is not an object"},
+		{"wrong type", map[string]interface{}{"metadata.progress_percent": "lots"}, "invalid patch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			work := &models.Work{ID: "work-1", Title: "Login", Metadata: models.WorkMetadata{ProgressPercent: 40}}
+			err := ApplyPatch(work, tt.patch)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("ApplyPatch = %v, want %q", err, tt.want)
+			}
+			if work.ID != "work-1" || work.Title != "Login" || work.Metadata.ProgressPercent != 40 {
+				t.Errorf("work changed by a rejected patch: %+v", work)
+			}
+		})
+	}
+}
+
+func TestParseBeforeResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		stdout string
+		want   BeforeAction
+		reason string
+		err    string
+	}{
+		{name: "nothing", stdout: "", want: ActionAllow},
+		{name: "plain text", stdout: "looks fine\n", want: ActionAllow},
+		{name: "allow", stdout: `{"decision":"allow"}`, want: ActionAllow},
+		{name: "deny", stdout: ` {"decision":"deny","reason":"no"}` + "\n", want: ActionDeny, reason: "no"},
+		{name: "patch", stdout: `{"decision":"patch","patch":{"schedule":"next"}}`, want: ActionPatch},
+		{name: "bare patch", stdout: `{"patch":{"schedule":"next"}}`, want: ActionPatch},
+		{name: "empty object", stdout: `{}`, want: ActionAllow},
+		{name: "unknown decision", stdout: `{"decision":"maybe"}`, want: ActionAllow, err: "unknown decision"},
+		{name: "broken JSON", stdout: `{"decision":`, want: ActionAllow, err: "invalid decision output"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBeforeResult(tt.stdout)
+			if tt.err == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Fatalf("error = %v, want %q", err, tt.err)
+			}
+			if got.Action != tt.want || got.Reason != tt.reason {
+				t.Errorf("result = %+v, want %s %q", got, tt.want, tt.reason)
+			}
+		})
+	}
+}
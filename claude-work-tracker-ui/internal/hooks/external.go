@@ -0,0 +1,264 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// maxHookOutput caps how much stdout/stderr is kept from a script hook
+const maxHookOutput = 64 * 1024
+
+// ExternalHook declares a shell command or executable run for a hook event
+type ExternalHook struct {
+	Name     string            `json:"name"`
+	Event    HookType          `json:"event"`
+	Command  string            `json:"command"`            // Executable path, or a shell command line when Args is empty
+	Args     []string          `json:"args,omitempty"`     // Arguments passed directly to Command
+	Dir      string            `json:"dir,omitempty"`      // Working directory, defaults to the current one
+	Env      map[string]string `json:"env,omitempty"`      // Extra environment variables
+	Disabled bool              `json:"disabled,omitempty"` // Keep the entry but don't run it
+}
+
+// ExternalHooksFile is the on-disk format of the hooks config
+type ExternalHooksFile struct {
+	Hooks []ExternalHook `json:"hooks"`
+}
+
+// VetoError is returned when a before hook rejects a change
+type VetoError struct {
+	HookName string
+	Event    HookType
+	Reason   string
+}
+
+func (e *VetoError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("%s vetoed by hook %s", e.Event, e.HookName)
+	}
+	return fmt.Sprintf("%s vetoed by hook %s: %s", e.Event, e.HookName, e.Reason)
+}
+
+// hookPayload is the JSON document written to a script hook's stdin
+type hookPayload struct {
+	Hook        string                 `json:"hook"`
+	Event       HookType               `json:"event"`
+	Timestamp   time.Time              `json:"timestamp"`
+	WorkItem    *models.Work           `json:"work_item,omitempty"`
+	OldWorkItem *models.Work           `json:"old_work_item,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// AllHookTypes returns every hook type the system emits
+func AllHookTypes() []HookType {
+	return []HookType{
+		BeforeStatusChange, AfterStatusChange,
+		BeforeScheduleChange, AfterScheduleChange,
+		ProgressUpdated,
+		ActivityDetected, InactivityWarning,
+		DueSoon, Overdue, StartByPassed,
+		GitContextChanged, CommitDetected,
+	}
+}
+
+// DefaultExternalHooksPath returns ~/.claude/config/hooks.json
+func DefaultExternalHooksPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".claude", "config", "hooks.json")
+}
+
+// LoadExternalHooks reads and validates a hooks config file. A missing file yields no hooks.
+func LoadExternalHooks(path string) ([]ExternalHook, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read hooks config: %w", err)
+	}
+
+	var file ExternalHooksFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse hooks config: %w", err)
+	}
+
+	for i, hook := range file.Hooks {
+		if err := hook.Validate(); err != nil {
+			return nil, fmt.Errorf("hook #%d: %w", i+1, err)
+		}
+	}
+
+	return file.Hooks, nil
+}
+
+// Validate checks that the hook names a known event and a command
+func (h ExternalHook) Validate() error {
+	if h.Name == "" {
+		return fmt.Errorf("missing name")
+	}
+	if h.Command == "" {
+		return fmt.Errorf("%s: missing command", h.Name)
+	}
+	for _, t := range AllHookTypes() {
+		if t == h.Event {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: unknown event %q", h.Name, h.Event)
+}
+
+// RegisterExternal adds a script hook for its event
+func (hs *HookSystem) RegisterExternal(hook ExternalHook) error {
+	if err := hook.Validate(); err != nil {
+		return err
+	}
+
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	hs.handlers[hook.Event] = append(hs.handlers[hook.Event], namedHandler{
+		name:     hook.Name,
+		external: &hook,
+	})
+	return nil
+}
+
+// LoadExternalHooks registers every enabled hook from a config file and returns how many were added
+func (hs *HookSystem) LoadExternalHooks(path string) (int, error) {
+	hooks, err := LoadExternalHooks(path)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, hook := range hooks {
+		if hook.Disabled {
+			continue
+		}
+		if err := hs.RegisterExternal(hook); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// runExternal runs a script hook with the context as JSON on stdin.
+// A non-zero exit from a before hook vetoes the change, using the script's output as the reason.
+func runExternal(ctx context.Context, hook *ExternalHook, hookCtx *HookContext) (map[string]interface{}, error) {
+	payload, err := json.Marshal(hookPayload{
+		Hook:        hook.Name,
+		Event:       hookCtx.EventType,
+		Timestamp:   hookCtx.Timestamp,
+		WorkItem:    hookCtx.WorkItem,
+		OldWorkItem: hookCtx.OldWorkItem,
+		Metadata:    hookCtx.Metadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode hook payload: %w", err)
+	}
+
+	var cmd *exec.Cmd
+	if len(hook.Args) > 0 {
+		cmd = exec.CommandContext(ctx, hook.Command, hook.Args...)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", hook.Command)
+	}
+	cmd.Dir = hook.Dir
+	cmd.WaitDelay = time.Second // Don't wait on background children holding the output pipes
+	cmd.Stdin = bytes.NewReader(payload)
+
+	cmd.Env = append(os.Environ(),
+		"HOOK_NAME="+hook.Name,
+		"HOOK_EVENT="+string(hookCtx.EventType),
+	)
+	if hookCtx.WorkItem != nil {
+		cmd.Env = append(cmd.Env, "WORK_ID="+hookCtx.WorkItem.ID)
+	}
+	for key, value := range hook.Env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+
+	var stdout, stderr cappedBuffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+
+	exitCode := 0
+	if cmd.ProcessState != nil {
+		exitCode = cmd.ProcessState.ExitCode()
+	}
+	metadata := map[string]interface{}{
+		"stdout":    stdout.String(),
+		"stderr":    stderr.String(),
+		"exit_code": exitCode,
+	}
+
+	if runErr == nil {
+		return metadata, nil
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return metadata, fmt.Errorf("hook %s timed out", hook.Name)
+	}
+
+	if _, ok := runErr.(*exec.ExitError); ok && hookCtx.EventType.IsBefore() {
+		reason := strings.TrimSpace(stderr.String())
+		if reason == "" {
+			reason = strings.TrimSpace(stdout.String())
+		}
+		return metadata, &VetoError{HookName: hook.Name, Event: hookCtx.EventType, Reason: firstLine(reason)}
+	}
+
+	if msg := firstLine(strings.TrimSpace(stderr.String())); msg != "" {
+		return metadata, fmt.Errorf("hook %s failed: %v: %s", hook.Name, runErr, msg)
+	}
+	return metadata, fmt.Errorf("hook %s failed: %w", hook.Name, runErr)
+}
+
+// cappedBuffer keeps the first maxHookOutput bytes of script output and drops the rest, so
+// a runaway script can't fill memory. Writes always succeed so the script isn't cut off.
+type cappedBuffer struct {
+	buf       bytes.Buffer
+	truncated bool
+}
+
+func (c *cappedBuffer) Write(p []byte) (int, error) {
+	room := maxHookOutput - c.buf.Len()
+	if len(p) > room {
+		c.truncated = true
+		c.buf.Write(p[:room])
+	} else {
+		c.buf.Write(p)
+	}
+	return len(p), nil
+}
+
+// String returns the kept output, marked when some was dropped
+func (c *cappedBuffer) String() string {
+	if c.truncated {
+		return c.buf.String() + "\n... (truncated)"
+	}
+	return c.buf.String()
+}
+
+// firstLine returns the first line of s
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
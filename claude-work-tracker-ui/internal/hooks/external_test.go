@@ -0,0 +1,58 @@
+package hooks
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+func TestCappedBuffer(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []int
+		want   int
+		cut    bool
+	}{
+		{"small", []int{10}, 10, false},
+		{"exactly full", []int{maxHookOutput}, maxHookOutput, false},
+		{"one write over", []int{maxHookOutput + 1}, maxHookOutput, true},
+		{"many writes over", []int{maxHookOutput - 5, 10, 10}, maxHookOutput, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c cappedBuffer
+			for _, size := range tt.writes {
+				if n, err := c.Write(make([]byte, size)); n != size || err != nil {
+					t.Fatalf("Write = %d, %v, want %d accepted", n, err, size)
+				}
+			}
+			if c.buf.Len() != tt.want || c.truncated != tt.cut {
+				t.Errorf("kept %d bytes, truncated %v, want %d, %v", c.buf.Len(), c.truncated, tt.want, tt.cut)
+			}
+			if strings.HasSuffix(c.String(), "(truncated)") != tt.cut {
+				t.Errorf("truncation marker is wrong: %q", c.String()[len(c.String())-20:])
+			}
+		})
+	}
+}
+
+func TestRunExternalCapsOutput(t *testing.T) {
+	hook := &ExternalHook{Name: "chatty", Event: AfterStatusChange, Command: "head -c 1000000 /dev/zero | tr '\\0' x; echo oops >&2"}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	metadata, err := runExternal(ctx, hook, &HookContext{EventType: AfterStatusChange, WorkItem: &models.Work{ID: "work-1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := metadata["stdout"].(string)
+	if len(stdout) > maxHookOutput+len("\n... (truncated)") || !strings.HasSuffix(stdout, "(truncated)") {
+		t.Errorf("kept %d bytes of stdout", len(stdout))
+	}
+	if stderr := metadata["stderr"].(string); stderr != "oops\n" {
+		t.Errorf("stderr = %q", stderr)
+	}
+}
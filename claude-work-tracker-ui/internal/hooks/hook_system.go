@@ -3,6 +3,7 @@ package hooks
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,6 +29,11 @@ const (
 	ActivityDetected HookType = "activity_detected"
 	InactivityWarning HookType = "inactivity_warning"
 
+	// Deadline hooks
+	DueSoon       HookType = "due_soon"
+	Overdue       HookType = "overdue"
+	StartByPassed HookType = "start_by_passed"
+
 	// Git hooks
 	GitContextChanged HookType = "git_context_changed"
 	CommitDetected    HookType = "commit_detected"
@@ -52,6 +58,7 @@ type HookResult struct {
 	Error     error
 	Duration  time.Duration
 	Metadata  map[string]interface{}
+	Decision  *BeforeResult // Set for before hooks
 }
 
 // HookSystem manages hook registration and execution
@@ -59,11 +66,14 @@ type HookSystem struct {
 	mu       sync.RWMutex
 	handlers map[HookType][]namedHandler
 	config   *HookConfig
+	audit    *AuditLog // Records every hook run when set
 }
 
 type namedHandler struct {
-	name    string
-	handler HookHandler
+	name     string
+	handler  HookHandler
+	before   BeforeHandler
+	external *ExternalHook // Set for script hooks loaded from the hooks config
 }
 
 // HookConfig contains configuration for the hook system
@@ -106,7 +116,8 @@ func (hs *HookSystem) Register(hookType HookType, name string, handler HookHandl
 	})
 }
 
-// Execute runs all registered handlers for a hook type
+// Execute runs all registered handlers for a hook type concurrently. Patches
+// from before hooks are ignored here, use ExecuteSync for before events.
 func (hs *HookSystem) Execute(ctx context.Context, hookCtx *HookContext) ([]HookResult, error) {
 	if !hs.config.Enabled {
 		return nil, nil
@@ -132,21 +143,7 @@ func (hs *HookSystem) Execute(ctx context.Context, hookCtx *HookContext) ([]Hook
 			sem <- struct{}{} // Acquire semaphore
 			defer func() { <-sem }() // Release semaphore
 
-			start := time.Now()
-			ctxWithTimeout, cancel := context.WithTimeout(ctx, hs.config.Timeout)
-			defer cancel()
-
-			err := handler.handler(ctxWithTimeout, hookCtx)
-			duration := time.Since(start)
-
-			result := HookResult{
-				HookName: handler.name,
-				Success:  err == nil,
-				Error:    err,
-				Duration: duration,
-			}
-
-			resultCh <- result
+			resultCh <- hs.invoke(ctx, handler, hookCtx)
 		}(h)
 	}
 
@@ -156,11 +153,21 @@ func (hs *HookSystem) Execute(ctx context.Context, hookCtx *HookContext) ([]Hook
 	}()
 
 	var firstError error
+	var veto *VetoError
 	for result := range resultCh {
+		hs.record(hookCtx, result, false)
 		results = append(results, result)
 		if result.Error != nil && firstError == nil {
 			firstError = result.Error
 		}
+		if v, ok := result.Error.(*VetoError); ok && veto == nil {
+			veto = v
+		}
+	}
+
+	// A veto always stops the change, regardless of ContinueOnError
+	if veto != nil {
+		return results, veto
 	}
 
 	if firstError != nil && !hs.config.ContinueOnError {
@@ -170,7 +177,8 @@ func (hs *HookSystem) Execute(ctx context.Context, hookCtx *HookContext) ([]Hook
 	return results, nil
 }
 
-// ExecuteSync executes hooks synchronously in order
+// ExecuteSync executes hooks synchronously in order. Before hooks may patch
+// hookCtx.WorkItem, and a denial stops the run with a *VetoError.
 func (hs *HookSystem) ExecuteSync(ctx context.Context, hookCtx *HookContext) ([]HookResult, error) {
 	if !hs.config.Enabled {
 		return nil, nil
@@ -187,29 +195,92 @@ func (hs *HookSystem) ExecuteSync(ctx context.Context, hookCtx *HookContext) ([]
 	results := make([]HookResult, 0, len(handlers))
 
 	for _, h := range handlers {
-		start := time.Now()
-		ctxWithTimeout, cancel := context.WithTimeout(ctx, hs.config.Timeout)
-		err := h.handler(ctxWithTimeout, hookCtx)
-		cancel()
-		duration := time.Since(start)
-
-		result := HookResult{
-			HookName: h.name,
-			Success:  err == nil,
-			Error:    err,
-			Duration: duration,
-		}
+		result := hs.invoke(ctx, h, hookCtx)
 
+		// Later hooks see earlier patches
+		if result.Decision != nil && result.Decision.Action == ActionPatch && hookCtx.WorkItem != nil {
+			if err := ApplyPatch(hookCtx.WorkItem, result.Decision.Patch); err != nil {
+				result.Success = false
+				result.Error = err
+			}
+		}
+		hs.record(hookCtx, result, false)
 		results = append(results, result)
 
-		if err != nil && !hs.config.ContinueOnError {
-			return results, fmt.Errorf("hook %s failed: %w", h.name, err)
+		if veto, ok := result.Error.(*VetoError); ok {
+			return results, veto
+		}
+		if result.Error != nil && !hs.config.ContinueOnError {
+			return results, fmt.Errorf("hook %s failed: %w", h.name, result.Error)
 		}
 	}
 
 	return results, nil
 }
 
+// invoke runs a single handler with the configured timeout
+func (hs *HookSystem) invoke(ctx context.Context, h namedHandler, hookCtx *HookContext) HookResult {
+	start := time.Now()
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, hs.config.Timeout)
+	defer cancel()
+
+	var err error
+	var metadata map[string]interface{}
+	var decision *BeforeResult
+
+	switch {
+	case h.external != nil:
+		metadata, err = runExternal(ctxWithTimeout, h.external, hookCtx)
+		if err == nil && hookCtx.EventType.IsBefore() {
+			stdout, _ := metadata["stdout"].(string)
+			result, parseErr := parseBeforeResult(stdout)
+			if parseErr != nil {
+				err = fmt.Errorf("hook %s: %w", h.name, parseErr)
+			} else {
+				decision = &result
+			}
+		}
+	case h.before != nil:
+		result := h.before(ctxWithTimeout, hookCtx)
+		decision = &result
+	default:
+		err = h.handler(ctxWithTimeout, hookCtx)
+	}
+
+	if veto, ok := err.(*VetoError); ok {
+		decision = &BeforeResult{Action: ActionDeny, Reason: veto.Reason}
+	} else if decision != nil && decision.Action == ActionDeny {
+		err = &VetoError{HookName: h.name, Event: hookCtx.EventType, Reason: decision.Reason}
+	}
+
+	if decision != nil {
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+		}
+		metadata["decision"] = string(decision.Action)
+		if decision.Reason != "" {
+			metadata["reason"] = decision.Reason
+		}
+		if len(decision.Patch) > 0 {
+			metadata["patch"] = decision.Patch
+		}
+	}
+
+	return HookResult{
+		HookName: h.name,
+		Success:  err == nil,
+		Error:    err,
+		Duration: time.Since(start),
+		Metadata: metadata,
+		Decision: decision,
+	}
+}
+
+// IsBefore reports whether hooks of this type run before a change and may veto it
+func (t HookType) IsBefore() bool {
+	return strings.HasPrefix(string(t), "before_")
+}
+
 // Clear removes all registered handlers
 func (hs *HookSystem) Clear() {
 	hs.mu.Lock()
@@ -0,0 +1,78 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// githubIssue matches the fields produced by `gh issue list --json`
+type githubIssue struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	State     string    `json:"state"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Labels    []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+	Milestone *struct {
+		Title string `json:"title"`
+		DueOn string `json:"dueOn"`
+	} `json:"milestone"`
+	StateReason string `json:"stateReason"`
+}
+
+// ParseGitHubIssues parses a GitHub issues export, e.g.
+// gh issue list --state all --json number,title,body,state,url,labels,assignees,createdAt,updatedAt
+func ParseGitHubIssues(data []byte) ([]IssueRecord, error) {
+	var issues []githubIssue
+	if err := json.Unmarshal(data, &issues); err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub issues: %w", err)
+	}
+
+	records := make([]IssueRecord, 0, len(issues))
+	for _, issue := range issues {
+		state := issue.State
+		// Closed as not planned shouldn't count as completed
+		if issue.StateReason == "NOT_PLANNED" {
+			state = "not planned"
+		}
+
+		record := IssueRecord{
+			Source:     "github",
+			ExternalID: strconv.Itoa(issue.Number),
+			Title:      issue.Title,
+			Body:       issue.Body,
+			State:      state,
+			URL:        issue.URL,
+			CreatedAt:  issue.CreatedAt,
+			UpdatedAt:  issue.UpdatedAt,
+		}
+
+		for _, label := range issue.Labels {
+			record.Labels = append(record.Labels, label.Name)
+		}
+		for _, assignee := range issue.Assignees {
+			record.Assignees = append(record.Assignees, assignee.Login)
+		}
+		if issue.Milestone != nil && issue.Milestone.Title != "" {
+			record.Labels = append(record.Labels, "milestone-"+issue.Milestone.Title)
+		}
+		if issue.Milestone != nil && issue.Milestone.DueOn != "" {
+			if due, err := time.Parse(time.RFC3339, issue.Milestone.DueOn); err == nil {
+				record.DueAt = due
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
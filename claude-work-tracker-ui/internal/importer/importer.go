@@ -0,0 +1,439 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// IssueRecord is the source-independent form of an imported issue
+type IssueRecord struct {
+	Source     string
+	ExternalID string
+	Title      string
+	Body       string
+	Labels     []string
+	State      string
+	Priority   string
+	URL        string
+	Links      []string
+	Assignees  []string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DueAt      time.Time // Zero when the source has no due date
+}
+
+// WorkStore is the storage the importer reads from and writes to
+type WorkStore interface {
+	GetAllWork() ([]*models.Work, error)
+	CreateWork(work *models.Work) error
+	UpdateWork(work *models.Work) error
+}
+
+// ImportConfig controls how issue records become Work items
+type ImportConfig struct {
+	DefaultSchedule string            // Schedule for newly imported open issues
+	StateMap        map[string]string // Source state (lowercase) -> Work status
+	PriorityMap     map[string]string // Source priority (lowercase) -> Work priority
+	DryRun          bool              // Report changes without writing
+}
+
+// DefaultImportConfig returns the default state and priority mappings
+func DefaultImportConfig() *ImportConfig {
+	return &ImportConfig{
+		DefaultSchedule: models.ScheduleNext,
+		StateMap: map[string]string{
+			"open":        models.WorkStatusActive,
+			"opened":      models.WorkStatusActive,
+			"to do":       models.WorkStatusActive,
+			"todo":        models.WorkStatusActive,
+			"backlog":     models.WorkStatusDraft,
+			"in progress": models.WorkStatusInProgress,
+			"in review":   models.WorkStatusInProgress,
+			"blocked":     models.WorkStatusBlocked,
+			"on hold":     models.WorkStatusOnHold,
+			"closed":      models.WorkStatusCompleted,
+			"done":        models.WorkStatusCompleted,
+			"resolved":    models.WorkStatusCompleted,
+			"completed":   models.WorkStatusCompleted,
+			"won't do":    models.WorkStatusCanceled,
+			"not planned": models.WorkStatusCanceled,
+			"canceled":    models.WorkStatusCanceled,
+			"cancelled":   models.WorkStatusCanceled,
+		},
+		PriorityMap: map[string]string{
+			"highest":  models.WorkPriorityCritical,
+			"critical": models.WorkPriorityCritical,
+			"blocker":  models.WorkPriorityCritical,
+			"p0":       models.WorkPriorityCritical,
+			"high":     models.WorkPriorityHigh,
+			"p1":       models.WorkPriorityHigh,
+			"medium":   models.WorkPriorityMedium,
+			"p2":       models.WorkPriorityMedium,
+			"low":      models.WorkPriorityLow,
+			"lowest":   models.WorkPriorityLow,
+			"p3":       models.WorkPriorityLow,
+		},
+	}
+}
+
+// ImportResult summarizes an import run
+type ImportResult struct {
+	Created   []string `json:"created"`
+	Updated   []string `json:"updated"`
+	Unchanged []string `json:"unchanged"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+// Importer upserts issue records into Work items keyed by their external ID
+type Importer struct {
+	store  WorkStore
+	config *ImportConfig
+}
+
+// NewImporter creates an importer writing to the given store
+func NewImporter(store WorkStore, config *ImportConfig) *Importer {
+	if config == nil {
+		config = DefaultImportConfig()
+	}
+	return &Importer{
+		store:  store,
+		config: config,
+	}
+}
+
+// Import creates or updates Work items for each record
+func (im *Importer) Import(records []IssueRecord) (*ImportResult, error) {
+	existing, err := im.store.GetAllWork()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load existing work: %w", err)
+	}
+
+	// Index previously imported items by source and external ID
+	index := make(map[string]*models.Work)
+	for _, work := range existing {
+		if work.External != nil {
+			index[externalKey(work.External.Source, work.External.ID)] = work
+		}
+	}
+
+	result := &ImportResult{}
+	for _, record := range records {
+		if record.ExternalID == "" || record.Title == "" {
+			result.Errors = append(result.Errors, fmt.Sprintf("skipped record without id or title: %q", record.Title))
+			continue
+		}
+
+		key := externalKey(record.Source, record.ExternalID)
+		if work, ok := index[key]; ok {
+			if !im.applyRecord(work, record) {
+				result.Unchanged = append(result.Unchanged, work.ID)
+				continue
+			}
+			if !im.config.DryRun {
+				if err := im.store.UpdateWork(work); err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", record.ExternalID, err))
+					continue
+				}
+			}
+			result.Updated = append(result.Updated, work.ID)
+			continue
+		}
+
+		work := im.newWork(record)
+		if !im.config.DryRun {
+			if err := im.store.CreateWork(work); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", record.ExternalID, err))
+				continue
+			}
+		}
+		index[key] = work
+		result.Created = append(result.Created, work.ID)
+	}
+
+	return result, nil
+}
+
+// newWork builds a Work item for a record seen for the first time
+func (im *Importer) newWork(record IssueRecord) *models.Work {
+	now := time.Now()
+	createdAt := record.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+
+	work := &models.Work{
+		ID:            workID(record),
+		CreatedAt:     createdAt,
+		TechnicalTags: []string{},
+		ArtifactRefs:  []string{},
+	}
+
+	// The status starts out empty so an issue that's already closed isn't recorded as
+	// moving there
+	im.applyRecord(work, record)
+
+	switch work.Metadata.Status {
+	case models.WorkStatusCompleted, models.WorkStatusCanceled, models.WorkStatusArchived:
+		work.Schedule = models.ScheduleClosed
+	case models.WorkStatusInProgress:
+		work.Schedule = models.ScheduleNow
+	default:
+		work.Schedule = im.config.DefaultSchedule
+	}
+
+	return work
+}
+
+// applyRecord copies record fields onto work, returning whether anything changed.
+// Schedule is only touched when the issue gets closed so local triage is preserved.
+func (im *Importer) applyRecord(work *models.Work, record IssueRecord) bool {
+	if work.External != nil && !record.UpdatedAt.IsZero() && !record.UpdatedAt.After(work.External.SourceUpdatedAt) {
+		return false
+	}
+
+	status := im.mapStatus(record.State, work.Metadata.Status)
+	priority := im.mapPriority(record)
+	var previousLabels []string
+	if work.External != nil {
+		previousLabels = work.External.Labels
+	}
+	tags := syncTags(work.TechnicalTags, previousLabels, record.Labels)
+	content := renderContent(record)
+	links := mergeLinks(record.Links, extractLinks(record.Body))
+	dueChanged := !record.DueAt.IsZero() && (work.DueAt == nil || !work.DueAt.Equal(record.DueAt))
+
+	changed := work.External == nil ||
+		work.Title != record.Title ||
+		work.Content != content ||
+		work.Metadata.Status != status ||
+		(priority != "" && work.Metadata.Priority != priority) ||
+		strings.Join(tags, ",") != strings.Join(work.TechnicalTags, ",") ||
+		dueChanged ||
+		strings.Join(links, ",") != strings.Join(work.External.Links, ",") ||
+		strings.Join(record.Labels, ",") != strings.Join(work.External.Labels, ",")
+	if !changed {
+		return false
+	}
+
+	now := time.Now()
+	work.Title = record.Title
+	work.Description = summarize(record.Body)
+	work.Content = content
+	work.TechnicalTags = tags
+	if priority != "" {
+		work.Metadata.Priority = priority
+	}
+	if dueChanged {
+		due := record.DueAt
+		work.DueAt = &due
+	}
+
+	if status != work.Metadata.Status {
+		if work.Metadata.Status != "" {
+			work.RecordTransition(models.Transition{
+				From:      work.Metadata.Status,
+				To:        status,
+				Reason:    fmt.Sprintf("%s issue %s is %s", record.Source, record.ExternalID, record.State),
+				Automatic: true,
+				Actor:     record.Source,
+				At:        now,
+			})
+		}
+		work.Metadata.Status = status
+		switch status {
+		case models.WorkStatusCompleted, models.WorkStatusCanceled:
+			if work.CompletedAt == nil {
+				work.CompletedAt = &now
+			}
+			if status == models.WorkStatusCompleted {
+				work.Metadata.ProgressPercent = 100
+			}
+			if work.Schedule != "" {
+				work.Schedule = models.ScheduleClosed
+			}
+		case models.WorkStatusInProgress:
+			if work.StartedAt == nil {
+				work.StartedAt = &now
+			}
+		}
+	}
+
+	sourceUpdated := record.UpdatedAt
+	if sourceUpdated.IsZero() {
+		sourceUpdated = now
+	}
+	work.External = &models.ExternalRef{
+		Source:          record.Source,
+		ID:              record.ExternalID,
+		URL:             record.URL,
+		Links:           links,
+		Labels:          record.Labels,
+		SourceUpdatedAt: sourceUpdated,
+		ImportedAt:      now,
+	}
+	work.UpdatedAt = now
+
+	return true
+}
+
+// mapStatus converts a source state to a Work status, keeping the current status for unknown states
+func (im *Importer) mapStatus(state, current string) string {
+	if status, ok := im.config.StateMap[strings.ToLower(strings.TrimSpace(state))]; ok {
+		return status
+	}
+	if current != "" {
+		return current
+	}
+	return models.WorkStatusActive
+}
+
+// mapPriority derives priority from the record or from priority-like labels
+func (im *Importer) mapPriority(record IssueRecord) string {
+	if priority, ok := im.config.PriorityMap[strings.ToLower(strings.TrimSpace(record.Priority))]; ok {
+		return priority
+	}
+
+	for _, label := range record.Labels {
+		normalized := strings.ToLower(label)
+		normalized = strings.TrimPrefix(normalized, "priority:")
+		normalized = strings.TrimPrefix(normalized, "priority/")
+		if priority, ok := im.config.PriorityMap[strings.TrimSpace(normalized)]; ok {
+			return priority
+		}
+	}
+
+	return ""
+}
+
+// externalKey builds the index key for an external item
+func externalKey(source, id string) string {
+	return strings.ToLower(source) + ":" + id
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// workID builds a stable Work ID for an external issue
+func workID(record IssueRecord) string {
+	id := nonSlugChars.ReplaceAllString(strings.ToLower(record.ExternalID), "-")
+	return fmt.Sprintf("work-%s-%s", strings.ToLower(record.Source), strings.Trim(id, "-"))
+}
+
+// syncTags adds normalized labels to existing tags without duplicates, and drops the tags
+// of labels the previous import brought in that the issue no longer has. Local tags stay.
+func syncTags(existing, previousLabels, labels []string) []string {
+	current := make(map[string]bool)
+	for _, label := range labels {
+		current[labelTag(label)] = true
+	}
+	dropped := make(map[string]bool)
+	for _, label := range previousLabels {
+		if tag := labelTag(label); !current[tag] {
+			dropped[tag] = true
+		}
+	}
+
+	tags := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range existing {
+		if dropped[tag] || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	for _, label := range labels {
+		tag := labelTag(label)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
+
+// labelTag normalizes a source label into a tag
+func labelTag(label string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(label), "-"), "-")
+}
+
+// mergeLinks unions link lists and returns them sorted
+func mergeLinks(lists ...[]string) []string {
+	seen := make(map[string]bool)
+	var links []string
+	for _, list := range lists {
+		for _, link := range list {
+			link = strings.TrimSpace(link)
+			if link == "" || seen[link] {
+				continue
+			}
+			seen[link] = true
+			links = append(links, link)
+		}
+	}
+	sort.Strings(links)
+	return links
+}
+
+var (
+	issueRefPattern = regexp.MustCompile(`(?:^|[\s(])((?:[\w.-]+/[\w.-]+)?#\d+)\b`)
+	jiraKeyPattern  = regexp.MustCompile(`\b([A-Z][A-Z0-9]+-\d+)\b`)
+	urlPattern      = regexp.MustCompile(`https?://\S+/(?:issues|pull|browse)/[\w-]+`)
+)
+
+// extractLinks finds issue references, Jira keys and tracker URLs in an issue body
+func extractLinks(body string) []string {
+	var links []string
+	for _, m := range issueRefPattern.FindAllStringSubmatch(body, -1) {
+		links = append(links, m[1])
+	}
+	for _, m := range jiraKeyPattern.FindAllStringSubmatch(body, -1) {
+		links = append(links, m[1])
+	}
+	links = append(links, urlPattern.FindAllString(body, -1)...)
+	return links
+}
+
+// summarize returns the first paragraph of the body as a short description
+func summarize(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(line, "#>*- "))
+		if line == "" {
+			continue
+		}
+		if runes := []rune(line); len(runes) > 200 {
+			line = string(runes[:197]) + "..."
+		}
+		return line
+	}
+	return ""
+}
+
+// renderContent builds the markdown body for an imported issue
+func renderContent(record IssueRecord) string {
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("# %s\n\n", record.Title))
+
+	if record.URL != "" {
+		content.WriteString(fmt.Sprintf("Imported from %s %s: %s\n\n", record.Source, record.ExternalID, record.URL))
+	} else {
+		content.WriteString(fmt.Sprintf("Imported from %s %s\n\n", record.Source, record.ExternalID))
+	}
+	if len(record.Assignees) > 0 {
+		content.WriteString(fmt.Sprintf("Assignees: %s\n\n", strings.Join(record.Assignees, ", ")))
+	}
+
+	if body := strings.TrimSpace(strings.ReplaceAll(record.Body, "\r\n", "\n")); body != "" {
+		content.WriteString(body)
+	}
+
+	// Stored content is trimmed on read, match it so re-imports compare equal
+	return strings.TrimSpace(content.String())
+}
@@ -0,0 +1,264 @@
+package importer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// memoryStore is a WorkStore kept in memory
+type memoryStore struct {
+	works   map[string]*models.Work
+	creates int
+	updates int
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{works: make(map[string]*models.Work)}
+}
+
+func (s *memoryStore) GetAllWork() ([]*models.Work, error) {
+	var works []*models.Work
+	for _, work := range s.works {
+		copied := *work
+		works = append(works, &copied)
+	}
+	return works, nil
+}
+
+func (s *memoryStore) CreateWork(work *models.Work) error {
+	if _, exists := s.works[work.ID]; exists {
+		return fmt.Errorf("work item exists: %s", work.ID)
+	}
+	s.creates++
+	copied := *work
+	s.works[work.ID] = &copied
+	return nil
+}
+
+func (s *memoryStore) UpdateWork(work *models.Work) error {
+	if _, exists := s.works[work.ID]; !exists {
+		return fmt.Errorf("work item not found: %s", work.ID)
+	}
+	s.updates++
+	copied := *work
+	s.works[work.ID] = &copied
+	return nil
+}
+
+// issue builds a GitHub issue record updated at the given hour
+func issue(id, state string, hour int, labels ...string) IssueRecord {
+	return IssueRecord{
+		Source:     "github",
+		ExternalID: id,
+		Title:      "Issue " + id,
+		Body:       "Fix it.",
+		Labels:     labels,
+		State:      state,
+		UpdatedAt:  time.Date(2024, 1, 1, hour, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestImportUpsertsByExternalID(t *testing.T) {
+	tests := []struct {
+		name        string
+		first       []IssueRecord
+		second      []IssueRecord
+		wantResult  ImportResult
+		wantWorks   int
+		wantCreates int
+		wantUpdates int
+	}{
+		{
+			name:        "same issue again is unchanged",
+			first:       []IssueRecord{issue("1", "open", 1)},
+			second:      []IssueRecord{issue("1", "open", 1)},
+			wantResult:  ImportResult{Unchanged: []string{"work-github-1"}},
+			wantWorks:   1,
+			wantCreates: 1,
+		},
+		{
+			name:  "newer issue updates the same item",
+			first: []IssueRecord{issue("1", "open", 1)},
+			second: []IssueRecord{func() IssueRecord {
+				record := issue("1", "open", 2)
+				record.Title = "Renamed"
+				return record
+			}()},
+			wantResult:  ImportResult{Updated: []string{"work-github-1"}},
+			wantWorks:   1,
+			wantCreates: 1,
+			wantUpdates: 1,
+		},
+		{
+			name:  "older issue is ignored",
+			first: []IssueRecord{issue("1", "open", 2)},
+			second: []IssueRecord{func() IssueRecord {
+				record := issue("1", "closed", 1)
+				record.Title = "Stale"
+				return record
+			}()},
+			wantResult:  ImportResult{Unchanged: []string{"work-github-1"}},
+			wantWorks:   1,
+			wantCreates: 1,
+		},
+		{
+			name:  "same ID from another source is another item",
+			first: []IssueRecord{issue("1", "open", 1)},
+			second: []IssueRecord{func() IssueRecord {
+				record := issue("1", "open", 1)
+				record.Source = "jira"
+				return record
+			}()},
+			wantResult:  ImportResult{Created: []string{"work-jira-1"}},
+			wantWorks:   2,
+			wantCreates: 2,
+		},
+		{
+			name:        "records without an ID are reported",
+			second:      []IssueRecord{{Source: "github", Title: "No ID"}},
+			wantResult:  ImportResult{Errors: []string{`skipped record without id or title: "No ID"`}},
+			wantWorks:   0,
+			wantCreates: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryStore()
+			im := NewImporter(store, nil)
+			if _, err := im.Import(tt.first); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := im.Import(tt.second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*result, tt.wantResult) {
+				t.Errorf("result = %+v, want %+v", *result, tt.wantResult)
+			}
+			if len(store.works) != tt.wantWorks || store.creates != tt.wantCreates || store.updates != tt.wantUpdates {
+				t.Errorf("%d works, %d creates, %d updates, want %d, %d, %d",
+					len(store.works), store.creates, store.updates, tt.wantWorks, tt.wantCreates, tt.wantUpdates)
+			}
+		})
+	}
+}
+
+func TestImportSyncsLabels(t *testing.T) {
+	tests := []struct {
+		name   string
+		first  []string
+		second []string
+		local  []string
+		want   []string
+	}{
+		{"label added", []string{"bug"}, []string{"bug", "UI"}, nil, []string{"bug", "ui"}},
+		{"label dropped", []string{"bug", "ui"}, []string{"bug"}, nil, []string{"bug"}},
+		{"label swapped for one of the same count", []string{"bug"}, []string{"feature"}, nil, []string{"feature"}},
+		{"local tags are kept", []string{"bug"}, nil, []string{"mine"}, []string{"mine"}},
+		{"local tag matching a dropped label goes too", []string{"bug"}, nil, []string{"bug"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryStore()
+			im := NewImporter(store, nil)
+			if _, err := im.Import([]IssueRecord{issue("1", "open", 1, tt.first...)}); err != nil {
+				t.Fatal(err)
+			}
+			work := store.works["work-github-1"]
+			work.TechnicalTags = append(work.TechnicalTags, tt.local...)
+
+			if _, err := im.Import([]IssueRecord{issue("1", "open", 2, tt.second...)}); err != nil {
+				t.Fatal(err)
+			}
+			work = store.works["work-github-1"]
+			if !reflect.DeepEqual(work.TechnicalTags, tt.want) {
+				t.Errorf("tags = %v, want %v", work.TechnicalTags, tt.want)
+			}
+			if !reflect.DeepEqual(work.External.Labels, tt.second) {
+				t.Errorf("stored labels = %v, want %v", work.External.Labels, tt.second)
+			}
+		})
+	}
+}
+
+func TestImportStatusTransitions(t *testing.T) {
+	tests := []struct {
+		name            string
+		records         []IssueRecord
+		wantStatus      string
+		wantSchedule    string
+		wantTransitions int
+	}{
+		{"new open issue", []IssueRecord{issue("1", "open", 1)}, models.WorkStatusActive, models.ScheduleNext, 0},
+		{"new closed issue", []IssueRecord{issue("1", "closed", 1)}, models.WorkStatusCompleted, models.ScheduleClosed, 0},
+		{"new issue in progress", []IssueRecord{issue("1", "in progress", 1)}, models.WorkStatusInProgress, models.ScheduleNow, 0},
+		{"issue closed later", []IssueRecord{issue("1", "open", 1), issue("1", "closed", 2)}, models.WorkStatusCompleted, models.ScheduleClosed, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryStore()
+			im := NewImporter(store, nil)
+			for _, record := range tt.records {
+				if _, err := im.Import([]IssueRecord{record}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			work := store.works["work-github-1"]
+			if work.Metadata.Status != tt.wantStatus || work.Schedule != tt.wantSchedule {
+				t.Errorf("status %s in %s, want %s in %s", work.Metadata.Status, work.Schedule, tt.wantStatus, tt.wantSchedule)
+			}
+			if len(work.Transitions) != tt.wantTransitions {
+				t.Errorf("transitions = %+v, want %d", work.Transitions, tt.wantTransitions)
+			}
+			if work.IsCompleted() && (work.CompletedAt == nil || work.Metadata.ProgressPercent != 100) {
+				t.Errorf("completed item without completion time or full progress: %+v", work.Metadata)
+			}
+		})
+	}
+}
+
+func TestImportDryRunWritesNothing(t *testing.T) {
+	store := newMemoryStore()
+	config := DefaultImportConfig()
+	config.DryRun = true
+
+	result, err := NewImporter(store, config).Import([]IssueRecord{issue("1", "open", 1), issue("2", "open", 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Created) != 2 || store.creates != 0 {
+		t.Errorf("created %v with %d writes, want 2 reported and none written", result.Created, store.creates)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	long := strings.Repeat("é", 250)
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"first paragraph", "\n## Heading\n\nMore", "Heading"},
+		{"quote and list markers", "> - quoted", "quoted"},
+		{"long line is cut by runes", long, strings.Repeat("é", 197) + "..."},
+		{"empty", "\n \n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarize(tt.body); got != tt.want {
+				t.Errorf("summarize = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
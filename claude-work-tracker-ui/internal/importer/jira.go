@@ -0,0 +1,112 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// jiraTimeFormats are the date layouts seen in Jira CSV exports
+var jiraTimeFormats = []string{
+	"02/Jan/06 3:04 PM",
+	"02/Jan/06 15:04",
+	"2006-01-02 15:04",
+	"02/Jan/06",
+	"2006-01-02",
+	"2006-01-02T15:04:05.000-0700",
+	time.RFC3339,
+}
+
+// ParseJiraCSV parses a Jira issue CSV export. Jira repeats columns such as
+// Labels and issue links once per value, so every column with a matching
+// header is collected.
+func ParseJiraCSV(data []byte) ([]IssueRecord, error) {
+	rows, err := readCSV(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Jira CSV: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	header := rows[0]
+	var records []IssueRecord
+	for _, row := range rows[1:] {
+		get := func(name string) string {
+			values := columnValues(header, row, name)
+			if len(values) == 0 {
+				return ""
+			}
+			return values[0]
+		}
+
+		record := IssueRecord{
+			Source:     "jira",
+			ExternalID: get("Issue key"),
+			Title:      get("Summary"),
+			Body:       get("Description"),
+			State:      get("Status"),
+			Priority:   get("Priority"),
+			Labels:     columnValues(header, row, "Labels"),
+			CreatedAt:  parseJiraTime(get("Created")),
+			UpdatedAt:  parseJiraTime(get("Updated")),
+			DueAt:      parseJiraTime(get("Due Date")),
+		}
+
+		if assignee := get("Assignee"); assignee != "" {
+			record.Assignees = []string{assignee}
+		}
+		if parent := get("Parent"); parent != "" {
+			record.Links = append(record.Links, parent)
+		}
+
+		// Link columns look like "Outward issue link (Blocks)" or "Inward issue link (Relates)"
+		for i, name := range header {
+			if strings.Contains(strings.ToLower(name), "issue link") && i < len(row) && row[i] != "" {
+				record.Links = append(record.Links, row[i])
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// readCSV reads all rows, tolerating a UTF-8 BOM and ragged rows
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	return reader.ReadAll()
+}
+
+// columnValues returns all non-empty values in columns whose header matches name
+func columnValues(header, row []string, name string) []string {
+	var values []string
+	for i, column := range header {
+		if !strings.EqualFold(strings.TrimSpace(column), name) || i >= len(row) {
+			continue
+		}
+		if value := strings.TrimSpace(row[i]); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// parseJiraTime parses a Jira timestamp, returning zero time if the format is unknown
+func parseJiraTime(value string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range jiraTimeFormats {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
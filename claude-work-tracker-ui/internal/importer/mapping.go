@@ -0,0 +1,261 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FieldMapping describes how to read issues from an arbitrary CSV or JSON export
+type FieldMapping struct {
+	Source        string            `yaml:"source" json:"source"`                 // Name stored in the external reference
+	Format        string            `yaml:"format" json:"format"`                 // csv|json
+	RecordsPath   string            `yaml:"records_path" json:"records_path"`     // JSON: dotted path to the record array
+	Fields        MappedFields      `yaml:"fields" json:"fields"`                 // Column names or dotted JSON paths
+	ListSeparator string            `yaml:"list_separator" json:"list_separator"` // Separator for list values in a single cell
+	TimeFormat    string            `yaml:"time_format" json:"time_format"`       // Go time layout, defaults to RFC3339
+	Schedule      string            `yaml:"schedule" json:"schedule"`             // Schedule for new open items
+	StateMap      map[string]string `yaml:"state_map" json:"state_map"`           // Extra state -> status mappings
+	PriorityMap   map[string]string `yaml:"priority_map" json:"priority_map"`     // Extra priority mappings
+}
+
+// MappedFields names the source field for each Work attribute
+type MappedFields struct {
+	ID          string `yaml:"id" json:"id"`
+	Title       string `yaml:"title" json:"title"`
+	Description string `yaml:"description" json:"description"`
+	Labels      string `yaml:"labels" json:"labels"`
+	State       string `yaml:"state" json:"state"`
+	Priority    string `yaml:"priority" json:"priority"`
+	URL         string `yaml:"url" json:"url"`
+	Links       string `yaml:"links" json:"links"`
+	Assignees   string `yaml:"assignees" json:"assignees"`
+	CreatedAt   string `yaml:"created_at" json:"created_at"`
+	UpdatedAt   string `yaml:"updated_at" json:"updated_at"`
+	DueAt       string `yaml:"due_at" json:"due_at"`
+}
+
+// LoadFieldMapping reads a YAML or JSON mapping file
+func LoadFieldMapping(path string) (*FieldMapping, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+
+	mapping := &FieldMapping{
+		ListSeparator: ",",
+		TimeFormat:    time.RFC3339,
+	}
+	if err := yaml.Unmarshal(data, mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file: %w", err)
+	}
+
+	if mapping.Source == "" {
+		return nil, fmt.Errorf("mapping file must set a source name")
+	}
+	if mapping.Fields.ID == "" || mapping.Fields.Title == "" {
+		return nil, fmt.Errorf("mapping file must map at least id and title")
+	}
+
+	return mapping, nil
+}
+
+// ApplyTo merges the mapping's state, priority and schedule settings into an import config
+func (m *FieldMapping) ApplyTo(config *ImportConfig) {
+	for state, status := range m.StateMap {
+		config.StateMap[strings.ToLower(state)] = status
+	}
+	for source, priority := range m.PriorityMap {
+		config.PriorityMap[strings.ToLower(source)] = priority
+	}
+	if m.Schedule != "" {
+		config.DefaultSchedule = m.Schedule
+	}
+}
+
+// ParseMapped parses CSV or JSON data using a field mapping
+func ParseMapped(data []byte, mapping *FieldMapping) ([]IssueRecord, error) {
+	switch strings.ToLower(mapping.Format) {
+	case "csv":
+		return parseMappedCSV(data, mapping)
+	case "json", "":
+		return parseMappedJSON(data, mapping)
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", mapping.Format)
+	}
+}
+
+// parseMappedCSV reads records from CSV columns
+func parseMappedCSV(data []byte, mapping *FieldMapping) ([]IssueRecord, error) {
+	rows, err := readCSV(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	header := rows[0]
+	var records []IssueRecord
+	for _, row := range rows[1:] {
+		get := func(column string) []string {
+			if column == "" {
+				return nil
+			}
+			var values []string
+			for _, value := range columnValues(header, row, column) {
+				values = append(values, mapping.split(value)...)
+			}
+			return values
+		}
+		first := func(column string) string {
+			if column == "" {
+				return ""
+			}
+			values := columnValues(header, row, column)
+			if len(values) == 0 {
+				return ""
+			}
+			return values[0]
+		}
+
+		records = append(records, mapping.buildRecord(first, get))
+	}
+
+	return records, nil
+}
+
+// parseMappedJSON reads records from a JSON array, optionally nested under RecordsPath
+func parseMappedJSON(data []byte, mapping *FieldMapping) ([]IssueRecord, error) {
+	var root interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	if mapping.RecordsPath != "" {
+		root = lookupPath(root, mapping.RecordsPath)
+	}
+
+	items, ok := root.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an array of records")
+	}
+
+	var records []IssueRecord
+	for _, item := range items {
+		get := func(path string) []string {
+			if path == "" {
+				return nil
+			}
+			var values []string
+			for _, value := range toStrings(lookupPath(item, path)) {
+				values = append(values, mapping.split(value)...)
+			}
+			return values
+		}
+		first := func(path string) string {
+			if path == "" {
+				return ""
+			}
+			values := toStrings(lookupPath(item, path))
+			if len(values) == 0 {
+				return ""
+			}
+			return values[0]
+		}
+
+		records = append(records, mapping.buildRecord(first, get))
+	}
+
+	return records, nil
+}
+
+// buildRecord assembles an IssueRecord from field accessors
+func (m *FieldMapping) buildRecord(first func(string) string, list func(string) []string) IssueRecord {
+	return IssueRecord{
+		Source:     m.Source,
+		ExternalID: first(m.Fields.ID),
+		Title:      first(m.Fields.Title),
+		Body:       first(m.Fields.Description),
+		Labels:     list(m.Fields.Labels),
+		State:      first(m.Fields.State),
+		Priority:   first(m.Fields.Priority),
+		URL:        first(m.Fields.URL),
+		Links:      list(m.Fields.Links),
+		Assignees:  list(m.Fields.Assignees),
+		CreatedAt:  m.parseTime(first(m.Fields.CreatedAt)),
+		UpdatedAt:  m.parseTime(first(m.Fields.UpdatedAt)),
+		DueAt:      m.parseTime(first(m.Fields.DueAt)),
+	}
+}
+
+// split breaks a single cell into list values
+func (m *FieldMapping) split(value string) []string {
+	if m.ListSeparator == "" {
+		return []string{value}
+	}
+
+	var values []string
+	for _, part := range strings.Split(value, m.ListSeparator) {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+// parseTime parses a timestamp with the mapping's layout
+func (m *FieldMapping) parseTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	if t, err := time.Parse(m.TimeFormat, value); err == nil {
+		return t
+	}
+	return parseJiraTime(value)
+}
+
+// lookupPath walks a dotted path through decoded JSON objects
+func lookupPath(value interface{}, path string) interface{} {
+	for _, part := range strings.Split(path, ".") {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = obj[part]
+	}
+	return value
+}
+
+// toStrings flattens a decoded JSON value into strings. Objects in arrays
+// contribute their name, login or key field, matching most tracker exports.
+func toStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	case bool:
+		return []string{strconv.FormatBool(v)}
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			values = append(values, toStrings(item)...)
+		}
+		return values
+	case map[string]interface{}:
+		for _, key := range []string{"name", "login", "key", "title", "id"} {
+			if inner, ok := v[key]; ok {
+				return toStrings(inner)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// Commit match reasons
+const (
+	CommitMatchID      = "id"      // The commit message mentions the work ID
+	CommitMatchTrailer = "trailer" // The commit has a Work: <id> trailer
+	CommitMatchBranch  = "branch"  // The commit is on the work's branch
+	CommitMatchFile    = "file"    // The commit touches a file the work mentions
+)
+
+// LinkedCommit is a git commit attributed to a Work item
+type LinkedCommit struct {
+	SHA       string    `yaml:"sha" json:"sha"`
+	Author    string    `yaml:"author" json:"author"`
+	Subject   string    `yaml:"subject" json:"subject"`
+	Timestamp time.Time `yaml:"timestamp" json:"timestamp"`
+	MatchedBy string    `yaml:"matched_by" json:"matched_by"` // id, trailer, branch or file
+}
+
+// ShortSHA returns the abbreviated commit hash
+func (c LinkedCommit) ShortSHA() string {
+	if len(c.SHA) > 8 {
+		return c.SHA[:8]
+	}
+	return c.SHA
+}
+
+// HasCommit reports whether a commit is already linked to the work
+func (w *Work) HasCommit(sha string) bool {
+	for _, commit := range w.Commits {
+		if commit.SHA == sha {
+			return true
+		}
+	}
+	return false
+}
+
+// LinkCommit adds a commit to the work, keeping the list newest first. It returns false
+// if the commit was already linked.
+func (w *Work) LinkCommit(commit LinkedCommit) bool {
+	if w.HasCommit(commit.SHA) {
+		return false
+	}
+	w.Commits = append(w.Commits, commit)
+	sort.SliceStable(w.Commits, func(i, j int) bool {
+		return w.Commits[i].Timestamp.After(w.Commits[j].Timestamp)
+	})
+	return true
+}
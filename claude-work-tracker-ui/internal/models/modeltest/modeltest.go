@@ -0,0 +1,13 @@
+// Package modeltest builds work items for tests in any package
+package modeltest
+
+import "claude-work-tracker-ui/internal/models"
+
+// Work creates a work item with a parent, status, effort and progress
+func Work(id, parentID, status, effort string, progress int) *models.Work {
+	work := &models.Work{ID: id, ParentID: parentID}
+	work.Metadata.Status = status
+	work.Metadata.EstimatedEffort = effort
+	work.Metadata.ProgressPercent = progress
+	return work
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -26,6 +28,11 @@ type Task struct {
 	Phase    string `yaml:"phase,omitempty" json:"phase,omitempty"`       // Phase or category
 	Category string `yaml:"category,omitempty" json:"category,omitempty"` // Alternative grouping
 	
+	// Hierarchy, from the indentation of the checklist
+	ParentID string   `yaml:"parent_id,omitempty" json:"parent_id,omitempty"` // Task this is a subtask of
+	Subtasks []string `yaml:"subtasks,omitempty" json:"subtasks,omitempty"`   // IDs of direct subtasks
+	Depth    int      `yaml:"depth,omitempty" json:"depth,omitempty"`         // 0 for top-level tasks
+	
 	// Source tracking
 	Source     string `yaml:"source" json:"source"`           // artifact_id or "manual"
 	LineNumber int    `yaml:"line_number" json:"line_number"` // Line in markdown content
@@ -35,11 +42,14 @@ type Task struct {
 	UpdatedAt   time.Time  `yaml:"updated_at" json:"updated_at"`
 	CompletedAt *time.Time `yaml:"completed_at,omitempty" json:"completed_at,omitempty"`
 	
-	// Optional metadata
-	AssignedTo   string   `yaml:"assigned_to,omitempty" json:"assigned_to,omitempty"`
-	Dependencies []string `yaml:"dependencies,omitempty" json:"dependencies,omitempty"`
-	Tags         []string `yaml:"tags,omitempty" json:"tags,omitempty"`
-	Notes        string   `yaml:"notes,omitempty" json:"notes,omitempty"`
+	// Optional metadata, written inline as @owner, after:task-id, #tag, due:2026-11-01 and ~2h
+	AssignedTo   string     `yaml:"assigned_to,omitempty" json:"assigned_to,omitempty"`
+	Dependencies []string   `yaml:"dependencies,omitempty" json:"dependencies,omitempty"`
+	Tags         []string   `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Notes        string     `yaml:"notes,omitempty" json:"notes,omitempty"`
+	DueAt        *time.Time `yaml:"due_at,omitempty" json:"due_at,omitempty"`
+	Estimate     string     `yaml:"estimate,omitempty" json:"estimate,omitempty"` // As written, e.g. 2h, 30m, 1d
+	LinkedWorkID string     `yaml:"linked_work_id,omitempty" json:"linked_work_id,omitempty"` // Promoted to its own work item, written as → [[work-id]]
 }
 
 // Update represents a progress update on a Work item
@@ -56,7 +66,7 @@ type Update struct {
 	// Context
 	Author     string `yaml:"author" json:"author"`           // "Claude" or user name
 	SessionID  string `yaml:"session_id,omitempty" json:"session_id,omitempty"`
-	UpdateType string `yaml:"update_type" json:"update_type"` // "automatic", "manual"
+	UpdateType string `yaml:"update_type" json:"update_type"` // "automatic", "manual"; see Kind for what it's about
 	
 	// Status changes
 	TasksCompleted []string `yaml:"tasks_completed,omitempty" json:"tasks_completed,omitempty"`
@@ -65,6 +75,11 @@ type Update struct {
 	// Progress indicators
 	ProgressBefore int `yaml:"progress_before,omitempty" json:"progress_before,omitempty"`
 	ProgressAfter  int `yaml:"progress_after,omitempty" json:"progress_after,omitempty"`
+	
+	// What the update records, and the artifacts it refers to
+	Kind        UpdateKind         `yaml:"kind,omitempty" json:"kind,omitempty"` // Progress when empty
+	Attachments []UpdateAttachment `yaml:"attachments,omitempty" json:"attachments,omitempty"`
+	EditedAt    *time.Time         `yaml:"edited_at,omitempty" json:"edited_at,omitempty"`
 }
 
 // TaskStatusFromMarkdown converts markdown checkbox syntax to TaskStatus
@@ -138,6 +153,24 @@ func (t *Task) MarkAsInProgress() {
 	t.UpdatedAt = time.Now()
 }
 
+// ParseTaskStatus reads a task status as written on the command line, accepting the
+// status names and a few common aliases such as done and doing
+func ParseTaskStatus(s string) (TaskStatus, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "todo", "open":
+		return TaskStatusTodo, nil
+	case "in_progress", "in-progress", "doing", "started":
+		return TaskStatusInProgress, nil
+	case "completed", "done", "x":
+		return TaskStatusCompleted, nil
+	case "blocked":
+		return TaskStatusBlocked, nil
+	case "cancelled", "canceled":
+		return TaskStatusCancelled, nil
+	}
+	return "", fmt.Errorf("unknown task status: %s", s)
+}
+
 // GetDisplayStatus returns a human-readable status
 func (t *Task) GetDisplayStatus() string {
 	switch t.Status {
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"os"
+	"time"
+)
+
+// TransitionActorAutomation is the actor recorded for rule-driven transitions
+const TransitionActorAutomation = "automation"
+
+// Transition records one status change of a Work item
+type Transition struct {
+	From      string    `yaml:"from" json:"from"`
+	To        string    `yaml:"to" json:"to"`
+	Rule      string    `yaml:"rule,omitempty" json:"rule,omitempty"`     // Rule that made the change, for automatic transitions
+	Reason    string    `yaml:"reason,omitempty" json:"reason,omitempty"` // Why the status changed
+	Automatic bool      `yaml:"automatic" json:"automatic"`
+	Actor     string    `yaml:"actor" json:"actor"` // User name, "automation" or an import source
+	At        time.Time `yaml:"at" json:"at"`
+}
+
+// CurrentActor returns the name recorded for manual transitions
+func CurrentActor() string {
+	if user := os.Getenv("USER"); user != "" {
+		return user
+	}
+	return "user"
+}
+
+// RecordTransition appends a transition to the work's history
+func (w *Work) RecordTransition(t Transition) {
+	if t.At.IsZero() {
+		t.At = time.Now()
+	}
+	w.Transitions = append(w.Transitions, t)
+}
+
+// RecordStatusChange records a manual transition from oldStatus to the current status,
+// unless the status is unchanged or the latest transition already covers it
+func (w *Work) RecordStatusChange(oldStatus, actor, reason string) {
+	newStatus := w.Metadata.Status
+	if oldStatus == newStatus {
+		return
+	}
+	if last := w.LastTransition(); last != nil && last.From == oldStatus && last.To == newStatus {
+		return
+	}
+	w.RecordTransition(Transition{
+		From:   oldStatus,
+		To:     newStatus,
+		Reason: reason,
+		Actor:  actor,
+	})
+}
+
+// LastTransition returns the most recent transition, or nil if there is none
+func (w *Work) LastTransition() *Transition {
+	if len(w.Transitions) == 0 {
+		return nil
+	}
+	return &w.Transitions[len(w.Transitions)-1]
+}
+
+// blockingTransition returns the transition that moved the work into its current blocked state
+func (w *Work) blockingTransition() *Transition {
+	for i := len(w.Transitions) - 1; i >= 0; i-- {
+		if w.Transitions[i].To == WorkStatusBlocked {
+			return &w.Transitions[i]
+		}
+	}
+	return nil
+}
+
+// StatusBeforeBlocked returns the status the work had before it was blocked, or active if
+// unknown. Being blocked again while blocked keeps the status from before the first block.
+func (w *Work) StatusBeforeBlocked() string {
+	for i := len(w.Transitions) - 1; i >= 0; i-- {
+		t := w.Transitions[i]
+		if t.To != WorkStatusBlocked || t.From == WorkStatusBlocked {
+			continue
+		}
+		if t.From != "" {
+			return t.From
+		}
+		break
+	}
+	return WorkStatusActive
+}
+
+// BlockedByRule returns the rule that blocked the work, or "" if it was blocked by hand
+func (w *Work) BlockedByRule() string {
+	if w.Metadata.Status != WorkStatusBlocked {
+		return ""
+	}
+	if t := w.blockingTransition(); t != nil && t.Automatic {
+		return t.Rule
+	}
+	return ""
+}
+
+// Unblock restores the status the work had before it was blocked and clears its blockers
+func (w *Work) Unblock() {
+	if w.Metadata.Status != WorkStatusBlocked {
+		return
+	}
+	w.Metadata.Status = w.StatusBeforeBlocked()
+	w.Metadata.BlockedBy = nil
+	w.UpdatedAt = time.Now()
+}
+
+// SnoozedTransition remembers a rejected automatic transition so it isn't proposed again too soon
+type SnoozedTransition struct {
+	Rule       string    `yaml:"rule" json:"rule"`
+	RejectedAt time.Time `yaml:"rejected_at" json:"rejected_at"`
+	Until      time.Time `yaml:"until" json:"until"`
+	RejectedBy string    `yaml:"rejected_by,omitempty" json:"rejected_by,omitempty"`
+}
+
+// IsTransitionSnoozed reports whether a rule's suggestion was rejected and is still snoozed
+func (w *Work) IsTransitionSnoozed(rule string, now time.Time) bool {
+	for _, snoozed := range w.SnoozedTransitions {
+		if snoozed.Rule == rule && now.Before(snoozed.Until) {
+			return true
+		}
+	}
+	return false
+}
+
+// SnoozeTransition rejects a rule's suggestion until the given time, dropping expired snoozes
+func (w *Work) SnoozeTransition(rule string, until time.Time, actor string) {
+	now := time.Now()
+	var kept []SnoozedTransition
+	for _, snoozed := range w.SnoozedTransitions {
+		if snoozed.Rule != rule && now.Before(snoozed.Until) {
+			kept = append(kept, snoozed)
+		}
+	}
+	w.SnoozedTransitions = append(kept, SnoozedTransition{
+		Rule:       rule,
+		RejectedAt: now,
+		Until:      until,
+		RejectedBy: actor,
+	})
+}
+
+// RecordTransitionFrom records an automatic transition made by a rule, if it changed the status
+func (w *Work) RecordTransitionFrom(old *Work, rule, reason, actor string) {
+	if old.Metadata.Status == w.Metadata.Status {
+		return
+	}
+	w.RecordTransition(Transition{
+		From:      old.Metadata.Status,
+		To:        w.Metadata.Status,
+		Rule:      rule,
+		Reason:    reason,
+		Automatic: true,
+		Actor:     actor,
+	})
+}
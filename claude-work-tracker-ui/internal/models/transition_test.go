@@ -0,0 +1,169 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSnoozeTransition(t *testing.T) {
+	now := time.Now()
+	work := &Work{ID: "work-1", SnoozedTransitions: []SnoozedTransition{
+		{Rule: "stale", Until: now.Add(-time.Hour)},  // Expired, dropped
+		{Rule: "finish", Until: now.Add(time.Hour)},  // Replaced
+		{Rule: "archive", Until: now.Add(time.Hour)}, // Kept
+	}}
+
+	work.SnoozeTransition("finish", now.Add(48*time.Hour), "sam")
+
+	var rules []string
+	for _, snoozed := range work.SnoozedTransitions {
+		rules = append(rules, snoozed.Rule)
+	}
+	if len(rules) != 2 || rules[0] != "archive" || rules[1] != "finish" {
+		t.Fatalf("snoozed rules = %v, want [archive finish]", rules)
+	}
+	if last := work.SnoozedTransitions[1]; last.RejectedBy != "sam" || last.RejectedAt.IsZero() {
+		t.Errorf("snooze = %+v", last)
+	}
+
+	tests := []struct {
+		rule string
+		at   time.Time
+		want bool
+	}{
+		{"finish", now, true},
+		{"finish", now.Add(47 * time.Hour), true},
+		{"finish", now.Add(49 * time.Hour), false},
+		{"archive", now.Add(2 * time.Hour), false},
+		{"stale", now, false},
+		{"other", now, false},
+	}
+	for _, tt := range tests {
+		if got := work.IsTransitionSnoozed(tt.rule, tt.at); got != tt.want {
+			t.Errorf("IsTransitionSnoozed(%s, %v) = %v, want %v", tt.rule, tt.at.Sub(now), got, tt.want)
+		}
+	}
+}
+
+func TestRecordStatusChange(t *testing.T) {
+	work := &Work{ID: "work-1", Metadata: WorkMetadata{Status: WorkStatusInProgress}}
+
+	work.RecordStatusChange(WorkStatusInProgress, "sam", "")
+	if len(work.Transitions) != 0 {
+		t.Fatalf("unchanged status recorded %+v", work.Transitions)
+	}
+
+	work.RecordStatusChange(WorkStatusActive, "sam", "picked up")
+	last := work.LastTransition()
+	if len(work.Transitions) != 1 || last.From != WorkStatusActive || last.To != WorkStatusInProgress ||
+		last.Actor != "sam" || last.Reason != "picked up" || last.Automatic || last.At.IsZero() {
+		t.Fatalf("transitions = %+v", work.Transitions)
+	}
+
+	// A transition already recorded, e.g. by a rule, isn't recorded twice
+	work.RecordStatusChange(WorkStatusActive, "sam", "")
+	if len(work.Transitions) != 1 {
+		t.Errorf("transition recorded twice: %+v", work.Transitions)
+	}
+}
+
+func TestBlockAndUnblock(t *testing.T) {
+	transition := func(from, to string) Transition {
+		return Transition{From: from, To: to, Actor: "sam", At: time.Now()}
+	}
+
+	tests := []struct {
+		name        string
+		status      string
+		transitions []Transition
+		want        string // Status after Unblock
+	}{
+		{
+			name:        "block then unblock",
+			status:      WorkStatusBlocked,
+			transitions: []Transition{transition(WorkStatusActive, WorkStatusInProgress), transition(WorkStatusInProgress, WorkStatusBlocked)},
+			want:        WorkStatusInProgress,
+		},
+		{
+			name:   "blocked a second time",
+			status: WorkStatusBlocked,
+			transitions: []Transition{
+				transition(WorkStatusInProgress, WorkStatusBlocked),
+				transition(WorkStatusBlocked, WorkStatusInProgress),
+				transition(WorkStatusInProgress, WorkStatusOnHold),
+				transition(WorkStatusOnHold, WorkStatusBlocked),
+			},
+			want: WorkStatusOnHold,
+		},
+		{
+			name:   "blocked again while blocked",
+			status: WorkStatusBlocked,
+			transitions: []Transition{
+				transition(WorkStatusOnHold, WorkStatusBlocked),
+				{From: WorkStatusBlocked, To: WorkStatusBlocked, Rule: "waiting_on_dependency", Automatic: true, At: time.Now()},
+			},
+			want: WorkStatusOnHold,
+		},
+		{
+			name:   "no history",
+			status: WorkStatusBlocked,
+			want:   WorkStatusActive,
+		},
+		{
+			name:        "history without the block",
+			status:      WorkStatusBlocked,
+			transitions: []Transition{transition(WorkStatusActive, WorkStatusInProgress)},
+			want:        WorkStatusActive,
+		},
+		{
+			name:        "blocked from an unknown status",
+			status:      WorkStatusBlocked,
+			transitions: []Transition{transition(WorkStatusOnHold, WorkStatusActive), transition("", WorkStatusBlocked)},
+			want:        WorkStatusActive,
+		},
+		{
+			name:        "not blocked",
+			status:      WorkStatusInProgress,
+			transitions: []Transition{transition(WorkStatusOnHold, WorkStatusBlocked), transition(WorkStatusBlocked, WorkStatusInProgress)},
+			want:        WorkStatusInProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			work := &Work{ID: "work-1", Transitions: tt.transitions}
+			work.Metadata.Status = tt.status
+			work.Metadata.BlockedBy = []string{"work-2"}
+
+			work.Unblock()
+			if work.Metadata.Status != tt.want {
+				t.Errorf("status after Unblock = %s, want %s", work.Metadata.Status, tt.want)
+			}
+			if blocked := tt.status == WorkStatusBlocked; blocked == (len(work.Metadata.BlockedBy) > 0) {
+				t.Errorf("blockers = %v after unblocking a %s item", work.Metadata.BlockedBy, tt.status)
+			}
+		})
+	}
+}
+
+func TestBlockedByRule(t *testing.T) {
+	work := &Work{ID: "work-1"}
+	work.Metadata.Status = WorkStatusBlocked
+	if rule := work.BlockedByRule(); rule != "" {
+		t.Errorf("rule without history = %q", rule)
+	}
+
+	work.RecordTransition(Transition{From: WorkStatusInProgress, To: WorkStatusBlocked, Rule: "waiting_on_dependency", Automatic: true})
+	if rule := work.BlockedByRule(); rule != "waiting_on_dependency" {
+		t.Errorf("rule = %q, want waiting_on_dependency", rule)
+	}
+
+	// Unblocked, then blocked again by hand
+	work.Unblock()
+	work.RecordStatusChange(WorkStatusBlocked, "sam", "")
+	work.Metadata.Status = WorkStatusBlocked
+	work.RecordStatusChange(WorkStatusInProgress, "sam", "")
+	if rule := work.BlockedByRule(); rule != "" {
+		t.Errorf("rule after blocking by hand = %q, want none", rule)
+	}
+}
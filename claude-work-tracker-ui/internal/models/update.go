@@ -0,0 +1,111 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// UpdateKind says what an update records
+type UpdateKind string
+
+const (
+	UpdateKindProgress       UpdateKind = "progress"
+	UpdateKindBlockerRaised  UpdateKind = "blocker_raised"
+	UpdateKindBlockerCleared UpdateKind = "blocker_cleared"
+	UpdateKindDecision       UpdateKind = "decision"
+	UpdateKindScopeChange    UpdateKind = "scope_change"
+	UpdateKindReview         UpdateKind = "review"
+)
+
+// UpdateKinds lists the kinds of update in the order they're offered
+var UpdateKinds = []UpdateKind{
+	UpdateKindProgress,
+	UpdateKindBlockerRaised,
+	UpdateKindBlockerCleared,
+	UpdateKindDecision,
+	UpdateKindScopeChange,
+	UpdateKindReview,
+}
+
+// UpdateAttachment links an update to an artifact, by artifact ID or by a path relative to
+// the work directory, as in ![[artifacts/plans/plan.md]]
+type UpdateAttachment struct {
+	Ref   string `yaml:"ref" json:"ref"`
+	Title string `yaml:"title,omitempty" json:"title,omitempty"`
+}
+
+// ParseUpdateKind reads an update kind, accepting dashes or spaces for underscores
+func ParseUpdateKind(value string) (UpdateKind, error) {
+	normalized := strings.NewReplacer("-", "_", " ", "_").Replace(strings.ToLower(strings.TrimSpace(value)))
+	if normalized == "" {
+		return UpdateKindProgress, nil
+	}
+	for _, kind := range UpdateKinds {
+		if string(kind) == normalized {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown update kind: %s", value)
+}
+
+// Label returns the kind as shown to people
+func (k UpdateKind) Label() string {
+	switch k {
+	case UpdateKindBlockerRaised:
+		return "Blocker raised"
+	case UpdateKindBlockerCleared:
+		return "Blocker cleared"
+	case UpdateKindDecision:
+		return "Decision"
+	case UpdateKindScopeChange:
+		return "Scope change"
+	case UpdateKindReview:
+		return "Review"
+	}
+	return "Progress"
+}
+
+// Icon returns the emoji shown next to updates of the kind
+func (k UpdateKind) Icon() string {
+	switch k {
+	case UpdateKindBlockerRaised:
+		return "🚧"
+	case UpdateKindBlockerCleared:
+		return "🟢"
+	case UpdateKindDecision:
+		return "⚖️"
+	case UpdateKindScopeChange:
+		return "📐"
+	case UpdateKindReview:
+		return "🔍"
+	}
+	return "📝"
+}
+
+// GetKind returns the update's kind, progress for updates written before kinds existed
+func (u *Update) GetKind() UpdateKind {
+	if u.Kind == "" {
+		return UpdateKindProgress
+	}
+	return u.Kind
+}
+
+// lastUpdateID is the timestamp of the latest update ID handed out
+var lastUpdateID int64
+
+// NewUpdateID returns an ID for a new update. IDs follow the clock but never repeat within
+// a process, even for updates created in the same nanosecond.
+func NewUpdateID() string {
+	for {
+		last := atomic.LoadInt64(&lastUpdateID)
+		next := time.Now().UnixNano()
+		if next <= last {
+			next = last + 1
+		}
+		if atomic.CompareAndSwapInt64(&lastUpdateID, last, next) {
+			return fmt.Sprintf("update-%d", next)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNewUpdateIDIsUnique(t *testing.T) {
+	const workers, perWorker = 8, 500
+
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids := make([]string, perWorker)
+			for j := range ids {
+				ids[j] = NewUpdateID()
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, id := range ids {
+				seen[id] = true
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(seen) != workers*perWorker {
+		t.Errorf("got %d distinct IDs, want %d", len(seen), workers*perWorker)
+	}
+}
+
+func TestNewUpdateIDFollowsTheClock(t *testing.T) {
+	previous := int64(0)
+	for i := 0; i < 100; i++ {
+		id := NewUpdateID()
+		nanos, err := strconv.ParseInt(strings.TrimPrefix(id, "update-"), 10, 64)
+		if err != nil {
+			t.Fatalf("ID %q isn't update-<nanoseconds>", id)
+		}
+		if nanos <= previous {
+			t.Fatalf("ID %q isn't after the one before", id)
+		}
+		previous = nanos
+	}
+}
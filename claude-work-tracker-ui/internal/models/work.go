@@ -19,22 +19,42 @@ type Work struct {
 	StartedAt     *time.Time `yaml:"started_at,omitempty" json:"started_at,omitempty"`
 	CompletedAt   *time.Time `yaml:"completed_at,omitempty" json:"completed_at,omitempty"`
 	
+	// Deadlines
+	DueAt         *time.Time `yaml:"due_at,omitempty" json:"due_at,omitempty"`     // When the work must be finished
+	StartBy       *time.Time `yaml:"start_by,omitempty" json:"start_by,omitempty"` // When work must have started to meet the due date
+	
 	// Context
-	GitContext    GitContext `yaml:"git_context" json:"git_context"`
-	SessionNumber string     `yaml:"session_number" json:"session_number"`
+	GitContext     GitContext `yaml:"git_context" json:"git_context"`
+	BranchMergedAt *time.Time `yaml:"branch_merged_at,omitempty" json:"branch_merged_at,omitempty"` // When GitContext.Branch was merged into the default branch
+	SessionNumber  string     `yaml:"session_number" json:"session_number"`
 	
 	// Associations - 3-tier system
 	TechnicalTags  []string `yaml:"technical_tags" json:"technical_tags"`               // Incidental relationships
 	ArtifactRefs   []string `yaml:"artifact_refs" json:"artifact_refs"`                 // Strong references to artifacts
 	GroupID        string   `yaml:"group_id,omitempty" json:"group_id,omitempty"`       // Explicit grouping
 	
+	// Hierarchy, from promoting a task or phase into its own work item
+	ParentID     string   `yaml:"parent_id,omitempty" json:"parent_id,omitempty"`         // Work this was split out of
+	ChildIDs     []string `yaml:"child_ids,omitempty" json:"child_ids,omitempty"`         // Work split out of this one
+	PromotedFrom string   `yaml:"promoted_from,omitempty" json:"promoted_from,omitempty"` // Work or artifact whose checklist links here
+	
 	// Work-specific metadata
 	Metadata      WorkMetadata `yaml:"metadata" json:"metadata"`
 	
+	// Status history, oldest first
+	Transitions        []Transition        `yaml:"transitions,omitempty" json:"transitions,omitempty"`
+	SnoozedTransitions []SnoozedTransition `yaml:"snoozed_transitions,omitempty" json:"snoozed_transitions,omitempty"` // Rejected automatic transitions
+
+	// Git commits linked to this work, newest first
+	Commits []LinkedCommit `yaml:"commits,omitempty" json:"commits,omitempty"`
+	
 	// Enhanced structure fields
 	OverviewUpdated *time.Time `yaml:"overview_updated,omitempty" json:"overview_updated,omitempty"`
 	UpdatesRef      string     `yaml:"updates_ref,omitempty" json:"updates_ref,omitempty"`
 	
+	// External tracker linkage for imported issues
+	External      *ExternalRef `yaml:"external,omitempty" json:"external,omitempty"`
+	
 	// Content is the markdown body after frontmatter
 	Content       string `yaml:"-" json:"content"`
 	
@@ -59,6 +79,7 @@ type WorkMetadata struct {
 	Milestones        []string `yaml:"milestones,omitempty" json:"milestones,omitempty"`          // Key milestones
 	CompletedTasks    []string `yaml:"completed_tasks,omitempty" json:"completed_tasks,omitempty"` // Completed sub-tasks
 	PendingTasks      []string `yaml:"pending_tasks,omitempty" json:"pending_tasks,omitempty"`    // Remaining sub-tasks
+	ProgressFromTasks bool     `yaml:"progress_from_tasks,omitempty" json:"progress_from_tasks,omitempty"` // Derive progress from the checklist
 	
 	// Dependency management
 	BlockedBy         []string `yaml:"blocked_by,omitempty" json:"blocked_by,omitempty"`          // Work IDs blocking this
@@ -85,6 +106,17 @@ type WorkMetadata struct {
 	DecayWarning      bool     `yaml:"decay_warning" json:"decay_warning"`                       // Flag for stale work
 }
 
+// ExternalRef links a Work item to an issue in an external tracker
+type ExternalRef struct {
+	Source          string    `yaml:"source" json:"source"`                                       // github|jira|csv|json or a custom source name
+	ID              string    `yaml:"id" json:"id"`                                               // Issue number or key in the source system
+	URL             string    `yaml:"url,omitempty" json:"url,omitempty"`                        // Link back to the issue
+	Links           []string  `yaml:"links,omitempty" json:"links,omitempty"`                    // Linked issues, PRs and URLs
+	Labels          []string  `yaml:"labels,omitempty" json:"labels,omitempty"`                  // Source labels as of the last import
+	SourceUpdatedAt time.Time `yaml:"source_updated_at,omitempty" json:"source_updated_at,omitempty"` // Last update in the source system
+	ImportedAt      time.Time `yaml:"imported_at" json:"imported_at"`                             // Last time this item was imported
+}
+
 // Work status constants
 const (
 	WorkStatusDraft      = "draft"       // Initial creation
@@ -105,6 +137,18 @@ const (
 	WorkPriorityCritical = "critical"
 )
 
+// Due state constants
+const (
+	DueStateNone         = ""              // No due date or start-by date set
+	DueStateOnTrack      = "on_track"      // Deadline is more than DueSoonWindow away
+	DueStateDueSoon      = "due_soon"      // Due within DueSoonWindow
+	DueStateStartOverdue = "start_overdue" // Start-by date passed without the work starting
+	DueStateOverdue      = "overdue"       // Due date passed before completion
+)
+
+// DueSoonWindow is how far ahead of a due date work counts as due soon
+const DueSoonWindow = 48 * time.Hour
+
 // Work effort estimation constants
 const (
 	WorkEffortSmall  = "small"  // 1-2 days
@@ -138,6 +182,8 @@ func (w *Work) GetDisplaySchedule() string {
 		return "NEXT"
 	case ScheduleLater:
 		return "LATER"
+	case ScheduleClosed:
+		return "CLOSED"
 	default:
 		return "Unscheduled"
 	}
@@ -159,10 +205,55 @@ func (w *Work) IsCompleted() bool {
 	return w.Metadata.Status == WorkStatusCompleted
 }
 
+// IsClosed returns true if this work is completed, canceled or archived
+func (w *Work) IsClosed() bool {
+	switch w.Metadata.Status {
+	case WorkStatusCompleted, WorkStatusCanceled, WorkStatusArchived:
+		return true
+	}
+	return w.Schedule == ScheduleClosed
+}
+
+// GetDueState returns the deadline state of this work at the given time
+func (w *Work) GetDueState(now time.Time) string {
+	if w.IsClosed() || (w.DueAt == nil && w.StartBy == nil) {
+		return DueStateNone
+	}
+	
+	if w.DueAt != nil && now.After(*w.DueAt) {
+		return DueStateOverdue
+	}
+	
+	// Start-by only matters until someone actually starts the work
+	started := w.StartedAt != nil || w.Metadata.Status == WorkStatusInProgress
+	if w.StartBy != nil && !started && now.After(*w.StartBy) {
+		return DueStateStartOverdue
+	}
+	
+	if w.DueAt != nil && w.DueAt.Sub(now) <= DueSoonWindow {
+		return DueStateDueSoon
+	}
+	
+	return DueStateOnTrack
+}
+
+// IsOverdue returns true if the due date or start-by date has passed
+func (w *Work) IsOverdue() bool {
+	state := w.GetDueState(time.Now())
+	return state == DueStateOverdue || state == DueStateStartOverdue
+}
+
+// IsDueSoon returns true if the due date is within DueSoonWindow
+func (w *Work) IsDueSoon() bool {
+	return w.GetDueState(time.Now()) == DueStateDueSoon
+}
+
 // NeedsAttention returns true if this work needs immediate attention
 func (w *Work) NeedsAttention() bool {
 	return w.IsBlocked() || 
 		   w.Metadata.DecayWarning ||
+		   w.IsOverdue() ||
+		   w.IsDueSoon() ||
 		   (w.IsActive() && w.Metadata.ProgressPercent == 0)
 }
 
@@ -233,6 +324,11 @@ func (w *Work) MatchesSearch(query string) bool {
 		return true
 	}
 	
+	// Search in external issue key
+	if w.External != nil && strings.Contains(strings.ToLower(w.External.ID), query) {
+		return true
+	}
+	
 	return false
 }
 
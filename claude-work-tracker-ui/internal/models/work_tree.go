@@ -0,0 +1,239 @@
+package models
+
+import (
+	"fmt"
+	"math"
+)
+
+// effortDays is roughly how many working days each effort estimate stands for, used to
+// weight children when rolling progress up and to sum their effort
+var effortDays = map[string]int{
+	WorkEffortSmall:  2,
+	WorkEffortMedium: 10,
+	WorkEffortLarge:  40,
+	WorkEffortEpic:   80,
+}
+
+// EffortDays returns the working days a work item's effort estimate stands for
+func (w *Work) EffortDays() int {
+	if days, ok := effortDays[w.Metadata.EstimatedEffort]; ok {
+		return days
+	}
+	return effortDays[WorkEffortMedium]
+}
+
+// EffortForDays returns the smallest effort estimate covering the given working days
+func EffortForDays(days int) string {
+	for _, effort := range []string{WorkEffortSmall, WorkEffortMedium, WorkEffortLarge} {
+		if days <= effortDays[effort] {
+			return effort
+		}
+	}
+	return WorkEffortEpic
+}
+
+// IsEpic returns true if this work is broken down into child work items or estimated as an epic
+func (w *Work) IsEpic() bool {
+	return len(w.ChildIDs) > 0 || w.Metadata.EstimatedEffort == WorkEffortEpic
+}
+
+// WorkTree indexes the parent/child relationships of a set of work items. ParentID is
+// authoritative; ChildIDs only adds children that don't name a parent themselves.
+type WorkTree struct {
+	byID     map[string]*Work
+	children map[string][]*Work
+}
+
+// NewWorkTree builds the hierarchy of the given work items. Links to items that aren't in
+// the set are ignored.
+func NewWorkTree(works []*Work) *WorkTree {
+	tree := &WorkTree{
+		byID:     make(map[string]*Work),
+		children: make(map[string][]*Work),
+	}
+	for _, work := range works {
+		tree.byID[work.ID] = work
+	}
+
+	linked := make(map[string]bool)
+	for _, work := range works {
+		if _, ok := tree.byID[work.ParentID]; ok && work.ParentID != work.ID {
+			tree.children[work.ParentID] = append(tree.children[work.ParentID], work)
+			linked[work.ID] = true
+		}
+	}
+	for _, work := range works {
+		for _, childID := range work.ChildIDs {
+			child, ok := tree.byID[childID]
+			if !ok || linked[childID] || child.ParentID != "" || childID == work.ID {
+				continue
+			}
+			tree.children[work.ID] = append(tree.children[work.ID], child)
+			linked[childID] = true
+		}
+	}
+	return tree
+}
+
+// Work returns the work item with the given ID, or nil
+func (t *WorkTree) Work(id string) *Work {
+	return t.byID[id]
+}
+
+// Children returns the direct children of a work item
+func (t *WorkTree) Children(id string) []*Work {
+	return t.children[id]
+}
+
+// Parent returns the parent of a work item, or nil for a top-level item
+func (t *WorkTree) Parent(id string) *Work {
+	work := t.byID[id]
+	if work == nil {
+		return nil
+	}
+	if parent := t.byID[work.ParentID]; parent != nil && parent.ID != id {
+		return parent
+	}
+	for parentID, children := range t.children {
+		for _, child := range children {
+			if child.ID == id {
+				return t.byID[parentID]
+			}
+		}
+	}
+	return nil
+}
+
+// Ancestors returns the parents of a work item, nearest first. A cycle in the stored links
+// ends the walk.
+func (t *WorkTree) Ancestors(id string) []*Work {
+	var ancestors []*Work
+	seen := map[string]bool{id: true}
+	for parent := t.Parent(id); parent != nil && !seen[parent.ID]; parent = t.Parent(parent.ID) {
+		seen[parent.ID] = true
+		ancestors = append(ancestors, parent)
+	}
+	return ancestors
+}
+
+// Depth returns how many parents a work item has, 0 for a top-level item
+func (t *WorkTree) Depth(id string) int {
+	return len(t.Ancestors(id))
+}
+
+// Descendants returns every work item below the given one, depth first
+func (t *WorkTree) Descendants(id string) []*Work {
+	var descendants []*Work
+	seen := map[string]bool{id: true}
+	var walk func(string)
+	walk = func(parentID string) {
+		for _, child := range t.children[parentID] {
+			if seen[child.ID] {
+				continue
+			}
+			seen[child.ID] = true
+			descendants = append(descendants, child)
+			walk(child.ID)
+		}
+	}
+	walk(id)
+	return descendants
+}
+
+// OpenDescendants returns the work items below the given one that aren't closed yet
+func (t *WorkTree) OpenDescendants(id string) []*Work {
+	var open []*Work
+	for _, work := range t.Descendants(id) {
+		if !work.IsClosed() {
+			open = append(open, work)
+		}
+	}
+	return open
+}
+
+// CheckParent returns an error if making parentID the parent of workID would create a cycle
+func (t *WorkTree) CheckParent(workID, parentID string) error {
+	if parentID == "" {
+		return nil
+	}
+	if parentID == workID {
+		return fmt.Errorf("%s can't be its own parent", workID)
+	}
+	for _, descendant := range t.Descendants(workID) {
+		if descendant.ID == parentID {
+			return fmt.Errorf("%s is already below %s", parentID, workID)
+		}
+	}
+	return nil
+}
+
+// WorkRollup summarizes the children of a work item
+type WorkRollup struct {
+	Children   int    // Direct children
+	Open       int    // Descendants that aren't closed
+	Completed  int    // Direct children that are completed
+	InProgress bool   // Whether any descendant is in progress
+	Progress   int    // Effort-weighted progress of the direct children, cancelled ones left out
+	EffortDays int    // Working days of all leaf descendants
+	Effort     string // Effort estimate covering EffortDays
+}
+
+// Rollup summarizes the children of a work item. The second result is false for items
+// without children.
+func (t *WorkTree) Rollup(id string) (WorkRollup, bool) {
+	return t.rollup(id, map[string]bool{id: true})
+}
+
+// rollup summarizes a work item's children, skipping those already seen so a cycle in the
+// stored links can't recurse forever
+func (t *WorkTree) rollup(id string, seen map[string]bool) (WorkRollup, bool) {
+	children := t.children[id]
+	if len(children) == 0 {
+		return WorkRollup{}, false
+	}
+
+	rollup := WorkRollup{Children: len(children)}
+	var weighted, weights float64
+	for _, child := range children {
+		if child.IsCompleted() {
+			rollup.Completed++
+		}
+		if child.Metadata.Status == WorkStatusCanceled {
+			continue
+		}
+
+		progress := child.Metadata.ProgressPercent
+		if child.IsCompleted() || child.Metadata.Status == WorkStatusArchived {
+			progress = 100
+		}
+		weight := float64(child.EffortDays())
+		if !seen[child.ID] {
+			seen[child.ID] = true
+			if sub, ok := t.rollup(child.ID, seen); ok {
+				weight = math.Max(weight, float64(sub.EffortDays))
+				if !child.Metadata.ProgressFromTasks && !child.IsCompleted() {
+					progress = sub.Progress
+				}
+			}
+		}
+		weighted += weight * float64(progress)
+		weights += weight
+	}
+	if weights > 0 {
+		rollup.Progress = int(math.Round(weighted / weights))
+	}
+
+	for _, work := range t.Descendants(id) {
+		if !work.IsClosed() {
+			rollup.Open++
+		}
+		if work.Metadata.Status == WorkStatusInProgress {
+			rollup.InProgress = true
+		}
+		if len(t.children[work.ID]) == 0 && work.Metadata.Status != WorkStatusCanceled {
+			rollup.EffortDays += work.EffortDays()
+		}
+	}
+	rollup.Effort = EffortForDays(rollup.EffortDays)
+	return rollup, true
+}
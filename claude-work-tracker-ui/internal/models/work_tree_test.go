@@ -0,0 +1,177 @@
+package models_test
+
+import (
+	"reflect"
+	"testing"
+
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/models/modeltest"
+)
+
+// ids returns the IDs of work items
+func ids(works []*models.Work) []string {
+	var result []string
+	for _, work := range works {
+		result = append(result, work.ID)
+	}
+	return result
+}
+
+func TestWorkTreeLinks(t *testing.T) {
+	epic := modeltest.Work("epic", "", models.WorkStatusActive, "", 0)
+	epic.ChildIDs = []string{"listed", "claimed"}
+	tree := models.NewWorkTree([]*models.Work{
+		epic,
+		modeltest.Work("story", "epic", models.WorkStatusActive, "", 0),
+		modeltest.Work("task", "story", models.WorkStatusActive, "", 0),
+		modeltest.Work("listed", "", models.WorkStatusActive, "", 0),       // Only named in ChildIDs
+		modeltest.Work("claimed", "other", models.WorkStatusActive, "", 0), // ParentID wins over ChildIDs
+		modeltest.Work("other", "", models.WorkStatusActive, "", 0),
+		modeltest.Work("orphan", "missing", models.WorkStatusActive, "", 0), // Parent isn't in the set
+	})
+
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"children by parent and child links", ids(tree.Children("epic")), []string{"story", "listed"}},
+		{"parent id wins", ids(tree.Children("other")), []string{"claimed"}},
+		{"descendants depth first", ids(tree.Descendants("epic")), []string{"story", "task", "listed"}},
+		{"ancestors nearest first", ids(tree.Ancestors("task")), []string{"story", "epic"}},
+		{"missing parent is top level", ids(tree.Ancestors("orphan")), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	if parent := tree.Parent("listed"); parent == nil || parent.ID != "epic" {
+		t.Errorf("parent of listed = %v, want epic", parent)
+	}
+	if depth := tree.Depth("task"); depth != 2 {
+		t.Errorf("depth of task = %d, want 2", depth)
+	}
+}
+
+func TestWorkTreeSurvivesStoredCycles(t *testing.T) {
+	tree := models.NewWorkTree([]*models.Work{
+		modeltest.Work("a", "c", models.WorkStatusActive, "", 0),
+		modeltest.Work("b", "a", models.WorkStatusActive, "", 0),
+		modeltest.Work("c", "b", models.WorkStatusActive, "", 0),
+	})
+
+	if got := ids(tree.Ancestors("a")); !reflect.DeepEqual(got, []string{"c", "b"}) {
+		t.Errorf("ancestors = %v", got)
+	}
+	if got := ids(tree.Descendants("a")); !reflect.DeepEqual(got, []string{"b", "c"}) {
+		t.Errorf("descendants = %v", got)
+	}
+	if _, ok := tree.Rollup("a"); !ok {
+		t.Error("rollup of a cycle should still finish")
+	}
+}
+
+func TestOpenDescendants(t *testing.T) {
+	tree := models.NewWorkTree([]*models.Work{
+		modeltest.Work("epic", "", models.WorkStatusActive, "", 0),
+		modeltest.Work("done", "epic", models.WorkStatusCompleted, "", 0),
+		modeltest.Work("dropped", "epic", models.WorkStatusCanceled, "", 0),
+		modeltest.Work("open", "epic", models.WorkStatusInProgress, "", 0),
+		modeltest.Work("deep", "done", models.WorkStatusBlocked, "", 0), // Open below a closed child
+	})
+
+	if got := ids(tree.OpenDescendants("epic")); !reflect.DeepEqual(got, []string{"deep", "open"}) {
+		t.Errorf("open descendants = %v, want [deep open]", got)
+	}
+	if got := tree.OpenDescendants("open"); len(got) != 0 {
+		t.Errorf("a leaf has no open descendants, got %v", ids(got))
+	}
+}
+
+func TestCheckParent(t *testing.T) {
+	tree := models.NewWorkTree([]*models.Work{
+		modeltest.Work("epic", "", models.WorkStatusActive, "", 0),
+		modeltest.Work("story", "epic", models.WorkStatusActive, "", 0),
+		modeltest.Work("task", "story", models.WorkStatusActive, "", 0),
+		modeltest.Work("other", "", models.WorkStatusActive, "", 0),
+	})
+
+	tests := []struct {
+		name     string
+		workID   string
+		parentID string
+		wantErr  bool
+	}{
+		{"no parent", "task", "", false},
+		{"unrelated item", "story", "other", false},
+		{"own parent", "epic", "epic", true},
+		{"direct child", "epic", "story", true},
+		{"deeper descendant", "epic", "task", true},
+		{"ancestor stays allowed", "task", "epic", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tree.CheckParent(tt.workID, tt.parentID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckParent(%s, %s) = %v, want error %v", tt.workID, tt.parentID, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRollup(t *testing.T) {
+	tests := []struct {
+		name  string
+		works []*models.Work
+		want  models.WorkRollup
+	}{
+		{
+			name: "effort weighted progress",
+			works: []*models.Work{
+				modeltest.Work("epic", "", models.WorkStatusActive, "", 0),
+				modeltest.Work("small", "epic", models.WorkStatusInProgress, models.WorkEffortSmall, 100), // 2 days
+				modeltest.Work("large", "epic", models.WorkStatusActive, models.WorkEffortLarge, 0),       // 40 days
+			},
+			want: models.WorkRollup{Children: 2, Open: 2, InProgress: true, Progress: 5, EffortDays: 42, Effort: models.WorkEffortEpic},
+		},
+		{
+			name: "completed counts as done, cancelled is left out",
+			works: []*models.Work{
+				modeltest.Work("epic", "", models.WorkStatusActive, "", 0),
+				modeltest.Work("done", "epic", models.WorkStatusCompleted, models.WorkEffortSmall, 10),
+				modeltest.Work("dropped", "epic", models.WorkStatusCanceled, models.WorkEffortLarge, 0),
+			},
+			want: models.WorkRollup{Children: 2, Completed: 1, Progress: 100, EffortDays: 2, Effort: models.WorkEffortSmall},
+		},
+		{
+			name: "nested children roll up through their parent",
+			works: []*models.Work{
+				modeltest.Work("epic", "", models.WorkStatusActive, "", 0),
+				modeltest.Work("story", "epic", models.WorkStatusActive, models.WorkEffortSmall, 0),
+				modeltest.Work("a", "story", models.WorkStatusCompleted, models.WorkEffortSmall, 0),
+				modeltest.Work("b", "story", models.WorkStatusActive, models.WorkEffortSmall, 0),
+			},
+			want: models.WorkRollup{Children: 1, Open: 2, Progress: 50, EffortDays: 4, Effort: models.WorkEffortMedium},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := models.NewWorkTree(tt.works).Rollup("epic")
+			if !ok {
+				t.Fatal("epic has children")
+			}
+			if got != tt.want {
+				t.Errorf("rollup = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, ok := models.NewWorkTree([]*models.Work{modeltest.Work("leaf", "", models.WorkStatusActive, "", 0)}).Rollup("leaf"); ok {
+		t.Error("a leaf has nothing to roll up")
+	}
+}
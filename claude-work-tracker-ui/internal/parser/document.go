@@ -0,0 +1,272 @@
+package parser
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// Document is markdown parsed into a goldmark AST. Line endings are normalized to \n for
+// parsing and restored by Join, so edits made line by line leave everything else in the
+// file exactly as it was written.
+type Document struct {
+	Source []byte   // The markdown with \n line endings
+	Root   ast.Node // The goldmark AST of Source
+	lines  []int    // Byte offset at which each line starts
+	crlf   bool     // Whether the original used \r\n line endings
+}
+
+// Heading is an ATX or setext heading of a document
+type Heading struct {
+	Level int
+	Text  string
+	Line  int // 1-based line of the heading text
+}
+
+// ParseMarkdown parses markdown content into a document. Markdown inside code blocks is
+// left as code, so checkboxes and headings shown in examples aren't picked up.
+func ParseMarkdown(content string) *Document {
+	doc := &Document{crlf: strings.Contains(content, "\r\n")}
+	doc.Source = []byte(strings.ReplaceAll(content, "\r\n", "\n"))
+	doc.Root = goldmark.DefaultParser().Parse(text.NewReader(doc.Source))
+
+	doc.lines = []int{0}
+	for i, b := range doc.Source {
+		if b == '\n' {
+			doc.lines = append(doc.lines, i+1)
+		}
+	}
+	return doc
+}
+
+// Lines returns the lines of the document without their line endings
+func (d *Document) Lines() []string {
+	return strings.Split(string(d.Source), "\n")
+}
+
+// Join puts edited lines back together with the document's original line endings
+func (d *Document) Join(lines []string) string {
+	if d.crlf {
+		return strings.Join(lines, "\r\n")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// LineAt returns the 1-based line holding a byte offset of Source
+func (d *Document) LineAt(offset int) int {
+	return sort.Search(len(d.lines), func(i int) bool { return d.lines[i] > offset })
+}
+
+// Line returns the text of a 1-based line
+func (d *Document) Line(line int) string {
+	if line < 1 || line > len(d.lines) {
+		return ""
+	}
+	end := len(d.Source)
+	if line < len(d.lines) {
+		end = d.lines[line] - 1
+	}
+	return string(d.Source[d.lines[line-1]:end])
+}
+
+// NodeLines returns the first and last 1-based line of a block node and everything inside
+// it, or 0, 0 for a node without any text. Both fences of a fenced code block count.
+func (d *Document) NodeLines(n ast.Node) (int, int) {
+	first, last := 0, 0
+	ast.Walk(n, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering || node.Type() != ast.TypeBlock {
+			return ast.WalkContinue, nil
+		}
+		start, end := 0, 0
+		if segments := node.Lines(); segments.Len() > 0 {
+			start = d.LineAt(segments.At(0).Start)
+			end = d.LineAt(segments.At(segments.Len()-1).Stop - 1)
+		}
+		if fence, ok := node.(*ast.FencedCodeBlock); ok {
+			if fence.Info != nil {
+				start = d.LineAt(fence.Info.Segment.Start)
+			} else if start > 1 {
+				start-- // The opening fence sits right above the code
+			}
+			if end == 0 {
+				end = start
+			}
+			if end > 0 && isFence(d.Line(end+1)) {
+				end++
+			}
+		}
+		if start > 0 && (first == 0 || start < first) {
+			first = start
+		}
+		if end > last {
+			last = end
+		}
+		return ast.WalkContinue, nil
+	})
+	return first, last
+}
+
+// isFence reports whether a line opens or closes a fenced code block
+func isFence(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+// Headings returns the document's headings in order
+func (d *Document) Headings() []Heading {
+	var headings []Heading
+	ast.Walk(d.Root, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if heading, ok := node.(*ast.Heading); ok && entering {
+			headings = append(headings, d.heading(heading))
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return headings
+}
+
+// heading describes a heading node
+func (d *Document) heading(node *ast.Heading) Heading {
+	h := Heading{Level: node.Level}
+	if segments := node.Lines(); segments.Len() > 0 {
+		h.Line = d.LineAt(segments.At(0).Start)
+		h.Text = strings.TrimSpace(string(segments.Value(d.Source)))
+	}
+	return h
+}
+
+// CodeRanges returns the byte ranges of Source that hold code or raw HTML: code blocks,
+// code spans and HTML blocks, where markdown syntax has no meaning
+func (d *Document) CodeRanges() [][2]int {
+	var ranges [][2]int
+	ast.Walk(d.Root, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node.Kind() {
+		case ast.KindFencedCodeBlock, ast.KindCodeBlock, ast.KindHTMLBlock:
+			if first, last := d.NodeLines(node); first > 0 {
+				ranges = append(ranges, [2]int{d.lines[first-1], d.lineEnd(last)})
+			}
+			return ast.WalkSkipChildren, nil
+		case ast.KindCodeSpan, ast.KindRawHTML:
+			start, stop := -1, -1
+			ast.Walk(node, func(child ast.Node, entering bool) (ast.WalkStatus, error) {
+				if t, ok := child.(*ast.Text); ok && entering {
+					if start < 0 {
+						start = t.Segment.Start
+					}
+					stop = t.Segment.Stop
+				}
+				return ast.WalkContinue, nil
+			})
+			if raw, ok := node.(*ast.RawHTML); ok && raw.Segments.Len() > 0 {
+				start, stop = raw.Segments.At(0).Start, raw.Segments.At(raw.Segments.Len()-1).Stop
+			}
+			if start >= 0 {
+				ranges = append(ranges, [2]int{start, stop})
+			}
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return ranges
+}
+
+// lineEnd returns the offset just past the text of a 1-based line
+func (d *Document) lineEnd(line int) int {
+	if line < len(d.lines) {
+		return d.lines[line] - 1
+	}
+	return len(d.Source)
+}
+
+// inRanges reports whether an offset falls in one of the ranges
+func inRanges(ranges [][2]int, offset int) bool {
+	for _, r := range ranges {
+		if offset >= r[0] && offset < r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplaceOutsideCode replaces the matches of a pattern with the result of replace, which is
+// given the match and its submatches. Matches in code or raw HTML are left alone, so syntax
+// shown in examples stays as written. Text between matches keeps its line endings.
+func ReplaceOutsideCode(content string, pattern *regexp.Regexp, replace func(submatches []string) string) string {
+	doc := ParseMarkdown(content)
+	code := doc.CodeRanges()
+	source := string(doc.Source)
+	offsets := contentOffsets(content, len(source))
+
+	var result strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringSubmatchIndex(source, -1) {
+		if inRanges(code, match[0]) {
+			continue
+		}
+		submatches := make([]string, len(match)/2)
+		for i := range submatches {
+			if match[2*i] >= 0 {
+				submatches[i] = source[match[2*i]:match[2*i+1]]
+			}
+		}
+		result.WriteString(content[offsets[last]:offsets[match[0]]])
+		result.WriteString(replace(submatches))
+		last = match[1]
+	}
+	result.WriteString(content[offsets[last]:])
+	return result.String()
+}
+
+// contentOffsets maps each offset of a document's Source, and the end, to the same place in
+// the content it was parsed from. A \n that was a \r\n maps to the \r, so slices of the
+// content keep the whole line ending.
+func contentOffsets(content string, sourceLen int) []int {
+	offsets := make([]int, 0, sourceLen+1)
+	for i := 0; i < len(content); i++ {
+		offsets = append(offsets, i)
+		if content[i] == '\r' && i+1 < len(content) && content[i+1] == '\n' {
+			i++
+		}
+	}
+	return append(offsets, len(content))
+}
+
+// SplitFrontmatter splits a markdown file into its YAML frontmatter and body. The
+// frontmatter opens with a --- line at the very start and closes with the next --- or ...
+// line; \r\n line endings and a byte order mark are accepted. The body is returned exactly
+// as written. ok is false when the file has no frontmatter.
+func SplitFrontmatter(content []byte) (frontmatter []byte, body []byte, ok bool) {
+	rest := bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	line, rest, found := cutLine(rest)
+	if !found || string(line) != "---" {
+		return nil, content, false
+	}
+
+	start := len(content) - len(rest)
+	for len(rest) > 0 {
+		offset := len(content) - len(rest)
+		line, next, _ := cutLine(rest)
+		if string(line) == "---" || string(line) == "..." {
+			return content[start:offset], next, true
+		}
+		rest = next
+	}
+	return nil, content, false
+}
+
+// cutLine splits off the first line, without its line ending or trailing spaces
+func cutLine(content []byte) (line []byte, rest []byte, found bool) {
+	if len(content) == 0 {
+		return nil, nil, false
+	}
+	line, rest, found = bytes.Cut(content, []byte("\n"))
+	return bytes.TrimRight(line, " \t\r"), rest, true
+}
@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestReplaceOutsideCode(t *testing.T) {
+	embedding := regexp.MustCompile(`!\[\[([^\]]+)\]\]`)
+	replace := func(submatches []string) string {
+		return "<" + strings.ToUpper(submatches[1]) + ">"
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "plain text",
+			content: "See ![[a]] and ![[b]]\n",
+			want:    "See <A> and <B>\n",
+		},
+		{
+			name:    "code is left alone",
+			content: "![[a]] `![[b]]`\n\n```\n![[c]]\n```\n\n    ![[d]]\n",
+			want:    "<A> `![[b]]`\n\n```\n![[c]]\n```\n\n    ![[d]]\n",
+		},
+		{
+			name:    "crlf line endings are kept",
+			content: "# Notes\r\n\r\n![[a]]\r\n\r\n```\r\n![[b]]\r\n```\r\n![[c]]\r\n",
+			want:    "# Notes\r\n\r\n<A>\r\n\r\n```\r\n![[b]]\r\n```\r\n<C>\r\n",
+		},
+		{
+			name:    "mixed line endings are kept",
+			content: "one\r\n![[a]]\ntwo\r\n",
+			want:    "one\r\n<A>\ntwo\r\n",
+		},
+		{
+			name:    "a lone carriage return isn't a line ending",
+			content: "one\r![[a]]\r\r\n",
+			want:    "one\r<A>\r\r\n",
+		},
+		{
+			name:    "no matches",
+			content: "nothing\r\nhere",
+			want:    "nothing\r\nhere",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReplaceOutsideCode(tt.content, embedding, replace); got != tt.want {
+				t.Errorf("ReplaceOutsideCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// Inline task metadata: - [ ] Add login redirect → [[work-123]] @sam #auth due:2026-11-01 after:task-1a2b3c4d ~2h
+var (
+	workLinkToken = regexp.MustCompile(`(^|\s)(?:→\s*)?\[\[([\w.-]+)\]\]`)
+	ownerToken    = regexp.MustCompile(`(^|\s)@([A-Za-z0-9](?:[\w.-]*\w)?)`)
+	tagToken      = regexp.MustCompile(`(^|\s)#([A-Za-z][\w/-]*)`)
+	dueToken      = regexp.MustCompile(`(^|\s)due:(\d{4}-\d{2}-\d{2})\b`)
+	afterToken    = regexp.MustCompile(`(^|\s)after:([\w,-]+)`)
+	estimateToken = regexp.MustCompile(`(^|\s)~((?:\d+(?:\.\d+)?[dhm])+)\b`)
+	estimatePart  = regexp.MustCompile(`(\d+(?:\.\d+)?)([dhm])`)
+)
+
+// HoursPerDay is how many hours a day counts for in estimates like ~2d
+const HoursPerDay = 8
+
+// parseTaskMetadata moves inline metadata tokens from a task title into the task's fields
+// and returns the title without them
+func parseTaskMetadata(title string, task *models.Task) string {
+	title = workLinkToken.ReplaceAllStringFunc(title, func(token string) string {
+		task.LinkedWorkID = workLinkToken.FindStringSubmatch(token)[2]
+		return ""
+	})
+	title = ownerToken.ReplaceAllStringFunc(title, func(token string) string {
+		task.AssignedTo = ownerToken.FindStringSubmatch(token)[2]
+		return ""
+	})
+	title = tagToken.ReplaceAllStringFunc(title, func(token string) string {
+		task.Tags = append(task.Tags, tagToken.FindStringSubmatch(token)[2])
+		return ""
+	})
+	title = dueToken.ReplaceAllStringFunc(title, func(token string) string {
+		if due, err := time.ParseInLocation("2006-01-02", dueToken.FindStringSubmatch(token)[2], time.Local); err == nil {
+			task.DueAt = &due
+			return ""
+		}
+		return token // Not a real date, leave it in the title
+	})
+	title = afterToken.ReplaceAllStringFunc(title, func(token string) string {
+		for _, id := range strings.Split(afterToken.FindStringSubmatch(token)[2], ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				task.Dependencies = append(task.Dependencies, id)
+			}
+		}
+		return ""
+	})
+	title = estimateToken.ReplaceAllStringFunc(title, func(token string) string {
+		task.Estimate = estimateToken.FindStringSubmatch(token)[2]
+		return ""
+	})
+	return strings.Join(strings.Fields(title), " ")
+}
+
+// formatTaskMetadata renders a task's metadata as inline tokens, in the order
+// → [[work]] @owner #tag due: after: ~estimate
+func formatTaskMetadata(task models.Task) string {
+	var tokens []string
+	if task.LinkedWorkID != "" {
+		tokens = append(tokens, WorkLink(task.LinkedWorkID))
+	}
+	if task.AssignedTo != "" {
+		tokens = append(tokens, "@"+task.AssignedTo)
+	}
+	for _, tag := range task.Tags {
+		tokens = append(tokens, "#"+tag)
+	}
+	if task.DueAt != nil {
+		tokens = append(tokens, "due:"+task.DueAt.Format("2006-01-02"))
+	}
+	if len(task.Dependencies) > 0 {
+		tokens = append(tokens, "after:"+strings.Join(task.Dependencies, ","))
+	}
+	if task.Estimate != "" {
+		tokens = append(tokens, "~"+task.Estimate)
+	}
+	return strings.Join(tokens, " ")
+}
+
+// WorkLink returns the inline link from a task to the work item it was promoted into
+func WorkLink(workID string) string {
+	return "→ [[" + workID + "]]"
+}
+
+// ParseEstimate converts an estimate such as 2h, 30m, 1h30m or 1.5d to a duration. A day
+// counts as HoursPerDay hours.
+func ParseEstimate(estimate string) (time.Duration, error) {
+	estimate = strings.TrimPrefix(strings.TrimSpace(estimate), "~")
+	parts := estimatePart.FindAllStringSubmatch(estimate, -1)
+	if len(parts) == 0 || strings.Join(flattenParts(parts), "") != estimate {
+		return 0, fmt.Errorf("invalid estimate: %q", estimate)
+	}
+
+	var total time.Duration
+	for _, part := range parts {
+		amount, err := strconv.ParseFloat(part[1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid estimate: %q", estimate)
+		}
+		unit := time.Minute
+		switch part[2] {
+		case "d":
+			unit = HoursPerDay * time.Hour
+		case "h":
+			unit = time.Hour
+		}
+		total += time.Duration(amount * float64(unit))
+	}
+	return total, nil
+}
+
+// flattenParts returns the full matches of a FindAllStringSubmatch result
+func flattenParts(parts [][]string) []string {
+	matches := make([]string, len(parts))
+	for i, part := range parts {
+		matches[i] = part[0]
+	}
+	return matches
+}
+
+// linkTaskTree fills in depth, parent and subtask IDs from the parents found in the list
+// structure
+func linkTaskTree(tasks []ParsedTask) {
+	// Parents come before their subtasks, so their depth is already known
+	for i := range tasks {
+		if parent := tasks[i].Parent; parent >= 0 {
+			tasks[i].Task.Depth = tasks[parent].Task.Depth + 1
+			tasks[i].Task.ParentID = tasks[parent].Task.ID
+			tasks[parent].Task.Subtasks = append(tasks[parent].Task.Subtasks, tasks[i].Task.ID)
+		}
+	}
+}
+
+// Children returns the indices of a task's direct subtasks
+func (r *TaskExtractionResult) Children(index int) []int {
+	var children []int
+	for i := range r.Tasks {
+		if r.Tasks[i].Parent == index {
+			children = append(children, i)
+		}
+	}
+	return children
+}
+
+// Progress returns how much of the checklist is done, from 0 to 1. Sibling tasks count by
+// their estimates, or equally when none of them has one; a task with subtasks counts by their
+// progress unless it is ticked itself, and cancelled tasks are left out. ok is false when
+// there is nothing to count.
+func (r *TaskExtractionResult) Progress() (progress float64, ok bool) {
+	return r.subtreeProgress(-1)
+}
+
+// subtreeProgress returns the weighted progress of the tasks directly under parent, -1 for
+// the top level
+func (r *TaskExtractionResult) subtreeProgress(parent int) (float64, bool) {
+	var counted []int
+	for _, i := range r.Children(parent) {
+		if r.Tasks[i].Task.Status != models.TaskStatusCancelled {
+			counted = append(counted, i)
+		}
+	}
+	if len(counted) == 0 {
+		return 0, false
+	}
+
+	weights := r.siblingWeights(counted)
+	done, total := 0.0, 0.0
+	for n, i := range counted {
+		total += weights[n]
+		if r.Tasks[i].Task.Status == models.TaskStatusCompleted {
+			done += weights[n]
+		} else if progress, ok := r.subtreeProgress(i); ok {
+			done += progress * weights[n]
+		}
+	}
+	return done / total, true
+}
+
+// siblingWeights weighs sibling tasks by their estimates. A task without an estimate weighs
+// the average of its estimated siblings; with no estimates at all every task weighs 1.
+func (r *TaskExtractionResult) siblingWeights(siblings []int) []float64 {
+	weights := make([]float64, len(siblings))
+	sum, estimated := 0.0, 0
+	for n, i := range siblings {
+		if estimate, ok := r.taskEstimate(i); ok {
+			weights[n] = estimate.Hours()
+			sum += weights[n]
+			estimated++
+		}
+	}
+
+	fallback := 1.0
+	if estimated > 0 {
+		fallback = sum / float64(estimated)
+	}
+	for n := range weights {
+		if weights[n] == 0 {
+			weights[n] = fallback
+		}
+	}
+	return weights
+}
+
+// taskEstimate returns a task's own estimate, or else the total of its subtasks' estimates
+func (r *TaskExtractionResult) taskEstimate(index int) (time.Duration, bool) {
+	if estimate, err := ParseEstimate(r.Tasks[index].Task.Estimate); err == nil && estimate > 0 {
+		return estimate, true
+	}
+
+	var total time.Duration
+	for _, i := range r.Children(index) {
+		if r.Tasks[i].Task.Status == models.TaskStatusCancelled {
+			continue
+		}
+		if estimate, ok := r.taskEstimate(i); ok {
+			total += estimate
+		}
+	}
+	return total, total > 0
+}
@@ -1,40 +1,47 @@
 package parser
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
 	"regexp"
 	"strings"
 	"time"
 
 	"claude-work-tracker-ui/internal/models"
+	"github.com/yuin/goldmark/ast"
 )
 
-// TaskParser handles extraction and parsing of tasks from markdown content
+// TaskParser handles extraction and parsing of tasks from markdown content. Tasks are list
+// items of the markdown AST that start with a checkbox, so checkboxes in code blocks aren't
+// tasks and nesting follows the list structure.
 type TaskParser struct {
-	// Regex patterns for different task formats
-	taskPattern   *regexp.Regexp
-	phasePattern  *regexp.Regexp
-	headerPattern *regexp.Regexp
+	markerPattern *regexp.Regexp
 }
 
 // NewTaskParser creates a new task parser
 func NewTaskParser() *TaskParser {
 	return &TaskParser{
-		// Matches: - [x] Task title, - [ ] Task title, etc.
-		taskPattern: regexp.MustCompile(`^(\s*)-\s*(\[[x\s…!-]\])\s*(.+)$`),
-		// Matches: ### Phase 1: Something, ## Phase 2: Something, etc.
-		phasePattern: regexp.MustCompile(`^#{1,6}\s*(?:Phase\s+\d+|phase\s+\d+):\s*(.+)$`),
-		// Matches: ### Something, ## Something (general headers for categories)
-		headerPattern: regexp.MustCompile(`^(#{1,6})\s*(.+)$`),
+		// Matches the hidden ID marker at the end of a task line: <!-- id:task-1a2b3c4d -->
+		markerPattern: regexp.MustCompile(`\s*<!--\s*id:\s*([A-Za-z0-9_-]+)\s*-->\s*$`),
 	}
 }
 
+// phaseTitle matches headings like "Phase 1: Backend", capturing the phase name
+var phaseTitle = regexp.MustCompile(`^[Pp]hase\s+\d+:\s*(.+)$`)
+
 // ParsedTask represents a task extracted from markdown
 type ParsedTask struct {
-	Task       *models.Task
-	RawLine    string
-	LineNumber int
+	Task        *models.Task
+	RawLine     string
+	LineNumber  int
+	EndLine     int    // Last line of the task with its subtasks and notes
 	Indentation int
+	Prefix      string // The line up to the checkbox: indentation and list marker
+	Checkbox    string // The checkbox as written, such as [x]
+	Text        string // The rest of the line after the checkbox
+	Marked      bool   // True when the ID comes from a marker on the line
+	Parent      int    // Index of the enclosing task, -1 for top-level tasks
 }
 
 // TaskExtractionResult contains all tasks found in markdown content
@@ -53,72 +60,138 @@ type Phase struct {
 
 // ExtractTasksFromMarkdown parses markdown content and extracts all tasks
 func (p *TaskParser) ExtractTasksFromMarkdown(content string, source string) *TaskExtractionResult {
-	lines := strings.Split(content, "\n")
+	return p.extractTasks(ParseMarkdown(content), source)
+}
+
+// extractTasks walks a document's headings and list items in order. Headings that look like
+// phases group the tasks below them; a task inside another task's list item is its subtask.
+func (p *TaskParser) extractTasks(doc *Document, source string) *TaskExtractionResult {
 	result := &TaskExtractionResult{
 		Tasks:  []ParsedTask{},
 		Phases: []Phase{},
 	}
 	
 	var currentPhase *Phase
+	var open []ast.Node // List items of the enclosing tasks
+	var openIndex []int
 	
-	for i, line := range lines {
-		lineNum := i + 1
-		
-		// Check for phase/category headers
-		if phase := p.parsePhase(line, lineNum); phase != nil {
-			// Save previous phase
-			if currentPhase != nil {
-				result.Phases = append(result.Phases, *currentPhase)
+	ast.Walk(doc.Root, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		switch n := node.(type) {
+		case *ast.Heading:
+			if !entering {
+				return ast.WalkContinue, nil
 			}
-			currentPhase = phase
-			continue
-		}
-		
-		// Check for tasks
-		if task := p.parseTask(line, lineNum, source); task != nil {
-			taskIndex := len(result.Tasks)
+			if phase := parsePhase(doc.heading(n)); phase != nil {
+				if currentPhase != nil {
+					result.Phases = append(result.Phases, *currentPhase)
+				}
+				currentPhase = phase
+			}
+			return ast.WalkSkipChildren, nil
 			
-			// Associate with current phase
+		case *ast.ListItem:
+			if !entering {
+				if len(open) > 0 && open[len(open)-1] == node {
+					open, openIndex = open[:len(open)-1], openIndex[:len(openIndex)-1]
+				}
+				return ast.WalkContinue, nil
+			}
+			task := p.parseTask(doc, n, source)
+			if task == nil {
+				return ast.WalkContinue, nil
+			}
+			
+			taskIndex := len(result.Tasks)
+			if len(openIndex) > 0 {
+				task.Parent = openIndex[len(openIndex)-1]
+			}
 			if currentPhase != nil {
 				task.Task.Phase = currentPhase.Name
 				currentPhase.TaskIndices = append(currentPhase.TaskIndices, taskIndex)
 			}
-			
 			result.Tasks = append(result.Tasks, *task)
+			open, openIndex = append(open, node), append(openIndex, taskIndex)
 		}
-	}
+		return ast.WalkContinue, nil
+	})
 	
 	// Don't forget the last phase
 	if currentPhase != nil {
 		result.Phases = append(result.Phases, *currentPhase)
 	}
 	
+	resolveTaskIDs(result.Tasks)
+	linkTaskTree(result.Tasks)
 	return result
 }
 
-// parseTask extracts a task from a single line
-func (p *TaskParser) parseTask(line string, lineNum int, source string) *ParsedTask {
-	matches := p.taskPattern.FindStringSubmatch(line)
-	if len(matches) != 4 {
+// indentWidth measures a line's leading whitespace, counting a tab as four spaces
+func indentWidth(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// taskCheckboxes are the checkboxes that make a list item a task
+var taskCheckboxes = []string{"[ ]", "[x]", "[…]", "[!]", "[-]"}
+
+// parseTask reads a task from a list item whose text starts with a checkbox. Paragraphs of
+// the item other than the task line become the task's notes.
+func (p *TaskParser) parseTask(doc *Document, item *ast.ListItem, source string) *ParsedTask {
+	first := item.FirstChild()
+	if first == nil || (first.Kind() != ast.KindTextBlock && first.Kind() != ast.KindParagraph) || first.Lines().Len() == 0 {
 		return nil
 	}
 	
-	indentation := len(matches[1])
-	checkbox := matches[2]
-	title := strings.TrimSpace(matches[3])
+	start := first.Lines().At(0).Start
+	lineNum := doc.LineAt(start)
+	line := doc.Line(lineNum)
+	column := start - doc.lines[lineNum-1]
+	rest := line[column:]
 	
-	// Generate task ID
-	taskID := fmt.Sprintf("task-%d-%s", time.Now().UnixNano(), generateShortID())
+	checkbox := ""
+	for _, candidate := range taskCheckboxes {
+		if strings.HasPrefix(rest, candidate) {
+			checkbox = candidate
+		}
+	}
+	title := strings.TrimSpace(strings.TrimPrefix(rest, checkbox))
+	if checkbox == "" || title == "" || (len(rest) > len(checkbox) && rest[len(checkbox)] != ' ' && rest[len(checkbox)] != '\t') {
+		return nil
+	}
+	text := title
+	
+	// Use the ID marker when the line has one, else derive the ID from the title
+	taskID := ""
+	if marker := p.markerPattern.FindStringSubmatch(title); marker != nil {
+		taskID = marker[1]
+		title = strings.TrimSpace(p.markerPattern.ReplaceAllString(title, ""))
+	}
 	
 	task := &models.Task{
-		ID:         taskID,
-		Title:      title,
 		Status:     models.TaskStatusFromMarkdown(checkbox),
 		Source:     source,
 		LineNumber: lineNum,
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}
+	task.Title = parseTaskMetadata(title, task)
+	task.Notes = taskNotes(doc, item)
+	
+	marked := taskID != ""
+	if !marked {
+		taskID = TaskIDForTitle(task.Title)
+	}
+	task.ID = taskID
 	
 	// Set completion time if completed
 	if task.Status == models.TaskStatusCompleted {
@@ -126,47 +199,154 @@ func (p *TaskParser) parseTask(line string, lineNum int, source string) *ParsedT
 		task.CompletedAt = &now
 	}
 	
+	_, endLine := doc.NodeLines(item)
 	return &ParsedTask{
 		Task:        task,
 		RawLine:     line,
 		LineNumber:  lineNum,
-		Indentation: indentation,
+		EndLine:     max(endLine, lineNum),
+		Indentation: indentWidth(line),
+		Prefix:      line[:column],
+		Checkbox:    checkbox,
+		Text:        text,
+		Marked:      marked,
+		Parent:      -1,
 	}
 }
 
-// parsePhase extracts phase information from a header line
-func (p *TaskParser) parsePhase(line string, lineNum int) *Phase {
-	// First try explicit phase pattern
-	matches := p.phasePattern.FindStringSubmatch(line)
-	if len(matches) == 2 {
-		return &Phase{
-			Name:        strings.TrimSpace(matches[1]),
-			Level:       strings.Count(line, "#"),
-			LineNumber:  lineNum,
-			TaskIndices: []int{},
+// taskNotes collects the text of a task's list item other than the task line itself, leaving
+// out subtasks and other nested blocks
+func taskNotes(doc *Document, item *ast.ListItem) string {
+	var notes []string
+	for child := item.FirstChild(); child != nil; child = child.NextSibling() {
+		if child.Kind() != ast.KindTextBlock && child.Kind() != ast.KindParagraph {
+			continue
+		}
+		segments := child.Lines()
+		for i := 0; i < segments.Len(); i++ {
+			if child == item.FirstChild() && i == 0 {
+				continue // The task line
+			}
+			segment := segments.At(i)
+			if note := strings.TrimSpace(string(segment.Value(doc.Source))); note != "" {
+				notes = append(notes, note)
+			}
 		}
 	}
-	
-	// Try general header pattern as category
-	matches = p.headerPattern.FindStringSubmatch(line)
-	if len(matches) == 3 {
-		level := len(matches[1])
-		name := strings.TrimSpace(matches[2])
-		
-		// Skip very generic headers
-		if level <= 2 && !isLikelyPhaseHeader(name) {
-			return nil
+	return strings.Join(notes, "\n")
+}
+
+// TaskIDForTitle derives the ID of a task without a marker from its title, so the same
+// task gets the same ID on every parse
+func TaskIDForTitle(title string) string {
+	normalized := strings.ToLower(strings.Join(strings.Fields(title), " "))
+	sum := sha1.Sum([]byte(normalized))
+	return "task-" + hex.EncodeToString(sum[:4])
+}
+
+// TaskMarker returns the HTML comment that pins a task's ID. Markdown renderers hide it.
+func TaskMarker(id string) string {
+	return fmt.Sprintf("<!-- id:%s -->", id)
+}
+
+// resolveTaskIDs makes task IDs unique within a document. Markers win; a repeated marker or
+// title is given an ID derived from the title and a counter.
+func resolveTaskIDs(tasks []ParsedTask) {
+	taken := make(map[string]bool)
+	for i := range tasks {
+		if !tasks[i].Marked {
+			continue
 		}
-		
-		return &Phase{
-			Name:        name,
-			Level:       level,
-			LineNumber:  lineNum,
-			TaskIndices: []int{},
+		if taken[tasks[i].Task.ID] {
+			tasks[i].Marked = false // A copied line, give it its own ID below
+			continue
+		}
+		taken[tasks[i].Task.ID] = true
+	}
+
+	for i := range tasks {
+		if tasks[i].Marked {
+			continue
+		}
+		title := tasks[i].Task.Title
+		id := TaskIDForTitle(title)
+		for n := 2; taken[id]; n++ {
+			id = TaskIDForTitle(fmt.Sprintf("%s #%d", title, n))
+		}
+		taken[id] = true
+		tasks[i].Task.ID = id
+	}
+}
+
+// AssignTaskIDs adds an ID marker to every task line that lacks one, so IDs survive later
+// renames and reordering. Given the content as it was last saved, a task renamed since then
+// keeps the ID of the task it replaced.
+func (p *TaskParser) AssignTaskIDs(content string, previous string) string {
+	doc := ParseMarkdown(content)
+	tasks := p.extractTasks(doc, "").Tasks
+	unmarked := false
+	for _, task := range tasks {
+		if !task.Marked {
+			unmarked = true
+			break
+		}
+	}
+	if !unmarked {
+		return content
+	}
+
+	// Tasks that were saved before but are gone now may have been renamed
+	current := make(map[string]bool)
+	for _, task := range tasks {
+		current[task.Task.ID] = true
+	}
+	previousTasks := p.ExtractTasksFromMarkdown(previous, "").Tasks
+	known := make(map[string]bool)
+	vanished := make(map[int]*ParsedTask) // By position in the task list
+	for i := range previousTasks {
+		known[previousTasks[i].Task.ID] = true
+		if !current[previousTasks[i].Task.ID] {
+			vanished[i] = &previousTasks[i]
+		}
+	}
+
+	lines := doc.Lines()
+	for i, task := range tasks {
+		if task.Marked {
+			continue
+		}
+		id := task.Task.ID
+		// A new title in the same place and phase as a vanished task is a rename
+		if old, ok := vanished[i]; ok && !known[id] && old.Task.Phase == task.Task.Phase {
+			id = old.Task.ID
+			delete(vanished, i)
 		}
+		line := p.markerPattern.ReplaceAllString(lines[task.LineNumber-1], "")
+		lines[task.LineNumber-1] = strings.TrimRight(line, " \t") + " " + TaskMarker(id)
+	}
+	return doc.Join(lines)
+}
+
+// parsePhase reads a phase from a heading: "Phase N: name" at any level, or a heading below
+// level 2 or one that names a kind of work as a category
+func parsePhase(heading Heading) *Phase {
+	name := heading.Text
+	if matches := phaseTitle.FindStringSubmatch(name); matches != nil {
+		name = strings.TrimSpace(matches[1])
+	} else if heading.Level <= 2 && !isLikelyPhaseHeader(name) {
+		// Skip very generic headers
+		return nil
+	}
+	if name == "" {
+		return nil
 	}
 	
-	return nil
+	return &Phase{
+		Name:        name,
+		Level:       heading.Level,
+		LineNumber:  heading.Line,
+		TaskIndices: []int{},
+	}
 }
 
 // isLikelyPhaseHeader checks if a header looks like it should group tasks
@@ -196,26 +376,68 @@ func isLikelyPhaseHeader(name string) bool {
 	return false
 }
 
-// UpdateTaskInMarkdown updates a task's status in markdown content
+// FindTask resolves a reference to a task in markdown content: a task ID, a title
+// (case-insensitive), or a fragment that matches exactly one title
+func (p *TaskParser) FindTask(content string, ref string) *ParsedTask {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil
+	}
+
+	tasks := p.ExtractTasksFromMarkdown(content, "").Tasks
+	for i := range tasks {
+		if tasks[i].Task.ID == ref || strings.EqualFold(tasks[i].Task.Title, ref) {
+			return &tasks[i]
+		}
+	}
+
+	var match *ParsedTask
+	for i := range tasks {
+		if strings.Contains(strings.ToLower(tasks[i].Task.Title), strings.ToLower(ref)) {
+			if match != nil {
+				return nil // Ambiguous
+			}
+			match = &tasks[i]
+		}
+	}
+	return match
+}
+
+// UpdateTaskInMarkdown updates a task's status in markdown content. The task is found by
+// ID or by its title; the rest of the line, including its ID marker, is kept.
 func (p *TaskParser) UpdateTaskInMarkdown(content string, taskID string, newStatus models.TaskStatus) string {
-	lines := strings.Split(content, "\n")
-	
-	for i, line := range lines {
-		if task := p.parseTask(line, i+1, ""); task != nil && task.Task.ID == taskID {
-			// Replace the checkbox
-			newCheckbox := models.TaskStatusToMarkdown(newStatus)
-			lines[i] = p.taskPattern.ReplaceAllStringFunc(line, func(match string) string {
-				parts := p.taskPattern.FindStringSubmatch(match)
-				return fmt.Sprintf("%s- %s %s", parts[1], newCheckbox, parts[3])
-			})
-			break
+	doc := ParseMarkdown(content)
+	lines := doc.Lines()
+	
+	for _, task := range p.extractTasks(doc, "").Tasks {
+		if task.Task.ID != taskID && !strings.EqualFold(task.Task.Title, taskID) {
+			continue
 		}
+		
+		// Replace the checkbox
+		i := task.LineNumber - 1
+		lines[i] = task.Prefix + models.TaskStatusToMarkdown(newStatus) + lines[i][len(task.Prefix)+len(task.Checkbox):]
+		break
 	}
 	
-	return strings.Join(lines, "\n")
+	return doc.Join(lines)
+}
+
+// ReplaceCheckboxes rewrites the checkbox of every task line, leaving brackets anywhere else,
+// such as in code or link text, as written
+func (p *TaskParser) ReplaceCheckboxes(content string, replace func(checkbox string) string) string {
+	doc := ParseMarkdown(content)
+	lines := doc.Lines()
+	for _, task := range p.extractTasks(doc, "").Tasks {
+		i := task.LineNumber - 1
+		lines[i] = task.Prefix + replace(task.Checkbox) + lines[i][len(task.Prefix)+len(task.Checkbox):]
+	}
+	return doc.Join(lines)
 }
 
-// RenderTasksAsMarkdown converts tasks back to markdown format
+// RenderTasksAsMarkdown converts tasks back to markdown format. Subtasks are indented under
+// their parent, and metadata, notes and ID markers are written inline, so parsing the result
+// gives back the same tasks.
 func (p *TaskParser) RenderTasksAsMarkdown(tasks []models.Task, phases []Phase) string {
 	var result strings.Builder
 	
@@ -231,38 +453,248 @@ func (p *TaskParser) RenderTasksAsMarkdown(tasks []models.Task, phases []Phase)
 		}
 	}
 	
+	// Render unphased tasks first, where the parser finds them
+	if len(unphased) > 0 {
+		for _, task := range unphased {
+			result.WriteString(renderTaskLine(task))
+		}
+		result.WriteString("\n")
+	}
+	
 	// Render phases with their tasks
 	for _, phase := range phases {
-		result.WriteString(fmt.Sprintf("### %s\n", phase.Name))
+		level := phase.Level
+		if level < 1 || level > 6 {
+			level = 3
+		}
+		result.WriteString(fmt.Sprintf("%s %s\n", strings.Repeat("#", level), phase.Name))
 		
 		if phaseTasks, exists := tasksByPhase[phase.Name]; exists {
 			for _, task := range phaseTasks {
-				checkbox := models.TaskStatusToMarkdown(task.Status)
-				result.WriteString(fmt.Sprintf("- %s %s\n", checkbox, task.Title))
+				result.WriteString(renderTaskLine(task))
 			}
 		}
 		result.WriteString("\n")
 	}
 	
-	// Render unphased tasks
-	if len(unphased) > 0 {
-		result.WriteString("### Tasks\n")
-		for _, task := range unphased {
-			checkbox := models.TaskStatusToMarkdown(task.Status)
-			result.WriteString(fmt.Sprintf("- %s %s\n", checkbox, task.Title))
+	return result.String()
+}
+
+// ApplyTasksToMarkdown writes changed tasks back into markdown content line by line. Lines of
+// unchanged tasks and everything around them are left exactly as written; a changed task
+// keeps its indentation and ID marker.
+func (p *TaskParser) ApplyTasksToMarkdown(content string, tasks []models.Task) string {
+	byID := make(map[string]models.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	
+	doc := ParseMarkdown(content)
+	lines := doc.Lines()
+	for _, parsed := range p.extractTasks(doc, "").Tasks {
+		task, ok := byID[parsed.Task.ID]
+		if !ok {
+			continue
+		}
+		
+		checkbox, text := parsed.Checkbox, parsed.Text
+		if task.Status != parsed.Task.Status {
+			checkbox = models.TaskStatusToMarkdown(task.Status)
+		}
+		if task.Title != parsed.Task.Title || formatTaskMetadata(task) != formatTaskMetadata(*parsed.Task) {
+			text = joinNonEmpty(task.Title, formatTaskMetadata(task))
+			if parsed.Marked {
+				text = joinNonEmpty(text, TaskMarker(task.ID))
+			}
+		}
+		if checkbox != parsed.Checkbox || text != parsed.Text {
+			lines[parsed.LineNumber-1] = parsed.Prefix + checkbox + " " + text
 		}
-		result.WriteString("\n")
 	}
 	
-	return result.String()
+	return doc.Join(lines)
+}
+
+// renderTaskLine renders a task as a checkbox line, indented by its depth, with its metadata,
+// ID marker and notes
+func renderTaskLine(task models.Task) string {
+	indent := strings.Repeat("  ", task.Depth)
+	text := joinNonEmpty(task.Title, formatTaskMetadata(task))
+	if task.ID != "" {
+		text = joinNonEmpty(text, TaskMarker(task.ID))
+	}
+	line := fmt.Sprintf("%s- %s %s\n", indent, models.TaskStatusToMarkdown(task.Status), text)
+	
+	if task.Notes != "" {
+		for _, note := range strings.Split(task.Notes, "\n") {
+			line += indent + "  " + note + "\n"
+		}
+	}
+	return line
+}
+
+// joinNonEmpty joins the non-empty strings with a space
+func joinNonEmpty(parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// InsertTaskInMarkdown adds a todo task after another task and its subtasks, at the same
+// indentation. Without afterID, or when it isn't found, the task goes after the last task, or
+// under the Tasks heading when there are none. Returns the new content and the task's ID.
+func (p *TaskParser) InsertTaskInMarkdown(content string, afterID string, title string) (string, string) {
+	title = strings.TrimSpace(title)
+	doc := ParseMarkdown(content)
+	lines := doc.Lines()
+	tasks := p.extractTasks(doc, "").Tasks
+	
+	// New tasks copy the list marker of their sibling so they join the same list
+	at, prefix := -1, "- "
+	if len(tasks) > 0 {
+		after := tasks[len(tasks)-1]
+		for _, task := range tasks {
+			if task.Task.ID == afterID {
+				after = task
+				break
+			}
+		}
+		_, end := after.block()
+		at = end + 1
+		prefix = after.Prefix
+	} else {
+		for _, heading := range doc.Headings() {
+			if strings.EqualFold(heading.Text, "tasks") {
+				at = heading.Line
+				if at < len(lines) && isSetextUnderline(lines[at]) {
+					at++
+				}
+				break
+			}
+		}
+	}
+	
+	line := prefix + models.TaskStatusToMarkdown(models.TaskStatusTodo) + " " + title
+	if at < 0 {
+		for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+			lines = lines[:len(lines)-1]
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "## Tasks")
+		at = len(lines)
+		lines = append(lines, line, "")
+	} else {
+		lines = append(lines[:at], append([]string{line}, lines[at:]...)...)
+	}
+	content = doc.Join(lines)
+	
+	for _, task := range p.ExtractTasksFromMarkdown(content, "").Tasks {
+		if task.LineNumber == at+1 {
+			return content, task.Task.ID
+		}
+	}
+	return content, ""
+}
+
+// DeleteTaskInMarkdown removes a task along with its subtasks and notes
+func (p *TaskParser) DeleteTaskInMarkdown(content string, taskID string) string {
+	doc := ParseMarkdown(content)
+	lines := doc.Lines()
+	for _, task := range p.extractTasks(doc, "").Tasks {
+		if task.Task.ID == taskID {
+			start, end := task.block()
+			lines = append(lines[:start], lines[end+1:]...)
+			break
+		}
+	}
+	return doc.Join(lines)
+}
+
+// MoveTaskInMarkdown swaps a task, with its subtasks and notes, with the sibling above
+// (delta < 0) or below (delta > 0). Tasks only move within their phase and parent.
+func (p *TaskParser) MoveTaskInMarkdown(content string, taskID string, delta int) string {
+	doc := ParseMarkdown(content)
+	lines := doc.Lines()
+	tasks := p.extractTasks(doc, "").Tasks
+	
+	current := -1
+	for i := range tasks {
+		if tasks[i].Task.ID == taskID {
+			current = i
+		}
+	}
+	if current < 0 || delta == 0 {
+		return content
+	}
+	
+	// The nearest task in the direction of the move with the same parent and phase
+	sibling := -1
+	for i := current + sign(delta); i >= 0 && i < len(tasks); i += sign(delta) {
+		if tasks[i].Parent == tasks[current].Parent && tasks[i].Task.Phase == tasks[current].Task.Phase {
+			sibling = i
+			break
+		}
+		if !isBelow(tasks, i, tasks[current].Parent) {
+			break // Left the parent
+		}
+	}
+	if sibling < 0 {
+		return content
+	}
+	
+	first, second := tasks[current], tasks[sibling]
+	if delta < 0 {
+		first, second = second, first
+	}
+	firstStart, firstEnd := first.block()
+	secondStart, secondEnd := second.block()
+	if firstEnd+1 != secondStart {
+		return content // Something other than tasks sits between them
+	}
+	
+	var moved []string
+	moved = append(moved, lines[:firstStart]...)
+	moved = append(moved, lines[secondStart:secondEnd+1]...)
+	moved = append(moved, lines[firstStart:firstEnd+1]...)
+	moved = append(moved, lines[secondEnd+1:]...)
+	return doc.Join(moved)
+}
+
+// block returns the first and last line index of a task with its subtasks and notes
+func (t ParsedTask) block() (int, int) {
+	return t.LineNumber - 1, t.EndLine - 1
 }
 
-// generateShortID creates a short unique identifier
-func generateShortID() string {
-	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
-	b := make([]byte, 6)
-	for i := range b {
-		b[i] = letters[time.Now().UnixNano()%int64(len(letters))]
+// isBelow reports whether a task is a subtask, at any depth, of the parent task. Every task
+// is below -1.
+func isBelow(tasks []ParsedTask, task, parent int) bool {
+	if parent < 0 {
+		return true
+	}
+	for i := tasks[task].Parent; i >= 0; i = tasks[i].Parent {
+		if i == parent {
+			return true
+		}
 	}
-	return string(b)
-}
\ No newline at end of file
+	return false
+}
+
+// isSetextUnderline reports whether a line underlines a setext heading
+func isSetextUnderline(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed != "" && (strings.Trim(trimmed, "=") == "" || strings.Trim(trimmed, "-") == "")
+}
+
+// sign returns -1 or 1 for the direction of n
+func sign(n int) int {
+	if n < 0 {
+		return -1
+	}
+	return 1
+}
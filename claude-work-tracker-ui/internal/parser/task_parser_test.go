@@ -0,0 +1,331 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// titles returns the titles of parsed tasks
+func titles(tasks []ParsedTask) []string {
+	var result []string
+	for _, task := range tasks {
+		result = append(result, task.Task.Title)
+	}
+	return result
+}
+
+func TestExtractTasksNesting(t *testing.T) {
+	content := `# Plan
+
+## Phase 1: Backend
+- [ ] API
+  Needs an auth check
+  - [x] Routes
+  - [ ] Handlers
+    - [!] Errors
+- [ ] Storage
+
+` + "```" + `
+- [ ] Not a task
+` + "```" + `
+
+### Frontend
+1. [-] Old page
+2. [ ] New page
+`
+	result := NewTaskParser().ExtractTasksFromMarkdown(content, "plan.md")
+
+	want := []struct {
+		title  string
+		parent int
+		depth  int
+		phase  string
+		status models.TaskStatus
+		lines  [2]int
+	}{
+		{"API", -1, 0, "Backend", models.TaskStatusTodo, [2]int{4, 8}},
+		{"Routes", 0, 1, "Backend", models.TaskStatusCompleted, [2]int{6, 6}},
+		{"Handlers", 0, 1, "Backend", models.TaskStatusTodo, [2]int{7, 8}},
+		{"Errors", 2, 2, "Backend", models.TaskStatusBlocked, [2]int{8, 8}},
+		{"Storage", -1, 0, "Backend", models.TaskStatusTodo, [2]int{9, 9}},
+		{"Old page", -1, 0, "Frontend", models.TaskStatusCancelled, [2]int{16, 16}},
+		{"New page", -1, 0, "Frontend", models.TaskStatusTodo, [2]int{17, 17}},
+	}
+	if len(result.Tasks) != len(want) {
+		t.Fatalf("tasks = %v, want %d", titles(result.Tasks), len(want))
+	}
+	for i, w := range want {
+		got := result.Tasks[i]
+		if got.Task.Title != w.title || got.Parent != w.parent || got.Task.Depth != w.depth ||
+			got.Task.Phase != w.phase || got.Task.Status != w.status ||
+			got.LineNumber != w.lines[0] || got.EndLine != w.lines[1] {
+			t.Errorf("task %d = %q parent %d depth %d phase %q status %s lines %d-%d, want %+v",
+				i, got.Task.Title, got.Parent, got.Task.Depth, got.Task.Phase, got.Task.Status, got.LineNumber, got.EndLine, w)
+		}
+	}
+
+	api := result.Tasks[0].Task
+	if api.Notes != "Needs an auth check" {
+		t.Errorf("notes = %q", api.Notes)
+	}
+	if want := []string{result.Tasks[1].Task.ID, result.Tasks[2].Task.ID}; !reflect.DeepEqual(api.Subtasks, want) {
+		t.Errorf("subtasks = %v, want %v", api.Subtasks, want)
+	}
+	if result.Tasks[3].Task.ParentID != result.Tasks[2].Task.ID {
+		t.Errorf("parent of Errors = %q, want Handlers", result.Tasks[3].Task.ParentID)
+	}
+	if got := result.Children(-1); !reflect.DeepEqual(got, []int{0, 4, 5, 6}) {
+		t.Errorf("top-level tasks = %v", got)
+	}
+	if len(result.Phases) != 2 || !reflect.DeepEqual(result.Phases[1].TaskIndices, []int{5, 6}) {
+		t.Errorf("phases = %+v", result.Phases)
+	}
+}
+
+func TestExtractTaskMetadata(t *testing.T) {
+	content := "- [ ] Add login redirect → [[work-123]] @sam #auth after:task-1,task-2 ~2h <!-- id:task-login -->\n"
+	task := NewTaskParser().ExtractTasksFromMarkdown(content, "").Tasks[0].Task
+
+	if task.ID != "task-login" || task.Title != "Add login redirect" || task.LinkedWorkID != "work-123" ||
+		task.AssignedTo != "sam" || !reflect.DeepEqual(task.Tags, []string{"auth"}) ||
+		!reflect.DeepEqual(task.Dependencies, []string{"task-1", "task-2"}) || task.Estimate != "2h" {
+		t.Errorf("task = %+v", task)
+	}
+}
+
+func TestTaskIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "derived from the title",
+			content: "- [ ] Write  Docs\n",
+			want:    []string{TaskIDForTitle("write docs")},
+		},
+		{
+			name:    "marker wins over the title",
+			content: "- [ ] Write docs <!-- id:task-docs -->\n",
+			want:    []string{"task-docs"},
+		},
+		{
+			name:    "repeated titles get their own IDs",
+			content: "- [ ] Test\n- [ ] Test\n",
+			want:    []string{TaskIDForTitle("Test"), TaskIDForTitle("Test #2")},
+		},
+		{
+			name:    "a copied marker is replaced on the copy",
+			content: "- [ ] One <!-- id:task-a -->\n- [ ] Two <!-- id:task-a -->\n",
+			want:    []string{"task-a", TaskIDForTitle("Two")},
+		},
+		{
+			name:    "a derived ID doesn't take a marked one",
+			content: "- [ ] Test\n- [ ] Other <!-- id:" + TaskIDForTitle("Test") + " -->\n",
+			want:    []string{TaskIDForTitle("Test #2"), TaskIDForTitle("Test")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, task := range NewTaskParser().ExtractTasksFromMarkdown(tt.content, "").Tasks {
+				got = append(got, task.Task.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IDs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignTaskIDs(t *testing.T) {
+	p := NewTaskParser()
+	previous := p.AssignTaskIDs("- [ ] One\n- [ ] Two\n", "")
+	if want := "- [ ] One " + TaskMarker(TaskIDForTitle("One")) + "\n- [ ] Two " + TaskMarker(TaskIDForTitle("Two")) + "\n"; previous != want {
+		t.Fatalf("assigned = %q, want %q", previous, want)
+	}
+	if again := p.AssignTaskIDs(previous, previous); again != previous {
+		t.Errorf("marked content changed: %q", again)
+	}
+
+	// A task renamed before it was marked keeps the ID of the task it replaced
+	renamed := "- [ ] One\n- [ ] Two renamed\n"
+	saved := "- [ ] One\n- [ ] Two\n"
+	got := p.AssignTaskIDs(renamed, saved)
+	if want := "- [ ] Two renamed " + TaskMarker(TaskIDForTitle("Two")); !strings.Contains(got, want) {
+		t.Errorf("renamed task = %q, want %q", got, want)
+	}
+}
+
+func TestFindTask(t *testing.T) {
+	content := "- [ ] Write docs <!-- id:task-docs -->\n- [ ] Write tests\n- [x] Release\n"
+
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"task-docs", "Write docs"},
+		{TaskIDForTitle("Write tests"), "Write tests"},
+		{"RELEASE", "Release"},
+		{"tests", "Write tests"},
+		{"write", ""}, // Matches two titles
+		{"deploy", ""},
+		{"  ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			task := NewTaskParser().FindTask(content, tt.ref)
+			got := ""
+			if task != nil {
+				got = task.Task.Title
+			}
+			if got != tt.want {
+				t.Errorf("FindTask(%q) = %q, want %q", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoveTaskInMarkdown(t *testing.T) {
+	content := `- [ ] A <!-- id:a -->
+  - [ ] A1 <!-- id:a1 -->
+  - [ ] A2 <!-- id:a2 -->
+- [ ] B <!-- id:b -->
+  note
+- [ ] C <!-- id:c -->
+
+## Phase 1: Later
+- [ ] D <!-- id:d -->
+`
+
+	tests := []struct {
+		name  string
+		id    string
+		delta int
+		want  []string // Task IDs in order
+	}{
+		{"down past a subtree", "a", 1, []string{"b", "a", "a1", "a2", "c", "d"}},
+		{"up past a subtree with notes", "c", -1, []string{"a", "a1", "a2", "c", "b", "d"}},
+		{"up to the top", "b", -1, []string{"b", "a", "a1", "a2", "c", "d"}},
+		{"subtask within its parent", "a2", -1, []string{"a", "a2", "a1", "b", "c", "d"}},
+		{"subtask stays in its parent", "a2", 1, []string{"a", "a1", "a2", "b", "c", "d"}},
+		{"first task can't go up", "a", -1, []string{"a", "a1", "a2", "b", "c", "d"}},
+		{"not into another phase", "c", 1, []string{"a", "a1", "a2", "b", "c", "d"}},
+		{"unknown task", "x", 1, []string{"a", "a1", "a2", "b", "c", "d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewTaskParser()
+			moved := p.MoveTaskInMarkdown(content, tt.id, tt.delta)
+			var got []string
+			for _, task := range p.ExtractTasksFromMarkdown(moved, "").Tasks {
+				got = append(got, task.Task.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("order = %v, want %v\n%s", got, tt.want, moved)
+			}
+			if tt.id == "b" && !strings.Contains(moved, "- [ ] B <!-- id:b -->\n  note\n") {
+				t.Errorf("notes didn't move with their task:\n%s", moved)
+			}
+		})
+	}
+}
+
+func TestInsertTaskInMarkdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		afterID string
+		want    string
+	}{
+		{
+			name:    "after a task and its subtasks",
+			content: "- [ ] A <!-- id:a -->\n  - [ ] A1\n- [ ] B\n",
+			afterID: "a",
+			want:    "- [ ] A <!-- id:a -->\n  - [ ] A1\n- [ ] New\n- [ ] B\n",
+		},
+		{
+			name:    "after a subtask at its indentation",
+			content: "- [ ] A\n  - [ ] A1 <!-- id:a1 -->\n- [ ] B\n",
+			afterID: "a1",
+			want:    "- [ ] A\n  - [ ] A1 <!-- id:a1 -->\n  - [ ] New\n- [ ] B\n",
+		},
+		{
+			name:    "after the last task by default",
+			content: "1. [ ] A\n2. [ ] B\n\nDone.\n",
+			want:    "1. [ ] A\n2. [ ] B\n2. [ ] New\n\nDone.\n",
+		},
+		{
+			name:    "under the Tasks heading",
+			content: "# Work\n\nTasks\n-----\n\nNotes\n",
+			want:    "# Work\n\nTasks\n-----\n- [ ] New\n\nNotes\n",
+		},
+		{
+			name:    "a new Tasks heading",
+			content: "# Work\n\nSome text\n\n",
+			want:    "# Work\n\nSome text\n\n## Tasks\n- [ ] New\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, id := NewTaskParser().InsertTaskInMarkdown(tt.content, tt.afterID, " New ")
+			if got != tt.want {
+				t.Errorf("content = %q, want %q", got, tt.want)
+			}
+			if id != TaskIDForTitle("New") {
+				t.Errorf("id = %q, want the new task's", id)
+			}
+		})
+	}
+}
+
+func TestDeleteTaskInMarkdown(t *testing.T) {
+	content := "- [ ] A <!-- id:a -->\n  note\n  - [ ] A1\n- [ ] B <!-- id:b -->\n"
+
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"a", "- [ ] B <!-- id:b -->\n"},
+		{"b", "- [ ] A <!-- id:a -->\n  note\n  - [ ] A1\n"},
+		{TaskIDForTitle("A1"), "- [ ] A <!-- id:a -->\n  note\n- [ ] B <!-- id:b -->\n"},
+		{"x", content},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			if got := NewTaskParser().DeleteTaskInMarkdown(content, tt.id); got != tt.want {
+				t.Errorf("content = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditsKeepLineEndings(t *testing.T) {
+	p := NewTaskParser()
+	content := "# Tasks\r\n- [ ] A <!-- id:a -->\r\n- [ ] B <!-- id:b -->\r\n"
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"update", p.UpdateTaskInMarkdown(content, "a", models.TaskStatusCompleted), "# Tasks\r\n- [x] A <!-- id:a -->\r\n- [ ] B <!-- id:b -->\r\n"},
+		{"move", p.MoveTaskInMarkdown(content, "b", -1), "# Tasks\r\n- [ ] B <!-- id:b -->\r\n- [ ] A <!-- id:a -->\r\n"},
+		{"delete", p.DeleteTaskInMarkdown(content, "a"), "# Tasks\r\n- [ ] B <!-- id:b -->\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("content = %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// PromotedBlock is the part of a checklist that was moved into its own work item
+type PromotedBlock struct {
+	Title   string
+	Notes   string       // The promoted task's notes, empty for a phase
+	Content string       // Body for the new item: the subtasks or everything under the phase
+	Task    *models.Task // The promoted task, or the link left in place of a phase
+}
+
+// PromoteTaskInMarkdown replaces a task, its subtasks and notes with a single line linking to
+// workID. The line keeps the task's ID, status and metadata; the subtasks become the new
+// item's checklist.
+func (p *TaskParser) PromoteTaskInMarkdown(content string, taskID string, workID string) (string, *PromotedBlock, error) {
+	doc := ParseMarkdown(content)
+	lines := doc.Lines()
+	result := p.extractTasks(doc, "")
+
+	for i, parsed := range result.Tasks {
+		if parsed.Task.ID != taskID {
+			continue
+		}
+		if parsed.Task.LinkedWorkID != "" {
+			return content, nil, fmt.Errorf("task already promoted to %s", parsed.Task.LinkedWorkID)
+		}
+
+		start, end := parsed.block()
+		block := &PromotedBlock{
+			Title: parsed.Task.Title,
+			Notes: parsed.Task.Notes,
+			Task:  parsed.Task,
+		}
+		if children := result.Children(i); len(children) > 0 {
+			first := result.Tasks[children[0]].LineNumber - 1
+			block.Content = "## Tasks\n" + dedent(lines[first:end+1]) + "\n"
+		}
+
+		link := *parsed.Task
+		link.LinkedWorkID = workID
+		link.Notes = ""
+		link.Depth = 0
+		line := parsed.Prefix + strings.TrimPrefix(strings.TrimSuffix(renderTaskLine(link), "\n"), "- ")
+
+		lines = append(lines[:start], append([]string{line}, lines[end+1:]...)...)
+		return doc.Join(lines), block, nil
+	}
+	return content, nil, fmt.Errorf("task not found: %s", taskID)
+}
+
+// PromotePhaseInMarkdown replaces everything under a phase heading, up to the next heading
+// of the same or a higher level, with a single task linking to workID
+func (p *TaskParser) PromotePhaseInMarkdown(content string, phaseName string, workID string) (string, *PromotedBlock, error) {
+	doc := ParseMarkdown(content)
+	lines := doc.Lines()
+	result := p.extractTasks(doc, "")
+
+	for _, phase := range result.Phases {
+		if !strings.EqualFold(phase.Name, strings.TrimSpace(phaseName)) {
+			continue
+		}
+		if len(phase.TaskIndices) == 0 {
+			return content, nil, fmt.Errorf("phase has no tasks: %s", phase.Name)
+		}
+
+		heading, headingEnd := phase.LineNumber-1, phase.LineNumber
+		if headingEnd < len(lines) && isSetextUnderline(lines[headingEnd]) {
+			headingEnd++
+		}
+		end := len(lines)
+		for _, next := range doc.Headings() {
+			if next.Line > phase.LineNumber && next.Level <= phase.Level {
+				end = next.Line - 1
+				break
+			}
+		}
+
+		// The link starts where the phase stands: done when everything is, started when anything is
+		var tasks []ParsedTask
+		for _, index := range phase.TaskIndices {
+			tasks = append(tasks, result.Tasks[index])
+		}
+		status := models.TaskStatusTodo
+		if progress, ok := (&TaskExtractionResult{Tasks: tasks}).Progress(); ok && progress >= 1 {
+			status = models.TaskStatusCompleted
+		} else if ok && progress > 0 {
+			status = models.TaskStatusInProgress
+		}
+		link := models.Task{Title: phase.Name, Status: status, LinkedWorkID: workID}
+		link.ID = TaskIDForTitle(link.Title)
+
+		body := strings.Trim(strings.Join(lines[headingEnd:end], "\n"), "\n")
+		block := &PromotedBlock{
+			Title:   phase.Name,
+			Content: "## Tasks\n" + body + "\n",
+			Task:    &link,
+		}
+
+		replacement := append(append([]string{}, lines[heading:headingEnd]...), strings.TrimSuffix(renderTaskLine(link), "\n"))
+		if end < len(lines) {
+			replacement = append(replacement, "")
+		}
+		lines = append(lines[:heading], append(replacement, lines[end:]...)...)
+		return doc.Join(lines), block, nil
+	}
+	return content, nil, fmt.Errorf("phase not found: %s", phaseName)
+}
+
+// LinkedTask returns the task that links to a work item, if there is one
+func (p *TaskParser) LinkedTask(content string, workID string) *ParsedTask {
+	tasks := p.ExtractTasksFromMarkdown(content, "").Tasks
+	for i := range tasks {
+		if tasks[i].Task.LinkedWorkID == workID {
+			return &tasks[i]
+		}
+	}
+	return nil
+}
+
+// dedent removes the indentation the lines have in common, ignoring blank lines
+func dedent(lines []string) string {
+	common := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if width := len(line) - len(strings.TrimLeft(line, " \t")); common < 0 || width < common {
+			common = width
+		}
+	}
+
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		if len(line) >= common && common > 0 {
+			line = line[common:]
+		}
+		out[i] = line
+	}
+	return strings.Join(out, "\n")
+}
@@ -6,6 +6,10 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/parser"
+	"github.com/yuin/goldmark/ast"
 )
 
 // MarkdownProcessor handles pre-processing of markdown content for Glamour rendering
@@ -13,6 +17,18 @@ type MarkdownProcessor struct {
 	baseDir string
 }
 
+// embeddingPattern matches the embedding syntax: ![[filename.md]] or ![[artifact-id]]
+var embeddingPattern = regexp.MustCompile(`!\[\[([^\]]+)\]\]`)
+
+// extendedTodos maps our extended checkbox syntax to checkboxes Glamour shows with an emoji
+var extendedTodos = map[string]string{
+	"[…]": "[🔄]", // In progress -> spinning arrow
+	"[!]": "[⚠️]", // Blocked -> warning
+	"[-]": "[❌]", // Cancelled -> X
+	"[x]": "[✅]", // Completed -> checkmark
+	"[ ]": "[⭕]", // Todo -> circle
+}
+
 // NewMarkdownProcessor creates a new markdown processor
 func NewMarkdownProcessor(baseDir string) *MarkdownProcessor {
 	return &MarkdownProcessor{
@@ -55,16 +71,7 @@ func (mp *MarkdownProcessor) ProcessWithAsyncEmbeddings(content string, loadedEm
 
 // replaceEmbeddingsWithSpinners replaces embeddings with loaded content, spinners, or placeholders
 func (mp *MarkdownProcessor) replaceEmbeddingsWithSpinners(content string, loadedEmbeddings map[string]string, loadingStates map[string]string) string {
-	// Pattern for embedding syntax: ![[filename.md]] or ![[artifact-id]]
-	embeddingPattern := regexp.MustCompile(`!\[\[([^\]]+)\]\]`)
-	
-	return embeddingPattern.ReplaceAllStringFunc(content, func(match string) string {
-		// Extract the reference
-		submatches := embeddingPattern.FindStringSubmatch(match)
-		if len(submatches) < 2 {
-			return match // Return original if can't parse
-		}
-		
+	return parser.ReplaceOutsideCode(content, embeddingPattern, func(submatches []string) string {
 		reference := submatches[1]
 		filename := filepath.Base(reference)
 		
@@ -96,35 +103,14 @@ func (mp *MarkdownProcessor) replaceEmbeddingsWithSpinners(content string, loade
 
 // convertExtendedTodos converts our extended checkbox syntax to Glamour-compatible format
 func (mp *MarkdownProcessor) convertExtendedTodos(content string) string {
-	// Define patterns for extended todo syntax
-	patterns := map[string]string{
-		`\[…\]`:  `[🔄]`,  // In progress -> spinning arrow
-		`\[!\]`:  `[⚠️]`,  // Blocked -> warning
-		`\[-\]`:  `[❌]`,  // Cancelled -> X
-		`\[x\]`:  `[✅]`,  // Completed -> checkmark
-		`\[ \]`:  `[⭕]`,  // Todo -> circle
-	}
-	
-	for pattern, replacement := range patterns {
-		re := regexp.MustCompile(pattern)
-		content = re.ReplaceAllString(content, replacement)
-	}
-	
-	return content
+	return parser.NewTaskParser().ReplaceCheckboxes(content, func(checkbox string) string {
+		return extendedTodos[checkbox]
+	})
 }
 
 // convertEmbeddingsToPlaceholders converts ![[artifact-id]] to clickable placeholders for lightweight rendering
 func (mp *MarkdownProcessor) convertEmbeddingsToPlaceholders(content string) string {
-	// Pattern for embedding syntax: ![[filename.md]] or ![[artifact-id]]
-	embeddingPattern := regexp.MustCompile(`!\[\[([^\]]+)\]\]`)
-	
-	return embeddingPattern.ReplaceAllStringFunc(content, func(match string) string {
-		// Extract the reference
-		submatches := embeddingPattern.FindStringSubmatch(match)
-		if len(submatches) < 2 {
-			return match // Return original if can't parse
-		}
-		
+	return parser.ReplaceOutsideCode(content, embeddingPattern, func(submatches []string) string {
 		reference := submatches[1]
 		filename := filepath.Base(reference)
 		
@@ -136,31 +122,18 @@ func (mp *MarkdownProcessor) convertEmbeddingsToPlaceholders(content string) str
 
 // ExtractEmbeddingReferences extracts all embedding references from content
 func (mp *MarkdownProcessor) ExtractEmbeddingReferences(content string) []string {
-	embeddingPattern := regexp.MustCompile(`!\[\[([^\]]+)\]\]`)
-	matches := embeddingPattern.FindAllStringSubmatch(content, -1)
-	
 	var references []string
-	for _, match := range matches {
-		if len(match) >= 2 {
-			references = append(references, match[1])
-		}
-	}
+	parser.ReplaceOutsideCode(content, embeddingPattern, func(submatches []string) string {
+		references = append(references, submatches[1])
+		return submatches[0]
+	})
 	
 	return references
 }
 
 // resolveEmbeddings resolves ![[artifact-id]] references to actual content
 func (mp *MarkdownProcessor) resolveEmbeddings(content string) string {
-	// Pattern for embedding syntax: ![[filename.md]] or ![[artifact-id]]
-	embeddingPattern := regexp.MustCompile(`!\[\[([^\]]+)\]\]`)
-	
-	return embeddingPattern.ReplaceAllStringFunc(content, func(match string) string {
-		// Extract the reference
-		submatches := embeddingPattern.FindStringSubmatch(match)
-		if len(submatches) < 2 {
-			return match // Return original if can't parse
-		}
-		
+	return parser.ReplaceOutsideCode(content, embeddingPattern, func(submatches []string) string {
 		reference := submatches[1]
 		
 		// Try to resolve the reference
@@ -258,23 +231,12 @@ func (mp *MarkdownProcessor) findArtifactByID(artifactID string) string {
 
 // extractContentFromMarkdown extracts the main content from a markdown file, skipping frontmatter
 func (mp *MarkdownProcessor) extractContentFromMarkdown(content string) string {
-	// Split by frontmatter delimiter
-	parts := strings.Split(content, "---")
-	
-	// If we have frontmatter (starts with ---), content is after the second ---
-	if len(parts) >= 3 && strings.TrimSpace(parts[0]) == "" {
-		// Join everything after frontmatter
-		mainContent := strings.Join(parts[2:], "---")
-		return strings.TrimSpace(mainContent)
-	}
-	
-	// No frontmatter, return as-is
-	return strings.TrimSpace(content)
+	_, body, _ := parser.SplitFrontmatter([]byte(content))
+	return strings.TrimSpace(string(body))
 }
 
-// GetTaskSummary returns a brief summary of tasks for quick display
+// GetTaskSummary returns a brief summary of tasks for quick display, including subtasks
 func (mp *MarkdownProcessor) GetTaskSummary(content string) string {
-	lines := strings.Split(content, "\n")
 	var summary strings.Builder
 	
 	todoCount := 0
@@ -282,18 +244,17 @@ func (mp *MarkdownProcessor) GetTaskSummary(content string) string {
 	completedCount := 0
 	blockedCount := 0
 	
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "- [") {
-			if strings.Contains(line, "[ ]") || strings.Contains(line, "[⭕]") {
-				todoCount++
-			} else if strings.Contains(line, "[x]") || strings.Contains(line, "[✅]") {
-				completedCount++
-			} else if strings.Contains(line, "[…]") || strings.Contains(line, "[🔄]") {
-				inProgressCount++
-			} else if strings.Contains(line, "[!]") || strings.Contains(line, "[⚠️]") {
-				blockedCount++
-			}
+	tasks := parser.NewTaskParser().ExtractTasksFromMarkdown(content, "")
+	for _, task := range tasks.Tasks {
+		switch task.Task.Status {
+		case models.TaskStatusTodo:
+			todoCount++
+		case models.TaskStatusCompleted:
+			completedCount++
+		case models.TaskStatusInProgress:
+			inProgressCount++
+		case models.TaskStatusBlocked:
+			blockedCount++
 		}
 	}
 	
@@ -315,6 +276,9 @@ func (mp *MarkdownProcessor) GetTaskSummary(content string) string {
 	if todoCount > 0 {
 		summary.WriteString(fmt.Sprintf(" • ⭕ %d todo", todoCount))
 	}
+	if progress, ok := tasks.Progress(); ok {
+		summary.WriteString(fmt.Sprintf(" • %.0f%% done", progress*100))
+	}
 	
 	return summary.String()
 }
@@ -324,26 +288,27 @@ func (mp *MarkdownProcessor) ProcessForDisplay(content string, maxLength int) st
 	// Get task summary
 	taskSummary := mp.GetTaskSummary(content)
 	
-	// Extract first paragraph of actual content (skip headers and tasks)
-	lines := strings.Split(content, "\n")
+	// Extract the first paragraphs of actual content; headings, lists, code and rules aren't
+	// paragraphs, so they're skipped
+	_, body, _ := parser.SplitFrontmatter([]byte(content))
+	doc := parser.ParseMarkdown(string(body))
 	var contentLines []string
 	
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		// Skip empty lines, headers, tasks, and frontmatter
-		if line == "" || strings.HasPrefix(line, "#") || 
-		   strings.HasPrefix(line, "- [") || strings.HasPrefix(line, "---") ||
-		   strings.HasPrefix(line, "*Last updated:") {
+	for node := doc.Root.FirstChild(); node != nil; node = node.NextSibling() {
+		if node.Kind() != ast.KindParagraph {
 			continue
 		}
-		
-		// Skip embeddings in display mode
-		if strings.Contains(line, "![[") {
-			continue
+		segments := node.Lines()
+		for i := 0; i < segments.Len(); i++ {
+			segment := segments.At(i)
+			line := strings.TrimSpace(string(segment.Value(doc.Source)))
+			// Skip embeddings in display mode
+			if line == "" || strings.HasPrefix(line, "*Last updated:") || strings.Contains(line, "![[") {
+				continue
+			}
+			contentLines = append(contentLines, line)
 		}
 		
-		contentLines = append(contentLines, line)
-		
 		// Stop after first substantial paragraph
 		if len(strings.Join(contentLines, " ")) > maxLength/2 {
 			break
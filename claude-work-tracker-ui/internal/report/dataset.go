@@ -0,0 +1,218 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+// Filter selects which Work items end up in a report
+type Filter struct {
+	Schedules []string   `json:"schedules,omitempty"` // now|next|later|closed, empty means all
+	Projects  []string   `json:"projects,omitempty"`  // Project IDs or names, empty means the current project
+	Tags      []string   `json:"tags,omitempty"`      // Items must have at least one of these tags
+	Since     *time.Time `json:"since,omitempty"`     // Items created, updated or completed on or after this date
+	Until     *time.Time `json:"until,omitempty"`     // Items created, updated or completed before this date
+}
+
+// Entry pairs a Work item with the project it belongs to
+type Entry struct {
+	Project *storage.Project `json:"project"`
+	Work    *models.Work     `json:"work"`
+}
+
+// DecisionEntry pairs a decision artifact with its project
+type DecisionEntry struct {
+	Project  *storage.Project `json:"project"`
+	Decision *models.Artifact `json:"decision"`
+}
+
+// Dataset is the filtered input shared by every report format
+type Dataset struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	Filter      Filter          `json:"filter"`
+	Entries     []Entry         `json:"items"`
+	Decisions   []DecisionEntry `json:"decisions,omitempty"`
+}
+
+// Collect loads Work and decisions matching the filter from centralized storage
+func Collect(client *storage.CentralizedClient, filter Filter) (*Dataset, error) {
+	projects, err := resolveProjects(client, filter.Projects)
+	if err != nil {
+		return nil, err
+	}
+
+	dataset := &Dataset{
+		GeneratedAt: time.Now(),
+		Filter:      filter,
+	}
+
+	for _, project := range projects {
+		work, err := client.GetProjectWork(project.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load work for %s: %w", project.Name, err)
+		}
+		for _, w := range work {
+			if filter.Matches(w) {
+				dataset.Entries = append(dataset.Entries, Entry{Project: project, Work: w})
+			}
+		}
+
+		artifacts, err := client.GetProjectArtifacts(project.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load artifacts for %s: %w", project.Name, err)
+		}
+		for _, a := range artifacts {
+			if a.Type == models.TypeDecision && filter.matchesTags(a.TechnicalTags) {
+				dataset.Decisions = append(dataset.Decisions, DecisionEntry{Project: project, Decision: a})
+			}
+		}
+	}
+
+	sort.SliceStable(dataset.Entries, func(i, j int) bool {
+		a, b := dataset.Entries[i], dataset.Entries[j]
+		if a.Project.Name != b.Project.Name {
+			return a.Project.Name < b.Project.Name
+		}
+		if a.Work.GetSchedulePriority() != b.Work.GetSchedulePriority() {
+			return a.Work.GetSchedulePriority() < b.Work.GetSchedulePriority()
+		}
+		return a.Work.UpdatedAt.After(b.Work.UpdatedAt)
+	})
+
+	return dataset, nil
+}
+
+// Matches reports whether a Work item passes the filter
+func (f Filter) Matches(work *models.Work) bool {
+	if len(f.Schedules) > 0 {
+		found := false
+		for _, schedule := range f.Schedules {
+			if strings.EqualFold(schedule, work.Schedule) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if !f.matchesTags(work.TechnicalTags) {
+		return false
+	}
+
+	if f.Since != nil || f.Until != nil {
+		times := []time.Time{work.CreatedAt, work.UpdatedAt}
+		if work.CompletedAt != nil {
+			times = append(times, *work.CompletedAt)
+		}
+
+		inRange := false
+		for _, t := range times {
+			if f.inRange(t) {
+				inRange = true
+				break
+			}
+		}
+		if !inRange {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesTags reports whether tags contain at least one filter tag
+func (f Filter) matchesTags(tags []string) bool {
+	if len(f.Tags) == 0 {
+		return true
+	}
+	for _, want := range f.Tags {
+		for _, tag := range tags {
+			if strings.EqualFold(want, tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inRange reports whether t falls within the filter's date range
+func (f Filter) inRange(t time.Time) bool {
+	if f.Since != nil && t.Before(*f.Since) {
+		return false
+	}
+	if f.Until != nil && !t.Before(*f.Until) {
+		return false
+	}
+	return true
+}
+
+// Describe returns a human-readable summary of the filter
+func (f Filter) Describe() string {
+	var parts []string
+	if len(f.Projects) > 0 {
+		parts = append(parts, "projects: "+strings.Join(f.Projects, ", "))
+	}
+	if len(f.Schedules) > 0 {
+		parts = append(parts, "schedules: "+strings.ToUpper(strings.Join(f.Schedules, ", ")))
+	}
+	if len(f.Tags) > 0 {
+		parts = append(parts, "tags: "+strings.Join(f.Tags, ", "))
+	}
+	if f.Since != nil {
+		parts = append(parts, "since "+f.Since.Format("2006-01-02"))
+	}
+	if f.Until != nil {
+		parts = append(parts, "before "+f.Until.Format("2006-01-02"))
+	}
+	if len(parts) == 0 {
+		return "all work"
+	}
+	return strings.Join(parts, " • ")
+}
+
+// resolveProjects maps project references to registered projects
+func resolveProjects(client *storage.CentralizedClient, refs []string) ([]*storage.Project, error) {
+	if len(refs) == 0 {
+		return []*storage.Project{client.GetCurrentProject()}, nil
+	}
+
+	all := client.GetAllProjects()
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+
+	var projects []*storage.Project
+	for _, ref := range refs {
+		if ref == "all" {
+			return all, nil
+		}
+
+		var match *storage.Project
+		for _, project := range all {
+			if project.ID == ref || strings.EqualFold(project.Name, ref) {
+				match = project
+				break
+			}
+		}
+		if match == nil {
+			return nil, fmt.Errorf("project not found: %s", ref)
+		}
+		projects = append(projects, match)
+	}
+
+	return projects, nil
+}
+
+// ParseDate parses a YYYY-MM-DD date in local time
+func ParseDate(value string) (*time.Time, error) {
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return &t, nil
+}
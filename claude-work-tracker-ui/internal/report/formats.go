@@ -0,0 +1,307 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// Supported export formats
+const (
+	FormatMarkdown = "markdown"
+	FormatCSV      = "csv"
+	FormatJSON     = "json"
+	FormatICS      = "ics"
+)
+
+// Render writes the dataset in the requested format
+func Render(dataset *Dataset, format string, w io.Writer) error {
+	switch format {
+	case FormatMarkdown, "md":
+		_, err := io.WriteString(w, RenderMarkdown(dataset))
+		return err
+	case FormatCSV:
+		return RenderCSV(dataset, w)
+	case FormatJSON:
+		return RenderJSON(dataset, w)
+	case FormatICS, "ical":
+		return RenderICS(dataset, w)
+	default:
+		return fmt.Errorf("unsupported format: %s (expected markdown, csv, json or ics)", format)
+	}
+}
+
+// RenderMarkdown renders a standalone markdown status report
+func RenderMarkdown(dataset *Dataset) string {
+	var md strings.Builder
+
+	md.WriteString("# Work Report\n\n")
+	md.WriteString(fmt.Sprintf("_Generated %s • %s_\n\n", dataset.GeneratedAt.Format("2006-01-02 15:04"), dataset.Filter.Describe()))
+
+	// Summary counts by schedule
+	counts := make(map[string]int)
+	for _, entry := range dataset.Entries {
+		counts[entry.Work.Schedule]++
+	}
+	md.WriteString("## Summary\n\n")
+	md.WriteString("| NOW | NEXT | LATER | CLOSED | Total |\n")
+	md.WriteString("|----:|-----:|------:|-------:|------:|\n")
+	md.WriteString(fmt.Sprintf("| %d | %d | %d | %d | %d |\n\n",
+		counts[models.ScheduleNow], counts[models.ScheduleNext], counts[models.ScheduleLater],
+		counts[models.ScheduleClosed], len(dataset.Entries)))
+
+	if len(dataset.Entries) == 0 {
+		md.WriteString("No work items match this filter.\n")
+	}
+
+	currentProject := ""
+	currentSchedule := ""
+	for _, entry := range dataset.Entries {
+		if entry.Project.Name != currentProject {
+			if currentProject != "" {
+				md.WriteString("\n")
+			}
+			currentProject = entry.Project.Name
+			currentSchedule = ""
+			md.WriteString(fmt.Sprintf("## %s\n\n", currentProject))
+		}
+		if entry.Work.Schedule != currentSchedule {
+			if currentSchedule != "" {
+				md.WriteString("\n")
+			}
+			currentSchedule = entry.Work.Schedule
+			md.WriteString(fmt.Sprintf("### %s\n\n", entry.Work.GetDisplaySchedule()))
+		}
+
+		work := entry.Work
+		md.WriteString(fmt.Sprintf("- **%s** — %s", work.Title, formatStatus(work.Metadata.Status)))
+		if work.Metadata.Priority != "" {
+			md.WriteString(fmt.Sprintf(", %s priority", work.Metadata.Priority))
+		}
+		md.WriteString(fmt.Sprintf(", %d%%", work.Metadata.ProgressPercent))
+		if work.CompletedAt != nil {
+			md.WriteString(fmt.Sprintf(", completed %s", work.CompletedAt.Format("2006-01-02")))
+		} else if work.DueAt != nil {
+			md.WriteString(fmt.Sprintf(", due %s", work.DueAt.Format("2006-01-02")))
+			if work.IsOverdue() {
+				md.WriteString(" **(overdue)**")
+			}
+		}
+		md.WriteString("\n")
+
+		if work.Description != "" {
+			md.WriteString(fmt.Sprintf("  %s\n", work.Description))
+		}
+		if len(work.TechnicalTags) > 0 {
+			md.WriteString(fmt.Sprintf("  _Tags: %s_\n", strings.Join(work.TechnicalTags, ", ")))
+		}
+	}
+
+	var reviews []DecisionEntry
+	for _, decision := range dataset.Decisions {
+		if decision.Decision.Metadata.ReviewDate != nil {
+			reviews = append(reviews, decision)
+		}
+	}
+	if len(reviews) > 0 {
+		md.WriteString("\n## Decision Reviews\n\n")
+		for _, d := range reviews {
+			md.WriteString(fmt.Sprintf("- %s — %s (%s)\n",
+				d.Decision.Metadata.ReviewDate.Format("2006-01-02"), d.Decision.Summary, d.Project.Name))
+		}
+	}
+
+	return md.String()
+}
+
+// RenderCSV writes one row per Work item
+func RenderCSV(dataset *Dataset, w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{
+		"project", "id", "title", "schedule", "status", "priority", "effort", "progress",
+		"tags", "created_at", "updated_at", "completed_at", "due_at", "start_by", "branch", "external_id",
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, entry := range dataset.Entries {
+		work := entry.Work
+		externalID := ""
+		if work.External != nil {
+			externalID = work.External.Source + ":" + work.External.ID
+		}
+
+		row := []string{
+			entry.Project.Name,
+			work.ID,
+			work.Title,
+			work.Schedule,
+			work.Metadata.Status,
+			work.Metadata.Priority,
+			work.Metadata.EstimatedEffort,
+			strconv.Itoa(work.Metadata.ProgressPercent),
+			strings.Join(work.TechnicalTags, ";"),
+			formatTime(&work.CreatedAt),
+			formatTime(&work.UpdatedAt),
+			formatTime(work.CompletedAt),
+			formatTime(work.DueAt),
+			formatTime(work.StartBy),
+			work.GitContext.Branch,
+			externalID,
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// RenderJSON writes the dataset as indented JSON
+func RenderJSON(dataset *Dataset, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(dataset)
+}
+
+// RenderICS writes an iCalendar feed of dated events
+func RenderICS(dataset *Dataset, w io.Writer) error {
+	var ics strings.Builder
+	ics.WriteString("BEGIN:VCALENDAR\r\n")
+	ics.WriteString("VERSION:2.0\r\n")
+	ics.WriteString("PRODID:-//claude-work-tracker//work-export//EN\r\n")
+	ics.WriteString("CALSCALE:GREGORIAN\r\n")
+
+	stamp := dataset.GeneratedAt.UTC().Format("20060102T150405Z")
+
+	for _, entry := range dataset.Entries {
+		work := entry.Work
+		if work.DueAt == nil || work.IsClosed() {
+			continue
+		}
+		writeEvent(&ics, icsEvent{
+			UID:         work.ID + "-due@claude-work-tracker",
+			Stamp:       stamp,
+			Date:        *work.DueAt,
+			Summary:     "Due: " + work.Title,
+			Description: fmt.Sprintf("Project: %s\nSchedule: %s\nProgress: %d%%", entry.Project.Name, work.GetDisplaySchedule(), work.Metadata.ProgressPercent),
+			Categories:  append([]string{"work"}, work.TechnicalTags...),
+		})
+	}
+
+	for _, d := range dataset.Decisions {
+		review := d.Decision.Metadata.ReviewDate
+		if review == nil {
+			continue
+		}
+		writeEvent(&ics, icsEvent{
+			UID:         d.Decision.ID + "-review@claude-work-tracker",
+			Stamp:       stamp,
+			Date:        *review,
+			Summary:     "Review decision: " + d.Decision.Summary,
+			Description: fmt.Sprintf("Project: %s", d.Project.Name),
+			Categories:  append([]string{"decision"}, d.Decision.TechnicalTags...),
+		})
+	}
+
+	ics.WriteString("END:VCALENDAR\r\n")
+
+	_, err := io.WriteString(w, ics.String())
+	return err
+}
+
+// icsEvent is a single all-day calendar event
+type icsEvent struct {
+	UID         string
+	Stamp       string
+	Date        time.Time
+	Summary     string
+	Description string
+	Categories  []string
+}
+
+// writeEvent appends a VEVENT block
+func writeEvent(ics *strings.Builder, event icsEvent) {
+	day := event.Date.Format("20060102")
+	next := event.Date.AddDate(0, 0, 1).Format("20060102")
+
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:" + event.UID,
+		"DTSTAMP:" + event.Stamp,
+		"DTSTART;VALUE=DATE:" + day,
+		"DTEND;VALUE=DATE:" + next,
+		"SUMMARY:" + escapeICS(event.Summary),
+	}
+	if event.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeICS(event.Description))
+	}
+	if len(event.Categories) > 0 {
+		escaped := make([]string, len(event.Categories))
+		for i, c := range event.Categories {
+			escaped[i] = escapeICS(c)
+		}
+		lines = append(lines, "CATEGORIES:"+strings.Join(escaped, ","))
+	}
+	lines = append(lines, "END:VEVENT")
+
+	for _, line := range lines {
+		ics.WriteString(foldICS(line))
+		ics.WriteString("\r\n")
+	}
+}
+
+// escapeICS escapes text values per RFC 5545
+func escapeICS(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, ";", "\\;")
+	value = strings.ReplaceAll(value, ",", "\\,")
+	value = strings.ReplaceAll(value, "\r\n", "\\n")
+	value = strings.ReplaceAll(value, "\n", "\\n")
+	return value
+}
+
+// foldICS folds lines longer than 75 octets without splitting UTF-8 sequences
+func foldICS(line string) string {
+	if len(line) <= 75 {
+		return line
+	}
+
+	var folded strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > 75 {
+			folded.WriteString("\r\n ")
+			width = 1
+		}
+		folded.WriteRune(r)
+		width += size
+	}
+	return folded.String()
+}
+
+// formatStatus turns a status constant into readable text
+func formatStatus(status string) string {
+	if status == "" {
+		return "no status"
+	}
+	return strings.ReplaceAll(status, "_", " ")
+}
+
+// formatTime formats an optional timestamp for CSV output
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,230 @@
+package report
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// exportDataset returns a dataset exercising the quoting and escaping of every format
+func exportDataset() *Dataset {
+	at := func(day, hour int) *time.Time {
+		t := time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC)
+		return &t
+	}
+	project := &storage.Project{ID: "tracker-1a2b", Name: "Tracker", Path: "/src/tracker"}
+
+	open := &models.Work{
+		ID:            "work-1",
+		Title:         `Fix login; redirect, "back" \ home`,
+		Description:   "Two lines:\nfirst, then second",
+		Schedule:      models.ScheduleNow,
+		TechnicalTags: []string{"auth", "ui,web"},
+		CreatedAt:     *at(1, 9),
+		UpdatedAt:     *at(2, 10),
+		DueAt:         at(20, 0),
+		GitContext:    models.GitContext{Branch: "work/work-1"},
+		External:      &models.ExternalRef{Source: "github", ID: "42"},
+		Content:       "- [ ] Redirect\n",
+		Metadata: models.WorkMetadata{
+			Status:          models.WorkStatusInProgress,
+			Priority:        "high",
+			EstimatedEffort: "medium",
+			ProgressPercent: 40,
+		},
+	}
+	long := &models.Work{
+		ID:        "work-2",
+		Title:     "Übersetzung der Anmeldeseite für alle unterstützten Sprachen überprüfen und abschließen",
+		Schedule:  models.ScheduleNext,
+		CreatedAt: *at(3, 9),
+		UpdatedAt: *at(3, 9),
+		DueAt:     at(31, 0),
+		Metadata:  models.WorkMetadata{Status: models.WorkStatusActive},
+	}
+	closed := &models.Work{
+		ID:          "work-3",
+		Title:       "Old work",
+		Schedule:    models.ScheduleClosed,
+		CreatedAt:   *at(1, 9),
+		UpdatedAt:   *at(4, 9),
+		CompletedAt: at(4, 9),
+		DueAt:       at(5, 0),
+		Metadata:    models.WorkMetadata{Status: models.WorkStatusCompleted, ProgressPercent: 100},
+	}
+
+	decision := &models.Artifact{
+		ID:            "decision-1",
+		Type:          models.TypeDecision,
+		Summary:       "Use sessions, not tokens",
+		TechnicalTags: []string{"auth"},
+		CreatedAt:     *at(1, 9),
+		UpdatedAt:     *at(1, 9),
+	}
+	decision.Metadata.ReviewDate = at(15, 0)
+
+	return &Dataset{
+		GeneratedAt: *at(6, 12),
+		Filter:      Filter{Schedules: []string{"now", "next", "closed"}},
+		Entries:     []Entry{{project, open}, {project, long}, {project, closed}},
+		Decisions:   []DecisionEntry{{project, decision}},
+	}
+}
+
+// checkGolden compares output with testdata/<name>, rewriting it when -update is given
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs from the golden file:\n--- got ---\n%s\n--- want ---\n%s", name, got, want)
+	}
+}
+
+func TestRenderGolden(t *testing.T) {
+	tests := []struct {
+		format string
+		golden string
+	}{
+		{FormatCSV, "export.csv"},
+		{FormatJSON, "export.json"},
+		{FormatICS, "export.ics"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := Render(exportDataset(), tt.format, &out); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, tt.golden, out.Bytes())
+		})
+	}
+}
+
+func TestRenderICSLines(t *testing.T) {
+	var out bytes.Buffer
+	if err := RenderICS(exportDataset(), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	ics := out.String()
+	if !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Errorf("feed doesn't end with END:VCALENDAR and CRLF")
+	}
+	for i, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if strings.Contains(line, "\n") {
+			t.Errorf("line %d has a bare newline: %q", i+1, line)
+		}
+		if len(line) > 75 {
+			t.Errorf("line %d is %d octets long: %q", i+1, len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line %d splits a UTF-8 sequence: %q", i+1, line)
+		}
+	}
+	if strings.Contains(ics, "work-3-due") {
+		t.Error("closed work has a due date event")
+	}
+}
+
+func TestEscapeICS(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"plain", "plain"},
+		{"a,b", `a\,b`},
+		{"a;b", `a\;b`},
+		{`a\b`, `a\\b`},
+		{"a\nb", `a\nb`},
+		{"a\r\nb", `a\nb`},
+		{`\,;`, `\\\,\;`},
+		{`already \n escaped`, `already \\n escaped`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := escapeICS(tt.value); got != tt.want {
+				t.Errorf("escapeICS(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFoldICS(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{
+			name: "short",
+			line: "SUMMARY:Short",
+			want: "SUMMARY:Short",
+		},
+		{
+			name: "exactly 75 octets",
+			line: strings.Repeat("a", 75),
+			want: strings.Repeat("a", 75),
+		},
+		{
+			name: "76 octets",
+			line: strings.Repeat("a", 76),
+			want: strings.Repeat("a", 75) + "\r\n a",
+		},
+		{
+			name: "continuation lines hold 74 octets after the space",
+			line: strings.Repeat("a", 75+74+1),
+			want: strings.Repeat("a", 75) + "\r\n " + strings.Repeat("a", 74) + "\r\n a",
+		},
+		{
+			name: "multi-byte rune moves to the next line whole",
+			line: strings.Repeat("a", 74) + "ü" + "b",
+			want: strings.Repeat("a", 74) + "\r\n üb",
+		},
+		{
+			name: "multi-byte rune that fits",
+			line: strings.Repeat("a", 73) + "ü" + "b",
+			want: strings.Repeat("a", 73) + "ü" + "\r\n b",
+		},
+		{
+			name: "four-byte runes",
+			line: strings.Repeat("😀", 20),
+			want: strings.Repeat("😀", 18) + "\r\n " + strings.Repeat("😀", 2),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := foldICS(tt.line)
+			if got != tt.want {
+				t.Errorf("foldICS = %q, want %q", got, tt.want)
+			}
+			if unfolded := strings.ReplaceAll(got, "\r\n ", ""); unfolded != tt.line {
+				t.Errorf("unfolding gives %q, want the original line", unfolded)
+			}
+		})
+	}
+}
@@ -0,0 +1,473 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/parser"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+// Summary periods
+const (
+	PeriodStandup = "standup" // Since the start of the previous working day
+	PeriodWeekly  = "weekly"  // The last seven days, today included
+	PeriodRange   = "range"   // Explicit dates
+)
+
+// Summary groupings
+const (
+	GroupByProject = "project"
+	GroupByWork    = "work"
+	GroupByTag     = "tag"
+)
+
+// SummaryGroupings lists the groupings in the order the TUI cycles through them
+var SummaryGroupings = []string{GroupByProject, GroupByWork, GroupByTag}
+
+// untaggedGroup holds items without tags when grouping by tag
+const untaggedGroup = "untagged"
+
+// SummaryOptions selects what a summary covers and how it's grouped
+type SummaryOptions struct {
+	Period   string
+	Since    time.Time
+	Until    time.Time // Exclusive
+	Projects []string  // Project IDs or names, empty means the current project, "all" every project
+	Tags     []string  // Only items with at least one of these tags
+	GroupBy  string    // project, work or tag
+}
+
+// SummaryItem is one line of a summary section
+type SummaryItem struct {
+	Project string     `json:"project"`
+	WorkID  string     `json:"work_id"`
+	Work    string     `json:"work"`
+	Text    string     `json:"text"`             // The work title for lines about a whole item, else the task or update
+	Detail  string     `json:"detail,omitempty"` // Progress, reason or kind of update
+	At      *time.Time `json:"at,omitempty"`
+	tags    []string
+}
+
+// SummaryGroup holds the done / doing / blocked / next sections of one group
+type SummaryGroup struct {
+	Name    string        `json:"name"`
+	Done    []SummaryItem `json:"done,omitempty"`
+	Doing   []SummaryItem `json:"doing,omitempty"`
+	Blocked []SummaryItem `json:"blocked,omitempty"`
+	Next    []SummaryItem `json:"next,omitempty"`
+}
+
+// Summary is a standup or changelog style report of what happened in a period
+type Summary struct {
+	Period      string         `json:"period"`
+	GeneratedAt time.Time      `json:"generated_at"`
+	Since       time.Time      `json:"since"`
+	Until       time.Time      `json:"until"`
+	Projects    []string       `json:"projects"`
+	GroupBy     string         `json:"group_by"`
+	Groups      []SummaryGroup `json:"groups"`
+}
+
+// StandupRange returns the period a standup covers: from the start of the previous working day
+// until now, so Monday's standup covers Friday and the weekend
+func StandupRange(now time.Time) (time.Time, time.Time) {
+	day := startOfDay(now).AddDate(0, 0, -1)
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day, now
+}
+
+// WeeklyRange returns the last seven days, today included
+func WeeklyRange(now time.Time) (time.Time, time.Time) {
+	return startOfDay(now).AddDate(0, 0, -6), now
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// section indexes of a summary group
+const (
+	sectionDone = iota
+	sectionDoing
+	sectionBlocked
+	sectionNext
+)
+
+// sectionItem is a summary line before it's grouped
+type sectionItem struct {
+	section int
+	item    SummaryItem
+}
+
+// Summarize collects what was done, is in progress, is blocked and comes next from work
+// items, their checklists and their updates
+func Summarize(client *storage.CentralizedClient, options SummaryOptions) (*Summary, error) {
+	projects, err := resolveProjects(client, options.Projects)
+	if err != nil {
+		return nil, err
+	}
+	if options.GroupBy == "" {
+		options.GroupBy = GroupByProject
+	}
+
+	summary := &Summary{
+		Period:      options.Period,
+		GeneratedAt: time.Now(),
+		Since:       options.Since,
+		Until:       options.Until,
+		GroupBy:     options.GroupBy,
+	}
+	filter := Filter{Tags: options.Tags, Since: &options.Since, Until: &options.Until}
+
+	var items []sectionItem
+	for _, project := range projects {
+		summary.Projects = append(summary.Projects, project.Name)
+
+		works, err := client.GetProjectWork(project.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load work for %s: %w", project.Name, err)
+		}
+		sort.SliceStable(works, func(i, j int) bool {
+			if works[i].GetSchedulePriority() != works[j].GetSchedulePriority() {
+				return works[i].GetSchedulePriority() < works[j].GetSchedulePriority()
+			}
+			return works[i].UpdatedAt.After(works[j].UpdatedAt)
+		})
+
+		for _, work := range works {
+			if !filter.matchesTags(work.TechnicalTags) {
+				continue
+			}
+			updates, err := client.GetProjectUpdates(project.ID, work.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load updates for %s: %w", work.ID, err)
+			}
+			var recent []*models.Update
+			for _, update := range updates {
+				if filter.inRange(update.Timestamp) {
+					recent = append(recent, update)
+				}
+			}
+			items = append(items, summarizeWork(project, work, recent, filter)...)
+		}
+	}
+
+	summary.Groups = groupSummary(items, options.GroupBy, len(projects) > 1)
+	return summary, nil
+}
+
+// summarizeWork sorts a work item, its tasks and its recent updates (newest first) into sections
+func summarizeWork(project *storage.Project, work *models.Work, recent []*models.Update, filter Filter) []sectionItem {
+	var items []sectionItem
+	add := func(section int, text, detail string, at *time.Time) {
+		items = append(items, sectionItem{section, SummaryItem{
+			Project: project.Name,
+			WorkID:  work.ID,
+			Work:    work.Title,
+			Text:    text,
+			Detail:  detail,
+			At:      at,
+			tags:    work.TechnicalTags,
+		}})
+	}
+
+	tasks := parser.NewTaskParser().ExtractTasksFromMarkdown(work.Content, work.ID).Tasks
+
+	// Transitions in the period: completing, starting and unblocking the item
+	var completedAt, startedAt, unblockedAt *time.Time
+	for _, transition := range work.Transitions {
+		if !filter.inRange(transition.At) {
+			continue
+		}
+		at := transition.At
+		switch {
+		case transition.To == models.WorkStatusCompleted:
+			completedAt = &at
+		case transition.From == models.WorkStatusBlocked:
+			unblockedAt = &at
+		case transition.To == models.WorkStatusInProgress:
+			startedAt = &at
+		}
+	}
+	if work.CompletedAt != nil && filter.inRange(*work.CompletedAt) {
+		completedAt = work.CompletedAt
+	}
+
+	// Done: the item itself, else the tasks it finished and didn't reopen, plus decisions,
+	// reviews and the like
+	completed := completedAt != nil && work.IsCompleted()
+	if completed {
+		add(sectionDone, work.Title, "completed", completedAt)
+	}
+	skip := make(map[string]bool)
+	for _, task := range tasks {
+		if task.Task.Status != models.TaskStatusCompleted {
+			skip[task.Task.Title] = true
+		}
+	}
+	blockerCleared := false
+	for i := len(recent) - 1; i >= 0; i-- {
+		update := recent[i]
+		at := update.Timestamp
+		if !completed {
+			for _, task := range update.TasksCompleted {
+				if !skip[task] {
+					skip[task] = true
+					add(sectionDone, task, "", &at)
+				}
+			}
+		}
+		switch update.GetKind() {
+		case models.UpdateKindProgress, models.UpdateKindBlockerRaised:
+		default:
+			blockerCleared = blockerCleared || update.GetKind() == models.UpdateKindBlockerCleared
+			add(sectionDone, updateText(update), update.GetKind().Label(), &at)
+		}
+	}
+	if unblockedAt != nil && !completed && !blockerCleared && !work.IsBlocked() {
+		add(sectionDone, work.Title, "unblocked", unblockedAt)
+	}
+
+	if work.IsClosed() {
+		return items
+	}
+
+	// Blocked: the item with its reason, and blocked tasks of items still moving
+	if work.IsBlocked() {
+		add(sectionBlocked, work.Title, blockedReason(work, recent), nil)
+		return items
+	}
+	for _, task := range tasks {
+		if task.Task.Status == models.TaskStatusBlocked {
+			add(sectionBlocked, task.Task.Title, "blocked task", nil)
+		}
+	}
+
+	// Doing: items in progress, or in NOW with something happening, and their next task
+	if work.Metadata.Status == models.WorkStatusInProgress || startedAt != nil || (work.Schedule == models.ScheduleNow && len(recent) > 0) {
+		detail := fmt.Sprintf("%d%%", work.Metadata.ProgressPercent)
+		if startedAt != nil {
+			detail += " • started " + startedAt.Format("Jan 2")
+		}
+		for _, update := range recent {
+			if update.GetKind() == models.UpdateKindProgress && !coveredUpdate(update) {
+				detail += " • " + updateText(update)
+				break
+			}
+		}
+		add(sectionDoing, work.Title, detail, nil)
+
+		for _, task := range tasks {
+			if task.Task.Status == models.TaskStatusTodo || task.Task.Status == models.TaskStatusInProgress {
+				add(sectionNext, task.Task.Title, "next task", nil)
+				break
+			}
+		}
+		return items
+	}
+
+	// Next: what's queued up
+	if work.Schedule == models.ScheduleNext {
+		var details []string
+		if work.Metadata.Priority != "" {
+			details = append(details, work.Metadata.Priority+" priority")
+		}
+		if work.DueAt != nil {
+			details = append(details, "due "+work.DueAt.Format("Jan 2"))
+		}
+		add(sectionNext, work.Title, strings.Join(details, " • "), nil)
+	}
+
+	return items
+}
+
+// coveredUpdateTitles start the titles of automatic updates that only restate what a summary
+// reads from the checklist, child work, transitions or linked commits themselves
+var coveredUpdateTitles = []string{"Checklist ", "Promoted to work item", "Child work rolled up", "Rule ", "Commit "}
+
+// coveredUpdate reports whether an update is an automatic one a summary shouldn't repeat.
+// The tasks it completed still count.
+func coveredUpdate(update *models.Update) bool {
+	if update.UpdateType != "automatic" {
+		return false
+	}
+	for _, prefix := range coveredUpdateTitles {
+		if strings.HasPrefix(update.Title, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateText returns an update's title, or the first line of its summary
+func updateText(update *models.Update) string {
+	if update.Title != "" {
+		return update.Title
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(update.Summary), "\n")
+	return line
+}
+
+// blockedReason explains why a work item is blocked, from its latest blocker update, the
+// transition that blocked it or what it's waiting on
+func blockedReason(work *models.Work, recent []*models.Update) string {
+	for _, update := range recent {
+		if update.GetKind() == models.UpdateKindBlockerRaised {
+			return updateText(update)
+		}
+	}
+	for i := len(work.Transitions) - 1; i >= 0; i-- {
+		if t := work.Transitions[i]; t.To == models.WorkStatusBlocked && t.Reason != "" {
+			return t.Reason
+		}
+	}
+	if len(work.Metadata.BlockedBy) > 0 {
+		return "waiting on " + strings.Join(work.Metadata.BlockedBy, ", ")
+	}
+	return ""
+}
+
+// groupSummary buckets summary lines by project, work item or tag, keeping their order
+func groupSummary(items []sectionItem, by string, multiProject bool) []SummaryGroup {
+	var groups []*SummaryGroup
+	index := make(map[string]*SummaryGroup)
+
+	for _, entry := range items {
+		var names []string
+		switch by {
+		case GroupByWork:
+			name := entry.item.Work
+			if multiProject {
+				name = entry.item.Project + " / " + name
+			}
+			names = []string{name}
+		case GroupByTag:
+			names = entry.item.tags
+			if len(names) == 0 {
+				names = []string{untaggedGroup}
+			}
+		default:
+			names = []string{entry.item.Project}
+		}
+
+		for _, name := range names {
+			group, ok := index[name]
+			if !ok {
+				group = &SummaryGroup{Name: name}
+				index[name] = group
+				groups = append(groups, group)
+			}
+			switch entry.section {
+			case sectionDone:
+				group.Done = append(group.Done, entry.item)
+			case sectionDoing:
+				group.Doing = append(group.Doing, entry.item)
+			case sectionBlocked:
+				group.Blocked = append(group.Blocked, entry.item)
+			case sectionNext:
+				group.Next = append(group.Next, entry.item)
+			}
+		}
+	}
+
+	if by == GroupByTag {
+		sort.SliceStable(groups, func(i, j int) bool {
+			if (groups[i].Name == untaggedGroup) != (groups[j].Name == untaggedGroup) {
+				return groups[j].Name == untaggedGroup
+			}
+			return groups[i].Name < groups[j].Name
+		})
+	}
+
+	result := make([]SummaryGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, *group)
+	}
+	return result
+}
+
+// RenderSummary writes a summary as markdown or JSON
+func RenderSummary(summary *Summary, format string, w io.Writer) error {
+	switch format {
+	case FormatMarkdown, "md":
+		_, err := io.WriteString(w, RenderSummaryMarkdown(summary))
+		return err
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(summary)
+	default:
+		return fmt.Errorf("unsupported format: %s (expected markdown or json)", format)
+	}
+}
+
+// RenderSummaryMarkdown renders a summary as a done / doing / blocked / next markdown report
+func RenderSummaryMarkdown(summary *Summary) string {
+	var md strings.Builder
+
+	title := "Report"
+	switch summary.Period {
+	case PeriodStandup:
+		title = "Standup"
+	case PeriodWeekly:
+		title = "Weekly Report"
+	}
+	// Until is exclusive, show the last day covered
+	last := summary.Until.Add(-time.Nanosecond)
+	md.WriteString(fmt.Sprintf("# %s: %s – %s\n\n", title, summary.Since.Format("Jan 2"), last.Format("Jan 2, 2006")))
+	md.WriteString(fmt.Sprintf("_Generated %s • %s • by %s_\n", summary.GeneratedAt.Format("2006-01-02 15:04"),
+		strings.Join(summary.Projects, ", "), summary.GroupBy))
+
+	if len(summary.Groups) == 0 {
+		md.WriteString("\nNothing to report for this period.\n")
+	}
+
+	for _, group := range summary.Groups {
+		md.WriteString(fmt.Sprintf("\n## %s\n", group.Name))
+		sections := []struct {
+			heading string
+			items   []SummaryItem
+		}{
+			{"✅ Done", group.Done},
+			{"🔄 Doing", group.Doing},
+			{"🚧 Blocked", group.Blocked},
+			{"⏭️ Next", group.Next},
+		}
+		for _, section := range sections {
+			if len(section.items) == 0 {
+				continue
+			}
+			md.WriteString(fmt.Sprintf("\n### %s\n\n", section.heading))
+			for _, item := range section.items {
+				md.WriteString("- " + formatSummaryItem(item, summary.GroupBy) + "\n")
+			}
+		}
+	}
+
+	return md.String()
+}
+
+// formatSummaryItem renders one summary line, naming its work item unless the group already does
+func formatSummaryItem(item SummaryItem, groupBy string) string {
+	var line string
+	switch {
+	case item.Text == item.Work:
+		line = "**" + item.Work + "**"
+	case groupBy == GroupByWork:
+		line = item.Text
+	default:
+		line = fmt.Sprintf("%s (**%s**)", item.Text, item.Work)
+	}
+	if item.Detail != "" {
+		line += " — " + item.Detail
+	}
+	return line
+}
@@ -0,0 +1,110 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/storage"
+)
+
+// line is a summary item reduced to what the tests compare
+type line struct {
+	section int
+	text    string
+	detail  string
+}
+
+func TestSummarizeWork(t *testing.T) {
+	since := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	until := since.Add(24 * time.Hour)
+	during := since.Add(10 * time.Hour)
+	before := since.Add(-48 * time.Hour)
+	filter := Filter{Since: &since, Until: &until}
+
+	work := func(status, schedule string, transitions ...models.Transition) *models.Work {
+		w := &models.Work{ID: "work-1", Title: "Ship it", Schedule: schedule, Transitions: transitions}
+		w.Metadata.Status = status
+		w.Metadata.ProgressPercent = 40
+		return w
+	}
+	transition := func(from, to string, at time.Time) models.Transition {
+		return models.Transition{From: from, To: to, At: at}
+	}
+
+	tests := []struct {
+		name   string
+		work   *models.Work
+		recent []*models.Update
+		want   []line
+	}{
+		{
+			name: "covered automatic updates aren't the progress detail",
+			work: work(models.WorkStatusInProgress, models.ScheduleNow),
+			recent: []*models.Update{
+				{Title: "Checklist edited", UpdateType: "automatic", Timestamp: during},
+				{Title: "Commit abcdef12", UpdateType: "automatic", Timestamp: during},
+				{Title: "Session Update", UpdateType: "automatic", Timestamp: during.Add(-time.Hour)},
+			},
+			want: []line{{sectionDoing, "Ship it", "40% • Session Update"}},
+		},
+		{
+			name: "tasks from covered updates still count as done",
+			work: work(models.WorkStatusInProgress, models.ScheduleNow),
+			recent: []*models.Update{
+				{Title: "Checklist edited", UpdateType: "automatic", Timestamp: during, TasksCompleted: []string{"Write tests"}},
+				{Title: "Checklist progress", UpdateType: "automatic", Timestamp: during, TasksCompleted: []string{"Write tests"}},
+			},
+			want: []line{{sectionDone, "Write tests", ""}, {sectionDoing, "Ship it", "40%"}},
+		},
+		{
+			name: "started in the period",
+			work: work(models.WorkStatusActive, models.ScheduleNext, transition(models.WorkStatusActive, models.WorkStatusInProgress, during), transition(models.WorkStatusInProgress, models.WorkStatusActive, during.Add(time.Hour))),
+			want: []line{{sectionDoing, "Ship it", "40% • started Mar 4"}},
+		},
+		{
+			name: "started before the period",
+			work: work(models.WorkStatusActive, models.ScheduleNext, transition(models.WorkStatusActive, models.WorkStatusInProgress, before)),
+			want: []line{{sectionNext, "Ship it", ""}},
+		},
+		{
+			name: "completed by a transition without a completion time",
+			work: work(models.WorkStatusCompleted, models.ScheduleClosed, transition(models.WorkStatusInProgress, models.WorkStatusCompleted, during)),
+			want: []line{{sectionDone, "Ship it", "completed"}},
+		},
+		{
+			name: "completed and reopened",
+			work: work(models.WorkStatusInProgress, models.ScheduleNow, transition(models.WorkStatusInProgress, models.WorkStatusCompleted, during), transition(models.WorkStatusCompleted, models.WorkStatusInProgress, during.Add(time.Hour))),
+			want: []line{{sectionDoing, "Ship it", "40% • started Mar 4"}},
+		},
+		{
+			name: "unblocked",
+			work: work(models.WorkStatusInProgress, models.ScheduleNow, transition(models.WorkStatusBlocked, models.WorkStatusInProgress, during)),
+			want: []line{{sectionDone, "Ship it", "unblocked"}, {sectionDoing, "Ship it", "40%"}},
+		},
+		{
+			name:   "unblocked with a blocker cleared update",
+			work:   work(models.WorkStatusInProgress, models.ScheduleNow, transition(models.WorkStatusBlocked, models.WorkStatusInProgress, during)),
+			recent: []*models.Update{{Title: "API is back", Kind: models.UpdateKindBlockerCleared, Timestamp: during}},
+			want:   []line{{sectionDone, "API is back", "Blocker cleared"}, {sectionDoing, "Ship it", "40%"}},
+		},
+		{
+			name: "blocked again",
+			work: work(models.WorkStatusBlocked, models.ScheduleNow, transition(models.WorkStatusBlocked, models.WorkStatusInProgress, during), models.Transition{From: models.WorkStatusInProgress, To: models.WorkStatusBlocked, Reason: "Waiting on review", At: during.Add(time.Hour)}),
+			want: []line{{sectionBlocked, "Ship it", "Waiting on review"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []line
+			for _, item := range summarizeWork(&storage.Project{Name: "project"}, tt.work, tt.recent, filter) {
+				got = append(got, line{item.section, item.item.Text, item.item.Detail})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("summary =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
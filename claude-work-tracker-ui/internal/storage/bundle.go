@@ -0,0 +1,651 @@
+package storage
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// BundleVersion is the current bundle format version
+	BundleVersion = 1
+
+	bundleManifestName = "manifest.json"
+)
+
+// Import strategies for items whose ID already exists in the target project
+const (
+	ImportStrategyMerge  = "merge"  // Keep whichever version was updated most recently
+	ImportStrategyRename = "rename" // Import colliding items under a new ID
+	ImportStrategySkip   = "skip"   // Keep the existing item
+)
+
+// BundleManifest describes the contents of an export bundle
+type BundleManifest struct {
+	Version    int          `json:"version"`
+	ExportedAt time.Time    `json:"exported_at"`
+	Hostname   string       `json:"hostname,omitempty"`
+	Project    *Project     `json:"project"`
+	Files      []BundleFile `json:"files"`
+}
+
+// BundleFile is a single file in a bundle with its checksum
+type BundleFile struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// BundleCollision is an incoming item whose ID already exists in the target project
+type BundleCollision struct {
+	ItemID          string    `json:"item_id"`
+	IncomingPath    string    `json:"incoming_path"`
+	ExistingPath    string    `json:"existing_path"`
+	IncomingUpdated time.Time `json:"incoming_updated"`
+	ExistingUpdated time.Time `json:"existing_updated"`
+}
+
+// ImportOptions controls how a bundle is imported
+type ImportOptions struct {
+	Strategy        string // merge, rename or skip; empty fails on collisions
+	TargetProjectID string // Import into an existing registered project
+	ProjectPath     string // Register the bundle under this local project path
+	DryRun          bool   // Only report what would happen
+}
+
+// ImportResult summarizes a bundle import
+type ImportResult struct {
+	ProjectID  string            `json:"project_id"`
+	Imported   []string          `json:"imported"`
+	Merged     []string          `json:"merged,omitempty"`
+	Renamed    map[string]string `json:"renamed,omitempty"`
+	Skipped    []string          `json:"skipped,omitempty"`
+	Collisions []BundleCollision `json:"collisions,omitempty"`
+}
+
+// BundleManager exports and imports projects as portable archives
+type BundleManager struct {
+	storage  *ExternalStorage
+	registry *ProjectRegistry
+}
+
+// NewBundleManager creates a bundle manager
+func NewBundleManager(storage *ExternalStorage, registry *ProjectRegistry) *BundleManager {
+	return &BundleManager{
+		storage:  storage,
+		registry: registry,
+	}
+}
+
+// Export writes all data for a project into a .tar.gz or .zip bundle
+func (b *BundleManager) Export(projectID, outputPath string) (*BundleManifest, error) {
+	project, exists := b.registry.GetProject(projectID)
+	if !exists {
+		return nil, fmt.Errorf("project not found: %s", projectID)
+	}
+
+	files := make(map[string][]byte)
+	roots := map[string]string{
+		"work":      b.storage.GetProjectWorkDir(projectID),
+		"artifacts": b.storage.GetProjectArtifactsDir(projectID),
+	}
+	for prefix, root := range roots {
+		if err := collectFiles(root, prefix, files); err != nil {
+			return nil, fmt.Errorf("failed to collect %s: %w", prefix, err)
+		}
+	}
+
+	hostname, _ := os.Hostname()
+	manifest := &BundleManifest{
+		Version:    BundleVersion,
+		ExportedAt: time.Now(),
+		Hostname:   hostname,
+		Project:    project,
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		manifest.Files = append(manifest.Files, BundleFile{
+			Path:   path,
+			SHA256: checksum(files[path]),
+			Size:   int64(len(files[path])),
+		})
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	files[bundleManifestName] = manifestData
+
+	if err := writeArchive(outputPath, files); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// Inspect reads a bundle's manifest and verifies its checksums
+func (b *BundleManager) Inspect(bundlePath string) (*BundleManifest, error) {
+	manifest, _, err := readBundle(bundlePath)
+	return manifest, err
+}
+
+// Import loads a bundle into centralized storage, re-keying it to the target project
+func (b *BundleManager) Import(bundlePath string, opts ImportOptions) (*ImportResult, error) {
+	manifest, files, err := readBundle(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := b.resolveTargetProject(manifest, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{
+		ProjectID: target.ID,
+		Renamed:   make(map[string]string),
+	}
+
+	existing, err := b.indexExistingItems(target.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Detect ID collisions before touching anything
+	incomingIDs := make(map[string]string)
+	for _, file := range manifest.Files {
+		id, updated := itemIdentity(files[file.Path])
+		if id == "" {
+			continue
+		}
+		incomingIDs[file.Path] = id
+
+		if current, ok := existing[id]; ok {
+			result.Collisions = append(result.Collisions, BundleCollision{
+				ItemID:          id,
+				IncomingPath:    file.Path,
+				ExistingPath:    current.path,
+				IncomingUpdated: updated,
+				ExistingUpdated: current.updated,
+			})
+		}
+	}
+
+	if len(result.Collisions) > 0 && opts.Strategy == "" {
+		return result, fmt.Errorf("%d items already exist in project %s, choose a merge, rename or skip strategy", len(result.Collisions), target.ID)
+	}
+
+	collisions := make(map[string]BundleCollision)
+	for _, c := range result.Collisions {
+		collisions[c.IncomingPath] = c
+	}
+
+	// Rename assigns fresh IDs first so references across the bundle can be rewritten
+	if opts.Strategy == ImportStrategyRename {
+		taken := make(map[string]bool)
+		for id := range existing {
+			taken[id] = true
+		}
+		for _, id := range incomingIDs {
+			taken[id] = true
+		}
+		for _, c := range result.Collisions {
+			newID := renamedID(c.ItemID, manifest.Project.ID, taken)
+			taken[newID] = true
+			result.Renamed[c.ItemID] = newID
+		}
+	}
+
+	writes := make(map[string][]byte)
+	var removals []string
+	for _, file := range manifest.Files {
+		data := files[file.Path]
+		destPath := file.Path
+
+		if collision, ok := collisions[file.Path]; ok {
+			switch opts.Strategy {
+			case ImportStrategySkip:
+				result.Skipped = append(result.Skipped, collision.ItemID)
+				continue
+			case ImportStrategyMerge:
+				if !collision.IncomingUpdated.After(collision.ExistingUpdated) {
+					result.Skipped = append(result.Skipped, collision.ItemID)
+					continue
+				}
+				// Incoming is newer, it replaces the existing file
+				if collision.ExistingPath != file.Path {
+					removals = append(removals, collision.ExistingPath)
+				}
+				result.Merged = append(result.Merged, collision.ItemID)
+			case ImportStrategyRename:
+				destPath = renamedPath(file.Path, collision.ItemID, result.Renamed[collision.ItemID])
+			default:
+				return nil, fmt.Errorf("unknown import strategy: %s", opts.Strategy)
+			}
+		} else if id := incomingIDs[file.Path]; id != "" {
+			result.Imported = append(result.Imported, id)
+		}
+
+		// Update logs and their documents are keyed by work ID in their filename
+		if strings.HasPrefix(file.Path, "work/updates/") {
+			ext := filepath.Ext(file.Path)
+			workID := strings.TrimSuffix(filepath.Base(file.Path), ext)
+			if newID, ok := result.Renamed[workID]; ok {
+				destPath = filepath.Join(filepath.Dir(file.Path), newID+ext)
+			} else if _, err := os.Stat(b.bundlePathToStorage(target.ID, destPath)); err == nil && !containsString(result.Merged, workID) {
+				// Keep the local log unless its work item was replaced
+				continue
+			}
+		}
+
+		if strings.HasSuffix(destPath, ".jsonl") {
+			data = rewriteReferences(data, result.Renamed)
+		}
+		if strings.HasSuffix(destPath, ".md") {
+			data = rewriteReferences(data, result.Renamed)
+			data, err = rekeyProject(data, target)
+			if err != nil {
+				return nil, fmt.Errorf("failed to re-key %s: %w", file.Path, err)
+			}
+		}
+
+		writes[destPath] = data
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	for path, data := range writes {
+		fullPath := b.bundlePathToStorage(target.ID, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		if err := ioutil.WriteFile(fullPath, data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	for _, path := range removals {
+		if err := os.Remove(b.bundlePathToStorage(target.ID, path)); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove replaced item %s: %w", path, err)
+		}
+	}
+
+	if _, exists := b.registry.GetProject(target.ID); !exists {
+		b.registry.Projects[target.ID] = target
+	}
+	target.LastAccess = time.Now()
+	if err := b.registry.Save(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// resolveTargetProject decides which project the bundle is imported into
+func (b *BundleManager) resolveTargetProject(manifest *BundleManifest, opts ImportOptions) (*Project, error) {
+	if manifest.Project == nil {
+		return nil, fmt.Errorf("bundle has no project entry")
+	}
+
+	if opts.TargetProjectID != "" {
+		project, exists := b.registry.GetProject(opts.TargetProjectID)
+		if !exists {
+			return nil, fmt.Errorf("target project not found: %s", opts.TargetProjectID)
+		}
+		return project, nil
+	}
+
+	if opts.ProjectPath != "" {
+		absPath, err := filepath.Abs(opts.ProjectPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve project path: %w", err)
+		}
+
+		id := generateProjectID(absPath)
+		if project, exists := b.registry.GetProject(id); exists {
+			return project, nil
+		}
+
+		project := *manifest.Project
+		project.ID = id
+		project.Path = absPath
+		project.Name = filepath.Base(absPath)
+		project.CreatedAt = time.Now()
+		return &project, nil
+	}
+
+	if project, exists := b.registry.GetProject(manifest.Project.ID); exists {
+		return project, nil
+	}
+
+	project := *manifest.Project
+	return &project, nil
+}
+
+// existingItem is an item already stored in the target project
+type existingItem struct {
+	path    string
+	updated time.Time
+}
+
+// indexExistingItems maps item IDs to their bundle-relative paths in the target project
+func (b *BundleManager) indexExistingItems(projectID string) (map[string]existingItem, error) {
+	files := make(map[string][]byte)
+	if err := collectFiles(b.storage.GetProjectWorkDir(projectID), "work", files); err != nil {
+		return nil, err
+	}
+	if err := collectFiles(b.storage.GetProjectArtifactsDir(projectID), "artifacts", files); err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]existingItem)
+	for path, data := range files {
+		if id, updated := itemIdentity(data); id != "" {
+			index[id] = existingItem{path: path, updated: updated}
+		}
+	}
+
+	return index, nil
+}
+
+// bundlePathToStorage maps a bundle-relative path to its location in storage
+func (b *BundleManager) bundlePathToStorage(projectID, path string) string {
+	if rest := strings.TrimPrefix(path, "artifacts/"); rest != path {
+		return filepath.Join(b.storage.GetProjectArtifactsDir(projectID), rest)
+	}
+	return filepath.Join(b.storage.GetProjectWorkDir(projectID), strings.TrimPrefix(path, "work/"))
+}
+
+// itemIdentity extracts the id and updated_at frontmatter fields from a markdown item
+func itemIdentity(data []byte) (string, time.Time) {
+	fm, _, err := splitMarkdown(data)
+	if err != nil || fm == nil {
+		return "", time.Time{}
+	}
+
+	id, _ := fm["id"].(string)
+	updated, _ := fm["updated_at"].(time.Time)
+	if updated.IsZero() {
+		updated, _ = fm["created_at"].(time.Time)
+	}
+
+	return id, updated
+}
+
+// rekeyProject points an item's git context at the target project
+func rekeyProject(data []byte, project *Project) ([]byte, error) {
+	fm, _, err := splitMarkdown(data)
+	if err != nil || fm == nil {
+		return data, err
+	}
+
+	gitContext, ok := fm["git_context"].(map[string]interface{})
+	if !ok {
+		return data, nil
+	}
+	if gitContext["projectid"] == project.ID && gitContext["projectpath"] == project.Path {
+		return data, nil
+	}
+
+	if data, err = ApplyFrontmatterValue(data, "git_context.projectid", project.ID); err != nil {
+		return nil, err
+	}
+	return ApplyFrontmatterValue(data, "git_context.projectpath", project.Path)
+}
+
+// renamedID derives a new ID for an incoming item that doesn't collide with any ID in taken
+func renamedID(id, seed string, taken map[string]bool) string {
+	base := id + "-" + hashString(seed + id)[:6]
+	newID := base
+	for n := 2; taken[newID]; n++ {
+		newID = fmt.Sprintf("%s-%d", base, n)
+	}
+	return newID
+}
+
+// rewriteReferences replaces renamed IDs wherever they're referenced. Only whole IDs are
+// replaced, so renaming work-1 leaves work-12 alone.
+func rewriteReferences(data []byte, renamed map[string]string) []byte {
+	if len(renamed) == 0 {
+		return data
+	}
+
+	// Try longest IDs first so an ID that prefixes another doesn't shadow it
+	ids := make([]string, 0, len(renamed))
+	for id := range renamed {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return len(ids[i]) > len(ids[j]) })
+	for i, id := range ids {
+		ids[i] = regexp.QuoteMeta(id)
+	}
+	pattern := regexp.MustCompile(strings.Join(ids, "|"))
+
+	var out bytes.Buffer
+	last := 0
+	for _, loc := range pattern.FindAllIndex(data, -1) {
+		start, end := loc[0], loc[1]
+		if (start > 0 && isIDByte(data[start-1])) || (end < len(data) && isIDByte(data[end])) {
+			continue
+		}
+		out.Write(data[last:start])
+		out.WriteString(renamed[string(data[start:end])])
+		last = end
+	}
+	out.Write(data[last:])
+	return out.Bytes()
+}
+
+// isIDByte reports whether b can be part of an item ID, so an ID next to it isn't a whole ID
+func isIDByte(b byte) bool {
+	return b == '-' || b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// renamedPath derives a non-colliding file path for a renamed item
+func renamedPath(path, oldID, newID string) string {
+	base := filepath.Base(path)
+	if strings.Contains(base, oldID) {
+		return filepath.Join(filepath.Dir(path), strings.Replace(base, oldID, newID, 1))
+	}
+
+	ext := filepath.Ext(base)
+	suffix := strings.TrimPrefix(newID, oldID)
+	return filepath.Join(filepath.Dir(path), strings.TrimSuffix(base, ext)+suffix+ext)
+}
+
+// collectFiles reads every file under root into files, keyed by prefix-relative path
+func collectFiles(root, prefix string, files map[string][]byte) error {
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		files[filepath.ToSlash(filepath.Join(prefix, rel))] = data
+		return nil
+	})
+}
+
+// containsString reports whether list contains value
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// checksum returns the hex sha256 of data
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// writeArchive writes files to a .zip or .tar.gz archive based on the output extension
+func writeArchive(outputPath string, files map[string][]byte) error {
+	out, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+	defer out.Close()
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	if strings.HasSuffix(outputPath, ".zip") {
+		zw := zip.NewWriter(out)
+		for _, path := range paths {
+			w, err := zw.Create(path)
+			if err != nil {
+				return fmt.Errorf("failed to add %s: %w", path, err)
+			}
+			if _, err := w.Write(files[path]); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+		}
+		return zw.Close()
+	}
+
+	gw := gzip.NewWriter(out)
+	tw := tar.NewWriter(gw)
+	for _, path := range paths {
+		header := &tar.Header{
+			Name:    path,
+			Mode:    0644,
+			Size:    int64(len(files[path])),
+			ModTime: time.Now(),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to add %s: %w", path, err)
+		}
+		if _, err := tw.Write(files[path]); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// readBundle reads an archive and verifies every file against the manifest checksums
+func readBundle(bundlePath string) (*BundleManifest, map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	if strings.HasSuffix(bundlePath, ".zip") {
+		zr, err := zip.OpenReader(bundlePath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open bundle: %w", err)
+		}
+		defer zr.Close()
+
+		for _, f := range zr.File {
+			rc, err := f.Open()
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+			}
+			data, err := ioutil.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+			}
+			files[f.Name] = data
+		}
+	} else {
+		in, err := os.Open(bundlePath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open bundle: %w", err)
+		}
+		defer in.Close()
+
+		gr, err := gzip.NewReader(in)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decompress bundle: %w", err)
+		}
+		tr := tar.NewReader(gr)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read bundle: %w", err)
+			}
+			data, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+			}
+			files[header.Name] = data
+		}
+	}
+
+	manifestData, ok := files[bundleManifestName]
+	if !ok {
+		return nil, nil, fmt.Errorf("bundle has no %s", bundleManifestName)
+	}
+
+	var manifest BundleManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if manifest.Version > BundleVersion {
+		return nil, nil, fmt.Errorf("bundle version %d is newer than supported version %d", manifest.Version, BundleVersion)
+	}
+
+	for _, file := range manifest.Files {
+		if strings.Contains(file.Path, "..") {
+			return nil, nil, fmt.Errorf("invalid path in bundle: %s", file.Path)
+		}
+		data, ok := files[file.Path]
+		if !ok {
+			return nil, nil, fmt.Errorf("bundle is missing %s", file.Path)
+		}
+		if checksum(data) != file.SHA256 {
+			return nil, nil, fmt.Errorf("checksum mismatch for %s", file.Path)
+		}
+	}
+
+	return &manifest, files, nil
+}
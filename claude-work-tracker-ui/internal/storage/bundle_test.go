@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"claude-work-tracker-ui/internal/models"
+)
+
+// bundleWork creates a work item last updated at the given time
+func bundleWork(t *testing.T, c *CentralizedClient, id, title, content string, updated time.Time) {
+	t.Helper()
+	createTestWork(t, c, &models.Work{ID: id, Title: title, Content: content, CreatedAt: updated, UpdatedAt: updated})
+}
+
+// titles maps the IDs of a client's work items to their titles
+func titles(t *testing.T, c *CentralizedClient) map[string]string {
+	t.Helper()
+	works, err := c.GetProjectWork(c.project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := make(map[string]string)
+	for _, work := range works {
+		result[work.ID] = work.Title
+	}
+	return result
+}
+
+// sorted returns a sorted copy of ids
+func sorted(ids []string) []string {
+	result := append([]string(nil), ids...)
+	sort.Strings(result)
+	return result
+}
+
+func TestBundleImportStrategies(t *testing.T) {
+	older := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	newer := older.Add(24 * time.Hour)
+
+	tests := []struct {
+		name         string
+		strategy     string
+		dryRun       bool
+		wantErr      bool
+		wantImported []string
+		wantMerged   []string
+		wantSkipped  []string
+		wantRenamed  []string
+		wantTitles   map[string]string
+	}{
+		{
+			name:       "no strategy refuses collisions",
+			wantErr:    true,
+			wantTitles: map[string]string{"work-1": "Target one", "work-2": "Target two"},
+		},
+		{
+			name:         "skip keeps existing items",
+			strategy:     ImportStrategySkip,
+			wantImported: []string{"work-12"},
+			wantSkipped:  []string{"work-1", "work-2"},
+			wantTitles:   map[string]string{"work-1": "Target one", "work-2": "Target two", "work-12": "Source twelve"},
+		},
+		{
+			name:         "merge keeps the newest version",
+			strategy:     ImportStrategyMerge,
+			wantImported: []string{"work-12"},
+			wantMerged:   []string{"work-1"},
+			wantSkipped:  []string{"work-2"},
+			wantTitles:   map[string]string{"work-1": "Source one", "work-2": "Target two", "work-12": "Source twelve"},
+		},
+		{
+			name:         "rename keeps both",
+			strategy:     ImportStrategyRename,
+			wantImported: []string{"work-12"},
+			wantRenamed:  []string{"work-1", "work-2"},
+			wantTitles: map[string]string{
+				"work-1": "Target one", "work-2": "Target two", "work-12": "Source twelve",
+				"renamed work-1": "Source one", "renamed work-2": "Source two",
+			},
+		},
+		{
+			name:         "dry run writes nothing",
+			strategy:     ImportStrategyRename,
+			dryRun:       true,
+			wantImported: []string{"work-12"},
+			wantRenamed:  []string{"work-1", "work-2"},
+			wantTitles:   map[string]string{"work-1": "Target one", "work-2": "Target two"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := newTestClient(t)
+			home := filepath.Dir(source.project.Path)
+			project, err := source.registry.RegisterProject(filepath.Join(home, "target"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			target := newCentralizedClient(source.storage, source.registry, project, source.hookSystem)
+
+			// work-1 is newer in the bundle, work-2 is newer in the target
+			bundleWork(t, target, "work-1", "Target one", "", older)
+			bundleWork(t, source, "work-1", "Source one", "See work-12.\n", newer)
+			bundleWork(t, source, "work-2", "Source two", "", older)
+			bundleWork(t, target, "work-2", "Target two", "", newer)
+			bundleWork(t, source, "work-12", "Source twelve", "Follows work-1 and work-2.\n", older)
+
+			manager := NewBundleManager(source.storage, source.registry)
+			bundlePath := filepath.Join(home, "bundle.tar.gz")
+			if _, err := manager.Export(source.project.ID, bundlePath); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := manager.Import(bundlePath, ImportOptions{Strategy: tt.strategy, TargetProjectID: project.ID, DryRun: tt.dryRun})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if len(result.Collisions) != 2 {
+				t.Errorf("collisions = %+v, want work-1 and work-2", result.Collisions)
+			}
+			if tt.wantErr {
+				if got := titles(t, target); !reflect.DeepEqual(got, tt.wantTitles) {
+					t.Errorf("titles = %v, want %v", got, tt.wantTitles)
+				}
+				return
+			}
+
+			if got := sorted(result.Imported); !reflect.DeepEqual(got, tt.wantImported) {
+				t.Errorf("imported = %v, want %v", got, tt.wantImported)
+			}
+			if got := sorted(result.Merged); !reflect.DeepEqual(got, sorted(tt.wantMerged)) {
+				t.Errorf("merged = %v, want %v", got, tt.wantMerged)
+			}
+			if got := sorted(result.Skipped); !reflect.DeepEqual(got, sorted(tt.wantSkipped)) {
+				t.Errorf("skipped = %v, want %v", got, tt.wantSkipped)
+			}
+			var renamed []string
+			for id := range result.Renamed {
+				renamed = append(renamed, id)
+			}
+			if got := sorted(renamed); !reflect.DeepEqual(got, sorted(tt.wantRenamed)) {
+				t.Errorf("renamed = %v, want %v", got, tt.wantRenamed)
+			}
+
+			want := make(map[string]string)
+			for id, title := range tt.wantTitles {
+				if oldID := strings.TrimPrefix(id, "renamed "); oldID != id {
+					id = result.Renamed[oldID]
+				}
+				want[id] = title
+			}
+			if got := titles(t, target); !reflect.DeepEqual(got, want) {
+				t.Errorf("titles = %v, want %v", got, want)
+			}
+
+			if tt.strategy == ImportStrategyRename && !tt.dryRun {
+				twelve := getTestWork(t, target, "work-12")
+				if wantContent := "Follows " + result.Renamed["work-1"] + " and " + result.Renamed["work-2"] + "."; !strings.Contains(twelve.Content, wantContent) {
+					t.Errorf("references weren't rewritten: %q", twelve.Content)
+				}
+				one := getTestWork(t, target, result.Renamed["work-1"])
+				if !strings.Contains(one.Content, "See work-12.") {
+					t.Errorf("a longer ID was rewritten: %q", one.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestRenamedIDAvoidsTakenIDs(t *testing.T) {
+	first := renamedID("work-1", "project", map[string]bool{"work-1": true})
+	if !strings.HasPrefix(first, "work-1-") || first == "work-1" {
+		t.Fatalf("renamed ID = %q", first)
+	}
+
+	taken := map[string]bool{"work-1": true, first: true, first + "-2": true}
+	if got := renamedID("work-1", "project", taken); got != first+"-3" {
+		t.Errorf("renamed ID = %q, want %q", got, first+"-3")
+	}
+}
+
+func TestRewriteReferences(t *testing.T) {
+	renamed := map[string]string{"work-1": "work-1-abc123", "work-1-a": "work-1-a-def456"}
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"bare", "see work-1 now", "see work-1-abc123 now"},
+		{"quoted", `parent_id: "work-1"`, `parent_id: "work-1-abc123"`},
+		{"list", "child_ids: [work-1, work-1-a]", "child_ids: [work-1-abc123, work-1-a-def456]"},
+		{"longer ID is left alone", "work-12 and work-1x and xwork-1", "work-12 and work-1x and xwork-1"},
+		{"ID with a suffix", "work-1-b", "work-1-b"},
+		{"file name", "updates/work-1.jsonl", "updates/work-1-abc123.jsonl"},
+		{"start and end", "work-1", "work-1-abc123"},
+		{"adjacent", "work-1,work-1", "work-1-abc123,work-1-abc123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(rewriteReferences([]byte(tt.in), renamed)); got != tt.want {
+				t.Errorf("rewriteReferences(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,16 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"claude-work-tracker-ui/internal/automation"
 	"claude-work-tracker-ui/internal/data"
+	"claude-work-tracker-ui/internal/hooks"
 	"claude-work-tracker-ui/internal/models"
 )
 
@@ -17,6 +21,10 @@ type CentralizedClient struct {
 	project    *Project
 	markdownIO *data.MarkdownIO
 	scanner    *ProjectScanner
+	gitSync    *GitSync
+	hookSystem *hooks.HookSystem
+	engine     *automation.TransitionEngine
+	proposed   chan automation.PendingTransition // Transitions waiting for confirmation after a save
 }
 
 // NewCentralizedClient creates a new centralized data client
@@ -43,25 +51,50 @@ func NewCentralizedClient() (*CentralizedClient, error) {
 		return nil, fmt.Errorf("failed to register project: %w", err)
 	}
 
-	// Initialize markdown IO with external storage path
-	projectWorkDir := storage.GetProjectWorkDir(project.ID)
-	markdownIO := data.NewMarkdownIO(projectWorkDir)
-
 	// Attempt migration from repository
 	if err := storage.MigrateFromRepository(projectRoot, project.ID); err != nil {
 		// Log but don't fail
 		fmt.Fprintf(os.Stderr, "Warning: Migration failed: %v\n", err)
 	}
 
+	// Git sync is optional, a broken config shouldn't block the tracker
+	gitSync, err := NewGitSync(storage)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Git sync disabled: %v\n", err)
+	}
+
+	// Script hooks are optional too
+	hookSystem := hooks.NewHookSystem(hooks.DefaultHookConfig())
+	hookSystem.SetAuditLog(hooks.NewAuditLog(hooks.DefaultAuditLogPath(), hooks.DefaultAuditConfig()))
+	if _, err := hookSystem.LoadExternalHooks(hooks.DefaultExternalHooksPath()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Hook scripts disabled: %v\n", err)
+	}
+
+	client := newCentralizedClient(storage, registry, project, hookSystem)
+	client.scanner = scanner
+	client.gitSync = gitSync
+
+	return client, nil
+}
+
+// newCentralizedClient creates a client for a registered project
+func newCentralizedClient(storage *ExternalStorage, registry *ProjectRegistry, project *Project, hookSystem *hooks.HookSystem) *CentralizedClient {
+	// Saving runs the hooks itself, so the engine gets a hook system of its own to avoid
+	// firing status hooks twice
+	engine := automation.NewTransitionEngine(hooks.NewHookSystem(hooks.DefaultHookConfig()), automation.DefaultTransitionConfig())
+
 	client := &CentralizedClient{
 		storage:    storage,
 		registry:   registry,
 		project:    project,
-		markdownIO: markdownIO,
-		scanner:    scanner,
+		markdownIO: data.NewMarkdownIO(storage.GetProjectWorkDir(project.ID)),
+		hookSystem: hookSystem,
+		engine:     engine,
+		proposed:   make(chan automation.PendingTransition, 8),
 	}
+	engine.SetUpdateSink(client.createRuleUpdate)
 
-	return client, nil
+	return client
 }
 
 // GetWorkDir returns the external work directory for the current project
@@ -122,13 +155,247 @@ func (c *CentralizedClient) CreateWork(work *models.Work) error {
 	// Ensure git context includes project info
 	work.GitContext.ProjectID = c.project.ID
 	work.GitContext.ProjectPath = c.project.Path
+	data.SyncTaskProgress(work)
 	
-	return c.markdownIO.WriteWork(work)
+	if err := c.markdownIO.WriteWork(work); err != nil {
+		return err
+	}
+
+	c.commitChange(fmt.Sprintf("Create %s: %s", work.ID, work.Title))
+	c.rollUpParents(c.project.ID, work, nil)
+	return nil
 }
 
-// UpdateWork updates an existing work item
+// UpdateWork updates an existing work item. Before hooks may patch the item
+// or deny the change, in which case a *hooks.VetoError is returned. Items that take
+// their progress from the checklist get an automatic update when it moves, and the
+// transition rules run against the new progress.
 func (c *CentralizedClient) UpdateWork(work *models.Work) error {
-	return c.markdownIO.WriteWork(work)
+	return c.UpdateProjectWork(c.project.ID, work)
+}
+
+// UpdateProjectWork updates a work item that belongs to any registered project, the same
+// way UpdateWork does for the current one
+func (c *CentralizedClient) UpdateProjectWork(projectID string, work *models.Work) error {
+	if _, exists := c.registry.GetProject(projectID); !exists {
+		return fmt.Errorf("project not found: %s", projectID)
+	}
+	workDir := c.storage.GetProjectWorkDir(projectID)
+	markdownIO := c.markdownIO
+	if projectID != c.project.ID {
+		markdownIO = data.NewMarkdownIO(workDir)
+	}
+
+	var stored *models.Work
+	if work.Filepath != "" {
+		if previous, err := markdownIO.ReadWork(work.Filepath); err == nil {
+			stored = previous
+		}
+	}
+
+	progress := data.SyncTaskProgress(work)
+	if stored != nil {
+		progress.ProgressBefore = stored.Metadata.ProgressPercent
+	}
+
+	// Work in a hierarchy follows its children, and can't be completed while they're open
+	var rollup data.WorkRollupChange
+	completing := work.IsCompleted() && (stored == nil || !stored.IsCompleted())
+	if work.ParentID != "" || len(work.ChildIDs) > 0 || completing || (stored != nil && stored.ParentID != "") {
+		tree, err := c.projectTree(projectID, work)
+		if err != nil {
+			return err
+		}
+		if err := checkHierarchy(tree, stored, work); err != nil {
+			return err
+		}
+		rollup = data.SyncWorkRollup(work, tree)
+	}
+
+	ctx := context.Background()
+	if _, err := c.hookSystem.RunBeforeChange(ctx, hooks.DiffWork(stored, work)); err != nil {
+		return err
+	}
+
+	if stored != nil {
+		work.RecordStatusChange(stored.Metadata.Status, models.CurrentActor(), "")
+	}
+
+	// A checklist edit can satisfy a rule, e.g. one completing work when its last task is ticked
+	progressed := work.Metadata.ProgressFromTasks && progress.Changed()
+	if progressed {
+		c.applyTransitions(ctx, projectID, work)
+	}
+
+	if err := markdownIO.WriteWork(work); err != nil {
+		return err
+	}
+
+	// The item itself is saved by now, failures below only leave its update history short
+	if progressed {
+		update := data.TaskProgressUpdate(work, progress)
+		if err := data.NewUpdatesManager(workDir).CreateUpdate(work.ID, update); err != nil {
+			c.recordFailure("progress-update", hooks.ProgressUpdated, work, fmt.Errorf("failed to record progress update: %w", err))
+		}
+	}
+	if rollup.Changed() {
+		if err := data.NewUpdatesManager(workDir).CreateUpdate(work.ID, data.WorkRollupUpdate(work, rollup)); err != nil {
+			c.recordFailure("roll-up-update", hooks.ProgressUpdated, work, fmt.Errorf("failed to record roll-up update: %w", err))
+		}
+	}
+
+	c.hookSystem.RunAfterChange(ctx, hooks.DiffWork(stored, work))
+	c.commitChange(fmt.Sprintf("Update %s: %s", work.ID, work.Title))
+
+	if work.PromotedFrom != "" && (stored == nil || linkStatus(stored) != linkStatus(work)) {
+		c.rollUpPromoted(projectID, work)
+	}
+	c.rollUpParents(projectID, work, stored)
+	return nil
+}
+
+// applyTransitions runs the transition rules against a work item being saved. A transition
+// that needs confirmation is left for the inbox and announced on ProposedTransitions.
+func (c *CentralizedClient) applyTransitions(ctx context.Context, projectID string, work *models.Work) {
+	tree, err := c.projectTree(projectID, work)
+	if err != nil {
+		c.recordFailure("transition-rules", hooks.AfterStatusChange, work, err)
+		return
+	}
+	c.engine.SetWorkTree(tree)
+
+	transitioned, applied, err := c.engine.EvaluateWork(ctx, work)
+	if err != nil {
+		c.recordFailure("transition-rules", hooks.AfterStatusChange, work, err)
+		return
+	}
+	if applied {
+		*work = *transitioned
+		return
+	}
+
+	if proposal, ok := c.engine.ProposeTransition(tree, work, time.Now()); ok && proposal.NeedsConfirmation {
+		select {
+		case c.proposed <- proposal:
+		default:
+			// Nobody's listening, the inbox finds it when it's next opened
+		}
+	}
+}
+
+// ProposedTransitions delivers transitions that a save made possible but that need
+// confirmation in the transition inbox
+func (c *CentralizedClient) ProposedTransitions() <-chan automation.PendingTransition {
+	return c.proposed
+}
+
+// createRuleUpdate writes an update created by a transition rule to its work item's project
+func (c *CentralizedClient) createRuleUpdate(work *models.Work, update *models.Update) {
+	projectID := work.GitContext.ProjectID
+	if _, exists := c.registry.GetProject(projectID); !exists {
+		projectID = c.project.ID
+	}
+	if err := c.CreateProjectUpdate(projectID, work.ID, update); err != nil {
+		c.recordFailure("rule-update", hooks.AfterStatusChange, work, err)
+	}
+}
+
+// recordFailure audits a step that failed after a change was saved. The change stands, so
+// the failure is listed with the hook runs rather than returned.
+func (c *CentralizedClient) recordFailure(step string, event hooks.HookType, work *models.Work, err error) {
+	c.hookSystem.RecordFailure(step, event, work.ID, err)
+}
+
+// CreateUpdate adds an entry to a work item's updates document
+func (c *CentralizedClient) CreateUpdate(workID string, update *models.Update) error {
+	return c.CreateProjectUpdate(c.project.ID, workID, update)
+}
+
+// CreateProjectUpdate adds an entry to the updates document of a work item in any
+// registered project
+func (c *CentralizedClient) CreateProjectUpdate(projectID, workID string, update *models.Update) error {
+	if _, exists := c.registry.GetProject(projectID); !exists {
+		return fmt.Errorf("project not found: %s", projectID)
+	}
+	if err := data.NewUpdatesManager(c.storage.GetProjectWorkDir(projectID)).CreateUpdate(workID, update); err != nil {
+		return fmt.Errorf("failed to write update: %w", err)
+	}
+	c.commitChange(fmt.Sprintf("Add update to %s", workID))
+	return nil
+}
+
+// GetUpdates returns the updates of a work item of the current project, newest first
+func (c *CentralizedClient) GetUpdates(workID string) ([]*models.Update, error) {
+	return c.GetProjectUpdates(c.project.ID, workID)
+}
+
+// GetProjectUpdates returns the updates of a work item in any registered project, newest
+// first
+func (c *CentralizedClient) GetProjectUpdates(projectID, workID string) ([]*models.Update, error) {
+	if _, exists := c.registry.GetProject(projectID); !exists {
+		return nil, fmt.Errorf("project not found: %s", projectID)
+	}
+	return data.NewUpdatesManager(c.storage.GetProjectWorkDir(projectID)).GetUpdates(workID)
+}
+
+// EditUpdate replaces an update of a work item of the current project, matched by ID
+func (c *CentralizedClient) EditUpdate(workID string, update *models.Update) error {
+	if err := data.NewUpdatesManager(c.GetWorkDir()).EditUpdate(workID, update); err != nil {
+		return fmt.Errorf("failed to edit update: %w", err)
+	}
+	c.commitChange(fmt.Sprintf("Edit update %s of %s", update.ID, workID))
+	return nil
+}
+
+// DeleteUpdate removes an update from a work item of the current project
+func (c *CentralizedClient) DeleteUpdate(workID, updateID string) error {
+	if err := data.NewUpdatesManager(c.GetWorkDir()).DeleteUpdate(workID, updateID); err != nil {
+		return fmt.Errorf("failed to delete update: %w", err)
+	}
+	c.commitChange(fmt.Sprintf("Delete update %s of %s", updateID, workID))
+	return nil
+}
+
+// GetHookSystem returns the hook system used for work updates
+func (c *CentralizedClient) GetHookSystem() *hooks.HookSystem {
+	return c.hookSystem
+}
+
+// GetGitSync returns the git sync manager, or nil if it couldn't be loaded
+func (c *CentralizedClient) GetGitSync() *GitSync {
+	return c.gitSync
+}
+
+// commitChange queues an auto-commit of storage changes when git sync is enabled. Failures
+// are reported on the sync manager's Errors; the write itself succeeded and sync catches up.
+func (c *CentralizedClient) commitChange(message string) {
+	if c.gitSync == nil {
+		return
+	}
+	c.gitSync.QueueCommit(message)
+}
+
+// Close waits for queued auto-commits, so command line tools don't exit before git ran
+func (c *CentralizedClient) Close() {
+	if c.gitSync != nil {
+		c.gitSync.Flush()
+	}
+}
+
+// GetProjectWork returns all work items for any registered project
+func (c *CentralizedClient) GetProjectWork(projectID string) ([]*models.Work, error) {
+	if _, exists := c.registry.GetProject(projectID); !exists {
+		return nil, fmt.Errorf("project not found: %s", projectID)
+	}
+	return data.NewMarkdownIO(c.storage.GetProjectWorkDir(projectID)).ListAllWork()
+}
+
+// GetProjectArtifacts returns all artifacts for any registered project
+func (c *CentralizedClient) GetProjectArtifacts(projectID string) ([]*models.Artifact, error) {
+	if _, exists := c.registry.GetProject(projectID); !exists {
+		return nil, fmt.Errorf("project not found: %s", projectID)
+	}
+	return data.NewMarkdownIO(c.storage.GetProjectWorkDir(projectID)).ListAllArtifacts()
 }
 
 // GetCrossProjectWork returns work items across all projects
@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"claude-work-tracker-ui/internal/hooks"
+	"claude-work-tracker-ui/internal/models"
+)
+
+// newTestClient creates a client for a fresh project in a temporary home, auditing to
+// audit.jsonl there
+func newTestClient(t *testing.T) *CentralizedClient {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	storage, err := NewExternalStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry, err := LoadProjectRegistry(storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	project, err := registry.RegisterProject(filepath.Join(home, "project"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hookSystem := hooks.NewHookSystem(nil)
+	hookSystem.SetAuditLog(hooks.NewAuditLog(filepath.Join(home, "audit.jsonl"), nil))
+	return newCentralizedClient(storage, registry, project, hookSystem)
+}
+
+// createTestWork creates a work item in the client's project and returns it as stored
+func createTestWork(t *testing.T, c *CentralizedClient, work *models.Work) *models.Work {
+	t.Helper()
+	if work.Schedule == "" {
+		work.Schedule = models.ScheduleNow
+	}
+	if work.Metadata.Status == "" {
+		work.Metadata.Status = models.WorkStatusActive
+	}
+	if err := c.CreateWork(work); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := c.GetProjectWorkByID(c.project.ID, work.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stored
+}
+
+// writeRules replaces the transition rules with a rules file
+func writeRules(t *testing.T, c *CentralizedClient, rules string) {
+	t.Helper()
+	path := filepath.Join(c.storage.ConfigDir, "rules.yaml")
+	if err := os.WriteFile(path, []byte(rules), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.engine.RulesError(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateWorkAppliesTransitionsAfterChecklistChange(t *testing.T) {
+	c := newTestClient(t)
+	work := createTestWork(t, c, &models.Work{
+		ID:       "work-1",
+		Title:    "Checklist",
+		Content:  "- [x] One\n- [ ] Two\n",
+		Metadata: models.WorkMetadata{Status: models.WorkStatusInProgress, ProgressFromTasks: true},
+	})
+
+	work.Content = strings.Replace(work.Content, "- [ ] Two", "- [x] Two", 1)
+	if err := c.UpdateWork(work); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := c.GetProjectWorkByID(c.project.ID, "work-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Metadata.Status != models.WorkStatusCompleted || saved.Schedule != models.ScheduleClosed {
+		t.Errorf("status %s in %s, want completed in closed", saved.Metadata.Status, saved.Schedule)
+	}
+	last := saved.LastTransition()
+	if last == nil || last.Rule != "in_progress_to_completed" || !last.Automatic {
+		t.Errorf("last transition = %+v, want the rule's", last)
+	}
+}
+
+func TestUpdateWorkLeavesConfirmedTransitionsForTheInbox(t *testing.T) {
+	c := newTestClient(t)
+	writeRules(t, c, `rules:
+  - name: start_at_half
+    description: Propose starting at half way
+    confirm: true
+    when:
+      - status == active
+      - progress >= 50
+    then:
+      - set_status: in_progress
+`)
+	work := createTestWork(t, c, &models.Work{
+		ID:       "work-1",
+		Title:    "Checklist",
+		Content:  "- [ ] One\n- [ ] Two\n",
+		Metadata: models.WorkMetadata{ProgressFromTasks: true},
+	})
+
+	work.Content = strings.Replace(work.Content, "- [ ] One", "- [x] One", 1)
+	if err := c.UpdateWork(work); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case proposal := <-c.ProposedTransitions():
+		if proposal.Rule != "start_at_half" || proposal.Work.ID != "work-1" {
+			t.Errorf("proposal = %+v", proposal)
+		}
+	default:
+		t.Fatal("no transition was proposed")
+	}
+
+	saved, _ := c.GetProjectWorkByID(c.project.ID, "work-1")
+	if saved.Metadata.Status != models.WorkStatusActive {
+		t.Errorf("status = %s, the transition needs confirmation first", saved.Metadata.Status)
+	}
+}
+
+func TestUpdateWorkRecordsFailedFollowUpsInAuditLog(t *testing.T) {
+	c := newTestClient(t)
+	work := createTestWork(t, c, &models.Work{
+		ID:       "work-1",
+		Title:    "Checklist",
+		Content:  "- [ ] One\n- [ ] Two\n",
+		Metadata: models.WorkMetadata{ProgressFromTasks: true},
+	})
+
+	// A directory where the update log should be makes recording the update fail
+	updatesDir := filepath.Join(c.GetWorkDir(), "updates")
+	if err := os.MkdirAll(filepath.Join(updatesDir, "work-1.jsonl"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	work.Content = strings.Replace(work.Content, "- [ ] One", "- [x] One", 1)
+	if err := c.UpdateWork(work); err != nil {
+		t.Fatalf("the save itself should succeed: %v", err)
+	}
+
+	entries, err := c.hookSystem.GetAuditLog().Recent(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, entry := range entries {
+		if entry.Hook == "progress-update" && entry.WorkID == "work-1" && !entry.Success {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("progress update failure wasn't audited: %+v", entries)
+	}
+}
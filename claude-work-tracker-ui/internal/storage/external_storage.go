@@ -178,6 +178,12 @@ func (r *ProjectRegistry) ListProjects() []*Project {
 	return projects
 }
 
+// CurrentProjectID returns the ID of the project containing the working directory,
+// without loading or updating the registry
+func CurrentProjectID() string {
+	return generateProjectID(NewProjectScanner().GetProjectRoot())
+}
+
 // generateProjectID creates a consistent ID for a project
 func generateProjectID(projectPath string) string {
 	// Try to get git remote URL first
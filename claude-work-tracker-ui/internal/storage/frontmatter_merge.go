@@ -0,0 +1,383 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/parser"
+	"gopkg.in/yaml.v3"
+)
+
+// MergeConflict describes a field that changed differently on both sides of a merge
+type MergeConflict struct {
+	Field  string      `json:"field"`
+	Base   interface{} `json:"base,omitempty"`
+	Ours   interface{} `json:"ours,omitempty"`
+	Theirs interface{} `json:"theirs,omitempty"`
+}
+
+// MergeMarkdown performs a three-way merge of markdown documents with YAML frontmatter.
+// Frontmatter is merged field by field, keeping the local key order; conflicting fields keep
+// the local value and are reported so they can be resolved later. Timestamps resolve to the
+// most recent value. The result has the local version's line endings.
+func MergeMarkdown(base, ours, theirs []byte) ([]byte, []MergeConflict, error) {
+	baseFM, baseBody, err := splitMarkdownNode(base)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse base version: %w", err)
+	}
+	oursFM, oursBody, err := splitMarkdownNode(ours)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse local version: %w", err)
+	}
+	theirsFM, theirsBody, err := splitMarkdownNode(theirs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse remote version: %w", err)
+	}
+
+	var conflicts []MergeConflict
+	mergedFM := mergeNode("", baseFM, oursFM, theirsFM, &conflicts)
+
+	mergedBody := oursBody
+	switch {
+	case oursBody == theirsBody, theirsBody == baseBody:
+		mergedBody = oursBody
+	case oursBody == baseBody:
+		mergedBody = theirsBody
+	default:
+		conflicts = append(conflicts, MergeConflict{
+			Field:  "content",
+			Base:   baseBody,
+			Ours:   oursBody,
+			Theirs: theirsBody,
+		})
+	}
+
+	if mergedFM != nil && mergedFM.Kind != yaml.MappingNode {
+		mergedFM = nil
+	}
+	merged, err := joinMarkdown(mergedFM, mergedBody, hasCRLF(ours))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return merged, conflicts, nil
+}
+
+// ApplyFrontmatterValue sets a dotted frontmatter field (or "content") in a markdown document
+func ApplyFrontmatterValue(doc []byte, field string, value interface{}) ([]byte, error) {
+	fm, body, err := splitMarkdownNode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if field == "content" {
+		body, _ = value.(string)
+		return joinMarkdown(fm, strings.ReplaceAll(body, "\r\n", "\n"), hasCRLF(doc))
+	}
+
+	if fm == nil {
+		fm = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+
+	parts := strings.Split(field, ".")
+	current := fm
+	for _, part := range parts[:len(parts)-1] {
+		next := mappingValue(current, part)
+		if next == nil || next.Kind != yaml.MappingNode {
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			setMappingValue(current, part, next)
+		}
+		current = next
+	}
+
+	last := parts[len(parts)-1]
+	if value == nil {
+		setMappingValue(current, last, nil)
+	} else {
+		node := &yaml.Node{}
+		if err := node.Encode(value); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", field, err)
+		}
+		setMappingValue(current, last, node)
+	}
+
+	return joinMarkdown(fm, body, hasCRLF(doc))
+}
+
+// splitMarkdown parses frontmatter into a generic map and returns the raw body
+func splitMarkdown(doc []byte) (map[string]interface{}, string, error) {
+	node, body, err := splitMarkdownNode(doc)
+	if err != nil || node == nil {
+		return nil, body, err
+	}
+
+	fm := make(map[string]interface{})
+	if err := node.Decode(&fm); err != nil {
+		return nil, "", fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+
+	return fm, body, nil
+}
+
+// splitMarkdownNode parses frontmatter into a YAML mapping node, which keeps the key order,
+// and returns the raw body with \n line endings
+func splitMarkdownNode(doc []byte) (*yaml.Node, string, error) {
+	if len(doc) == 0 {
+		return nil, "", nil
+	}
+
+	normalized := []byte(strings.ReplaceAll(string(doc), "\r\n", "\n"))
+	frontmatter, body, ok := parser.SplitFrontmatter(normalized)
+	if !ok {
+		// No frontmatter, the whole document is body
+		return nil, string(normalized), nil
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(frontmatter, &document); err != nil {
+		return nil, "", fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+	if len(document.Content) == 0 {
+		// Empty frontmatter
+		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, string(body), nil
+	}
+
+	node := document.Content[0]
+	if node.Kind != yaml.MappingNode {
+		return nil, "", fmt.Errorf("failed to parse frontmatter: expected a mapping")
+	}
+
+	return node, string(body), nil
+}
+
+// hasCRLF reports whether a document uses \r\n line endings, as parser.ParseMarkdown does
+func hasCRLF(doc []byte) bool {
+	return bytes.Contains(doc, []byte("\r\n"))
+}
+
+// joinMarkdown renders frontmatter and a body with \n line endings back into a markdown
+// document, with \r\n line endings if crlf is set
+func joinMarkdown(fm *yaml.Node, body string, crlf bool) ([]byte, error) {
+	if fm == nil {
+		return restoreLineEndings(body, crlf), nil
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(fm); err != nil {
+		return nil, fmt.Errorf("failed to encode frontmatter: %w", err)
+	}
+	encoder.Close()
+
+	var out strings.Builder
+	out.WriteString("---\n")
+	if len(fm.Content) > 0 {
+		out.WriteString(buf.String())
+	}
+	out.WriteString("---\n")
+	out.WriteString(body)
+
+	return restoreLineEndings(out.String(), crlf), nil
+}
+
+// restoreLineEndings turns the \n line endings of text back into \r\n if crlf is set
+func restoreLineEndings(text string, crlf bool) []byte {
+	if crlf {
+		text = strings.ReplaceAll(text, "\n", "\r\n")
+	}
+	return []byte(text)
+}
+
+// mappingValue returns the value of a key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue replaces the value of a key in a mapping node in place, appends the key if
+// it's missing, or removes it when value is nil
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != key {
+			continue
+		}
+		if value == nil {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+		} else {
+			node.Content[i+1] = value
+		}
+		return
+	}
+	if value != nil {
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+	}
+}
+
+// nodeValue decodes a node into a generic value, nil when the node is missing
+func nodeValue(node *yaml.Node) interface{} {
+	if node == nil {
+		return nil
+	}
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return node.Value
+	}
+	return value
+}
+
+// mergeNode merges a single value three ways, recursing into mappings. A nil node is a
+// missing key.
+func mergeNode(path string, base, ours, theirs *yaml.Node, conflicts *[]MergeConflict) *yaml.Node {
+	baseValue, oursValue, theirsValue := nodeValue(base), nodeValue(ours), nodeValue(theirs)
+	if reflect.DeepEqual(oursValue, theirsValue) {
+		return ours
+	}
+	if reflect.DeepEqual(baseValue, oursValue) {
+		return theirs
+	}
+	if reflect.DeepEqual(baseValue, theirsValue) {
+		return ours
+	}
+
+	// Both sides changed the value differently
+	if ours != nil && theirs != nil && ours.Kind == yaml.MappingNode && theirs.Kind == yaml.MappingNode {
+		if base != nil && base.Kind != yaml.MappingNode {
+			base = nil
+		}
+		return mergeMappings(path, base, ours, theirs, conflicts)
+	}
+
+	// Timestamps are last-writer-wins
+	oursTime, oursIsTime := oursValue.(time.Time)
+	theirsTime, theirsIsTime := theirsValue.(time.Time)
+	if oursIsTime && theirsIsTime {
+		if theirsTime.After(oursTime) {
+			return theirs
+		}
+		return ours
+	}
+
+	// Scalar lists (tags, refs) are merged as a union
+	oursList, oursIsList := oursValue.([]interface{})
+	theirsList, theirsIsList := theirsValue.([]interface{})
+	if oursIsList && theirsIsList && isScalarList(oursList) && isScalarList(theirsList) {
+		baseList, _ := baseValue.([]interface{})
+		return mergeSequences(ours, theirs, mergeLists(baseList, oursList, theirsList))
+	}
+
+	*conflicts = append(*conflicts, MergeConflict{
+		Field:  path,
+		Base:   baseValue,
+		Ours:   oursValue,
+		Theirs: theirsValue,
+	})
+	return ours
+}
+
+// mergeMappings merges frontmatter mappings key by key. Keys keep their local order, keys
+// only the remote side added follow in its order.
+func mergeMappings(path string, base, ours, theirs *yaml.Node, conflicts *[]MergeConflict) *yaml.Node {
+	var keys []*yaml.Node
+	seen := make(map[string]bool)
+	for _, node := range []*yaml.Node{ours, theirs, base} {
+		if node == nil {
+			continue
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if !seen[key.Value] {
+				seen[key.Value] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: ours.Tag, Style: ours.Style}
+	for _, key := range keys {
+		fieldPath := key.Value
+		if path != "" {
+			fieldPath = path + "." + key.Value
+		}
+
+		var baseValue *yaml.Node
+		if base != nil {
+			baseValue = mappingValue(base, key.Value)
+		}
+		value := mergeNode(fieldPath, baseValue, mappingValue(ours, key.Value), mappingValue(theirs, key.Value), conflicts)
+		if value != nil {
+			merged.Content = append(merged.Content, key, value)
+		}
+	}
+
+	return merged
+}
+
+// mergeSequences builds a sequence node holding the merged values, reusing the item nodes of
+// either side so their styles survive
+func mergeSequences(ours, theirs *yaml.Node, values []interface{}) *yaml.Node {
+	items := make(map[interface{}]*yaml.Node)
+	for _, node := range []*yaml.Node{theirs, ours} {
+		for _, item := range node.Content {
+			items[nodeValue(item)] = item
+		}
+	}
+
+	merged := &yaml.Node{Kind: yaml.SequenceNode, Tag: ours.Tag, Style: ours.Style}
+	for _, v := range values {
+		merged.Content = append(merged.Content, items[v])
+	}
+	return merged
+}
+
+// mergeLists unions two scalar lists, dropping entries removed on either side
+func mergeLists(base, ours, theirs []interface{}) []interface{} {
+	inBase := make(map[interface{}]bool)
+	for _, v := range base {
+		inBase[v] = true
+	}
+	inOurs := make(map[interface{}]bool)
+	for _, v := range ours {
+		inOurs[v] = true
+	}
+	inTheirs := make(map[interface{}]bool)
+	for _, v := range theirs {
+		inTheirs[v] = true
+	}
+
+	var merged []interface{}
+	seen := make(map[interface{}]bool)
+	for _, v := range append(append([]interface{}{}, ours...), theirs...) {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+
+		// Removed on one side and untouched on the other
+		if inBase[v] && (!inOurs[v] || !inTheirs[v]) {
+			continue
+		}
+		merged = append(merged, v)
+	}
+
+	return merged
+}
+
+// isScalarList reports whether a list only contains comparable scalar values
+func isScalarList(list []interface{}) bool {
+	for _, v := range list {
+		switch v.(type) {
+		case string, int, int64, float64, bool:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,232 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeMarkdown(t *testing.T) {
+	tests := []struct {
+		name      string
+		base      string
+		ours      string
+		theirs    string
+		want      string
+		conflicts []string
+	}{
+		{
+			name:   "edits to different fields are combined in the local key order",
+			base:   "---\nid: work-1\ntitle: Old\nstatus: active\npriority: low\n---\nBody\n",
+			ours:   "---\nid: work-1\ntitle: New\nstatus: active\npriority: low\n---\nBody\n",
+			theirs: "---\nid: work-1\ntitle: Old\nstatus: completed\npriority: low\n---\nBody\n",
+			want:   "---\nid: work-1\ntitle: New\nstatus: completed\npriority: low\n---\nBody\n",
+		},
+		{
+			name:   "keys added remotely follow the local keys",
+			base:   "---\nid: work-1\ntitle: Old\n---\n",
+			ours:   "---\nid: work-1\ntitle: Old\nowner: me\n---\n",
+			theirs: "---\nid: work-1\nassignee: bob\ntitle: Old\n---\n",
+			want:   "---\nid: work-1\ntitle: Old\nowner: me\nassignee: bob\n---\n",
+		},
+		{
+			name:   "key removed on one side",
+			base:   "---\nid: work-1\nowner: me\ntitle: Old\n---\n",
+			ours:   "---\nid: work-1\nowner: me\ntitle: New\n---\n",
+			theirs: "---\nid: work-1\ntitle: Old\n---\n",
+			want:   "---\nid: work-1\ntitle: New\n---\n",
+		},
+		{
+			name:      "same field changed on both sides keeps ours",
+			base:      "---\nid: work-1\nstatus: active\n---\n",
+			ours:      "---\nid: work-1\nstatus: blocked\n---\n",
+			theirs:    "---\nid: work-1\nstatus: completed\n---\n",
+			want:      "---\nid: work-1\nstatus: blocked\n---\n",
+			conflicts: []string{"status"},
+		},
+		{
+			name:   "timestamps take the latest",
+			base:   "---\nid: work-1\nupdated_at: 2024-01-01T10:00:00Z\n---\n",
+			ours:   "---\nid: work-1\nupdated_at: 2024-01-02T10:00:00Z\n---\n",
+			theirs: "---\nid: work-1\nupdated_at: 2024-01-03T10:00:00Z\n---\n",
+			want:   "---\nid: work-1\nupdated_at: 2024-01-03T10:00:00Z\n---\n",
+		},
+		{
+			name:   "tag lists are unioned, removals kept",
+			base:   "---\ntags:\n  - a\n  - b\n---\n",
+			ours:   "---\ntags:\n  - a\n  - b\n  - c\n---\n",
+			theirs: "---\ntags:\n  - b\n  - d\n---\n",
+			want:   "---\ntags:\n  - b\n  - c\n  - d\n---\n",
+		},
+		{
+			name:      "nested fields merge by path",
+			base:      "---\ngit_context:\n  branch: main\n  commit: aaa\n---\n",
+			ours:      "---\ngit_context:\n  branch: feature\n  commit: bbb\n---\n",
+			theirs:    "---\ngit_context:\n  branch: main\n  commit: ccc\n---\n",
+			want:      "---\ngit_context:\n  branch: feature\n  commit: bbb\n---\n",
+			conflicts: []string{"git_context.commit"},
+		},
+		{
+			name:   "body edited remotely",
+			base:   "---\nid: work-1\n---\nOld body\n",
+			ours:   "---\nid: work-1\n---\nOld body\n",
+			theirs: "---\nid: work-1\n---\nNew body\n",
+			want:   "---\nid: work-1\n---\nNew body\n",
+		},
+		{
+			name:      "body edited on both sides keeps ours",
+			base:      "---\nid: work-1\n---\nOld body\n",
+			ours:      "---\nid: work-1\n---\nOur body\n",
+			theirs:    "---\nid: work-1\n---\nTheir body\n",
+			want:      "---\nid: work-1\n---\nOur body\n",
+			conflicts: []string{"content"},
+		},
+		{
+			name:   "CRLF documents keep their line endings",
+			base:   "---\r\nid: work-1\r\ntitle: Old\r\n---\r\nBody\r\n",
+			ours:   "---\r\nid: work-1\r\ntitle: New\r\n---\r\nBody\r\n",
+			theirs: "---\r\nid: work-1\r\ntitle: Old\r\n---\r\nBody\r\n",
+			want:   "---\r\nid: work-1\r\ntitle: New\r\n---\r\nBody\r\n",
+		},
+		{
+			name:   "remote LF changes take the local CRLF line endings",
+			base:   "---\r\nid: work-1\r\nstatus: active\r\n---\r\nOld\r\nbody\r\n",
+			ours:   "---\r\nid: work-1\r\nstatus: active\r\n---\r\nOld\r\nbody\r\n",
+			theirs: "---\nid: work-1\nstatus: completed\n---\nNew\nbody\n",
+			want:   "---\r\nid: work-1\r\nstatus: completed\r\n---\r\nNew\r\nbody\r\n",
+		},
+		{
+			name:   "CRLF without frontmatter",
+			base:   "Old\r\n",
+			ours:   "Old\r\n",
+			theirs: "New\r\n",
+			want:   "New\r\n",
+		},
+		{
+			name:   "no frontmatter",
+			base:   "Old\n",
+			ours:   "Old\n",
+			theirs: "New\n",
+			want:   "New\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, conflicts, err := MergeMarkdown([]byte(tt.base), []byte(tt.ours), []byte(tt.theirs))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(merged) != tt.want {
+				t.Errorf("merged:\n%s\nwant:\n%s", merged, tt.want)
+			}
+
+			var fields []string
+			for _, c := range conflicts {
+				fields = append(fields, c.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.conflicts) {
+				t.Errorf("conflicts = %v, want %v", fields, tt.conflicts)
+			}
+		})
+	}
+}
+
+func TestMergeMarkdownContentConflictKeepsBothBodies(t *testing.T) {
+	_, conflicts, err := MergeMarkdown(
+		[]byte("---\nid: work-1\n---\nbase\n"),
+		[]byte("---\nid: work-1\n---\nours\n"),
+		[]byte("---\nid: work-1\n---\ntheirs\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 1 {
+		t.Fatalf("conflicts = %v, want one", conflicts)
+	}
+	c := conflicts[0]
+	if c.Base != "base\n" || c.Ours != "ours\n" || c.Theirs != "theirs\n" {
+		t.Errorf("conflict = %+v", c)
+	}
+}
+
+func TestApplyFrontmatterValue(t *testing.T) {
+	doc := "---\nid: work-1\nstatus: active\ngit_context:\n  branch: main\n---\nBody\n"
+	tests := []struct {
+		name  string
+		field string
+		value interface{}
+		want  string
+	}{
+		{
+			name:  "replaces in place",
+			field: "status",
+			value: "completed",
+			want:  "---\nid: work-1\nstatus: completed\ngit_context:\n  branch: main\n---\nBody\n",
+		},
+		{
+			name:  "nested field",
+			field: "git_context.projectid",
+			value: "abc",
+			want:  "---\nid: work-1\nstatus: active\ngit_context:\n  branch: main\n  projectid: abc\n---\nBody\n",
+		},
+		{
+			name:  "nil removes the field",
+			field: "status",
+			value: nil,
+			want:  "---\nid: work-1\ngit_context:\n  branch: main\n---\nBody\n",
+		},
+		{
+			name:  "content replaces the body",
+			field: "content",
+			value: "New\n",
+			want:  "---\nid: work-1\nstatus: active\ngit_context:\n  branch: main\n---\nNew\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyFrontmatterValue([]byte(doc), tt.field, tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyFrontmatterValueKeepsCRLF(t *testing.T) {
+	doc := "---\r\nid: work-1\r\nstatus: active\r\n---\r\nBody\r\n"
+	tests := []struct {
+		name  string
+		field string
+		value interface{}
+		want  string
+	}{
+		{
+			name:  "field",
+			field: "status",
+			value: "completed",
+			want:  "---\r\nid: work-1\r\nstatus: completed\r\n---\r\nBody\r\n",
+		},
+		{
+			name:  "content",
+			field: "content",
+			value: "New\nbody\n",
+			want:  "---\r\nid: work-1\r\nstatus: active\r\n---\r\nNew\r\nbody\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyFrontmatterValue([]byte(doc), tt.field, tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,744 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"claude-work-tracker-ui/internal/data"
+)
+
+// SyncConfig controls git-backed synchronization of the centralized work-data directory
+type SyncConfig struct {
+	Enabled     bool       `json:"enabled"`
+	Remote      string     `json:"remote,omitempty"`
+	Branch      string     `json:"branch"`
+	AutoCommit  bool       `json:"auto_commit"`
+	AutoPush    bool       `json:"auto_push"`
+	PullOnStart bool       `json:"pull_on_start"`
+	LastSync    *time.Time `json:"last_sync,omitempty"` // Written by Sync, see SyncResult.SyncedAt
+}
+
+// DefaultSyncConfig returns sensible defaults for git sync
+func DefaultSyncConfig() *SyncConfig {
+	return &SyncConfig{
+		Enabled:     false,
+		Branch:      "main",
+		AutoCommit:  true,
+		AutoPush:    false,
+		PullOnStart: true,
+	}
+}
+
+// SyncConflict is a merge conflict that was auto-resolved in favour of the local
+// version and still needs a decision from the user
+type SyncConflict struct {
+	Path       string      `json:"path"`
+	Field      string      `json:"field"`
+	Base       interface{} `json:"base,omitempty"`
+	Ours       interface{} `json:"ours,omitempty"`
+	Theirs     interface{} `json:"theirs,omitempty"`
+	DetectedAt time.Time   `json:"detected_at"`
+}
+
+// SyncResult summarizes a pull/push cycle
+type SyncResult struct {
+	Committed   bool           `json:"committed"`
+	Pulled      bool           `json:"pulled"`
+	Pushed      bool           `json:"pushed"`
+	MergedFiles []string       `json:"merged_files,omitempty"`
+	Conflicts   []SyncConflict `json:"conflicts,omitempty"`
+	SyncedAt    *time.Time     `json:"synced_at,omitempty"` // When the sync finished
+}
+
+// SyncStatus describes the current state of the sync repository
+type SyncStatus struct {
+	Enabled          bool       `json:"enabled"`
+	Initialized      bool       `json:"initialized"`
+	Remote           string     `json:"remote,omitempty"`
+	Branch           string     `json:"branch"`
+	UncommittedFiles int        `json:"uncommitted_files"`
+	OpenConflicts    int        `json:"open_conflicts"`
+	LastSync         *time.Time `json:"last_sync,omitempty"`
+}
+
+// autoCommitQueueSize is how many auto-commits can wait for the worker before writers block
+const autoCommitQueueSize = 64
+
+// GitSync treats the centralized work-data directory as a git repository. Git commands are
+// serialized, since they share one index, and auto-commits run on a background worker so
+// writers never wait for git or the network.
+type GitSync struct {
+	storage *ExternalStorage
+	config  *SyncConfig
+	mu      sync.Mutex     // Held while git runs against the repository
+	queue   chan string    // Auto-commit messages waiting for the worker
+	pending sync.WaitGroup // Queued auto-commits not yet done
+	errs    chan error     // Failures of queued auto-commits and pushes
+	worker  sync.Once
+}
+
+// NewGitSync creates a git sync manager, loading config from the config directory
+func NewGitSync(storage *ExternalStorage) (*GitSync, error) {
+	gs := &GitSync{
+		storage: storage,
+		config:  DefaultSyncConfig(),
+		queue:   make(chan string, autoCommitQueueSize),
+		errs:    make(chan error, 8),
+	}
+
+	data, err := ioutil.ReadFile(gs.configPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return gs, nil
+		}
+		return nil, fmt.Errorf("failed to read sync config: %w", err)
+	}
+
+	if err := json.Unmarshal(data, gs.config); err != nil {
+		return nil, fmt.Errorf("failed to parse sync config: %w", err)
+	}
+
+	return gs, nil
+}
+
+// GetConfig returns the sync configuration
+func (g *GitSync) GetConfig() *SyncConfig {
+	return g.config
+}
+
+// IsEnabled reports whether git sync is configured and the repository exists
+func (g *GitSync) IsEnabled() bool {
+	return g.config.Enabled && g.isInitialized()
+}
+
+// SaveConfig persists the sync configuration
+func (g *GitSync) SaveConfig() error {
+	data, err := json.MarshalIndent(g.config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync config: %w", err)
+	}
+
+	if err := ioutil.WriteFile(g.configPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write sync config: %w", err)
+	}
+
+	return nil
+}
+
+// Init turns the work-data directory into a git repository tracking the given remote
+func (g *GitSync) Init(remote, branch string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if branch == "" {
+		branch = g.config.Branch
+	}
+
+	if !g.isInitialized() {
+		if _, err := g.git("init", "-b", branch); err != nil {
+			return fmt.Errorf("failed to initialize repository: %w", err)
+		}
+	}
+
+	// Commits must work even without a global git identity
+	if out, _ := g.git("config", "user.email"); out == "" {
+		g.git("config", "user.name", "Claude Work Tracker")
+		g.git("config", "user.email", "work-tracker@localhost")
+	}
+
+	ignorePath := filepath.Join(g.storage.BaseDir, ".gitignore")
+	if _, err := os.Stat(ignorePath); os.IsNotExist(err) {
+		if err := ioutil.WriteFile(ignorePath, []byte(".sync/\n*.lock\n*.tmp\n"), 0644); err != nil {
+			return fmt.Errorf("failed to write .gitignore: %w", err)
+		}
+	}
+
+	if remote != "" {
+		if _, err := g.git("remote", "get-url", "origin"); err == nil {
+			if _, err := g.git("remote", "set-url", "origin", remote); err != nil {
+				return fmt.Errorf("failed to update remote: %w", err)
+			}
+		} else if _, err := g.git("remote", "add", "origin", remote); err != nil {
+			return fmt.Errorf("failed to add remote: %w", err)
+		}
+	}
+
+	g.config.Enabled = true
+	g.config.Branch = branch
+	if remote != "" {
+		g.config.Remote = remote
+	}
+	if err := g.SaveConfig(); err != nil {
+		return err
+	}
+
+	if _, err := g.commitAll("Initialize work-data sync"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CommitAll stages and commits every change, returning false if there was nothing to commit
+func (g *GitSync) CommitAll(message string) (bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.commitAll(message)
+}
+
+// commitAll is CommitAll for callers already holding the lock
+func (g *GitSync) commitAll(message string) (bool, error) {
+	if !g.isInitialized() {
+		return false, fmt.Errorf("sync repository not initialized")
+	}
+
+	if _, err := g.git("add", "-A"); err != nil {
+		return false, fmt.Errorf("failed to stage changes: %w", err)
+	}
+
+	// Only look at what was staged; files written since then are left for the next commit
+	staged, err := g.git("diff", "--cached", "--name-only")
+	if err != nil {
+		return false, fmt.Errorf("failed to read status: %w", err)
+	}
+	if staged == "" {
+		return false, nil
+	}
+
+	if _, err := g.git("commit", "-m", message); err != nil {
+		return false, fmt.Errorf("failed to commit changes: %w", err)
+	}
+
+	return true, nil
+}
+
+// AutoCommit commits pending changes after a write when auto-commit is enabled, and pushes
+// them when auto-push is. It waits for git; writers should use QueueCommit instead.
+func (g *GitSync) AutoCommit(message string) error {
+	if !g.IsEnabled() || !g.config.AutoCommit {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	committed, err := g.commitAll(message)
+	if err != nil {
+		return err
+	}
+
+	if committed && g.config.AutoPush && g.config.Remote != "" {
+		return g.push()
+	}
+
+	return nil
+}
+
+// QueueCommit schedules an auto-commit on the background worker and returns at once.
+// Failures are reported on Errors.
+func (g *GitSync) QueueCommit(message string) {
+	if !g.IsEnabled() || !g.config.AutoCommit {
+		return
+	}
+
+	g.worker.Do(func() { go g.runQueue() })
+	g.pending.Add(1)
+	g.queue <- message
+}
+
+// Flush waits until every queued auto-commit is done
+func (g *GitSync) Flush() {
+	g.pending.Wait()
+}
+
+// Errors delivers failures of queued auto-commits and pushes. Failures are dropped while
+// nobody reads them; the next sync commits whatever is left.
+func (g *GitSync) Errors() <-chan error {
+	return g.errs
+}
+
+// runQueue commits queued changes, folding writes that arrive together into one commit
+func (g *GitSync) runQueue() {
+	for message := range g.queue {
+		count := 1
+		for drained := false; !drained; {
+			select {
+			case <-g.queue:
+				count++
+			default:
+				drained = true
+			}
+		}
+		if count > 1 {
+			message = fmt.Sprintf("%s (and %d more changes)", message, count-1)
+		}
+
+		if err := g.AutoCommit(message); err != nil {
+			select {
+			case g.errs <- fmt.Errorf("auto-commit failed: %w", err):
+			default:
+			}
+		}
+		for i := 0; i < count; i++ {
+			g.pending.Done()
+		}
+	}
+}
+
+// Pull fetches the remote branch and merges it, resolving markdown conflicts field by field
+func (g *GitSync) Pull() (*SyncResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.pull()
+}
+
+// pull is Pull for callers already holding the lock
+func (g *GitSync) pull() (*SyncResult, error) {
+	result := &SyncResult{}
+
+	if !g.IsEnabled() {
+		return nil, fmt.Errorf("git sync is not enabled")
+	}
+	if g.config.Remote == "" {
+		return nil, fmt.Errorf("no sync remote configured")
+	}
+
+	committed, err := g.commitAll("Local changes before sync")
+	if err != nil {
+		return nil, err
+	}
+	result.Committed = committed
+
+	if out, err := g.git("fetch", "origin", g.config.Branch); err != nil {
+		if strings.Contains(out, "couldn't find remote ref") {
+			// Remote branch doesn't exist yet, first push will create it
+			return result, nil
+		}
+		return nil, fmt.Errorf("failed to fetch: %w", err)
+	}
+
+	remoteRef := "origin/" + g.config.Branch
+	before, _ := g.git("rev-parse", "HEAD")
+
+	if _, err := g.git("merge", "--no-edit", "--allow-unrelated-histories", remoteRef); err != nil {
+		conflicted, listErr := g.git("diff", "--name-only", "--diff-filter=U")
+		if listErr != nil || conflicted == "" {
+			g.git("merge", "--abort")
+			return nil, fmt.Errorf("failed to merge %s: %w", remoteRef, err)
+		}
+
+		// Update logs go first, the updates documents beside them are rendered from them
+		paths := strings.Split(conflicted, "\n")
+		sort.SliceStable(paths, func(i, j int) bool {
+			return isUpdateLog(paths[i]) && !isUpdateLog(paths[j])
+		})
+
+		rendered := make(map[string]bool)
+		for _, path := range paths {
+			if rendered[path] {
+				// Rendered and staged with its update log already
+				result.MergedFiles = append(result.MergedFiles, path)
+				continue
+			}
+			if isUpdateLog(path) {
+				rendered[updatesDocument(path)] = true
+			}
+
+			conflicts, err := g.resolveFile(path)
+			if err != nil {
+				g.git("merge", "--abort")
+				return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+			}
+			result.MergedFiles = append(result.MergedFiles, path)
+			result.Conflicts = append(result.Conflicts, conflicts...)
+		}
+
+		if _, err := g.git("commit", "--no-edit"); err != nil {
+			g.git("merge", "--abort")
+			return nil, fmt.Errorf("failed to commit merge: %w", err)
+		}
+	}
+
+	after, _ := g.git("rev-parse", "HEAD")
+	result.Pulled = before != after
+
+	if len(result.Conflicts) > 0 {
+		if err := g.addConflicts(result.Conflicts); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// Push pushes local commits to the configured remote
+func (g *GitSync) Push() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.push()
+}
+
+// push is Push for callers already holding the lock
+func (g *GitSync) push() error {
+	if g.config.Remote == "" {
+		return fmt.Errorf("no sync remote configured")
+	}
+
+	if _, err := g.git("push", "origin", "HEAD:"+g.config.Branch); err != nil {
+		return fmt.Errorf("failed to push: %w", err)
+	}
+
+	return nil
+}
+
+// Sync commits local changes, pulls remote changes and pushes the result
+func (g *GitSync) Sync() (*SyncResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	result, err := g.pull()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.push(); err != nil {
+		return result, err
+	}
+	result.Pushed = true
+
+	now := time.Now()
+	g.config.LastSync = &now
+	result.SyncedAt = &now
+	if err := g.SaveConfig(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// Status reports the current sync state
+func (g *GitSync) Status() (*SyncStatus, error) {
+	status := &SyncStatus{
+		Enabled:     g.config.Enabled,
+		Initialized: g.isInitialized(),
+		Remote:      g.config.Remote,
+		Branch:      g.config.Branch,
+		LastSync:    g.config.LastSync,
+	}
+
+	if status.Initialized {
+		g.mu.Lock()
+		out, err := g.git("status", "--porcelain")
+		g.mu.Unlock()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read status: %w", err)
+		}
+		if out != "" {
+			status.UncommittedFiles = len(strings.Split(out, "\n"))
+		}
+	}
+
+	conflicts, err := g.LoadConflicts()
+	if err != nil {
+		return nil, err
+	}
+	status.OpenConflicts = len(conflicts)
+
+	return status, nil
+}
+
+// LoadConflicts returns conflicts awaiting a decision
+func (g *GitSync) LoadConflicts() ([]SyncConflict, error) {
+	data, err := ioutil.ReadFile(g.conflictsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read sync conflicts: %w", err)
+	}
+
+	var conflicts []SyncConflict
+	if err := json.Unmarshal(data, &conflicts); err != nil {
+		return nil, fmt.Errorf("failed to parse sync conflicts: %w", err)
+	}
+
+	return conflicts, nil
+}
+
+// ResolveConflict settles a recorded conflict, either keeping the local value or taking the remote one
+func (g *GitSync) ResolveConflict(index int, takeTheirs bool) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	conflicts, err := g.LoadConflicts()
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(conflicts) {
+		return fmt.Errorf("conflict index out of range: %d", index)
+	}
+
+	conflict := conflicts[index]
+	_, theirsExists := conflict.Theirs.(string)
+	if takeTheirs && conflict.Field == "file" && !theirsExists {
+		// Deleted remotely
+		if _, err := g.git("rm", "--quiet", "--ignore-unmatch", "--", conflict.Path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", conflict.Path, err)
+		}
+	} else if takeTheirs {
+		fullPath := filepath.Join(g.storage.BaseDir, conflict.Path)
+
+		var updated []byte
+		if conflict.Field == "file" {
+			updated = []byte(conflict.Theirs.(string))
+		} else {
+			current, err := ioutil.ReadFile(fullPath)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", conflict.Path, err)
+			}
+			updated, err = ApplyFrontmatterValue(current, conflict.Field, conflict.Theirs)
+			if err != nil {
+				return fmt.Errorf("failed to apply remote value: %w", err)
+			}
+		}
+
+		if err := ioutil.WriteFile(fullPath, updated, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", conflict.Path, err)
+		}
+	}
+
+	conflicts = append(conflicts[:index], conflicts[index+1:]...)
+	if err := g.saveConflicts(conflicts); err != nil {
+		return err
+	}
+
+	if takeTheirs {
+		_, err := g.commitAll(fmt.Sprintf("Resolve sync conflict in %s (%s)", conflict.Path, conflict.Field))
+		return err
+	}
+
+	return nil
+}
+
+// resolveFile merges a single conflicted file and stages the result
+func (g *GitSync) resolveFile(path string) ([]SyncConflict, error) {
+	base, _ := g.show(":1:" + path)
+	ours, oursErr := g.show(":2:" + path)
+	theirs, theirsErr := g.show(":3:" + path)
+
+	var merged []byte
+	var conflicts []SyncConflict
+	now := time.Now()
+
+	switch {
+	case oursErr != nil || theirsErr != nil:
+		// Deleted on one side, keep whichever version still exists. The deleted side is
+		// left nil in the conflict.
+		conflict := SyncConflict{Path: path, Field: "file", DetectedAt: now}
+		merged = []byte(ours)
+		if oursErr != nil {
+			merged = []byte(theirs)
+		} else {
+			conflict.Ours = ours
+		}
+		if theirsErr == nil {
+			conflict.Theirs = theirs
+		}
+		conflicts = append(conflicts, conflict)
+
+	case isUpdateLog(path):
+		result, err := data.MergeUpdateLogs([]byte(base), []byte(ours), []byte(theirs))
+		if err != nil {
+			return nil, err
+		}
+		merged = result
+
+	case g.hasUpdateLog(path):
+		// The log is merged by now, render the document from it
+		result, err := g.renderUpdates(path)
+		if err != nil {
+			return nil, err
+		}
+		merged = result
+
+	case strings.HasSuffix(path, ".md"):
+		result, mergeConflicts, err := MergeMarkdown([]byte(base), []byte(ours), []byte(theirs))
+		if err != nil {
+			return nil, err
+		}
+		merged = result
+		for _, c := range mergeConflicts {
+			conflicts = append(conflicts, SyncConflict{
+				Path:       path,
+				Field:      c.Field,
+				Base:       c.Base,
+				Ours:       c.Ours,
+				Theirs:     c.Theirs,
+				DetectedAt: now,
+			})
+		}
+
+	case filepath.Base(path) == "project-index.json":
+		result, err := mergeRegistry([]byte(ours), []byte(theirs))
+		if err != nil {
+			return nil, err
+		}
+		merged = result
+
+	default:
+		merged = []byte(ours)
+		conflicts = append(conflicts, SyncConflict{Path: path, Field: "file", Ours: ours, Theirs: theirs, DetectedAt: now})
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(g.storage.BaseDir, path), merged, 0644); err != nil {
+		return nil, err
+	}
+	if _, err := g.git("add", path); err != nil {
+		return nil, err
+	}
+
+	// Render the updates document from the merged log, whether or not git merged the document itself
+	if isUpdateLog(path) {
+		document := updatesDocument(path)
+		rendered, err := g.renderUpdates(document)
+		if err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(filepath.Join(g.storage.BaseDir, document), rendered, 0644); err != nil {
+			return nil, err
+		}
+		if _, err := g.git("add", document); err != nil {
+			return nil, err
+		}
+	}
+
+	return conflicts, nil
+}
+
+// isUpdateLog reports whether a path is a work item's update log
+func isUpdateLog(path string) bool {
+	return strings.HasSuffix(path, ".jsonl") && filepath.Base(filepath.Dir(path)) == "updates"
+}
+
+// updatesDocument returns the path of the updates document rendered from an update log
+func updatesDocument(logPath string) string {
+	return strings.TrimSuffix(logPath, ".jsonl") + ".md"
+}
+
+// hasUpdateLog reports whether a path is an updates document rendered from an update log
+func (g *GitSync) hasUpdateLog(path string) bool {
+	if !strings.HasSuffix(path, ".md") || filepath.Base(filepath.Dir(path)) != "updates" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(g.storage.BaseDir, strings.TrimSuffix(path, ".md")+".jsonl"))
+	return err == nil
+}
+
+// renderUpdates renders the updates document at path from the update log beside it
+func (g *GitSync) renderUpdates(path string) ([]byte, error) {
+	full := filepath.Join(g.storage.BaseDir, path)
+	workDir := filepath.Dir(filepath.Dir(full))
+	workID := strings.TrimSuffix(filepath.Base(path), ".md")
+	return data.NewUpdatesManager(workDir).RenderDocument(workID)
+}
+
+// mergeRegistry unions two project registries, keeping the most recently accessed entry
+func mergeRegistry(ours, theirs []byte) ([]byte, error) {
+	var oursReg, theirsReg ProjectRegistry
+	if err := json.Unmarshal(ours, &oursReg); err != nil {
+		return nil, fmt.Errorf("failed to parse local registry: %w", err)
+	}
+	if err := json.Unmarshal(theirs, &theirsReg); err != nil {
+		return nil, fmt.Errorf("failed to parse remote registry: %w", err)
+	}
+
+	if oursReg.Projects == nil {
+		oursReg.Projects = make(map[string]*Project)
+	}
+	for id, project := range theirsReg.Projects {
+		existing, ok := oursReg.Projects[id]
+		if !ok || project.LastAccess.After(existing.LastAccess) {
+			oursReg.Projects[id] = project
+		}
+	}
+
+	return json.MarshalIndent(&oursReg, "", "  ")
+}
+
+// addConflicts appends newly detected conflicts to the persisted list
+func (g *GitSync) addConflicts(newConflicts []SyncConflict) error {
+	conflicts, err := g.LoadConflicts()
+	if err != nil {
+		return err
+	}
+	return g.saveConflicts(append(conflicts, newConflicts...))
+}
+
+// saveConflicts persists the open conflict list
+func (g *GitSync) saveConflicts(conflicts []SyncConflict) error {
+	if err := os.MkdirAll(filepath.Dir(g.conflictsPath()), 0755); err != nil {
+		return fmt.Errorf("failed to create sync state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(conflicts, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync conflicts: %w", err)
+	}
+
+	if err := ioutil.WriteFile(g.conflictsPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write sync conflicts: %w", err)
+	}
+
+	return nil
+}
+
+// isInitialized reports whether the work-data directory is a git repository
+func (g *GitSync) isInitialized() bool {
+	_, err := os.Stat(filepath.Join(g.storage.BaseDir, ".git"))
+	return err == nil
+}
+
+// configPath returns the path of the sync config file
+func (g *GitSync) configPath() string {
+	return filepath.Join(g.storage.ConfigDir, "sync.json")
+}
+
+// conflictsPath returns the path of the open conflicts file (ignored by git)
+func (g *GitSync) conflictsPath() string {
+	return filepath.Join(g.storage.BaseDir, ".sync", "conflicts.json")
+}
+
+// git runs a git command inside the work-data directory
+func (g *GitSync) git(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.storage.BaseDir
+
+	output, err := cmd.CombinedOutput()
+	out := strings.TrimSpace(string(output))
+	if err != nil {
+		return out, fmt.Errorf("git %s: %s: %w", strings.Join(args, " "), out, err)
+	}
+
+	return out, nil
+}
+
+// show returns the exact contents of a git object such as an index stage
+func (g *GitSync) show(object string) (string, error) {
+	cmd := exec.Command("git", "show", object)
+	cmd.Dir = g.storage.BaseDir
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+
+	return string(output), nil
+}
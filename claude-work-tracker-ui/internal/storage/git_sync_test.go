@@ -0,0 +1,550 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"claude-work-tracker-ui/internal/data"
+	"claude-work-tracker-ui/internal/models"
+)
+
+// newTestGitSync creates a sync repository in a temporary home, with a bare repository as
+// its remote
+func newTestGitSync(t *testing.T, autoPush bool) *GitSync {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	remote := filepath.Join(home, "remote.git")
+	if out, err := exec.Command("git", "init", "--bare", "-b", "main", remote).CombinedOutput(); err != nil {
+		t.Fatalf("git init --bare: %s: %v", out, err)
+	}
+
+	storage, err := NewExternalStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs, err := NewGitSync(storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gs.Init(remote, "main"); err != nil {
+		t.Fatal(err)
+	}
+	gs.config.AutoPush = autoPush
+	return gs
+}
+
+// writeSyncFile writes a file below the sync repository
+func writeSyncFile(t *testing.T, gs *GitSync, path, content string) {
+	t.Helper()
+	full := filepath.Join(gs.storage.BaseDir, path)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueueCommitCommitsInBackground(t *testing.T) {
+	gs := newTestGitSync(t, false)
+
+	for i := 0; i < 5; i++ {
+		writeSyncFile(t, gs, fmt.Sprintf("work/p/now/item-%d.md", i), "x")
+		gs.QueueCommit(fmt.Sprintf("Update item-%d", i))
+	}
+	gs.Flush()
+
+	status, err := gs.git("status", "--porcelain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != "" {
+		t.Errorf("changes left uncommitted after Flush:\n%s", status)
+	}
+
+	select {
+	case err := <-gs.Errors():
+		t.Errorf("unexpected auto-commit failure: %v", err)
+	default:
+	}
+}
+
+func TestQueueCommitIsNoOpWhenDisabled(t *testing.T) {
+	gs := newTestGitSync(t, false)
+	gs.config.AutoCommit = false
+
+	writeSyncFile(t, gs, "work/p/now/item.md", "x")
+	gs.QueueCommit("Update item")
+	gs.Flush()
+
+	status, _ := gs.git("status", "--porcelain")
+	if status == "" {
+		t.Error("change was committed with auto-commit off")
+	}
+}
+
+func TestQueuedCommitsAndSyncDontRace(t *testing.T) {
+	gs := newTestGitSync(t, true)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// Fatal can't be called off the test goroutine, so write directly
+		dir := filepath.Join(gs.storage.BaseDir, "work", "p", "now")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Error(err)
+			return
+		}
+		for i := 0; i < 20; i++ {
+			if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("item-%d.md", i)), []byte("x"), 0644); err != nil {
+				t.Error(err)
+				return
+			}
+			gs.QueueCommit(fmt.Sprintf("Update item-%d", i))
+		}
+	}()
+	for i := 0; i < 3; i++ {
+		if _, err := gs.Sync(); err != nil {
+			t.Errorf("sync %d: %v", i, err)
+		}
+	}
+	wg.Wait()
+	gs.Flush()
+
+	select {
+	case err := <-gs.Errors():
+		if strings.Contains(err.Error(), "index.lock") {
+			t.Errorf("auto-commit raced with sync: %v", err)
+		} else {
+			t.Errorf("unexpected auto-commit failure: %v", err)
+		}
+	default:
+	}
+
+	if status, _ := gs.git("status", "--porcelain"); status != "" {
+		t.Errorf("changes left uncommitted:\n%s", status)
+	}
+}
+
+// newTestPeer creates a second sync repository, as on another machine, sharing the remote
+// of gs
+func newTestPeer(t *testing.T, gs *GitSync) *GitSync {
+	t.Helper()
+	dir := t.TempDir()
+	peer, err := NewGitSync(&ExternalStorage{BaseDir: filepath.Join(dir, "work-data"), ConfigDir: filepath.Join(dir, "config")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range []string{peer.storage.BaseDir, peer.storage.ConfigDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := peer.Init(gs.config.Remote, gs.config.Branch); err != nil {
+		t.Fatal(err)
+	}
+	return peer
+}
+
+// deleted marks a file removed on one side in the resolution tests
+const deleted = "\x00deleted"
+
+// applyFiles writes or removes files in a sync repository and commits them
+func applyFiles(t *testing.T, gs *GitSync, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		if content == deleted {
+			if err := os.Remove(filepath.Join(gs.storage.BaseDir, path)); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		writeSyncFile(t, gs, path, content)
+	}
+	if _, err := gs.CommitAll("test changes"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// updateLog renders updates as an update log
+func updateLog(t *testing.T, updates ...*models.Update) string {
+	t.Helper()
+	content, err := data.MarshalUpdateLog(updates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+// registry renders a project registry
+func registry(t *testing.T, projects ...*Project) string {
+	t.Helper()
+	reg := ProjectRegistry{Projects: make(map[string]*Project)}
+	for _, p := range projects {
+		reg.Projects[p.ID] = p
+	}
+	content, err := json.MarshalIndent(&reg, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+// divergeAndPull diverges gs from its remote and pulls the remote change into gs
+func divergeAndPull(t *testing.T, gs *GitSync, path, base, ours, theirs string) *SyncResult {
+	t.Helper()
+	diverge(t, gs, path, base, ours, theirs)
+	result, err := gs.Pull()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// diverge commits base to gs and a peer, then pushes theirs from the peer and commits ours
+// on gs
+func diverge(t *testing.T, gs *GitSync, path, base, ours, theirs string) {
+	t.Helper()
+	peer := newTestPeer(t, gs)
+
+	applyFiles(t, gs, map[string]string{path: base})
+	if err := gs.Push(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := peer.Pull(); err != nil {
+		t.Fatal(err)
+	}
+	applyFiles(t, peer, map[string]string{path: theirs})
+	if err := peer.Push(); err != nil {
+		t.Fatal(err)
+	}
+
+	applyFiles(t, gs, map[string]string{path: ours})
+}
+
+func TestPullResolvesConflictsByFileType(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 10, 0, 0, 0, time.UTC) }
+	first := &models.Update{ID: "update-1", WorkID: "work-1", Timestamp: day(1), Title: "First"}
+	mine := &models.Update{ID: "update-2", WorkID: "work-1", Timestamp: day(2), Title: "Mine"}
+	theirs := &models.Update{ID: "update-3", WorkID: "work-1", Timestamp: day(3), Title: "Theirs"}
+
+	tests := []struct {
+		name      string
+		path      string
+		base      string
+		ours      string
+		theirs    string
+		want      string
+		json      bool // Compare as JSON, git may merge the lines itself
+		conflicts []string
+	}{
+		{
+			name:   "markdown merges frontmatter fields",
+			path:   "work/p/now/work-1.md",
+			base:   "---\nid: work-1\ntitle: Old\nstatus: active\n---\nBody\n",
+			ours:   "---\nid: work-1\ntitle: New\nstatus: active\n---\nBody\n",
+			theirs: "---\nid: work-1\ntitle: Old\nstatus: completed\n---\nBody\n",
+			want:   "---\nid: work-1\ntitle: New\nstatus: completed\n---\nBody\n",
+		},
+		{
+			name:      "markdown body edited on both sides keeps ours",
+			path:      "work/p/now/work-1.md",
+			base:      "---\nid: work-1\n---\nBase\n",
+			ours:      "---\nid: work-1\n---\nOurs\n",
+			theirs:    "---\nid: work-1\n---\nTheirs\n",
+			want:      "---\nid: work-1\n---\nOurs\n",
+			conflicts: []string{"content"},
+		},
+		{
+			name:   "update log keeps new updates from both sides",
+			path:   "work/p/updates/work-1.jsonl",
+			base:   updateLog(t, first),
+			ours:   updateLog(t, first, mine),
+			theirs: updateLog(t, first, theirs),
+			want:   updateLog(t, first, mine, theirs),
+		},
+		{
+			name:   "project registry unions projects",
+			path:   "projects/project-index.json",
+			base:   registry(t, &Project{ID: "a", LastAccess: day(1)}),
+			ours:   registry(t, &Project{ID: "a", LastAccess: day(1)}, &Project{ID: "b", LastAccess: day(2)}),
+			theirs: registry(t, &Project{ID: "a", LastAccess: day(3)}, &Project{ID: "c", LastAccess: day(2)}),
+			want: registry(t, &Project{ID: "a", LastAccess: day(3)}, &Project{ID: "b", LastAccess: day(2)},
+				&Project{ID: "c", LastAccess: day(2)}),
+			json: true,
+		},
+		{
+			name:      "other files keep ours",
+			path:      "notes.txt",
+			base:      "base\n",
+			ours:      "ours\n",
+			theirs:    "theirs\n",
+			want:      "ours\n",
+			conflicts: []string{"file"},
+		},
+		{
+			name:      "file deleted remotely and edited locally is kept",
+			path:      "work/p/now/work-1.md",
+			base:      "---\nid: work-1\n---\nBase\n",
+			ours:      "---\nid: work-1\n---\nOurs\n",
+			theirs:    deleted,
+			want:      "---\nid: work-1\n---\nOurs\n",
+			conflicts: []string{"file"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := newTestGitSync(t, false)
+			result := divergeAndPull(t, gs, tt.path, tt.base, tt.ours, tt.theirs)
+
+			got, err := os.ReadFile(filepath.Join(gs.storage.BaseDir, tt.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.json {
+				var gotValue, wantValue interface{}
+				if err := json.Unmarshal(got, &gotValue); err != nil {
+					t.Fatalf("merged file isn't JSON: %v\n%s", err, got)
+				}
+				json.Unmarshal([]byte(tt.want), &wantValue)
+				if !reflect.DeepEqual(gotValue, wantValue) {
+					t.Errorf("merged:\n%s\nwant:\n%s", got, tt.want)
+				}
+			} else if string(got) != tt.want {
+				t.Errorf("merged:\n%s\nwant:\n%s", got, tt.want)
+			}
+
+			var fields []string
+			for _, c := range result.Conflicts {
+				fields = append(fields, c.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.conflicts) {
+				t.Errorf("conflicts = %v, want %v", fields, tt.conflicts)
+			}
+
+			if status, _ := gs.git("status", "--porcelain"); status != "" {
+				t.Errorf("merge left changes behind:\n%s", status)
+			}
+		})
+	}
+}
+
+func TestResolveConflictTakingRemoteFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		ours   string
+		theirs string
+		want   string // Empty when the file should be gone
+	}{
+		{
+			name:   "remote edit replaces the file",
+			path:   "notes.txt",
+			ours:   "ours\n",
+			theirs: "theirs\n",
+			want:   "theirs\n",
+		},
+		{
+			name:   "remote deletion removes the file",
+			path:   "work/p/now/work-1.md",
+			ours:   "---\nid: work-1\n---\nOurs\n",
+			theirs: deleted,
+		},
+		{
+			name:   "remote edit of a locally deleted file is kept",
+			path:   "work/p/now/work-1.md",
+			ours:   deleted,
+			theirs: "---\nid: work-1\n---\nTheirs\n",
+			want:   "---\nid: work-1\n---\nTheirs\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := newTestGitSync(t, false)
+			result := divergeAndPull(t, gs, tt.path, "base\n", tt.ours, tt.theirs)
+			if len(result.Conflicts) != 1 || result.Conflicts[0].Field != "file" {
+				t.Fatalf("conflicts = %+v, want one file conflict", result.Conflicts)
+			}
+
+			if err := gs.ResolveConflict(0, true); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := os.ReadFile(filepath.Join(gs.storage.BaseDir, tt.path))
+			switch {
+			case tt.want == "" && !os.IsNotExist(err):
+				t.Errorf("file left behind: %q, %v", got, err)
+			case tt.want != "" && string(got) != tt.want:
+				t.Errorf("file = %q, %v, want %q", got, err, tt.want)
+			}
+			if status, _ := gs.git("status", "--porcelain"); status != "" {
+				t.Errorf("resolution left changes uncommitted:\n%s", status)
+			}
+			if conflicts, _ := gs.LoadConflicts(); len(conflicts) != 0 {
+				t.Errorf("conflict still open: %+v", conflicts)
+			}
+		})
+	}
+}
+
+func TestPullAbortsMergeWhenCommitFails(t *testing.T) {
+	gs := newTestGitSync(t, false)
+	diverge(t, gs, "notes.txt", "base\n", "ours\n", "theirs\n")
+
+	// A failing pre-commit hook stops the merge commit after the conflict is resolved
+	hook := filepath.Join(gs.storage.BaseDir, ".git", "hooks", "pre-commit")
+	writeSyncFile(t, gs, filepath.Join(".git", "hooks", "pre-commit"), "#!/bin/sh\nexit 1\n")
+	if err := os.Chmod(hook, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := gs.Pull(); err == nil || !strings.Contains(err.Error(), "failed to commit merge") {
+		t.Fatalf("Pull() = %v, want the merge commit to fail", err)
+	}
+	if _, err := gs.git("rev-parse", "-q", "--verify", "MERGE_HEAD"); err == nil {
+		t.Error("repository was left mid-merge")
+	}
+	if status, _ := gs.git("status", "--porcelain"); status != "" {
+		t.Errorf("failed merge left changes behind:\n%s", status)
+	}
+
+	// Later commits and pulls work again
+	if err := os.Remove(hook); err != nil {
+		t.Fatal(err)
+	}
+	writeSyncFile(t, gs, "other.txt", "x")
+	if _, err := gs.CommitAll("after the failed merge"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gs.Pull(); err != nil {
+		t.Errorf("pull after the failed merge: %v", err)
+	}
+}
+
+func TestPullRendersUpdatesDocumentFromMergedLog(t *testing.T) {
+	gs := newTestGitSync(t, false)
+	peer := newTestPeer(t, gs)
+
+	workDir := func(g *GitSync) string { return filepath.Join(g.storage.BaseDir, "work", "p") }
+	create := func(g *GitSync, title string) {
+		t.Helper()
+		if err := data.NewUpdatesManager(workDir(g)).CreateUpdate("work-1", &models.Update{Title: title}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := g.CommitAll("add update"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	create(gs, "First")
+	if err := gs.Push(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := peer.Pull(); err != nil {
+		t.Fatal(err)
+	}
+	create(peer, "Theirs")
+	if err := peer.Push(); err != nil {
+		t.Fatal(err)
+	}
+	create(gs, "Ours")
+
+	result, err := gs.Pull()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Conflicts) != 0 {
+		t.Errorf("conflicts = %v, want none", result.Conflicts)
+	}
+
+	updates, err := data.NewUpdatesManager(workDir(gs)).GetUpdates("work-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 3 {
+		t.Fatalf("got %d updates, want 3", len(updates))
+	}
+	document, err := os.ReadFile(filepath.Join(workDir(gs), "updates", "work-1.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, title := range []string{"First", "Ours", "Theirs"} {
+		if !strings.Contains(string(document), title) {
+			t.Errorf("updates document is missing %q:\n%s", title, document)
+		}
+	}
+}
+
+func TestMergeRegistry(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 10, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name   string
+		ours   []*Project
+		theirs []*Project
+		want   map[string]string // Project ID to path
+	}{
+		{
+			name:   "projects from both sides",
+			ours:   []*Project{{ID: "a", Path: "/a"}},
+			theirs: []*Project{{ID: "b", Path: "/b"}},
+			want:   map[string]string{"a": "/a", "b": "/b"},
+		},
+		{
+			name:   "most recently accessed entry wins",
+			ours:   []*Project{{ID: "a", Path: "/ours", LastAccess: day(2)}},
+			theirs: []*Project{{ID: "a", Path: "/theirs", LastAccess: day(3)}},
+			want:   map[string]string{"a": "/theirs"},
+		},
+		{
+			name:   "local entry wins a tie",
+			ours:   []*Project{{ID: "a", Path: "/ours", LastAccess: day(2)}},
+			theirs: []*Project{{ID: "a", Path: "/theirs", LastAccess: day(2)}},
+			want:   map[string]string{"a": "/ours"},
+		},
+		{
+			name:   "empty local registry",
+			theirs: []*Project{{ID: "a", Path: "/a"}},
+			want:   map[string]string{"a": "/a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := mergeRegistry([]byte(registry(t, tt.ours...)), []byte(registry(t, tt.theirs...)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var reg ProjectRegistry
+			if err := json.Unmarshal(merged, &reg); err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]string)
+			for id, p := range reg.Projects {
+				got[id] = p.Path
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("projects = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := mergeRegistry([]byte("{"), []byte("{}")); err == nil {
+		t.Error("expected an error for a broken local registry")
+	}
+}
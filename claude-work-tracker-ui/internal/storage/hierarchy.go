@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"claude-work-tracker-ui/internal/data"
+	"claude-work-tracker-ui/internal/hooks"
+	"claude-work-tracker-ui/internal/models"
+)
+
+// projectTree builds the hierarchy of a project's work, with work standing in for the
+// stored copy of the item being saved
+func (c *CentralizedClient) projectTree(projectID string, work *models.Work) (*models.WorkTree, error) {
+	works, err := c.GetProjectWork(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load work: %w", err)
+	}
+	found := false
+	for i, existing := range works {
+		if existing.ID == work.ID {
+			works[i] = work
+			found = true
+		}
+	}
+	if !found {
+		works = append(works, work)
+	}
+	return models.NewWorkTree(works), nil
+}
+
+// checkHierarchy refuses parent and child links that would form a cycle, and completing a
+// work item while work below it is still open
+func checkHierarchy(tree *models.WorkTree, stored, work *models.Work) error {
+	if stored == nil || stored.ParentID != work.ParentID {
+		if err := tree.CheckParent(work.ID, work.ParentID); err != nil {
+			return err
+		}
+	}
+	for _, childID := range work.ChildIDs {
+		if stored != nil && containsID(stored.ChildIDs, childID) {
+			continue
+		}
+		if err := tree.CheckParent(childID, work.ID); err != nil {
+			return err
+		}
+	}
+
+	if !work.IsCompleted() || (stored != nil && stored.IsCompleted()) {
+		return nil
+	}
+	open := tree.OpenDescendants(work.ID)
+	if len(open) == 0 {
+		return nil
+	}
+	var ids []string
+	for _, child := range open {
+		ids = append(ids, child.ID)
+	}
+	return fmt.Errorf("can't complete %s while work below it is open: %s", work.ID, strings.Join(ids, ", "))
+}
+
+// SetWorkParent makes parentID the parent of a work item of the current project, or makes
+// it a top-level item when parentID is empty
+func (c *CentralizedClient) SetWorkParent(workID, parentID string) (*models.Work, error) {
+	work, err := c.GetProjectWorkByID(c.project.ID, workID)
+	if err != nil {
+		return nil, err
+	}
+	if parentID != "" {
+		if _, err := c.GetProjectWorkByID(c.project.ID, parentID); err != nil {
+			return nil, err
+		}
+	}
+	if work.ParentID == parentID {
+		return work, nil
+	}
+
+	work.ParentID = parentID
+	if err := c.UpdateWork(work); err != nil {
+		return nil, err
+	}
+	return work, nil
+}
+
+// rollUpParents keeps the parents of a saved work item in step with it: the new parent
+// lists it among its children and the old one no longer does, and both get their
+// progress, effort and status rolled up again. Saving a parent rolls up further.
+func (c *CentralizedClient) rollUpParents(projectID string, work, stored *models.Work) {
+	parentIDs := []string{work.ParentID}
+	if stored != nil && stored.ParentID != work.ParentID {
+		parentIDs = append(parentIDs, stored.ParentID)
+	}
+
+	for _, parentID := range parentIDs {
+		if parentID == "" {
+			continue
+		}
+		tree, err := c.projectTree(projectID, work)
+		if err != nil {
+			c.recordFailure("roll-up", hooks.ProgressUpdated, work, fmt.Errorf("failed to roll up %s: %w", parentID, err))
+			return
+		}
+		parent := tree.Work(parentID)
+		if parent == nil {
+			continue
+		}
+
+		childIDs := syncChildIDs(parent.ChildIDs, work.ID, parentID == work.ParentID)
+		preview := *parent
+		preview.Transitions = nil
+		if len(childIDs) == len(parent.ChildIDs) && !data.SyncWorkRollup(&preview, tree).Changed() {
+			continue
+		}
+
+		parent.ChildIDs = childIDs
+		if err := c.UpdateProjectWork(projectID, parent); err != nil {
+			c.recordFailure("roll-up", hooks.ProgressUpdated, parent, fmt.Errorf("failed to roll up %s: %w", parentID, err))
+		}
+	}
+}
+
+// containsID reports whether ids holds id
+func containsID(ids []string, id string) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
+
+// syncChildIDs adds or removes a child in a parent's list of children
+func syncChildIDs(childIDs []string, childID string, linked bool) []string {
+	var result []string
+	for _, id := range childIDs {
+		if id != childID {
+			result = append(result, id)
+		}
+	}
+	if linked {
+		result = append(result, childID)
+		if len(result) == len(childIDs) {
+			// Already listed, keep the original order
+			return childIDs
+		}
+	}
+	return result
+}
@@ -0,0 +1,192 @@
+package storage
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"claude-work-tracker-ui/internal/hooks"
+	"claude-work-tracker-ui/internal/models"
+)
+
+// createTestHierarchy creates epic > story > task, with the task in progress, and another
+// top-level item
+func createTestHierarchy(t *testing.T, c *CentralizedClient) {
+	t.Helper()
+	createTestWork(t, c, &models.Work{ID: "epic", Title: "Epic"})
+	createTestWork(t, c, &models.Work{ID: "story", Title: "Story", ParentID: "epic"})
+	createTestWork(t, c, &models.Work{ID: "task", Title: "Task", ParentID: "story",
+		Metadata: models.WorkMetadata{Status: models.WorkStatusInProgress, ProgressPercent: 40}})
+	createTestWork(t, c, &models.Work{ID: "other", Title: "Other"})
+}
+
+// getTestWork reads a work item of the client's project
+func getTestWork(t *testing.T, c *CentralizedClient, id string) *models.Work {
+	t.Helper()
+	work, err := c.GetProjectWorkByID(c.project.ID, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return work
+}
+
+func TestHierarchyRefusesChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(c *CentralizedClient) error
+		want   string
+	}{
+		{
+			name: "completing a parent with open children",
+			change: func(c *CentralizedClient) error {
+				epic := getTestWork(t, c, "epic")
+				epic.Metadata.Status = models.WorkStatusCompleted
+				return c.UpdateWork(epic)
+			},
+			want: "can't complete epic while work below it is open: story, task",
+		},
+		{
+			name: "moving a parent below its own child",
+			change: func(c *CentralizedClient) error {
+				_, err := c.SetWorkParent("epic", "task")
+				return err
+			},
+			want: "task is already below epic",
+		},
+		{
+			name: "listing an ancestor as a child",
+			change: func(c *CentralizedClient) error {
+				task := getTestWork(t, c, "task")
+				task.ChildIDs = append(task.ChildIDs, "epic")
+				return c.UpdateWork(task)
+			},
+			want: "task is already below epic",
+		},
+		{
+			name: "own parent",
+			change: func(c *CentralizedClient) error {
+				_, err := c.SetWorkParent("story", "story")
+				return err
+			},
+			want: "story can't be its own parent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t)
+			createTestHierarchy(t, c)
+
+			err := tt.change(c)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want %q", err, tt.want)
+			}
+			if epic := getTestWork(t, c, "epic"); epic.IsCompleted() || epic.ParentID != "" {
+				t.Errorf("refused change was saved: %+v", epic.Metadata)
+			}
+		})
+	}
+}
+
+func TestHierarchyRollsUpParents(t *testing.T) {
+	c := newTestClient(t)
+	createTestHierarchy(t, c)
+
+	story := getTestWork(t, c, "story")
+	epic := getTestWork(t, c, "epic")
+	if story.Metadata.ProgressPercent != 40 || epic.Metadata.ProgressPercent != 40 {
+		t.Errorf("progress story %d%%, epic %d%%, want 40%% rolled up", story.Metadata.ProgressPercent, epic.Metadata.ProgressPercent)
+	}
+	if story.Metadata.Status != models.WorkStatusInProgress || epic.Metadata.Status != models.WorkStatusInProgress {
+		t.Errorf("status story %s, epic %s, want both in progress", story.Metadata.Status, epic.Metadata.Status)
+	}
+	if !reflect.DeepEqual(epic.ChildIDs, []string{"story"}) || !reflect.DeepEqual(story.ChildIDs, []string{"task"}) {
+		t.Errorf("children epic %v, story %v", epic.ChildIDs, story.ChildIDs)
+	}
+
+	// Completing the last open child lets the parents be completed
+	task := getTestWork(t, c, "task")
+	task.Metadata.Status = models.WorkStatusCompleted
+	if err := c.UpdateWork(task); err != nil {
+		t.Fatal(err)
+	}
+	if story = getTestWork(t, c, "story"); story.Metadata.ProgressPercent != 100 {
+		t.Errorf("story progress = %d%%, want 100%%", story.Metadata.ProgressPercent)
+	}
+	story.Metadata.Status = models.WorkStatusCompleted
+	if err := c.UpdateWork(story); err != nil {
+		t.Fatal(err)
+	}
+	epic = getTestWork(t, c, "epic")
+	epic.Metadata.Status = models.WorkStatusCompleted
+	if err := c.UpdateWork(epic); err != nil {
+		t.Errorf("completing epic once everything below is done: %v", err)
+	}
+}
+
+func TestReparentingAParentWithOpenChildren(t *testing.T) {
+	c := newTestClient(t)
+	createTestHierarchy(t, c)
+
+	if _, err := c.SetWorkParent("story", "other"); err != nil {
+		t.Fatal(err)
+	}
+
+	epic := getTestWork(t, c, "epic")
+	other := getTestWork(t, c, "other")
+	if len(epic.ChildIDs) != 0 {
+		t.Errorf("old parent still lists %v", epic.ChildIDs)
+	}
+	if !reflect.DeepEqual(other.ChildIDs, []string{"story"}) {
+		t.Errorf("new parent lists %v, want [story]", other.ChildIDs)
+	}
+	if other.Metadata.ProgressPercent != 40 || other.Metadata.Status != models.WorkStatusInProgress {
+		t.Errorf("new parent at %d%% %s, want the open subtree rolled up", other.Metadata.ProgressPercent, other.Metadata.Status)
+	}
+	if task := getTestWork(t, c, "task"); task.ParentID != "story" {
+		t.Errorf("task moved out of story: parent %q", task.ParentID)
+	}
+
+	// The open work came along, so the new parent can't be completed yet
+	other.Metadata.Status = models.WorkStatusCompleted
+	if err := c.UpdateWork(other); err == nil {
+		t.Error("completed a parent whose moved subtree is still open")
+	}
+
+	// The old parent has nothing open below it any more
+	epic.Metadata.Status = models.WorkStatusCompleted
+	if err := c.UpdateWork(epic); err != nil {
+		t.Errorf("completing the old parent: %v", err)
+	}
+}
+
+func TestFailedRollUpIsAudited(t *testing.T) {
+	c := newTestClient(t)
+	createTestWork(t, c, &models.Work{ID: "epic", Title: "Epic"})
+	task := createTestWork(t, c, &models.Work{ID: "task", Title: "Task", ParentID: "epic"})
+
+	// Refuse the status change the roll-up makes to the parent
+	c.hookSystem.RegisterBefore(hooks.BeforeStatusChange, "keep-epic", func(ctx context.Context, hookCtx *hooks.HookContext) hooks.BeforeResult {
+		if hookCtx.WorkItem.ID == "epic" {
+			return hooks.Deny("epic stays as it is")
+		}
+		return hooks.Allow()
+	})
+
+	task.Metadata.Status = models.WorkStatusInProgress
+	if err := c.UpdateWork(task); err != nil {
+		t.Fatalf("the save itself should succeed: %v", err)
+	}
+
+	entries, err := c.hookSystem.GetAuditLog().Recent(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.Hook == "roll-up" && entry.WorkID == "epic" && !entry.Success {
+			return
+		}
+	}
+	t.Errorf("failed roll-up wasn't audited: %+v", entries)
+}
@@ -0,0 +1,268 @@
+package storage
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"claude-work-tracker-ui/internal/data"
+	"claude-work-tracker-ui/internal/hooks"
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/parser"
+)
+
+// splitChecklist cuts part of a checklist out of content, leaving a link behind to the work
+// item idFor names after the part's title
+type splitChecklist func(p *parser.TaskParser, content string, idFor func(title string) string) (string, *parser.PromotedBlock, error)
+
+// PromoteTask spins a task, with its subtasks, out of a work item or plan artifact of the
+// current project into a new work item. The task stays in place as a link to the new item.
+func (c *CentralizedClient) PromoteTask(sourceID, taskRef string) (*models.Work, error) {
+	return c.promote(sourceID, func(p *parser.TaskParser, content string, idFor func(string) string) (string, *parser.PromotedBlock, error) {
+		task := p.FindTask(content, taskRef)
+		if task == nil {
+			return content, nil, fmt.Errorf("task not found: %s", taskRef)
+		}
+		return p.PromoteTaskInMarkdown(content, task.Task.ID, idFor(task.Task.Title))
+	})
+}
+
+// PromotePhase spins everything under a phase heading of a work item or plan artifact of the
+// current project into a new work item, leaving a link under the heading
+func (c *CentralizedClient) PromotePhase(sourceID, phase string) (*models.Work, error) {
+	return c.promote(sourceID, func(p *parser.TaskParser, content string, idFor func(string) string) (string, *parser.PromotedBlock, error) {
+		return p.PromotePhaseInMarkdown(content, phase, idFor(phase))
+	})
+}
+
+// promote creates the new work item for a split-off part of a checklist and records the
+// parent/child relationship on both sides
+func (c *CentralizedClient) promote(sourceID string, split splitChecklist) (*models.Work, error) {
+	var parent *models.Work
+	var artifact *models.Artifact
+	works, err := c.GetAllWork()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load work: %w", err)
+	}
+	for _, work := range works {
+		if work.ID == sourceID {
+			parent = work
+		}
+	}
+	if parent == nil {
+		artifacts, err := c.markdownIO.ListAllArtifacts()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load artifacts: %w", err)
+		}
+		for _, a := range artifacts {
+			if a.ID == sourceID {
+				artifact = a
+			}
+		}
+		if artifact == nil {
+			return nil, fmt.Errorf("work item or artifact not found: %s", sourceID)
+		}
+		// A plan's checklist rolls up into the work the plan supports
+		for _, work := range works {
+			if len(artifact.WorkRefs) > 0 && work.ID == artifact.WorkRefs[0] {
+				parent = work
+			}
+		}
+	}
+
+	content := ""
+	if artifact != nil {
+		content = artifact.Content
+	} else {
+		content = parent.Content
+	}
+
+	now := time.Now()
+	childID := ""
+	content, block, err := split(parser.NewTaskParser(), content, func(title string) string {
+		childID = fmt.Sprintf("work-%s-%d", slugify(title), now.UnixNano())
+		return childID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	child := newPromotedWork(childID, block, now)
+	child.PromotedFrom = sourceID
+	if artifact != nil {
+		child.GitContext = artifact.GitContext
+		child.TechnicalTags = mergeTags(artifact.TechnicalTags, block.Task.Tags)
+		child.ArtifactRefs = []string{artifact.ID}
+		child.Metadata.ArtifactCount = 1
+	}
+	if parent != nil {
+		child.ParentID = parent.ID
+		child.Schedule = parent.Schedule
+		child.GitContext = parent.GitContext
+		child.TechnicalTags = mergeTags(parent.TechnicalTags, child.TechnicalTags)
+		child.Metadata.Priority = parent.Metadata.Priority
+	}
+	if child.Schedule == "" || child.Schedule == models.ScheduleClosed {
+		child.Schedule = models.ScheduleNext
+	}
+
+	if err := c.CreateWork(child); err != nil {
+		return nil, fmt.Errorf("failed to create work: %w", err)
+	}
+
+	if artifact != nil {
+		artifact.Content = content
+		artifact.WorkRefs = append(artifact.WorkRefs, child.ID)
+		artifact.UpdatedAt = now
+		if err := c.markdownIO.WriteArtifact(artifact); err != nil {
+			return child, fmt.Errorf("failed to save %s: %w", artifact.ID, err)
+		}
+		c.commitChange(fmt.Sprintf("Promote %s from %s", child.ID, artifact.ID))
+	}
+	if parent != nil {
+		if artifact == nil {
+			parent.Content = content
+		}
+		parent.ChildIDs = syncChildIDs(parent.ChildIDs, child.ID, true)
+		parent.UpdatedAt = now
+		if err := c.UpdateWork(parent); err != nil {
+			return child, fmt.Errorf("failed to save %s: %w", parent.ID, err)
+		}
+
+		update := &models.Update{
+			ID:         models.NewUpdateID(),
+			WorkID:     parent.ID,
+			Timestamp:  now,
+			Title:      "Promoted to work item",
+			Summary:    fmt.Sprintf("%s is now tracked as %s", block.Title, child.ID),
+			Author:     models.CurrentActor(),
+			UpdateType: "automatic",
+		}
+		if err := c.CreateUpdate(parent.ID, update); err != nil {
+			return child, err
+		}
+	}
+	return child, nil
+}
+
+// newPromotedWork builds the work item for a promoted task or phase
+func newPromotedWork(id string, block *parser.PromotedBlock, now time.Time) *models.Work {
+	work := &models.Work{
+		ID:            id,
+		Title:         block.Title,
+		Description:   block.Notes,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		SessionNumber: fmt.Sprintf("session-%d", now.Unix()),
+		TechnicalTags: block.Task.Tags,
+		DueAt:         block.Task.DueAt,
+		Content:       block.Content,
+		Metadata: models.WorkMetadata{
+			Status:            models.WorkStatusActive,
+			EstimatedEffort:   models.WorkEffortMedium,
+			ProgressFromTasks: block.Content != "",
+		},
+	}
+	if work.Description == "" {
+		work.Description = block.Title
+	}
+
+	switch block.Task.Status {
+	case models.TaskStatusInProgress:
+		work.Metadata.Status = models.WorkStatusInProgress
+		work.StartedAt = &now
+	case models.TaskStatusBlocked:
+		work.Metadata.Status = models.WorkStatusBlocked
+	case models.TaskStatusCompleted:
+		work.Metadata.Status = models.WorkStatusCompleted
+		work.CompletedAt = &now
+	}
+	return work
+}
+
+// linkStatus is the status the task linking to a promoted work item should show
+func linkStatus(work *models.Work) models.TaskStatus {
+	switch work.Metadata.Status {
+	case models.WorkStatusCompleted:
+		return models.TaskStatusCompleted
+	case models.WorkStatusCanceled, models.WorkStatusArchived:
+		return models.TaskStatusCancelled
+	case models.WorkStatusBlocked:
+		return models.TaskStatusBlocked
+	case models.WorkStatusInProgress:
+		return models.TaskStatusInProgress
+	}
+	if work.Metadata.ProgressPercent > 0 {
+		return models.TaskStatusInProgress
+	}
+	return models.TaskStatusTodo
+}
+
+// rollUpPromoted keeps the task linking to a promoted work item in step with it, so the
+// item's completion counts toward the progress of the work or plan it came from
+func (c *CentralizedClient) rollUpPromoted(projectID string, work *models.Work) {
+	status := linkStatus(work)
+	p := parser.NewTaskParser()
+
+	if parent, err := c.GetProjectWorkByID(projectID, work.PromotedFrom); err == nil {
+		task := p.LinkedTask(parent.Content, work.ID)
+		if task == nil || task.Task.Status == status {
+			return
+		}
+		if _, err := c.SetTaskStatus(projectID, parent.ID, task.Task.ID, status); err != nil {
+			c.recordFailure("promotion-roll-up", hooks.ProgressUpdated, parent, fmt.Errorf("failed to update %s: %w", parent.ID, err))
+		}
+		return
+	}
+
+	markdownIO := data.NewMarkdownIO(c.storage.GetProjectWorkDir(projectID))
+	artifacts, err := markdownIO.ListAllArtifacts()
+	if err != nil {
+		c.recordFailure("promotion-roll-up", hooks.ProgressUpdated, work, fmt.Errorf("failed to load artifacts: %w", err))
+		return
+	}
+	for _, artifact := range artifacts {
+		if artifact.ID != work.PromotedFrom {
+			continue
+		}
+		task := p.LinkedTask(artifact.Content, work.ID)
+		if task == nil || task.Task.Status == status {
+			return
+		}
+		artifact.Content = p.UpdateTaskInMarkdown(artifact.Content, task.Task.ID, status)
+		artifact.UpdatedAt = time.Now()
+		if err := markdownIO.WriteArtifact(artifact); err != nil {
+			c.recordFailure("promotion-roll-up", hooks.ProgressUpdated, work, fmt.Errorf("failed to update %s: %w", artifact.ID, err))
+			return
+		}
+		c.commitChange(fmt.Sprintf("Update %s: %s", artifact.ID, task.Task.Title))
+		return
+	}
+}
+
+// mergeTags appends the tags of extra that base doesn't have yet
+func mergeTags(base, extra []string) []string {
+	merged := append([]string(nil), base...)
+	for _, tag := range extra {
+		found := false
+		for _, existing := range merged {
+			found = found || strings.EqualFold(existing, tag)
+		}
+		if !found {
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
+
+var slugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugify turns the first words of a title into an ID fragment
+func slugify(title string) string {
+	words := strings.Fields(strings.ToLower(title))
+	if len(words) > 4 {
+		words = words[:4]
+	}
+	return strings.Trim(slugPattern.ReplaceAllString(strings.Join(words, "-"), "-"), "-")
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/parser"
+)
+
+func TestPromotedWorkRollsUpIntoItsTask(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		progress int
+		want     models.TaskStatus
+	}{
+		{"started", models.WorkStatusInProgress, 0, models.TaskStatusInProgress},
+		{"progress alone", models.WorkStatusActive, 30, models.TaskStatusInProgress},
+		{"blocked", models.WorkStatusBlocked, 0, models.TaskStatusBlocked},
+		{"completed", models.WorkStatusCompleted, 100, models.TaskStatusCompleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t)
+			createTestWork(t, c, &models.Work{ID: "work-1", Title: "Source", Content: "- [ ] Write docs\n- [ ] Ship\n"})
+			child, err := c.PromoteTask("work-1", "Write docs")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			child = getTestWork(t, c, child.ID)
+			child.Metadata.Status = tt.status
+			child.Metadata.ProgressPercent = tt.progress
+			if err := c.UpdateWork(child); err != nil {
+				t.Fatal(err)
+			}
+
+			source := getTestWork(t, c, "work-1")
+			task := parser.NewTaskParser().LinkedTask(source.Content, child.ID)
+			if task == nil {
+				t.Fatalf("no task links to %s:\n%s", child.ID, source.Content)
+			}
+			if task.Task.Status != tt.want {
+				t.Errorf("linked task is %s, want %s", task.Task.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestFailedPromotionRollUpIsAudited(t *testing.T) {
+	c := newTestClient(t)
+	createTestWork(t, c, &models.Work{ID: "work-1", Title: "Source", Content: "- [ ] Write docs\n- [ ] Ship\n"})
+	child, err := c.PromoteTask("work-1", "Write docs")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A directory where the source's update log should be makes the checklist edit fail
+	logPath := filepath.Join(c.GetWorkDir(), "updates", "work-1.jsonl")
+	if err := os.Remove(logPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(logPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	child = getTestWork(t, c, child.ID)
+	child.Metadata.Status = models.WorkStatusCompleted
+	if err := c.UpdateWork(child); err != nil {
+		t.Fatalf("the save itself should succeed: %v", err)
+	}
+
+	entries, err := c.hookSystem.GetAuditLog().Recent(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.Hook == "promotion-roll-up" && entry.WorkID == "work-1" && !entry.Success {
+			return
+		}
+	}
+	t.Errorf("failed promotion roll-up wasn't audited: %+v", entries)
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"claude-work-tracker-ui/internal/data"
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/parser"
+)
+
+// TaskEdit changes a work item's checklist and describes the change as an update. A nil
+// update means nothing changed.
+type TaskEdit func(work *models.Work, p *parser.TaskParser) (*models.Update, error)
+
+// GetAllTasks returns the checklist tasks of every work item across all projects, ordered by
+// project name. Projects whose work can't be read are skipped.
+func (c *CentralizedClient) GetAllTasks() ([]data.TaskEntry, error) {
+	projects := c.registry.ListProjects()
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+
+	var entries []data.TaskEntry
+	for _, project := range projects {
+		works, err := c.GetProjectWork(project.ID)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, data.CollectTasks(project.ID, project.Name, works)...)
+	}
+	return entries, nil
+}
+
+// GetProjectWorkByID finds a work item in any registered project
+func (c *CentralizedClient) GetProjectWorkByID(projectID, workID string) (*models.Work, error) {
+	works, err := c.GetProjectWork(projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, work := range works {
+		if work.ID == workID {
+			return work, nil
+		}
+	}
+	return nil, fmt.Errorf("work item not found: %s", workID)
+}
+
+// EditTasks applies a checklist edit to a work item in any project, saves it and records the
+// update the edit describes. Items that take their progress from the checklist already get
+// an update from the save when the edit moves their progress.
+func (c *CentralizedClient) EditTasks(projectID, workID string, edit TaskEdit) (*models.Work, error) {
+	work, err := c.GetProjectWorkByID(projectID, workID)
+	if err != nil {
+		return nil, err
+	}
+
+	progressBefore := work.Metadata.ProgressPercent
+	update, err := edit(work, parser.NewTaskParser())
+	if err != nil || update == nil {
+		return work, err
+	}
+
+	work.UpdatedAt = time.Now()
+	if err := c.UpdateProjectWork(projectID, work); err != nil {
+		return nil, err
+	}
+	if work.Metadata.ProgressFromTasks && work.Metadata.ProgressPercent != progressBefore {
+		return work, nil
+	}
+
+	now := time.Now()
+	update.ID = models.NewUpdateID()
+	update.WorkID = work.ID
+	update.Timestamp = now
+	update.Title = "Checklist edited"
+	update.Author = models.CurrentActor()
+	update.UpdateType = "automatic"
+	update.ProgressBefore = progressBefore
+	update.ProgressAfter = work.Metadata.ProgressPercent
+	if err := c.CreateProjectUpdate(projectID, work.ID, update); err != nil {
+		return work, err
+	}
+	return work, nil
+}
+
+// SetTaskStatus changes the status of a task in the checklist of a work item in any project.
+// The task may be given by ID or title.
+func (c *CentralizedClient) SetTaskStatus(projectID, workID, taskID string, status models.TaskStatus) (*models.Work, error) {
+	return c.EditTasks(projectID, workID, func(work *models.Work, p *parser.TaskParser) (*models.Update, error) {
+		task := p.FindTask(work.Content, taskID)
+		if task == nil {
+			return nil, fmt.Errorf("task not found: %s", taskID)
+		}
+		if task.Task.Status == status {
+			return nil, nil
+		}
+		work.Content = p.UpdateTaskInMarkdown(work.Content, task.Task.ID, status)
+
+		changed := models.Task{Status: status}
+		update := &models.Update{
+			Summary: fmt.Sprintf("%s: %s → %s", task.Task.Title, task.Task.GetDisplayStatus(), changed.GetDisplayStatus()),
+		}
+		if status == models.TaskStatusCompleted {
+			update.TasksCompleted = []string{task.Task.Title}
+		}
+		return update, nil
+	})
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"claude-work-tracker-ui/internal/data"
+	"claude-work-tracker-ui/internal/models"
+)
+
+// taskSummary lists tasks as "<project>/<work>/<title>:<status>"
+func taskSummary(entries []data.TaskEntry) string {
+	var summary []string
+	for _, entry := range entries {
+		summary = append(summary, entry.ProjectName+"/"+entry.Work.ID+"/"+entry.Task.Title+":"+string(entry.Task.Status))
+	}
+	return strings.Join(summary, " ")
+}
+
+func TestGetAllTasksFollowsWrites(t *testing.T) {
+	c := newTestClient(t)
+	createTestWork(t, c, &models.Work{ID: "work-1", Title: "Login", Content: "- [ ] Form\n- [ ] Redirect\n"})
+
+	// A second project, written to directly
+	other, err := c.registry.RegisterProject(filepath.Join(t.TempDir(), "another"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherWork := &models.Work{ID: "work-9", Title: "Docs", Schedule: models.ScheduleNow, Content: "- [x] Outline\n"}
+	otherWork.Metadata.Status = models.WorkStatusActive
+	if err := data.NewMarkdownIO(c.storage.GetProjectWorkDir(other.ID)).WriteWork(otherWork); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := c.GetAllTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "another/work-9/Outline:completed project/work-1/Form:todo project/work-1/Redirect:todo"
+	if got := taskSummary(entries); got != want {
+		t.Fatalf("tasks = %s, want %s", got, want)
+	}
+
+	// Edits in either project show up in the next read
+	if _, err := c.SetTaskStatus(c.project.ID, "work-1", "Redirect", models.TaskStatusCompleted); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.SetTaskStatus(other.ID, "work-9", "outline", models.TaskStatusTodo); err != nil {
+		t.Fatal(err)
+	}
+	entries, err = c.GetAllTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = "another/work-9/Outline:todo project/work-1/Form:todo project/work-1/Redirect:completed"
+	if got := taskSummary(entries); got != want {
+		t.Errorf("tasks after writing = %s, want %s", got, want)
+	}
+
+	updates, err := c.GetUpdates("work-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) == 0 || strings.Join(updates[0].TasksCompleted, ",") != "Redirect" {
+		t.Errorf("latest update = %+v, want one completing Redirect", updates)
+	}
+
+	if _, err := c.SetTaskStatus(c.project.ID, "work-1", "Deploy", models.TaskStatusCompleted); err == nil || !strings.Contains(err.Error(), "task not found") {
+		t.Errorf("ticking a missing task = %v, want task not found", err)
+	}
+}
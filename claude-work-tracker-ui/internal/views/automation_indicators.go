@@ -3,6 +3,7 @@ package views
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"claude-work-tracker-ui/internal/models"
@@ -21,6 +22,8 @@ type AutomationIndicators struct {
 	ActivityLow       string
 	GitLinked         string
 	GitDirty          string
+	DueSoon           string
+	Overdue           string
 	
 	// Styles for indicators
 	AutoStyle     lipgloss.Style
@@ -28,6 +31,7 @@ type AutomationIndicators struct {
 	WarningStyle  lipgloss.Style
 	FocusStyle    lipgloss.Style
 	GitStyle      lipgloss.Style
+	OverdueStyle  lipgloss.Style
 }
 
 // DefaultAutomationIndicators returns indicators with Unicode characters
@@ -44,6 +48,8 @@ func DefaultAutomationIndicators() *AutomationIndicators {
 		ActivityLow:       "▰□□",
 		GitLinked:         "⎇",  // Branch symbol
 		GitDirty:          "±",  // Plus-minus for uncommitted changes
+		DueSoon:           "◷",  // Clock face for approaching due date
+		Overdue:           "⧗",  // Hourglass for missed deadline
 		
 		// Styles
 		AutoStyle:     lipgloss.NewStyle().Foreground(lipgloss.Color("42")),  // Green
@@ -51,6 +57,7 @@ func DefaultAutomationIndicators() *AutomationIndicators {
 		WarningStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("208")), // Orange
 		FocusStyle:    lipgloss.NewStyle().Foreground(lipgloss.Color("170")), // Purple
 		GitStyle:      lipgloss.NewStyle().Foreground(lipgloss.Color("39")),  // Blue
+		OverdueStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("196")), // Red
 	}
 }
 
@@ -73,6 +80,14 @@ func (ai *AutomationIndicators) GetWorkItemIndicators(work *models.Work) string
 		indicators = append(indicators, ai.WarningStyle.Render(ai.BlockedTransition))
 	}
 	
+	// Deadline status
+	switch work.GetDueState(time.Now()) {
+	case models.DueStateOverdue, models.DueStateStartOverdue:
+		indicators = append(indicators, ai.OverdueStyle.Render(ai.Overdue))
+	case models.DueStateDueSoon:
+		indicators = append(indicators, ai.PendingStyle.Render(ai.DueSoon))
+	}
+	
 	// Check for focus mode - would need focus_session field in WorkMetadata
 	if false { // Disabled for now
 		indicators = append(indicators, ai.FocusStyle.Render(ai.FocusMode))
@@ -122,6 +137,16 @@ func (ai *AutomationIndicators) GetTransitionTooltip(work *models.Work) string {
 		tooltips = append(tooltips, "Auto transition")
 	}
 	
+	now := time.Now()
+	switch work.GetDueState(now) {
+	case models.DueStateOverdue:
+		tooltips = append(tooltips, "Overdue since "+work.DueAt.Format("Jan 2 15:04"))
+	case models.DueStateStartOverdue:
+		tooltips = append(tooltips, "Should have started by "+work.StartBy.Format("Jan 2 15:04"))
+	case models.DueStateDueSoon:
+		tooltips = append(tooltips, "Due "+work.DueAt.Format("Jan 2 15:04"))
+	}
+	
 	return strings.Join(tooltips, " | ")
 }
 
@@ -134,6 +159,8 @@ func (ai *AutomationIndicators) RenderIndicatorLegend() string {
 		fmt.Sprintf("%s Blocked", ai.WarningStyle.Render(ai.BlockedTransition)),
 		fmt.Sprintf("%s Focus mode", ai.FocusStyle.Render(ai.FocusMode)),
 		fmt.Sprintf("%s Inactive", ai.WarningStyle.Render(ai.InactivityWarning)),
+		fmt.Sprintf("%s Due soon", ai.PendingStyle.Render(ai.DueSoon)),
+		fmt.Sprintf("%s Overdue", ai.OverdueStyle.Render(ai.Overdue)),
 		fmt.Sprintf("%s Git linked", ai.GitStyle.Render(ai.GitLinked)),
 	}
 	return legendStyle.Render("Indicators: " + strings.Join(items, "  "))
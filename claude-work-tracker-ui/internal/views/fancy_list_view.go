@@ -1,6 +1,7 @@
 package views
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -18,6 +19,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"claude-work-tracker-ui/internal/data"
+	"claude-work-tracker-ui/internal/hooks"
 	"claude-work-tracker-ui/internal/models"
 	"claude-work-tracker-ui/internal/renderer"
 )
@@ -25,6 +27,9 @@ import (
 // WorkItem implements list.Item interface for Work items
 type WorkItem struct {
 	*models.Work
+	TreePrefix  string             // Branch lines placing the item under its parent
+	Rollup      *models.WorkRollup // Summary of the item's children, nil without any
+	ParentTitle string             // Parent shown in another tab, or filtered out
 }
 
 
@@ -37,6 +42,8 @@ type ItemDelegate struct {
 	showDetail     bool
 	glamour        *glamour.TermRenderer
 	animatingItems map[string]string // Reference to parent's animating items
+	pinnedID       string            // Work item matching the current git branch
+	branchWarnings map[string]string // Work ID to merged/deleted branch warning
 }
 
 func (d ItemDelegate) Height() int {
@@ -57,6 +64,7 @@ func (d ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 
 	item := workItem.Work
 	isSelected := index == m.Index()
+	indent := strings.Repeat(" ", lipgloss.Width(workItem.TreePrefix))
 	
 	// Check if this item is animating
 	animationType, isAnimating := d.animatingItems[item.ID]
@@ -179,8 +187,17 @@ func (d ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 	
 	// Title line with status badge + title + automation indicators
 	var titleParts []string
+	if workItem.TreePrefix != "" {
+		titleParts = append(titleParts, lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(workItem.TreePrefix))
+	}
 	titleParts = append(titleParts, statusBadge)
 	titleParts = append(titleParts, " ")
+	if item.IsEpic() {
+		titleParts = append(titleParts, lipgloss.NewStyle().Foreground(lipgloss.Color("141")).Bold(true).Render("EPIC "))
+	}
+	if item.ID == d.pinnedID {
+		titleParts = append(titleParts, "📌 ")
+	}
 	titleParts = append(titleParts, titleStyle.Render(item.Title))
 	if automationIndicators != "" {
 		titleParts = append(titleParts, " ")
@@ -215,7 +232,7 @@ func (d ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 		if len(overviewText) > 120 {
 			overviewText = overviewText[:120] + "..."
 		}
-		renderedOverview := overviewStyle.Render(overviewText)
+		renderedOverview := overviewStyle.PaddingLeft(len(indent)).Render(overviewText)
 		content = lipgloss.JoinVertical(lipgloss.Left, content, renderedOverview)
 	}
 
@@ -227,6 +244,17 @@ func (d ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 		metaParts = append(metaParts, fmt.Sprintf("progress:%d%%", item.Metadata.ProgressPercent))
 	}
 	
+	// Add the roll-up of child work, and the parent when it isn't listed above
+	if rollup := workItem.Rollup; rollup != nil {
+		metaParts = append(metaParts, fmt.Sprintf("children:%d/%d done", rollup.Completed, rollup.Children))
+		if rollup.Open > 0 && rollup.Open != rollup.Children-rollup.Completed {
+			metaParts = append(metaParts, fmt.Sprintf("%d open below", rollup.Open))
+		}
+	}
+	if workItem.ParentTitle != "" {
+		metaParts = append(metaParts, "⊂ "+workItem.ParentTitle)
+	}
+	
 	// Add artifact count
 	if item.Metadata.ArtifactCount > 0 {
 		metaParts = append(metaParts, fmt.Sprintf("artifacts:%d", item.Metadata.ArtifactCount))
@@ -249,6 +277,16 @@ func (d ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 		}
 		metaParts = append(metaParts, "wt:"+worktreeName)
 	}
+	if warning := d.branchWarnings[item.ID]; warning != "" {
+		metaParts = append(metaParts, lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("⚠ "+warning))
+	}
+	
+	// Show deadline
+	if item.DueAt != nil && !item.IsClosed() {
+		metaParts = append(metaParts, "due: "+formatDueTime(*item.DueAt))
+	} else if item.StartBy != nil && !item.IsClosed() && item.StartedAt == nil {
+		metaParts = append(metaParts, "start by: "+formatDueTime(*item.StartBy))
+	}
 	
 	// Show last update time
 	lastUpdate := item.GetLastUpdateTime()
@@ -257,7 +295,7 @@ func (d ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 	}
 	
 	if len(metaParts) > 0 {
-		metadata := metadataStyle.Render(strings.Join(metaParts, " • "))
+		metadata := metadataStyle.PaddingLeft(len(indent)).Render(strings.Join(metaParts, " • "))
 		content = lipgloss.JoinVertical(lipgloss.Left, content, metadata)
 	}
 
@@ -291,6 +329,21 @@ type FancyListView struct {
 	searchInput      string            // Current search query
 	filteredItems    []*models.Work    // Filtered results
 	animatingItems   map[string]string // Maps workID to animation type ("complete" or "cancel")
+	sortByDue        bool              // Sort by nearest deadline instead of last update
+	dueFilter        bool              // Only show items with a due or start-by date
+	statusMessage    string            // Last action error or hook denial, cleared on the next key press
+	statusIsInfo     bool              // Status message reports a success rather than an error
+	pinnedID         string            // Work item matching the current git branch, kept first in its tab
+	focusPending     bool              // Select the pinned item once its tab has loaded
+	branchWarnings   map[string]string // Work ID to merged/deleted branch warning
+	taskMode         bool              // Full post shows the editable checklist
+	taskCursor       int               // Selected task in task mode
+	taskAdding       bool              // Typing the title of a new task
+	taskInput        string            // Title of the task being added
+	updatesMode      bool              // Full post shows the updates timeline
+	updatesView      UpdatesView       // Updates timeline of the item in the full post
+	workTree         *models.WorkTree  // Hierarchy of the items of all tabs
+	treePrefixes     map[string]string // Work ID to the branch lines drawn before it
 }
 
 // embeddingState tracks the state of embedded content
@@ -315,6 +368,18 @@ type workItemCompletedMsg struct {
 	workID string
 }
 
+// workActionFailedMsg is sent when completing, canceling or promoting an item fails
+type workActionFailedMsg struct {
+	err error
+}
+
+// workBranchedMsg is sent when a branch or worktree was created for a work item
+type workBranchedMsg struct {
+	workID   string
+	location string
+	worktree bool
+}
+
 // animateCompletionMsg triggers the green flash animation
 type animateCompletionMsg struct {
 	workID string
@@ -344,6 +409,13 @@ type FancyKeyMap struct {
 	CompleteItem  key.Binding
 	CancelItem    key.Binding
 	PromoteItem   key.Binding
+	ToggleBlocked key.Binding
+	CreateBranch  key.Binding
+	CreateWorktree key.Binding
+	TaskMode      key.Binding
+	ViewUpdates   key.Binding
+	SortByDue     key.Binding
+	FilterDue     key.Binding
 	Search        key.Binding
 	ClearSearch   key.Binding
 	AutomationConfig key.Binding
@@ -394,6 +466,34 @@ func DefaultFancyKeyMap() FancyKeyMap {
 			key.WithKeys("p"),
 			key.WithHelp("p", "promote item"),
 		),
+		ToggleBlocked: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "block/unblock item"),
+		),
+		CreateBranch: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "create branch for item"),
+		),
+		CreateWorktree: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "create worktree for item"),
+		),
+		TaskMode: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "edit tasks"),
+		),
+		ViewUpdates: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "updates"),
+		),
+		SortByDue: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort by due date"),
+		),
+		FilterDue: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "filter items with deadlines"),
+		),
 		Search: key.NewBinding(
 			key.WithKeys("/"),
 			key.WithHelp("/", "search"),
@@ -521,6 +621,7 @@ func NewFancyListView(dataClient *data.EnhancedClient) *FancyListView {
 		lastWidth:         0,
 		ready:             false,
 		animatingItems:    make(map[string]string),
+		updatesView:       NewUpdatesView(),
 	}
 }
 
@@ -605,6 +706,7 @@ func NewFancyListViewWithAdapter(dataProvider WorkDataProvider) *FancyListView {
 		lastWidth:         0,
 		ready:             false,
 		animatingItems:    make(map[string]string),
+		updatesView:       NewUpdatesView(),
 	}
 }
 
@@ -656,10 +758,18 @@ func (f *FancyListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case errMsg:
-		// Handle error messages from async operations
-		// For now, silently continue
-		// TODO: Show error to user in a proper way
+		// Show the failure in the status bar
+		f.setStatusError(msg.err)
 		return f, nil
+	
+	case updatesLoadedMsg:
+		f.updatesView, cmd = f.updatesView.Update(msg)
+		return f, cmd
+
+	case workActionFailedMsg:
+		// The item may have been changed optimistically, reload to show the stored state
+		f.setStatusError(msg.err)
+		return f, f.loadWorkItems()
 		
 	case tea.WindowSizeMsg:
 		// Always update dimensions
@@ -714,6 +824,7 @@ func (f *FancyListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		
 		// Update last width for cache invalidation
 		f.lastWidth = f.width
+		f.updatesView, _ = f.updatesView.Update(msg)
 		
 		if !f.ready {
 			f.ready = true
@@ -733,9 +844,25 @@ func (f *FancyListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			f.ready = true
 			// Update list immediately when we become ready
 			f.updateListItems()
-		} else if msg.schedule == f.getCurrentSchedule() {
+		} else {
+			// Other tabs can hold the children or parent of items in this one
 			f.updateListItems()
 		}
+		f.applyFocus()
+
+	case tasksEditedMsg:
+		f.applyTaskEdit(msg)
+		return f, f.loadWorkItems()
+
+	case workBranchedMsg:
+		if msg.worktree {
+			f.setStatusInfo("🌳 Created worktree " + msg.location)
+		} else {
+			// The checkout is now on the item's branch, so it becomes the pinned item
+			f.setStatusInfo("🌿 Switched to new branch " + msg.location)
+			f.FocusWork(msg.workID)
+		}
+		return f, f.loadWorkItems()
 	
 	case animationCompleteMsg:
 		// Animation finished, remove from animating items
@@ -787,21 +914,34 @@ func (f *FancyListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.KeyMsg:
+		f.statusMessage = ""
+		f.statusIsInfo = false
+
 		// Handle specific keys that might conflict
 		switch msg.String() {
 		case "q":
-			return f, tea.Quit
+			if !f.IsEditing() { // Typed into an input instead
+				return f, tea.Quit
+			}
 		case "ctrl+c":
 			return f, tea.Quit
 		// Remove the 'c' case to let it be handled by key.Matches below
 		}
 
-		if f.showFullPost {
+		if f.showFullPost && f.updatesMode {
+			cmd = f.updateUpdatesMode(msg)
+		} else if f.showFullPost && f.taskMode {
+			cmd = f.updateTaskMode(msg)
+		} else if f.showFullPost {
 			// Full post view navigation
 			switch {
 			case key.Matches(msg, f.keys.Back):
 				f.showFullPost = false
 				f.selectedItem = nil
+			case key.Matches(msg, f.keys.TaskMode):
+				f.enterTaskMode()
+			case key.Matches(msg, f.keys.ViewUpdates):
+				cmd = f.enterUpdatesMode()
 			case key.Matches(msg, f.keys.NextItem):
 				f.navigateToNextItem()
 				f.updateViewportContent() // Update viewport with new content
@@ -848,6 +988,12 @@ func (f *FancyListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					f.searchInput = ""
 					f.updateListItems()
 				}
+			case key.Matches(msg, f.keys.SortByDue):
+				f.sortByDue = !f.sortByDue
+				f.updateListItems()
+			case key.Matches(msg, f.keys.FilterDue):
+				f.dueFilter = !f.dueFilter
+				f.updateListItems()
 			case key.Matches(msg, f.keys.NextTab):
 				f.nextTab()
 				f.updateListItems()
@@ -930,6 +1076,18 @@ func (f *FancyListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 					}
 				}
+			case key.Matches(msg, f.keys.ToggleBlocked):
+				if selectedItem := f.list.SelectedItem(); selectedItem != nil {
+					if workItem, ok := selectedItem.(WorkItem); ok && workItem.Work != nil && !workItem.Work.IsClosed() {
+						return f, f.toggleBlocked(workItem.Work)
+					}
+				}
+			case key.Matches(msg, f.keys.CreateBranch), key.Matches(msg, f.keys.CreateWorktree):
+				if selectedItem := f.list.SelectedItem(); selectedItem != nil {
+					if workItem, ok := selectedItem.(WorkItem); ok && workItem.Work != nil && !workItem.Work.IsClosed() {
+						return f, f.createWorkBranch(workItem.Work, key.Matches(msg, f.keys.CreateWorktree))
+					}
+				}
 			case key.Matches(msg, f.keys.AutomationConfig):
 				// TODO: Open automation configuration view
 				// This will be implemented when the automation config view is integrated
@@ -990,7 +1148,14 @@ func (f *FancyListView) View() string {
 	if f.searchMode || f.searchInput != "" {
 		components = append(components, searchBar)
 	}
-	components = append(components, listContent, help)
+	if status := f.renderSortStatus(); status != "" {
+		components = append(components, status)
+	}
+	components = append(components, listContent)
+	if f.statusMessage != "" {
+		components = append(components, f.renderStatusMessage())
+	}
+	components = append(components, help)
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -1028,6 +1193,63 @@ func (f *FancyListView) renderSearchBar() string {
 	return searchStyle.Render(searchContent)
 }
 
+// setStatusError shows an error in the status bar, calling out hook denials
+func (f *FancyListView) setStatusError(err error) {
+	if err == nil {
+		return
+	}
+
+	f.statusIsInfo = false
+	var veto *hooks.VetoError
+	if !errors.As(err, &veto) {
+		f.statusMessage = "✗ " + err.Error()
+		return
+	}
+
+	if veto.Reason == "" {
+		f.statusMessage = fmt.Sprintf("🚫 Denied by hook %s", veto.HookName)
+	} else {
+		f.statusMessage = fmt.Sprintf("🚫 Denied by hook %s: %s", veto.HookName, veto.Reason)
+	}
+}
+
+// setStatusInfo shows the outcome of a successful action in the status bar
+func (f *FancyListView) setStatusInfo(message string) {
+	f.statusMessage = message
+	f.statusIsInfo = true
+}
+
+// renderStatusMessage renders the last error, hook denial or action outcome
+func (f *FancyListView) renderStatusMessage() string {
+	color := lipgloss.Color("196")
+	if f.statusIsInfo {
+		color = lipgloss.Color("42")
+	}
+	return lipgloss.NewStyle().
+		Foreground(color).
+		Padding(0, 2).
+		Width(f.width - 4).
+		Render(f.statusMessage)
+}
+
+// renderSortStatus describes the active due sort and filter
+func (f *FancyListView) renderSortStatus() string {
+	var parts []string
+	if f.sortByDue {
+		parts = append(parts, "sorted by due date")
+	}
+	if f.dueFilter {
+		parts = append(parts, fmt.Sprintf("%d items with deadlines", len(f.filteredItems)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("220")).
+		Padding(0, 2).
+		Render("◷ " + strings.Join(parts, " • "))
+}
+
 // renderConnectedTabBar creates the top tab bar that connects to list content
 func (f *FancyListView) renderConnectedTabBar() string {
 	var renderedTabs []string
@@ -1085,6 +1307,12 @@ func (f *FancyListView) renderConnectedList() string {
 	if f.searchMode || f.searchInput != "" {
 		maxHeight -= 2 // Search bar takes 2 lines (no margins)
 	}
+	if f.sortByDue || f.dueFilter {
+		maxHeight-- // Sort status takes 1 line
+	}
+	if f.statusMessage != "" {
+		maxHeight-- // Status message takes 1 line
+	}
 	
 	if maxHeight < 5 {
 		maxHeight = 5
@@ -1177,11 +1405,11 @@ func (f *FancyListView) renderHelp() string {
 		if f.searchMode {
 			helpText = "Type to search • enter: confirm • esc: cancel"
 		} else if schedule == models.ScheduleNow {
-			helpText = "tab: switch • ↑/↓: nav • enter: view • c: complete • x: cancel • /: search • q: quit"
+			helpText = "tab: switch • ↑/↓: nav • enter: view • c: complete • x: cancel • b: block • B/W: branch/worktree • s/f: due sort/filter • /: search • q: quit"
 		} else if schedule == models.ScheduleNext {
-			helpText = "tab: switch • ↑/↓: nav • enter: view • c: complete • x: cancel • p: promote • /: search • q: quit"
+			helpText = "tab: switch • ↑/↓: nav • enter: view • c: complete • x: cancel • p: promote • b: block • B/W: branch/worktree • s/f: due sort/filter • /: search • q: quit"
 		} else if schedule == models.ScheduleLater {
-			helpText = "tab: switch • ↑/↓: nav • enter: view • c: complete • x: cancel • p: promote • /: search • q: quit"
+			helpText = "tab: switch • ↑/↓: nav • enter: view • c: complete • x: cancel • p: promote • b: block • B/W: branch/worktree • s/f: due sort/filter • /: search • q: quit"
 		} else {
 			helpText = "tab: switch • ↑/↓: nav • enter: view • /: search • d: detail • q: quit"
 		}
@@ -1198,9 +1426,9 @@ func (f *FancyListView) renderScrollableHelp() string {
 	
 	var helpText string
 	if itemCount > 1 {
-		helpText = "↑/↓/j/k: scroll • space/pgdn: page down • pgup: page up • ←/→: items • esc: back • q: quit"
+		helpText = "↑/↓/j/k: scroll • space/pgdn: page down • pgup: page up • ←/→: items • t: tasks • u: updates • esc: back • q: quit"
 	} else {
-		helpText = "↑/↓/j/k: scroll • space/pgdn: page down • pgup: page up • esc: back • q: quit"
+		helpText = "↑/↓/j/k: scroll • space/pgdn: page down • pgup: page up • t: tasks • u: updates • esc: back • q: quit"
 	}
 	
 	return lipgloss.NewStyle().
@@ -1214,6 +1442,13 @@ func (f *FancyListView) renderFullPost() string {
 		return "No item selected"
 	}
 
+	if f.updatesMode {
+		return f.updatesView.View()
+	}
+	if f.taskMode {
+		return f.renderTaskMode()
+	}
+
 	// Use viewport for scrollable content - no truncation!
 	content := f.viewport.View()
 
@@ -1234,7 +1469,14 @@ func (f *FancyListView) updateListItems() {
 	// Convert to list items
 	var listItems []list.Item
 	for _, item := range f.filteredItems {
-		listItems = append(listItems, WorkItem{item})
+		listItem := WorkItem{Work: item, TreePrefix: f.treePrefixes[item.ID]}
+		if rollup, ok := f.workTree.Rollup(item.ID); ok {
+			listItem.Rollup = &rollup
+		}
+		if parent := f.workTree.Parent(item.ID); parent != nil && listItem.TreePrefix == "" {
+			listItem.ParentTitle = parent.Title
+		}
+		listItems = append(listItems, listItem)
 	}
 	
 	f.list.SetItems(listItems)
@@ -1248,10 +1490,70 @@ func (f *FancyListView) updateDelegate() {
 		showDetail:     f.showDetail,
 		glamour:        f.glamour,
 		animatingItems: f.animatingItems,
+		pinnedID:       f.pinnedID,
+		branchWarnings: f.branchWarnings,
 	}
 	f.list.SetDelegate(delegate)
 }
 
+// FocusWork pins a work item to the top of its tab and selects it once it has loaded
+func (f *FancyListView) FocusWork(workID string) {
+	f.pinnedID = workID
+	f.focusPending = workID != ""
+	f.updateDelegate()
+	f.applyFocus()
+}
+
+// PinnedWorkID returns the work item pinned for the current git branch
+func (f *FancyListView) PinnedWorkID() string {
+	return f.pinnedID
+}
+
+// SetBranchWarnings replaces the merged/deleted branch warnings shown on items
+func (f *FancyListView) SetBranchWarnings(warnings map[string]string) {
+	f.branchWarnings = warnings
+	f.updateDelegate()
+}
+
+// applyFocus switches to the pinned item's tab and selects it, once that tab has loaded
+func (f *FancyListView) applyFocus() {
+	if !f.focusPending || !f.ready {
+		return
+	}
+	for i, tab := range f.tabs {
+		for _, item := range f.workItems[tab.Schedule] {
+			if item.ID != f.pinnedID {
+				continue
+			}
+			f.activeTab = i
+			f.updateListItems()
+			for index, listItem := range f.list.Items() {
+				if workItem, ok := listItem.(WorkItem); ok && workItem.ID == f.pinnedID {
+					f.list.Select(index)
+				}
+			}
+			f.focusPending = false
+			return
+		}
+	}
+}
+
+// createWorkBranch starts a branch, or a worktree, for a work item
+func (f *FancyListView) createWorkBranch(item *models.Work, worktree bool) tea.Cmd {
+	return func() tea.Msg {
+		brancher, ok := f.dataProvider.(WorkBrancher)
+		if !ok {
+			return errMsg{err: fmt.Errorf("creating branches is not supported by this storage")}
+		}
+
+		location, err := brancher.CreateWorkBranch(item.ID, worktree)
+		if err != nil {
+			return workActionFailedMsg{err: err}
+		}
+		return workBranchedMsg{workID: item.ID, location: location, worktree: worktree}
+	}
+}
+
 // tickAnimation creates a command that waits then sends animation complete message
 func (f *FancyListView) tickAnimation(workID string, action string) tea.Cmd {
 	return tea.Tick(150*time.Millisecond, func(t time.Time) tea.Msg {
@@ -1281,7 +1583,7 @@ func (f *FancyListView) promoteWorkItem(item *models.Work) tea.Cmd {
 		// Use data provider if available (centralized storage)
 		if f.dataProvider != nil {
 			if err := f.dataProvider.UpdateWorkSchedule(item.ID, targetSchedule); err != nil {
-				return errMsg{err: fmt.Errorf("failed to promote work item: %w", err)}
+				return workActionFailedMsg{err: fmt.Errorf("failed to promote work item: %w", err)}
 			}
 			// Return workItemCompletedMsg to trigger reload
 			return workItemCompletedMsg{workID: item.ID}
@@ -1326,6 +1628,25 @@ func (f *FancyListView) promoteWorkItem(item *models.Work) tea.Cmd {
 	}
 }
 
+// toggleBlocked blocks an item, or unblocks it back to the status it had before
+func (f *FancyListView) toggleBlocked(item *models.Work) tea.Cmd {
+	return func() tea.Msg {
+		blocker, ok := f.dataProvider.(WorkBlocker)
+		if !ok {
+			return errMsg{err: fmt.Errorf("blocking is not supported by this storage")}
+		}
+
+		if item.Metadata.Status == models.WorkStatusBlocked {
+			if err := blocker.UnblockWork(item.ID); err != nil {
+				return workActionFailedMsg{err: fmt.Errorf("failed to unblock work item: %w", err)}
+			}
+		} else if err := blocker.BlockWork(item.ID); err != nil {
+			return workActionFailedMsg{err: fmt.Errorf("failed to block work item: %w", err)}
+		}
+		return workItemCompletedMsg{workID: item.ID}
+	}
+}
+
 // completeWorkItem marks a work item as completed and moves it to CLOSED
 func (f *FancyListView) completeWorkItem(item *models.Work) tea.Cmd {
 	return func() tea.Msg {
@@ -1337,7 +1658,7 @@ func (f *FancyListView) completeWorkItem(item *models.Work) tea.Cmd {
 		// Use data provider if available
 		if f.dataProvider != nil {
 			if err := f.dataProvider.CompleteWork(item.ID); err != nil {
-				return errMsg{err: fmt.Errorf("failed to complete work item: %w", err)}
+				return workActionFailedMsg{err: fmt.Errorf("failed to complete work item: %w", err)}
 			}
 			return workItemCompletedMsg{workID: item.ID}
 		}
@@ -1401,7 +1722,7 @@ func (f *FancyListView) cancelWorkItem(item *models.Work) tea.Cmd {
 			item.Metadata.Status = models.WorkStatusCanceled
 			item.Schedule = models.ScheduleClosed
 			if err := f.dataProvider.UpdateWorkSchedule(item.ID, models.ScheduleClosed); err != nil {
-				return errMsg{err: fmt.Errorf("failed to cancel work item: %w", err)}
+				return workActionFailedMsg{err: fmt.Errorf("failed to cancel work item: %w", err)}
 			}
 			return workItemCompletedMsg{workID: item.ID}
 		}
@@ -1472,6 +1793,12 @@ func fuzzyMatch(query, target string) bool {
 func (f *FancyListView) filterAndSortItems() {
 	schedule := f.getCurrentSchedule()
 	allItems := f.workItems[schedule]
+
+	var everything []*models.Work
+	for _, items := range f.workItems {
+		everything = append(everything, items...)
+	}
+	f.workTree = models.NewWorkTree(everything)
 	
 	// Filter based on search
 	var filtered []*models.Work
@@ -1509,6 +1836,33 @@ func (f *FancyListView) filterAndSortItems() {
 		}
 	}
 	
+	// Keep only items with deadlines when the due filter is on
+	if f.dueFilter {
+		withDeadline := make([]*models.Work, 0, len(filtered))
+		for _, item := range filtered {
+			if item.DueAt != nil || item.StartBy != nil {
+				withDeadline = append(withDeadline, item)
+			}
+		}
+		filtered = withDeadline
+	}
+	
+	if f.sortByDue {
+		// Nearest deadline first, items without one last
+		sort.SliceStable(filtered, func(i, j int) bool {
+			di, dj := nextDeadline(filtered[i]), nextDeadline(filtered[j])
+			if di == nil || dj == nil {
+				return di != nil && dj == nil
+			}
+			if !di.Equal(*dj) {
+				return di.Before(*dj)
+			}
+			return filtered[i].UpdatedAt.After(filtered[j].UpdatedAt)
+		})
+		f.filteredItems, f.treePrefixes = treeOrder(pinFirst(filtered, f.pinnedID), f.workTree)
+		return
+	}
+	
 	// Sort by newest first (most recent updated_at or created_at)
 	sort.Slice(filtered, func(i, j int) bool {
 		// For CLOSED items, prefer CompletedAt if available
@@ -1522,7 +1876,77 @@ func (f *FancyListView) filterAndSortItems() {
 		return filtered[i].UpdatedAt.After(filtered[j].UpdatedAt)
 	})
 	
-	f.filteredItems = filtered
+	f.filteredItems, f.treePrefixes = treeOrder(pinFirst(filtered, f.pinnedID), f.workTree)
+}
+
+// pinFirst moves the pinned work item to the front of the list
+func pinFirst(items []*models.Work, pinnedID string) []*models.Work {
+	for i, item := range items {
+		if item.ID == pinnedID && i > 0 {
+			pinned := append([]*models.Work{item}, items[:i]...)
+			return append(pinned, items[i+1:]...)
+		}
+	}
+	return items
+}
+
+// treeOrder lists child work right below its parent, keeping the order within each level,
+// and returns the branch lines to draw before each child. Items whose parent isn't in the
+// list stay at the top level.
+func treeOrder(items []*models.Work, tree *models.WorkTree) ([]*models.Work, map[string]string) {
+	listed := make(map[string]bool)
+	for _, item := range items {
+		listed[item.ID] = true
+	}
+	children := make(map[string][]*models.Work)
+	var roots []*models.Work
+	for _, item := range items {
+		if parent := tree.Parent(item.ID); parent != nil && listed[parent.ID] {
+			children[parent.ID] = append(children[parent.ID], item)
+		} else {
+			roots = append(roots, item)
+		}
+	}
+
+	ordered := make([]*models.Work, 0, len(items))
+	prefixes := make(map[string]string)
+	visited := make(map[string]bool)
+	var walk func(item *models.Work, prefix, indent string)
+	walk = func(item *models.Work, prefix, indent string) {
+		if visited[item.ID] {
+			return
+		}
+		visited[item.ID] = true
+		ordered = append(ordered, item)
+		if prefix != "" {
+			prefixes[item.ID] = prefix
+		}
+		kids := children[item.ID]
+		for i, child := range kids {
+			if i == len(kids)-1 {
+				walk(child, indent+"└─ ", indent+"   ")
+			} else {
+				walk(child, indent+"├─ ", indent+"│  ")
+			}
+		}
+	}
+	for _, root := range roots {
+		walk(root, "", "")
+	}
+	// Items caught in a parent cycle have no root to hang from
+	for _, item := range items {
+		walk(item, "", "")
+	}
+	return ordered, prefixes
+}
+
+// nextDeadline returns the earliest pending deadline of a work item
+func nextDeadline(work *models.Work) *time.Time {
+	deadline := work.DueAt
+	if work.StartBy != nil && work.StartedAt == nil && (deadline == nil || work.StartBy.Before(*deadline)) {
+		deadline = work.StartBy
+	}
+	return deadline
 }
 
 func (f *FancyListView) prevTab() {
@@ -1610,7 +2034,7 @@ func (f *FancyListView) updateViewportContent() {
 	if glamourWidth < 20 {
 		glamourWidth = 20
 	}
-	cacheKey := fmt.Sprintf("%s_%d", item.ID, glamourWidth)
+	cacheKey := fmt.Sprintf("%s_%d_%d_%d", item.ID, glamourWidth, len(item.Transitions), len(item.Commits))
 
 	// Check cache first, but skip cache if embeddings are loading for this item
 	hasLoadingEmbeddings := false
@@ -1635,6 +2059,9 @@ func (f *FancyListView) updateViewportContent() {
 		} else {
 			fullContent = "# " + item.Title + "\n\nNo detailed content available."
 		}
+		fullContent += renderHierarchy(item, f.workTree)
+		fullContent += renderTransitionHistory(item)
+		fullContent += renderCommits(item)
 		
 		if fullContent != "" {
 			var processedContent string
@@ -1815,6 +2242,119 @@ func formatRelativeTime(t time.Time) string {
 	}
 }
 
+// formatDueTime formats a deadline relative to now, e.g. "in 3d" or "2h overdue"
+func formatDueTime(t time.Time) string {
+	duration := time.Until(t)
+	overdue := duration < 0
+	if overdue {
+		duration = -duration
+	}
+	
+	var amount string
+	switch {
+	case duration < time.Hour:
+		amount = fmt.Sprintf("%dm", int(duration.Minutes()))
+	case duration < 24*time.Hour:
+		amount = fmt.Sprintf("%dh", int(duration.Hours()))
+	default:
+		amount = fmt.Sprintf("%dd", int(duration.Hours()/24))
+	}
+	
+	if overdue {
+		return amount + " overdue"
+	}
+	return "in " + amount
+}
+
+// renderHierarchy renders a work item's parents and the work below it as a markdown section
+func renderHierarchy(work *models.Work, tree *models.WorkTree) string {
+	if tree == nil {
+		return ""
+	}
+	ancestors := tree.Ancestors(work.ID)
+	rollup, hasChildren := tree.Rollup(work.ID)
+	if len(ancestors) == 0 && !hasChildren {
+		return ""
+	}
+
+	var section strings.Builder
+	section.WriteString("\n\n## Hierarchy\n\n")
+	if len(ancestors) > 0 {
+		var path []string
+		for i := len(ancestors) - 1; i >= 0; i-- {
+			path = append(path, fmt.Sprintf("%s (%s)", ancestors[i].Title, ancestors[i].GetDisplaySchedule()))
+		}
+		section.WriteString("Part of " + strings.Join(path, " → ") + "\n\n")
+	}
+	if hasChildren {
+		section.WriteString(fmt.Sprintf("%d of %d children done • %d%% • effort %s (%d open below)\n\n",
+			rollup.Completed, rollup.Children, rollup.Progress, rollup.Effort, rollup.Open))
+		depth := tree.Depth(work.ID)
+		for _, child := range tree.Descendants(work.ID) {
+			mark := "○"
+			if child.IsCompleted() {
+				mark = "✓"
+			}
+			indent := strings.Repeat("  ", tree.Depth(child.ID)-depth-1)
+			section.WriteString(fmt.Sprintf("%s- %s %s — %s, %s, %d%%\n", indent, mark, child.Title,
+				child.GetDisplaySchedule(), child.Metadata.Status, child.Metadata.ProgressPercent))
+		}
+	}
+	return section.String()
+}
+
+// renderTransitionHistory renders a work item's status changes as a markdown section, newest first
+func renderTransitionHistory(work *models.Work) string {
+	if len(work.Transitions) == 0 {
+		return ""
+	}
+
+	var history strings.Builder
+	history.WriteString("\n\n## Status History\n\n")
+	history.WriteString("| When | Change | By | Why |\n")
+	history.WriteString("|------|--------|----|-----|\n")
+	for i := len(work.Transitions) - 1; i >= 0; i-- {
+		t := work.Transitions[i]
+		by := t.Actor
+		if t.Automatic && t.Rule != "" {
+			by = fmt.Sprintf("%s (%s)", t.Actor, t.Rule)
+		}
+		from := t.From
+		if from == "" {
+			from = "new"
+		}
+		reason := strings.ReplaceAll(t.Reason, "|", "/")
+		history.WriteString(fmt.Sprintf("| %s | %s → %s | %s | %s |\n",
+			t.At.Format("Jan 2 15:04"), from, t.To, by, reason))
+	}
+	return history.String()
+}
+
+// maxDetailCommits caps the commit list in the detail view
+const maxDetailCommits = 20
+
+// renderCommits renders the commits linked to a work item as a markdown section, newest first
+func renderCommits(work *models.Work) string {
+	if len(work.Commits) == 0 {
+		return ""
+	}
+
+	var commits strings.Builder
+	commits.WriteString(fmt.Sprintf("\n\n## Commits (%d)\n\n", len(work.Commits)))
+	commits.WriteString("| When | Commit | Author | Message | Linked by |\n")
+	commits.WriteString("|------|--------|--------|---------|-----------|\n")
+	for i, c := range work.Commits {
+		if i == maxDetailCommits {
+			commits.WriteString(fmt.Sprintf("\n_%d older commits not shown_\n", len(work.Commits)-maxDetailCommits))
+			break
+		}
+		subject := strings.ReplaceAll(c.Subject, "|", "/")
+		commits.WriteString(fmt.Sprintf("| %s | `%s` | %s | %s | %s |\n",
+			c.Timestamp.Format("Jan 2 15:04"), c.ShortSHA(), c.Author, subject, c.MatchedBy))
+	}
+	return commits.String()
+}
+
 func extractOverview(content string) string {
 	lines := strings.Split(content, "\n")
 	var overview []string
@@ -8,4 +8,42 @@ type WorkDataProvider interface {
 	UpdateWorkSchedule(workID, newSchedule string) error
 	CompleteWork(workID string) error
 	SearchWork(query string) ([]*models.Work, error)
-}
\ No newline at end of file
+}
+
+// WorkBlocker is implemented by providers that can block and unblock work items
+type WorkBlocker interface {
+	BlockWork(workID string) error
+	UnblockWork(workID string) error // Restores the status from before the item was blocked
+}
+
+// WorkBrancher is implemented by providers that can start a git branch for a work item
+type WorkBrancher interface {
+	// CreateWorkBranch creates a branch for the item, in a new worktree when worktree is
+	// set, and returns where it was created
+	CreateWorkBranch(workID string, worktree bool) (string, error)
+}
+
+// WorkTaskEditor is implemented by providers that can edit a work item's checklist. Each
+// method saves the item, records an automatic update and returns the saved item.
+type WorkTaskEditor interface {
+	SetTaskStatus(workID, taskID string, status models.TaskStatus) (*models.Work, error)
+	AddTask(workID, afterTaskID, title string) (*models.Work, string, error) // Also returns the new task's ID
+	MoveTask(workID, taskID string, delta int) (*models.Work, error)         // delta < 0 moves up
+	DeleteTask(workID, taskID string) (*models.Work, error)
+}
+
+// WorkPromoter is implemented by providers that can spin a task or a whole phase out of a
+// work item's checklist into a new work item. Both return the saved parent and the new item.
+type WorkPromoter interface {
+	PromoteTask(workID, taskID string) (*models.Work, *models.Work, error)
+	PromotePhase(workID, phase string) (*models.Work, *models.Work, error)
+}
+
+// WorkUpdateLog is implemented by providers that keep a work item's updates. Updates are
+// returned newest first and matched by ID when edited or deleted.
+type WorkUpdateLog interface {
+	GetUpdates(workID string) ([]*models.Update, error)
+	CreateUpdate(workID string, update *models.Update) error
+	EditUpdate(workID string, update *models.Update) error
+	DeleteUpdate(workID, updateID string) error
+}
@@ -237,11 +237,14 @@ func (t *TabbedWorkView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			t.updateViewportContent()
 		}
 
+	case updatesLoadedMsg:
+		t.updatesView, cmd = t.updatesView.Update(msg)
+
 	case tea.KeyMsg:
 		if t.showUpdates {
 			// Handle updates view navigation
 			switch {
-			case key.Matches(msg, t.keys.Back):
+			case key.Matches(msg, t.keys.Back) && !t.updatesView.Typing():
 				t.showUpdates = false
 				t.updateViewportContent()
 			default:
@@ -260,11 +263,12 @@ func (t *TabbedWorkView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Load updates for current work item
 				currentWork := t.getCurrentWork()
 				if currentWork != nil {
-					t.updatesView.LoadFromWork(currentWork)
+					loadCmd := t.updatesView.SetLog(t.dataClient, currentWork.ID)
 					t.updatesView, cmd = t.updatesView.Update(tea.WindowSizeMsg{
 						Width:  t.width,
 						Height: t.height,
 					})
+					cmd = tea.Batch(loadCmd, cmd)
 				}
 			default:
 				t.viewport, cmd = t.viewport.Update(msg)
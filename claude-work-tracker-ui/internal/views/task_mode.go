@@ -0,0 +1,328 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"claude-work-tracker-ui/internal/models"
+	"claude-work-tracker-ui/internal/parser"
+)
+
+// tasksEditedMsg is sent when a checklist edit has been saved
+type tasksEditedMsg struct {
+	work     *models.Work
+	cursorID string // Task to select afterwards
+	info     string // Outcome for the status bar
+}
+
+// taskStatusCycle is the order space steps a task through, matching [ ] […] [x] [!] [-]
+var taskStatusCycle = []models.TaskStatus{
+	models.TaskStatusTodo,
+	models.TaskStatusInProgress,
+	models.TaskStatusCompleted,
+	models.TaskStatusBlocked,
+	models.TaskStatusCancelled,
+}
+
+var (
+	taskCursorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true)
+	taskPhaseStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
+	taskMetaStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	taskDoneStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Strikethrough(true)
+)
+
+// nextTaskStatus returns the status after s in the cycle
+func nextTaskStatus(s models.TaskStatus) models.TaskStatus {
+	for i, status := range taskStatusCycle {
+		if status == s {
+			return taskStatusCycle[(i+1)%len(taskStatusCycle)]
+		}
+	}
+	return models.TaskStatusTodo
+}
+
+// enterTaskMode switches the full post to its editable checklist
+func (f *FancyListView) enterTaskMode() {
+	if _, ok := f.dataProvider.(WorkTaskEditor); !ok {
+		f.setStatusError(fmt.Errorf("editing tasks is not supported by this storage"))
+		return
+	}
+	f.taskMode = true
+	f.taskAdding = false
+	f.taskInput = ""
+	f.clampTaskCursor()
+}
+
+// selectedTasks parses the checklist of the item shown in the full post
+func (f *FancyListView) selectedTasks() []parser.ParsedTask {
+	if f.selectedItem == nil {
+		return nil
+	}
+	return parser.NewTaskParser().ExtractTasksFromMarkdown(f.selectedItem.Content, f.selectedItem.ID).Tasks
+}
+
+// clampTaskCursor keeps the cursor on an existing task
+func (f *FancyListView) clampTaskCursor() {
+	count := len(f.selectedTasks())
+	if f.taskCursor >= count {
+		f.taskCursor = count - 1
+	}
+	if f.taskCursor < 0 {
+		f.taskCursor = 0
+	}
+}
+
+// updateTaskMode handles keys while the checklist is being edited
+func (f *FancyListView) updateTaskMode(msg tea.KeyMsg) tea.Cmd {
+	if f.taskAdding {
+		switch msg.Type {
+		case tea.KeyEnter:
+			f.taskAdding = false
+			if title := strings.TrimSpace(f.taskInput); title != "" {
+				return f.addTask(title)
+			}
+		case tea.KeyEsc:
+			f.taskAdding = false
+			f.taskInput = ""
+		case tea.KeyBackspace:
+			if runes := []rune(f.taskInput); len(runes) > 0 {
+				f.taskInput = string(runes[:len(runes)-1])
+			}
+		case tea.KeySpace:
+			f.taskInput += " "
+		case tea.KeyRunes:
+			f.taskInput += string(msg.Runes)
+		}
+		return nil
+	}
+
+	tasks := f.selectedTasks()
+	var current *models.Task
+	if f.taskCursor < len(tasks) {
+		current = tasks[f.taskCursor].Task
+	}
+
+	switch msg.String() {
+	case "esc", "t":
+		f.taskMode = false
+	case "up", "k":
+		if f.taskCursor > 0 {
+			f.taskCursor--
+		}
+	case "down", "j":
+		if f.taskCursor < len(tasks)-1 {
+			f.taskCursor++
+		}
+	case " ", "enter":
+		if current != nil {
+			return f.editTask(func(editor WorkTaskEditor) (*models.Work, string, error) {
+				work, err := editor.SetTaskStatus(f.selectedItem.ID, current.ID, nextTaskStatus(current.Status))
+				return work, current.ID, err
+			}, "")
+		}
+	case "a":
+		f.taskAdding = true
+		f.taskInput = ""
+	case "K", "shift+up":
+		if current != nil {
+			return f.editTask(func(editor WorkTaskEditor) (*models.Work, string, error) {
+				work, err := editor.MoveTask(f.selectedItem.ID, current.ID, -1)
+				return work, current.ID, err
+			}, "")
+		}
+	case "J", "shift+down":
+		if current != nil {
+			return f.editTask(func(editor WorkTaskEditor) (*models.Work, string, error) {
+				work, err := editor.MoveTask(f.selectedItem.ID, current.ID, 1)
+				return work, current.ID, err
+			}, "")
+		}
+	case "P":
+		if current != nil {
+			return f.promote(func(promoter WorkPromoter) (*models.Work, *models.Work, error) {
+				return promoter.PromoteTask(f.selectedItem.ID, current.ID)
+			}, current.ID)
+		}
+	case "H":
+		if current != nil && current.Phase != "" {
+			return f.promote(func(promoter WorkPromoter) (*models.Work, *models.Work, error) {
+				return promoter.PromotePhase(f.selectedItem.ID, current.Phase)
+			}, "")
+		}
+	case "d", "delete":
+		if current != nil {
+			return f.editTask(func(editor WorkTaskEditor) (*models.Work, string, error) {
+				work, err := editor.DeleteTask(f.selectedItem.ID, current.ID)
+				return work, "", err
+			}, "🗑 Removed "+current.Title)
+		}
+	}
+	return nil
+}
+
+// addTask adds a task after the selected one
+func (f *FancyListView) addTask(title string) tea.Cmd {
+	afterID := ""
+	if tasks := f.selectedTasks(); f.taskCursor < len(tasks) {
+		afterID = tasks[f.taskCursor].Task.ID
+	}
+	return f.editTask(func(editor WorkTaskEditor) (*models.Work, string, error) {
+		return editor.AddTask(f.selectedItem.ID, afterID, title)
+	}, "➕ Added "+title)
+}
+
+// editTask runs a checklist edit through the provider and reports the saved item
+func (f *FancyListView) editTask(edit func(WorkTaskEditor) (*models.Work, string, error), info string) tea.Cmd {
+	return func() tea.Msg {
+		editor, ok := f.dataProvider.(WorkTaskEditor)
+		if !ok {
+			return errMsg{err: fmt.Errorf("editing tasks is not supported by this storage")}
+		}
+		work, cursorID, err := edit(editor)
+		if err != nil {
+			return workActionFailedMsg{err: err}
+		}
+		return tasksEditedMsg{work: work, cursorID: cursorID, info: info}
+	}
+}
+
+// promote spins part of the checklist out into a new work item through the provider
+func (f *FancyListView) promote(run func(WorkPromoter) (*models.Work, *models.Work, error), cursorID string) tea.Cmd {
+	return func() tea.Msg {
+		promoter, ok := f.dataProvider.(WorkPromoter)
+		if !ok {
+			return errMsg{err: fmt.Errorf("promoting tasks is not supported by this storage")}
+		}
+		parent, child, err := run(promoter)
+		if err != nil {
+			return workActionFailedMsg{err: err}
+		}
+		info := fmt.Sprintf("⇪ Promoted %s to its own work item in %s", child.Title, strings.ToUpper(child.Schedule))
+		return tasksEditedMsg{work: parent, cursorID: cursorID, info: info}
+	}
+}
+
+// applyTaskEdit shows the saved item and keeps the cursor on the edited task
+func (f *FancyListView) applyTaskEdit(msg tasksEditedMsg) {
+	if msg.work == nil || f.selectedItem == nil || f.selectedItem.ID != msg.work.ID {
+		return
+	}
+	f.selectedItem = msg.work
+	f.renderCache = make(map[string]string) // The cache key doesn't cover content
+	f.updateViewportContent()
+
+	for i, task := range f.selectedTasks() {
+		if task.Task.ID == msg.cursorID {
+			f.taskCursor = i
+		}
+	}
+	f.clampTaskCursor()
+	if msg.info != "" {
+		f.setStatusInfo(msg.info)
+	}
+}
+
+// renderTaskMode renders the checklist of the selected item with the cursor on one task
+func (f *FancyListView) renderTaskMode() string {
+	tasks := f.selectedTasks()
+	var lines []string
+	cursorLine := 0
+	phase := ""
+	for i, task := range tasks {
+		if task.Task.Phase != phase {
+			phase = task.Task.Phase
+			lines = append(lines, "", taskPhaseStyle.Render(phase))
+		}
+
+		title := task.Task.Title
+		if task.Task.Status == models.TaskStatusCompleted || task.Task.Status == models.TaskStatusCancelled {
+			title = taskDoneStyle.Render(title)
+		}
+		line := fmt.Sprintf("%s%s %s", strings.Repeat("  ", task.Task.Depth), task.Task.GetStatusIcon(), title)
+		if meta := taskMetaSummary(task.Task); meta != "" {
+			line += "  " + taskMetaStyle.Render(meta)
+		}
+
+		if i == f.taskCursor && !f.taskAdding {
+			cursorLine = len(lines)
+			line = taskCursorStyle.Render("▸ ") + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+
+		if i == f.taskCursor && f.taskAdding {
+			cursorLine = len(lines)
+			lines = append(lines, taskCursorStyle.Render("▸ ")+strings.Repeat("  ", task.Task.Depth)+"○ "+f.taskInput+"▏")
+		}
+	}
+	if len(tasks) == 0 {
+		if f.taskAdding {
+			lines = append(lines, taskCursorStyle.Render("▸ ")+"○ "+f.taskInput+"▏")
+		} else {
+			lines = append(lines, taskMetaStyle.Render("No tasks yet, press a to add one"))
+		}
+	}
+
+	// Scroll so the cursor stays in view
+	height := f.viewport.Height - 2
+	if height < 3 {
+		height = 3
+	}
+	start := 0
+	if cursorLine >= height {
+		start = cursorLine - height + 1
+	}
+	end := start + height
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	header := lipgloss.NewStyle().Bold(true).Render("☑ " + f.selectedItem.Title)
+	if progress, ok := (&parser.TaskExtractionResult{Tasks: tasks}).Progress(); ok {
+		header += taskMetaStyle.Render(fmt.Sprintf("  %.0f%% done", progress*100))
+	}
+	body := lipgloss.NewStyle().
+		Padding(1, 2).
+		Width(f.viewport.Width).
+		Height(f.viewport.Height).
+		Render(header + "\n" + strings.Join(lines[start:end], "\n"))
+
+	help := "↑/↓: select • space: cycle status • a: add • K/J: move up/down • d: delete • P/H: promote task/phase • esc: done"
+	if f.taskAdding {
+		help = "Type the task title • enter: add • esc: cancel"
+	}
+	components := []string{body}
+	if f.statusMessage != "" {
+		components = append(components, f.renderStatusMessage())
+	}
+	components = append(components, lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Padding(1, 2).
+		Render(help))
+	return lipgloss.JoinVertical(lipgloss.Left, components...)
+}
+
+// taskMetaSummary lists a task's inline metadata for display
+func taskMetaSummary(task *models.Task) string {
+	var parts []string
+	if task.LinkedWorkID != "" {
+		parts = append(parts, "→ "+task.LinkedWorkID)
+	}
+	if task.AssignedTo != "" {
+		parts = append(parts, "@"+task.AssignedTo)
+	}
+	for _, tag := range task.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	if task.DueAt != nil {
+		parts = append(parts, "due "+task.DueAt.Format("Jan 2"))
+	}
+	if task.Estimate != "" {
+		parts = append(parts, "~"+task.Estimate)
+	}
+	return strings.Join(parts, " ")
+}
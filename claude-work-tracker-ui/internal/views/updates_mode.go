@@ -0,0 +1,53 @@
+package views
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// updateLog returns where the updates of the shown items are kept, if anywhere
+func (f *FancyListView) updateLog() (WorkUpdateLog, bool) {
+	if f.dataProvider != nil {
+		log, ok := f.dataProvider.(WorkUpdateLog)
+		return log, ok
+	}
+	if f.dataClient != nil {
+		return f.dataClient, true
+	}
+	return nil, false
+}
+
+// enterUpdatesMode switches the full post to the updates timeline of the item
+func (f *FancyListView) enterUpdatesMode() tea.Cmd {
+	log, ok := f.updateLog()
+	if !ok || f.selectedItem == nil {
+		f.setStatusError(fmt.Errorf("updates are not supported by this storage"))
+		return nil
+	}
+	f.updatesMode = true
+	cmd := f.updatesView.SetLog(log, f.selectedItem.ID)
+	f.updatesView, _ = f.updatesView.Update(tea.WindowSizeMsg{Width: f.width, Height: f.height})
+	return cmd
+}
+
+// updateUpdatesMode handles keys while the updates timeline is shown
+func (f *FancyListView) updateUpdatesMode(msg tea.KeyMsg) tea.Cmd {
+	if !f.updatesView.Typing() {
+		switch msg.String() {
+		case "esc", "u":
+			f.updatesMode = false
+			return nil
+		}
+	}
+	var cmd tea.Cmd
+	f.updatesView, cmd = f.updatesView.Update(msg)
+	return cmd
+}
+
+// IsEditing reports whether keys are being typed into an input, so they shouldn't be taken
+// as shortcuts
+func (f *FancyListView) IsEditing() bool {
+	return f.searchMode || (f.showFullPost && f.taskMode && f.taskAdding) ||
+		(f.showFullPost && f.updatesMode && f.updatesView.Typing())
+}
@@ -24,16 +24,51 @@ var (
 
 	updateContentStyle = lipgloss.NewStyle().
 		Padding(0, 2)
+
+	updateCursorStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true)
+
+	updateErrorStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("196"))
 )
 
+// updatesLoadedMsg carries a work item's updates, once loaded or after a change was saved
+type updatesLoadedMsg struct {
+	workID  string
+	updates []*models.Update
+	info    string // Outcome of a change for the status line
+	err     error
+}
+
+// updateFormFields are the fields of the update form, in tab order
+var updateFormFields = []string{"Kind", "Title", "Summary", "Attachments"}
+
+// updateForm holds an update being written or edited
+type updateForm struct {
+	editing     *models.Update // The update being edited, nil for a new one
+	kind        int            // Index into models.UpdateKinds
+	title       string
+	summary     string
+	attachments string // Artifact references, separated by commas
+	field       int    // Index into updateFormFields
+}
+
 type UpdatesView struct {
-	updates   []models.Update
-	viewport  viewport.Model
-	width     int
-	height    int
-	ready     bool
-	processor *renderer.MarkdownProcessor
-	baseDir   string
+	updates       []models.Update
+	viewport      viewport.Model
+	width         int
+	height        int
+	ready         bool
+	processor     *renderer.MarkdownProcessor
+	baseDir       string
+	log           WorkUpdateLog // Where the updates are read from and written to
+	workID        string
+	cursor        int         // Selected update
+	form          *updateForm // Update being written or edited
+	confirmDelete bool        // Waiting for y to delete the selected update
+	status        string
+	statusIsError bool
 }
 
 func NewUpdatesView() UpdatesView {
@@ -49,6 +84,41 @@ func (v UpdatesView) Init() tea.Cmd {
 	return nil
 }
 
+// SetLog shows the updates of a work item, loading them from log
+func (v *UpdatesView) SetLog(log WorkUpdateLog, workID string) tea.Cmd {
+	v.log = log
+	v.workID = workID
+	v.cursor = 0
+	v.form = nil
+	v.confirmDelete = false
+	v.status = ""
+	v.SetUpdates(nil)
+	return v.change(nil, "")
+}
+
+// Typing reports whether keys go into the form or a delete confirmation, rather than
+// navigating the timeline
+func (v UpdatesView) Typing() bool {
+	return v.form != nil || v.confirmDelete
+}
+
+// change applies an edit to the log, if any, and reloads the updates
+func (v *UpdatesView) change(edit func(log WorkUpdateLog) error, info string) tea.Cmd {
+	log, workID := v.log, v.workID
+	if log == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		if edit != nil {
+			if err := edit(log); err != nil {
+				return updatesLoadedMsg{workID: workID, err: err}
+			}
+		}
+		updates, err := log.GetUpdates(workID)
+		return updatesLoadedMsg{workID: workID, updates: updates, info: info, err: err}
+	}
+}
+
 func (v UpdatesView) Update(msg tea.Msg) (UpdatesView, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -67,43 +137,281 @@ func (v UpdatesView) Update(msg tea.Msg) (UpdatesView, tea.Cmd) {
 		v.height = msg.Height
 		v.renderContent()
 
+	case updatesLoadedMsg:
+		if msg.workID != v.workID {
+			return v, nil // A reply for an item that's no longer shown
+		}
+		if msg.err != nil {
+			v.status, v.statusIsError = msg.err.Error(), true
+			return v, nil
+		}
+		updates := make([]models.Update, 0, len(msg.updates))
+		for _, update := range msg.updates {
+			updates = append(updates, *update)
+		}
+		v.status, v.statusIsError = msg.info, false
+		v.SetUpdates(updates)
+		return v, nil
+
 	case tea.KeyMsg:
+		if v.form != nil {
+			return v, v.updateForm(msg)
+		}
+		if v.confirmDelete {
+			v.confirmDelete = false
+			if msg.String() == "y" && v.cursor < len(v.updates) {
+				updateID := v.updates[v.cursor].ID
+				return v, v.change(func(log WorkUpdateLog) error {
+					return log.DeleteUpdate(v.workID, updateID)
+				}, "Update deleted")
+			}
+			v.status = ""
+			return v, nil
+		}
+
+		v.status = ""
 		switch msg.String() {
 		case "up", "k":
-			v.viewport.LineUp(1)
+			if v.cursor > 0 {
+				v.cursor--
+				v.renderContent()
+			}
 		case "down", "j":
-			v.viewport.LineDown(1)
+			if v.cursor < len(v.updates)-1 {
+				v.cursor++
+				v.renderContent()
+			}
 		case "pgup":
 			v.viewport.ViewUp()
-		case "pgdn":
+		case "pgdn", " ":
 			v.viewport.ViewDown()
 		case "home":
 			v.viewport.GotoTop()
 		case "end":
 			v.viewport.GotoBottom()
+		case "a":
+			if v.log != nil {
+				v.form = &updateForm{field: 1}
+			}
+		case "e":
+			if v.log != nil && v.cursor < len(v.updates) {
+				v.form = newUpdateForm(&v.updates[v.cursor])
+			}
+		case "d":
+			if v.log != nil && v.cursor < len(v.updates) {
+				v.confirmDelete = true
+			}
 		}
+		return v, nil
 	}
 
 	v.viewport, cmd = v.viewport.Update(msg)
 	return v, cmd
 }
 
+// newUpdateForm fills the form with an update to edit
+func newUpdateForm(update *models.Update) *updateForm {
+	form := &updateForm{editing: update, title: update.Title, summary: update.Summary, field: 1}
+	for i, kind := range models.UpdateKinds {
+		if kind == update.GetKind() {
+			form.kind = i
+		}
+	}
+	var refs []string
+	for _, attachment := range update.Attachments {
+		refs = append(refs, attachment.Ref)
+	}
+	form.attachments = strings.Join(refs, ", ")
+	return form
+}
+
+// updateForm handles keys while an update is being written
+func (v *UpdatesView) updateForm(msg tea.KeyMsg) tea.Cmd {
+	form := v.form
+	var text *string
+	switch form.field {
+	case 1:
+		text = &form.title
+	case 2:
+		text = &form.summary
+	case 3:
+		text = &form.attachments
+	}
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		v.form = nil
+	case tea.KeyEnter:
+		return v.saveForm()
+	case tea.KeyTab, tea.KeyDown:
+		form.field = (form.field + 1) % len(updateFormFields)
+	case tea.KeyShiftTab, tea.KeyUp:
+		form.field = (form.field + len(updateFormFields) - 1) % len(updateFormFields)
+	case tea.KeyLeft, tea.KeyRight:
+		if text == nil {
+			step := 1
+			if msg.Type == tea.KeyLeft {
+				step = len(models.UpdateKinds) - 1
+			}
+			form.kind = (form.kind + step) % len(models.UpdateKinds)
+		}
+	case tea.KeyCtrlJ:
+		if form.field == 2 {
+			form.summary += "\n"
+		}
+	case tea.KeyBackspace:
+		if text != nil {
+			if runes := []rune(*text); len(runes) > 0 {
+				*text = string(runes[:len(runes)-1])
+			}
+		}
+	case tea.KeySpace:
+		if text != nil {
+			*text += " "
+		} else {
+			form.kind = (form.kind + 1) % len(models.UpdateKinds)
+		}
+	case tea.KeyRunes:
+		if text != nil {
+			*text += string(msg.Runes)
+		}
+	}
+	return nil
+}
+
+// saveForm writes the update in the form to the log
+func (v *UpdatesView) saveForm() tea.Cmd {
+	form := v.form
+	title, summary := strings.TrimSpace(form.title), strings.TrimSpace(form.summary)
+	if title == "" && summary == "" {
+		v.status, v.statusIsError = "an update needs a title or a summary", true
+		return nil
+	}
+	v.form = nil
+
+	update := &models.Update{
+		Author:     models.CurrentActor(),
+		UpdateType: "manual",
+	}
+	var existing []models.UpdateAttachment
+	if form.editing != nil {
+		edited := *form.editing
+		update = &edited
+		existing = form.editing.Attachments
+	}
+	update.Kind = models.UpdateKinds[form.kind]
+	update.Title = title
+	update.Summary = summary
+	update.Attachments = parseAttachments(form.attachments, existing)
+
+	workID := v.workID
+	if form.editing != nil {
+		return v.change(func(log WorkUpdateLog) error {
+			return log.EditUpdate(workID, update)
+		}, "Update saved")
+	}
+	v.cursor = 0 // The new update is the newest
+	return v.change(func(log WorkUpdateLog) error {
+		return log.CreateUpdate(workID, update)
+	}, "Update added")
+}
+
+// parseAttachments reads comma separated artifact references, keeping the titles of
+// attachments that were already there
+func parseAttachments(refs string, existing []models.UpdateAttachment) []models.UpdateAttachment {
+	var attachments []models.UpdateAttachment
+	for _, ref := range strings.Split(refs, ",") {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		attachment := models.UpdateAttachment{Ref: ref}
+		for _, previous := range existing {
+			if previous.Ref == ref {
+				attachment.Title = previous.Title
+			}
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments
+}
+
 func (v UpdatesView) View() string {
 	if !v.ready {
 		return "\n  Initializing updates view..."
 	}
 
 	header := updateHeaderStyle.Render("📝 Updates Timeline")
-	
+	if v.form != nil {
+		return fmt.Sprintf("%s\n\n%s%s", header, v.renderForm(), v.renderStatus())
+	}
+
+	help := "j/k: select • a: add • e: edit • d: delete • esc: back"
+	if v.log == nil {
+		help = "j/k: scroll • esc: back"
+	}
+	if v.confirmDelete {
+		help = "Delete the selected update? y: delete • any other key: keep"
+	}
 	footer := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("241")).
-		Render(fmt.Sprintf("\n %d updates • %d%% ", len(v.updates), int(v.viewport.ScrollPercent()*100)))
+		Render(fmt.Sprintf("\n %d updates • %d%% • %s", len(v.updates), int(v.viewport.ScrollPercent()*100), help))
+
+	return fmt.Sprintf("%s\n\n%s%s%s", header, v.viewport.View(), footer, v.renderStatus())
+}
 
-	return fmt.Sprintf("%s\n\n%s%s", header, v.viewport.View(), footer)
+// renderStatus renders the outcome of the last change, if any
+func (v UpdatesView) renderStatus() string {
+	if v.status == "" {
+		return ""
+	}
+	if v.statusIsError {
+		return "\n " + updateErrorStyle.Render("⚠️ "+v.status)
+	}
+	return "\n " + updateTimeStyle.Render("✓ "+v.status)
+}
+
+// renderForm renders the update being written, one field per line
+func (v UpdatesView) renderForm() string {
+	form := v.form
+	var content strings.Builder
+	if form.editing != nil {
+		content.WriteString(" Editing the update of " + form.editing.Timestamp.Format("Jan 2, 15:04") + "\n\n")
+	} else {
+		content.WriteString(" New update\n\n")
+	}
+
+	kind := models.UpdateKinds[form.kind]
+	values := []string{
+		fmt.Sprintf("◂ %s %s ▸", kind.Icon(), kind.Label()),
+		form.title,
+		strings.ReplaceAll(form.summary, "\n", "\n               "),
+		form.attachments,
+	}
+	for i, name := range updateFormFields {
+		marker := "  "
+		value := values[i]
+		if i == form.field {
+			marker = updateCursorStyle.Render("▸ ")
+			if i > 0 {
+				value += "█"
+			}
+		}
+		content.WriteString(fmt.Sprintf(" %s%-12s %s\n", marker, name+":", value))
+	}
+
+	content.WriteString(updateTimeStyle.Render("\n tab: next field • ←/→: kind • ctrl+j: new line in summary • enter: save • esc: cancel"))
+	return content.String()
 }
 
 func (v *UpdatesView) SetUpdates(updates []models.Update) {
 	v.updates = updates
+	if v.cursor >= len(updates) {
+		v.cursor = len(updates) - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
 	v.renderContent()
 }
 
@@ -113,6 +421,7 @@ func (v *UpdatesView) renderContent() {
 	}
 
 	var content strings.Builder
+	cursorLine := 0
 
 	if len(v.updates) == 0 {
 		content.WriteString("\n  No updates yet.\n")
@@ -122,9 +431,21 @@ func (v *UpdatesView) renderContent() {
 				content.WriteString("\n" + strings.Repeat("─", v.width-4) + "\n\n")
 			}
 
-			// Update header with time
+			// Update header with kind, time and author
+			marker := "  "
+			if i == v.cursor {
+				marker = updateCursorStyle.Render("▸ ")
+				cursorLine = strings.Count(content.String(), "\n")
+			}
+			kind := update.GetKind()
 			timeStr := update.Timestamp.Format("Jan 2, 15:04")
-			header := fmt.Sprintf("Update #%d • %s", i+1, updateTimeStyle.Render(timeStr))
+			header := fmt.Sprintf("%s%s %s • %s", marker, kind.Icon(), kind.Label(), updateTimeStyle.Render(timeStr))
+			if update.Author != "" {
+				header += updateTimeStyle.Render(" by " + update.Author)
+			}
+			if update.EditedAt != nil {
+				header += updateTimeStyle.Render(" (edited)")
+			}
 			content.WriteString(header + "\n\n")
 
 			// Process and render markdown content
@@ -139,27 +460,20 @@ func (v *UpdatesView) renderContent() {
 			if len(update.TasksAdded) > 0 {
 				content.WriteString("\n\n➕ Added: " + strings.Join(update.TasksAdded, ", "))
 			}
+			for _, attachment := range update.Attachments {
+				line := "\n\n📎 " + attachment.Ref
+				if attachment.Title != "" {
+					line += " — " + attachment.Title
+				}
+				content.WriteString(line)
+			}
 		}
 	}
 
 	v.viewport.SetContent(content.String())
-}
 
-// Helper to create updates from a Work item
-func (v *UpdatesView) LoadFromWork(work *models.Work) {
-	// In a real implementation, this would load updates from the updates file
-	// For now, we'll create a sample update
-	if work.UpdatesRef != "" {
-		sampleUpdate := models.Update{
-			ID:        "update-001",
-			WorkID:    work.ID,
-			Timestamp: work.UpdatedAt,
-			Title:     "Initial Implementation Progress",
-			Summary:   "Work item created and initial tasks defined. TUI modification is progressing well with core implementation complete.",
-			Author:    "Claude",
-			UpdateType: "automatic",
-			TasksCompleted: []string{"Modified WorkItem struct", "Updated data loading", "Fixed rendering"},
-		}
-		v.SetUpdates([]models.Update{sampleUpdate})
-	}
-}
\ No newline at end of file
+	// Keep the selected update in view
+	if cursorLine < v.viewport.YOffset || cursorLine >= v.viewport.YOffset+v.viewport.Height {
+		v.viewport.SetYOffset(cursorLine)
+	}
+}